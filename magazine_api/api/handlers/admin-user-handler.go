@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"magazine_api/api/serializers/requests"
+	"magazine_api/api/serializers/responses"
+	"magazine_api/lib"
+	"magazine_api/orchestrators"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminUserHandler struct {
+	logger       lib.Logger
+	orchestrator orchestrators.AdminUserOrchestrator
+}
+
+func NewAdminUserHandler(logger lib.Logger, orchestrator orchestrators.AdminUserOrchestrator) AdminUserHandler {
+	return AdminUserHandler{logger: logger, orchestrator: orchestrator}
+}
+
+// ListUsers godoc
+// @Summary      Lists users for admin
+// @Description  Lists users of identity provider merged with users in database
+// @Tags         Admin
+// @Produce      json
+// @Param        limit  query     int     false  "Limit (max 60)"
+// @Param        token  query     string  false  "Pagination token"
+// @Success      200    {object}  object{data=[]responses.AdminUser,pagination_token=string}
+// @Failure      400    {object}  responses.Problem
+// @Failure      422    {object}  responses.Problem
+// @Security     BearerAuth
+// @Router       /admin/users [get]
+//
+// List users controller
+func (a AdminUserHandler) ListUsers(c *gin.Context) {
+	var query requests.ListAdminUsers
+	if !bindQuery(c, &query) {
+		return
+	}
+
+	users, next, err := a.orchestrator.ListUsers(query.Limit, query.PaginationToken())
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": users, "pagination_token": next})
+}
+
+// ChangeUserRole godoc
+// @Summary      Change role of user
+// @Description  Changes role of user in database and role claim of identity provider
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                   true  "User ID"
+// @Param        role  body      requests.UpdateUserRole  true  "Roles"
+// @Success      200   {object}  object{data=responses.User}
+// @Security     BearerAuth
+// @Router       /admin/users/{id}/role [patch]
+//
+// Change role of user controller
+func (a AdminUserHandler) ChangeUserRole(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var request requests.UpdateUserRole
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	user, err := a.orchestrator.ChangeRole(id, request.Role)
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": responses.NewUser(user)})
+}
+
+// DisableUser godoc
+// @Summary      Disable user
+// @Description  Disables user in identity provider
+// @Tags         Admin
+// @Produce      json
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  object{data=string}
+// @Security     BearerAuth
+// @Router       /admin/users/{id}/disable [post]
+//
+// Disable user controller
+func (a AdminUserHandler) DisableUser(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := a.orchestrator.DisableUser(id); err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": "successfully disabled"})
+}
+
+// EnableUser godoc
+// @Summary      Enable user
+// @Description  Enables user in identity provider
+// @Tags         Admin
+// @Produce      json
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  object{data=string}
+// @Security     BearerAuth
+// @Router       /admin/users/{id}/enable [post]
+//
+// Enable user controller
+func (a AdminUserHandler) EnableUser(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := a.orchestrator.EnableUser(id); err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": "successfully enabled"})
+}
+
+// ResetPassword godoc
+// @Summary      Force password reset
+// @Description  Resets password of user, user has to set new password on next sign in
+// @Tags         Admin
+// @Produce      json
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  object{data=string}
+// @Security     BearerAuth
+// @Router       /admin/users/{id}/reset-password [post]
+//
+// Reset password controller
+func (a AdminUserHandler) ResetPassword(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := a.orchestrator.ResetPassword(id); err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": "password reset successfully"})
+}
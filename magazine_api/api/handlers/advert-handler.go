@@ -1,45 +1,56 @@
 package handlers
 
 import (
+	"magazine_api/api/serializers/requests"
+	"magazine_api/api/serializers/responses"
 	"magazine_api/lib"
-	"magazine_api/models/magazine"
 	"magazine_api/services"
 	"time"
 
 	"github.com/danhper/structomap"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 )
 
 type AdvertHandler struct {
-	logger  lib.Logger
-	service services.AdvertService
+	logger   lib.Logger
+	service  services.AdvertService
+	resolver services.URLResolver
 }
 
-func NewAdvertHandler(logger lib.Logger, service services.AdvertService) AdvertHandler {
+func NewAdvertHandler(logger lib.Logger, service services.AdvertService, resolver services.URLResolver) AdvertHandler {
 	return AdvertHandler{
-		logger:  logger,
-		service: service,
+		logger:   logger,
+		service:  service,
+		resolver: resolver,
 	}
 }
 
 // CreateAdvert godoc
 // @Summary      Create Advert
-// @Description  It creates Advert structure
+// @Description  It creates Advert structure, it is published when created unless publish_at is later
 // @Tags         Advert
 // @Accept       json
 // @Produce      json
-// @Param        Advert  body      magazine.Advert  true  "Add Advert"
-// @Success      200       {object}  object{data=magazine.Advert}
+// @Param        Advert  body      requests.CreateAdvert  true  "Add Advert"
+// @Success      200       {object}  object{data=responses.Advert}
+// @Failure      400       {object}  responses.Problem
+// @Security     BearerAuth
 // @Router       /ad [post]
 //
 // Creates Advert
 func (s AdvertHandler) CreateAdvert(c *gin.Context) {
-	var ad *magazine.Advert
-	if err := c.ShouldBind(&ad); err != nil {
-		handleError(s.logger, c, err)
+	userID, ok := currentUserID(c)
+	if !ok {
+		handleError(s.logger, c, errNotAuthenticated)
+		return
+	}
+
+	var request requests.CreateAdvert
+	if !bindJSON(c, &request) {
 		return
 	}
+	ad := request.Advert()
+	ad.CreatedBy = &userID
 
 	// Create Advert in our Database
 	ad, err := s.service.CreateAdvert(ad)
@@ -48,7 +59,7 @@ func (s AdvertHandler) CreateAdvert(c *gin.Context) {
 		return
 	}
 
-	c.JSON(200, gin.H{"data": ad})
+	respondResolved(s.logger, s.resolver, c, gin.H{"data": responses.NewAdvert(ad)})
 }
 
 // ListAllAdverts godoc
@@ -56,18 +67,24 @@ func (s AdvertHandler) CreateAdvert(c *gin.Context) {
 // @Description  List stories
 // @Tags         Advert
 // @Produce      json
-// @Success      200  {object}  object{data=[]magazine.Advert}
+// @Param        filter  query     string  false  "filter[column]=value or filter[column][eq|ne|gt|gte|lt|lte|like|in|null]=value"
+// @Param        sort    query     string  false  "columns to sort by, - sorts descending"
+// @Param        fields  query     string  false  "fields to send"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200     {object}  object{data=[]responses.Advert,next_cursor=string,total=int}
 // @Router       /ad [get]
 //
 // List all suppliers from database
 func (s AdvertHandler) ListAdvert(c *gin.Context) {
-	stories, err := s.service.ListAds(c)
+	stories, page, err := s.service.ListAds(c)
 	if err != nil {
 		handleError(s.logger, c, err)
 		return
 	}
 
-	c.JSON(200, stories)
+	respondPage(s.logger, s.resolver, c, responses.List(stories, responses.NewAdvert), page)
 }
 
 // ListAdvertFromUserId godoc
@@ -75,22 +92,31 @@ func (s AdvertHandler) ListAdvert(c *gin.Context) {
 // @Description  List Advert by creator id
 // @Tags         Advert
 // @Produce      json
-// @Param        id   path      string  true  "ID"
-// @Success      200  {object}  object{data=[]magazine.Advert}
+// @Param        id      path      string  true   "ID"
+// @Param        filter  query     string  false  "filter[column]=value or filter[column][eq|ne|gt|gte|lt|lte|like|in|null]=value"
+// @Param        sort    query     string  false  "columns to sort by, - sorts descending"
+// @Param        fields  query     string  false  "fields to send"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200     {object}  object{data=[]responses.Advert,next_cursor=string,total=int}
 // @Router       /ad/profile/{id} [get]
 //
 // List Advert from creator ID controller
 func (a AdvertHandler) ListAdvertByProfileId(c *gin.Context) {
 
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	Advert, err := a.service.ListAdsByProfileId(uuid.MustParse(id))
+	Advert, page, err := a.service.ListAdsByProfileId(id, services.PaginationFrom(c), services.ListQueryFrom(c))
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": Advert})
+	respondPage(a.logger, a.resolver, c, responses.List(Advert, responses.NewAdvert), page)
 }
 
 // GetAdvertById godoc
@@ -99,20 +125,23 @@ func (a AdvertHandler) ListAdvertByProfileId(c *gin.Context) {
 // @Tags         Advert
 // @Produce      json
 // @Param        id   path      string  true  "ID"
-// @Success      200  {object}  object{data=models.Advert}
+// @Success      200  {object}  object{data=responses.Advert}
 // @Router       /ad/id/{id} [get]
 //
 // Gets Advert By Company ID controller
 func (a AdvertHandler) GetAdvertById(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	Advert, err := a.service.GetAdvertById(uuid.MustParse(id))
+	Advert, err := a.service.GetAdvertById(id)
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": Advert})
+	respondResolved(a.logger, a.resolver, c, gin.H{"data": responses.NewAdvert(Advert)})
 }
 
 // UpdateAdvert godoc
@@ -122,26 +151,29 @@ func (a AdvertHandler) GetAdvertById(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Update Advert"
-// @Success      200  {object}  object{data=models.Advert}
+// @Success      200  {object}  object{data=responses.Advert}
+// @Failure      400  {object}  responses.Problem
+// @Failure      422  {object}  responses.Problem
 // @Router       /ad/{id} [patch]
 // Patch Advert of creator by Id controller
 func (a AdvertHandler) PatchAdvertById(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	Advert, err := a.service.GetAdvertById(uuid.MustParse(id))
+	Advert, err := a.service.GetAdvertById(id)
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	var newAdvert magazine.AdvertBase
-	if err := c.ShouldBindJSON(&newAdvert); err != nil {
-		handleError(a.logger, c, err)
+	var newAdvert requests.PatchAdvert
+	if !bindJSON(c, &newAdvert) {
 		return
 	}
 
 	AdvertMap := structomap.New().UseSnakeCase().PickAll().
-		Omit("CreatorId").
 		OmitIf(func(ch interface{}) bool {
 			return newAdvert.AdvertCode == nil
 		}, "AdvertCode").
@@ -154,9 +186,33 @@ func (a AdvertHandler) PatchAdvertById(c *gin.Context) {
 		OmitIf(func(ch interface{}) bool {
 			return newAdvert.AdvertContent == nil
 		}, "AdvertContent").
+		OmitIf(func(ch interface{}) bool {
+			return newAdvert.AdvertiserId == nil
+		}, "AdvertiserId").
+		OmitIf(func(ch interface{}) bool {
+			return newAdvert.Size == nil
+		}, "Size").
+		OmitIf(func(ch interface{}) bool {
+			return newAdvert.PublishAt == nil
+		}, "PublishAt").
+		OmitIf(func(ch interface{}) bool {
+			return newAdvert.UnpublishAt == nil
+		}, "UnpublishAt").
+		OmitIf(func(ch interface{}) bool {
+			return newAdvert.AdvertURL == nil
+		}, "AdvertURL").
+		OmitIf(func(ch interface{}) bool {
+			return newAdvert.Remarks == nil
+		}, "Remarks").
 		Transform(newAdvert)
 
 	if len(AdvertMap) > 0 {
+		if err := services.SchedulePatch(&AdvertMap, Advert.PublishAt, Advert.UnpublishAt); err != nil {
+			handleError(a.logger, c, err)
+			return
+		}
+		services.PublishPatch(&AdvertMap, Advert.PublishedOn, Advert.UnpublishAt)
+
 		AdvertMap["updated_on"] = time.Now()
 		AdvertMap["id"] = Advert.ID
 
@@ -166,7 +222,13 @@ func (a AdvertHandler) PatchAdvertById(c *gin.Context) {
 			return
 		}
 
-		c.JSON(200, gin.H{"data": AdvertMap})
+		updated, err := a.service.GetAdvertById(Advert.ID)
+		if err != nil {
+			handleError(a.logger, c, err)
+			return
+		}
+
+		respondResolved(a.logger, a.resolver, c, gin.H{"data": responses.NewAdvert(updated)})
 		return
 	}
 
@@ -184,9 +246,12 @@ func (a AdvertHandler) PatchAdvertById(c *gin.Context) {
 //
 // Delete Advert By ID controller
 func (a AdvertHandler) DeleteAdvertByID(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	err := a.service.DeleteAdvert(uuid.MustParse(id))
+	err := a.service.DeleteAdvert(id)
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
@@ -0,0 +1,680 @@
+package handlers
+
+import (
+	"encoding/json"
+	"magazine_api/api/serializers/requests"
+	"magazine_api/api/serializers/responses"
+	"magazine_api/apperrors"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"magazine_api/orchestrators"
+	"magazine_api/services"
+	"strconv"
+	"time"
+
+	"github.com/danhper/structomap"
+	"github.com/gin-gonic/gin"
+)
+
+type AdvertisingHandler struct {
+	logger       lib.Logger
+	service      services.AdBookingService
+	orchestrator orchestrators.AdvertisingOrchestrator
+	resolver     services.URLResolver
+}
+
+func NewAdvertisingHandler(
+	logger lib.Logger,
+	service services.AdBookingService,
+	orchestrator orchestrators.AdvertisingOrchestrator,
+	resolver services.URLResolver,
+) AdvertisingHandler {
+	return AdvertisingHandler{
+		logger:       logger,
+		service:      service,
+		orchestrator: orchestrator,
+		resolver:     resolver,
+	}
+}
+
+// CreateAdvertiser godoc
+// @Summary      Create Advertiser
+// @Description  Creates advertiser account linked to user with advertiser role
+// @Tags         Advertising
+// @Accept       json
+// @Produce      json
+// @Param        advertiser  body      requests.CreateAdvertiser  true  "Add Advertiser"
+// @Success      200         {object}  object{data=responses.Advertiser}
+// @Security     BearerAuth
+// @Router       /advertiser [post]
+//
+// Creates advertiser account
+func (a AdvertisingHandler) CreateAdvertiser(c *gin.Context) {
+	var request requests.CreateAdvertiser
+	if !bindJSON(c, &request) {
+		return
+	}
+	advertiser := request.Advertiser()
+
+	created, err := a.orchestrator.CreateAdvertiser(advertiser)
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": responses.NewAdvertiser(created)})
+}
+
+// ListAdvertisers godoc
+// @Summary      Lists Advertisers
+// @Description  Lists advertiser accounts
+// @Tags         Advertising
+// @Produce      json
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200  {object}  object{data=[]responses.Advertiser,next_cursor=string,total=int}
+// @Security     BearerAuth
+// @Router       /advertiser [get]
+//
+// List advertisers controller
+func (a AdvertisingHandler) ListAdvertisers(c *gin.Context) {
+	advertisers, page, err := a.service.ListAdvertisers(services.PaginationFrom(c), services.ListQueryFrom(c))
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	respondPage(a.logger, a.resolver, c, responses.List(advertisers, responses.NewAdvertiser), page)
+}
+
+// GetMyAdvertiser godoc
+// @Summary      Gets my advertiser account
+// @Description  Gets advertiser account linked to authenticated user
+// @Tags         Advertising
+// @Produce      json
+// @Success      200  {object}  object{data=responses.Advertiser}
+// @Security     BearerAuth
+// @Router       /advertiser/me [get]
+//
+// Get advertiser account of authenticated user controller
+func (a AdvertisingHandler) GetMyAdvertiser(c *gin.Context) {
+	advertiser, ok := a.currentAdvertiser(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(200, gin.H{"data": responses.NewAdvertiser(advertiser)})
+}
+
+// GetAdvertiserById godoc
+// @Summary      Gets Advertiser by ID
+// @Description  Gets advertiser account by id
+// @Tags         Advertising
+// @Produce      json
+// @Param        id   path      string  true  "Advertiser ID"
+// @Success      200  {object}  object{data=responses.Advertiser}
+// @Security     BearerAuth
+// @Router       /advertiser/{id} [get]
+//
+// Get advertiser by id controller
+func (a AdvertisingHandler) GetAdvertiserById(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	advertiser, err := a.service.GetAdvertiserByID(id)
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": responses.NewAdvertiser(advertiser)})
+}
+
+// PatchAdvertiser godoc
+// @Summary      Update Advertiser
+// @Description  Updates advertiser account
+// @Tags         Advertising
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                 true  "Advertiser ID"
+// @Param        advertiser  body      requests.PatchAdvertiser  true  "Update Advertiser"
+// @Success      200         {object}  object{data=responses.Advertiser}
+// @Security     BearerAuth
+// @Router       /advertiser/{id} [patch]
+//
+// Patch advertiser controller
+func (a AdvertisingHandler) PatchAdvertiser(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	advertiser, err := a.service.GetAdvertiserByID(id)
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	var newAdvertiser requests.PatchAdvertiser
+	if !bindJSON(c, &newAdvertiser) {
+		return
+	}
+
+	advertiserMap := structomap.New().UseSnakeCase().PickAll().
+		Omit("UserId").
+		OmitIf(func(ch interface{}) bool {
+			return newAdvertiser.CompanyName == nil
+		}, "CompanyName").
+		OmitIf(func(ch interface{}) bool {
+			return newAdvertiser.ContactName == nil
+		}, "ContactName").
+		OmitIf(func(ch interface{}) bool {
+			return newAdvertiser.Email == nil
+		}, "Email").
+		OmitIf(func(ch interface{}) bool {
+			return newAdvertiser.ContactNumber == nil
+		}, "ContactNumber").
+		OmitIf(func(ch interface{}) bool {
+			return newAdvertiser.BillingAddress == nil
+		}, "BillingAddress").
+		OmitIf(func(ch interface{}) bool {
+			return newAdvertiser.PanNumber == nil
+		}, "PanNumber").
+		OmitIf(func(ch interface{}) bool {
+			return newAdvertiser.Remarks == nil
+		}, "Remarks").
+		Transform(newAdvertiser)
+
+	if len(advertiserMap) > 0 {
+		advertiserMap["updated_on"] = time.Now()
+
+		err := a.service.UpdateAdvertiser(advertiser.ID, &advertiserMap)
+		if err != nil {
+			handleError(a.logger, c, err)
+			return
+		}
+
+		advertiserMap["id"] = advertiser.ID
+		c.JSON(200, gin.H{"data": advertiserMap})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": "nothing to update"})
+}
+
+// DeleteAdvertiser godoc
+// @Summary      Soft Delete Advertiser
+// @Description  Delete by advertiser ID
+// @Tags         Advertising
+// @Produce      json
+// @Param        id   path      string  true  "Advertiser ID"
+// @Success      200  {object}  object{data=string}
+// @Security     BearerAuth
+// @Router       /advertiser/{id} [delete]
+//
+// Delete advertiser controller
+func (a AdvertisingHandler) DeleteAdvertiser(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := a.service.DeleteAdvertiser(id); err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": "successfully deleted"})
+}
+
+// CreateRateCard godoc
+// @Summary      Create Rate Card
+// @Description  Creates price of ad size at placement
+// @Tags         Advertising
+// @Accept       json
+// @Produce      json
+// @Param        card  body      requests.CreateRateCard  true  "Add Rate Card"
+// @Success      200   {object}  object{data=responses.RateCard}
+// @Security     BearerAuth
+// @Router       /rate_card [post]
+//
+// Creates rate card
+func (a AdvertisingHandler) CreateRateCard(c *gin.Context) {
+	var request requests.CreateRateCard
+	if !bindJSON(c, &request) {
+		return
+	}
+	card := request.RateCard()
+
+	created, err := a.service.CreateRateCard(card)
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": responses.NewRateCard(created)})
+}
+
+// ListRateCards godoc
+// @Summary      Lists Rate Cards
+// @Description  Lists prices of ad sizes at placements
+// @Tags         Advertising
+// @Produce      json
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200  {object}  object{data=[]responses.RateCard,next_cursor=string,total=int}
+// @Security     BearerAuth
+// @Router       /rate_card [get]
+//
+// List rate cards controller
+func (a AdvertisingHandler) ListRateCards(c *gin.Context) {
+	cards, page, err := a.service.ListRateCards(services.PaginationFrom(c), services.ListQueryFrom(c))
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	respondPage(a.logger, a.resolver, c, responses.List(cards, responses.NewRateCard), page)
+}
+
+// PatchRateCard godoc
+// @Summary      Update Rate Card
+// @Description  Updates rate card
+// @Tags         Advertising
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string               true  "Rate Card ID"
+// @Param        card  body      requests.PatchRateCard  true  "Update Rate Card"
+// @Success      200   {object}  object{data=responses.RateCard}
+// @Security     BearerAuth
+// @Router       /rate_card/{id} [patch]
+//
+// Patch rate card controller
+func (a AdvertisingHandler) PatchRateCard(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var newCard requests.PatchRateCard
+	if !bindJSON(c, &newCard) {
+		return
+	}
+
+	cardMap := structomap.New().UseSnakeCase().PickAll().
+		OmitIf(func(ch interface{}) bool {
+			return newCard.Size == nil
+		}, "Size").
+		OmitIf(func(ch interface{}) bool {
+			return newCard.Placement == nil
+		}, "Placement").
+		OmitIf(func(ch interface{}) bool {
+			return newCard.Price == nil
+		}, "Price").
+		OmitIf(func(ch interface{}) bool {
+			return newCard.Remarks == nil
+		}, "Remarks").
+		Transform(newCard)
+
+	if len(cardMap) > 0 {
+		cardMap["updated_on"] = time.Now()
+
+		err := a.service.UpdateRateCard(id, &cardMap)
+		if err != nil {
+			handleError(a.logger, c, err)
+			return
+		}
+
+		cardMap["id"] = id
+		c.JSON(200, gin.H{"data": cardMap})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": "nothing to update"})
+}
+
+// DeleteRateCard godoc
+// @Summary      Soft Delete Rate Card
+// @Description  Delete by rate card ID
+// @Tags         Advertising
+// @Produce      json
+// @Param        id   path      string  true  "Rate Card ID"
+// @Success      200  {object}  object{data=string}
+// @Security     BearerAuth
+// @Router       /rate_card/{id} [delete]
+//
+// Delete rate card controller
+func (a AdvertisingHandler) DeleteRateCard(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := a.service.DeleteRateCard(id); err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": "successfully deleted"})
+}
+
+// BookAdvert godoc
+// @Summary      Book Advert
+// @Description  Books advert into page of an issue, price defaults to the rate card
+// @Tags         Advertising
+// @Accept       json
+// @Produce      json
+// @Param        booking  body      requests.BookAdvert  true  "Add Booking"
+// @Success      200      {object}  object{data=responses.Booking}
+// @Failure      409      {object}  responses.Problem
+// @Security     BearerAuth
+// @Router       /ad_booking [post]
+//
+// Books advert into issue
+func (a AdvertisingHandler) BookAdvert(c *gin.Context) {
+	var request requests.BookAdvert
+	if !bindJSON(c, &request) {
+		return
+	}
+	booking := request.Booking()
+
+	created, err := a.service.BookAdvert(booking)
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": responses.NewBooking(created)})
+}
+
+// ListIssueBookings godoc
+// @Summary      Lists bookings of issue
+// @Description  Lists advert bookings into the issue
+// @Tags         Advertising
+// @Produce      json
+// @Param        id   path      string  true  "Issue ID"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200  {object}  object{data=[]responses.Booking,next_cursor=string,total=int}
+// @Security     BearerAuth
+// @Router       /ad_booking/issue/{id} [get]
+//
+// List bookings of issue controller
+func (a AdvertisingHandler) ListIssueBookings(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	bookings, page, err := a.service.ListBookingsByIssueID(id, services.PaginationFrom(c), services.ListQueryFrom(c))
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	respondPage(a.logger, a.resolver, c, responses.List(bookings, responses.NewBooking), page)
+}
+
+// ListAdvertiserBookings godoc
+// @Summary      Lists bookings of advertiser
+// @Description  Lists advert bookings of the advertiser
+// @Tags         Advertising
+// @Produce      json
+// @Param        id   path      string  true  "Advertiser ID"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200  {object}  object{data=[]responses.Booking,next_cursor=string,total=int}
+// @Security     BearerAuth
+// @Router       /advertiser/{id}/booking [get]
+//
+// List bookings of advertiser controller
+func (a AdvertisingHandler) ListAdvertiserBookings(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	bookings, page, err := a.service.ListBookingsByAdvertiserID(id, services.PaginationFrom(c), services.ListQueryFrom(c))
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	respondPage(a.logger, a.resolver, c, responses.List(bookings, responses.NewBooking), page)
+}
+
+// ListMyBookings godoc
+// @Summary      Lists my bookings
+// @Description  Lists advert bookings of authenticated advertiser
+// @Tags         Advertising
+// @Produce      json
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200  {object}  object{data=[]responses.Booking,next_cursor=string,total=int}
+// @Security     BearerAuth
+// @Router       /advertiser/me/booking [get]
+//
+// List bookings of authenticated advertiser controller
+func (a AdvertisingHandler) ListMyBookings(c *gin.Context) {
+	advertiser, ok := a.currentAdvertiser(c)
+	if !ok {
+		return
+	}
+
+	bookings, page, err := a.service.ListBookingsByAdvertiserID(advertiser.ID, services.PaginationFrom(c), services.ListQueryFrom(c))
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	respondPage(a.logger, a.resolver, c, responses.List(bookings, responses.NewBooking), page)
+}
+
+// CancelBooking godoc
+// @Summary      Cancel booking
+// @Description  Cancels advert booking and frees its slot, invoiced bookings can't be cancelled
+// @Tags         Advertising
+// @Produce      json
+// @Param        id   path      string  true  "Booking ID"
+// @Success      200  {object}  object{data=string}
+// @Security     BearerAuth
+// @Router       /ad_booking/{id}/cancel [patch]
+//
+// Cancel booking controller
+func (a AdvertisingHandler) CancelBooking(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := a.service.CancelBooking(id); err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": "successfully cancelled"})
+}
+
+// CreateInvoice godoc
+// @Summary      Invoice advertiser
+// @Description  Invoices bookings of advertiser and posts receivable transaction, each booking can be
+// @Description  given once
+// @Tags         Advertising
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                  true  "Advertiser ID"
+// @Param        invoice  body      requests.CreateInvoice  true  "Invoice"
+// @Success      200      {object}  object{data=responses.Invoice}
+// @Failure      409      {object}  responses.Problem
+// @Failure      422      {object}  responses.Problem
+// @Security     BearerAuth
+// @Router       /advertiser/{id}/invoice [post]
+//
+// Invoice advertiser controller
+func (a AdvertisingHandler) CreateInvoice(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var request requests.CreateInvoice
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	invoice, err := a.orchestrator.Invoice(id, request)
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": responses.NewInvoice(invoice)})
+}
+
+// ListInvoices godoc
+// @Summary      Lists invoices
+// @Description  Lists advert invoices, filtered by paid status when given
+// @Tags         Advertising
+// @Produce      json
+// @Param        paid_status  query     string  false  "Complete or Pending"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200          {object}  object{data=[]responses.Invoice,next_cursor=string,total=int}
+// @Security     BearerAuth
+// @Router       /ad_invoice [get]
+//
+// List invoices controller
+func (a AdvertisingHandler) ListInvoices(c *gin.Context) {
+	var status *models.PaidStatus
+	if query := c.Query("paid_status"); query != "" {
+		var paidStatus models.PaidStatus
+		if err := json.Unmarshal([]byte(strconv.Quote(query)), &paidStatus); err != nil {
+			handleError(a.logger, c, apperrors.NewBadRequest("invalid paid_status",
+				apperrors.FieldError{Field: "paid_status", Message: "is not one of the allowed values"}))
+			return
+		}
+		status = &paidStatus
+	}
+
+	invoices, page, err := a.service.ListInvoices(status, services.PaginationFrom(c), services.ListQueryFrom(c))
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	respondPage(a.logger, a.resolver, c, responses.List(invoices, responses.NewInvoice), page)
+}
+
+// ListAdvertiserInvoices godoc
+// @Summary      Lists invoices of advertiser
+// @Description  Lists advert invoices of the advertiser
+// @Tags         Advertising
+// @Produce      json
+// @Param        id   path      string  true  "Advertiser ID"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200  {object}  object{data=[]responses.Invoice,next_cursor=string,total=int}
+// @Security     BearerAuth
+// @Router       /advertiser/{id}/invoice [get]
+//
+// List invoices of advertiser controller
+func (a AdvertisingHandler) ListAdvertiserInvoices(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	invoices, page, err := a.service.ListInvoicesByAdvertiserID(id, services.PaginationFrom(c), services.ListQueryFrom(c))
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	respondPage(a.logger, a.resolver, c, responses.List(invoices, responses.NewInvoice), page)
+}
+
+// ListMyInvoices godoc
+// @Summary      Lists my invoices
+// @Description  Lists advert invoices of authenticated advertiser
+// @Tags         Advertising
+// @Produce      json
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200  {object}  object{data=[]responses.Invoice,next_cursor=string,total=int}
+// @Security     BearerAuth
+// @Router       /advertiser/me/invoice [get]
+//
+// List invoices of authenticated advertiser controller
+func (a AdvertisingHandler) ListMyInvoices(c *gin.Context) {
+	advertiser, ok := a.currentAdvertiser(c)
+	if !ok {
+		return
+	}
+
+	invoices, page, err := a.service.ListInvoicesByAdvertiserID(advertiser.ID, services.PaginationFrom(c), services.ListQueryFrom(c))
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	respondPage(a.logger, a.resolver, c, responses.List(invoices, responses.NewInvoice), page)
+}
+
+// PayInvoice godoc
+// @Summary      Pay invoice
+// @Description  Marks invoice paid and records payment on its transaction
+// @Tags         Advertising
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string               true  "Invoice ID"
+// @Param        payment  body      requests.PayInvoice  true  "Payment"
+// @Success      200      {object}  object{data=responses.Invoice}
+// @Security     BearerAuth
+// @Router       /ad_invoice/{id}/pay [patch]
+//
+// Pay invoice controller
+func (a AdvertisingHandler) PayInvoice(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var request requests.PayInvoice
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	invoice, err := a.orchestrator.PayInvoice(id, request)
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": responses.NewInvoice(invoice)})
+}
+
+func (a AdvertisingHandler) currentAdvertiser(c *gin.Context) (*models.Advertiser, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		handleError(a.logger, c, errNotAuthenticated)
+		return nil, false
+	}
+
+	advertiser, err := a.service.GetAdvertiserByUserID(userID)
+	if err != nil {
+		handleError(a.logger, c, err)
+		return nil, false
+	}
+
+	return advertiser, true
+}
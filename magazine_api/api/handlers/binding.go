@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"magazine_api/apperrors"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// bindJSON binds json body of the request into request and validates it by its `binding` tags.
+// Bodies which can not be read are bad requests and bodies failing validation are unprocessable,
+// both are handed to the error middleware with the fields at fault
+func bindJSON(c *gin.Context, request interface{}) bool {
+	err := c.ShouldBindJSON(request)
+	if err == nil {
+		return true
+	}
+
+	_ = c.Error(bindingError(err))
+	c.Abort()
+	return false
+}
+
+// bindQuery binds query of the request into request and validates it by its `binding` tags, it fails
+// like bindJSON
+func bindQuery(c *gin.Context, request interface{}) bool {
+	err := c.ShouldBindQuery(request)
+	if err == nil {
+		return true
+	}
+
+	_ = c.Error(bindingError(err))
+	c.Abort()
+	return false
+}
+
+// pathUUID parses uuid path parameter of the name, it is a bad request when it is not a uuid
+func pathUUID(c *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		_ = c.Error(apperrors.NewBadRequest("invalid "+name, apperrors.FieldError{Field: name, Message: "must be a uuid"}))
+		c.Abort()
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+func bindingError(err error) error {
+	var invalid validator.ValidationErrors
+	if errors.As(err, &invalid) {
+		fields := make([]apperrors.FieldError, 0, len(invalid))
+		for _, field := range invalid {
+			fields = append(fields, apperrors.FieldError{Field: fieldName(field.Namespace()), Message: ruleMessage(field)})
+		}
+		return apperrors.NewValidation("request is not valid", fields...)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return apperrors.NewBadRequest("request body is not valid", apperrors.FieldError{Field: typeErr.Field, Message: "must be " + typeErr.Type.String()})
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return apperrors.NewBadRequest("request body is not valid json")
+	}
+	if errors.Is(err, io.EOF) {
+		return apperrors.NewBadRequest("request body is required")
+	}
+
+	return apperrors.NewBadRequest(err.Error())
+}
+
+// fieldName json path of the field from its namespace, leaving out the request and structs
+// embedded in it, which are named by their go names
+func fieldName(namespace string) string {
+	parts := strings.Split(namespace, ".")
+	names := make([]string, 0, len(parts))
+	for _, part := range parts[1:] {
+		if part != "" && unicode.IsUpper(rune(part[0])) {
+			continue
+		}
+		names = append(names, part)
+	}
+
+	return strings.Join(names, ".")
+}
+
+// ruleMessage describes the rule the field failed
+func ruleMessage(field validator.FieldError) string {
+	switch field.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be an email address"
+	case "e164":
+		return "must be a phone number in E.164 format, e.g. +9779801234567"
+	case "uuid":
+		return "must be a uuid"
+	case "url":
+		return "must be a url"
+	case "enum":
+		return "is not one of the allowed values"
+	case "oneof":
+		return "must be one of " + field.Param()
+	case "min":
+		if field.Kind() == reflect.String {
+			return "must be at least " + field.Param() + " characters"
+		}
+		return "must be at least " + field.Param()
+	case "max":
+		if field.Kind() == reflect.String {
+			return "must be at most " + field.Param() + " characters"
+		}
+		return "must be at most " + field.Param()
+	case "gt":
+		return "must be greater than " + field.Param()
+	case "gte":
+		return "must be at least " + field.Param()
+	case "required_without":
+		return "is required when " + snakeCase(field.Param()) + " is not given"
+	case "unique":
+		return "must not contain duplicates"
+	case "dive":
+		return "is not valid"
+	}
+
+	return "failed " + field.Tag() + " rule"
+}
+
+// snakeCase json name of the go field name in rule params, e.g. PublishAt to publish_at
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
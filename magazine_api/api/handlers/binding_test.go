@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+	"magazine_api/api/serializers/requests"
+	"magazine_api/apperrors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// jsonContext test context of request with the json body
+func jsonContext(body string) (*gin.Context, *httptest.ResponseRecorder) {
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return c, recorder
+}
+
+func TestBindJSONEndOfScheduleWithoutStart(t *testing.T) {
+	contributor := `"contributor_id": "3f1c2a4e-5b6d-4e7f-8a9b-0c1d2e3f4a5b"`
+	tests := []struct {
+		name    string
+		request interface{}
+		body    string
+	}{
+		{"patch story", &requests.PatchStory{}, `{"unpublish_at": "2030-01-01T00:00:00Z"}`},
+		{"patch issue", &requests.PatchIssue{}, `{"unpublish_at": "2030-01-01T00:00:00Z"}`},
+		{"patch advert", &requests.PatchAdvert{}, `{"unpublish_at": "2030-01-01T00:00:00Z"}`},
+		{"patch agreement", &requests.PatchAgreement{}, `{"effective_to": "2030-01-01T00:00:00Z"}`},
+		{"create story", &requests.CreateStory{},
+			`{"story_title": "t", "story_type": "news", "story_content": "c", "unpublish_at": "2030-01-01T00:00:00Z"}`},
+		{"create issue", &requests.CreateIssue{}, `{"issue_code": "i", "unpublish_at": "2030-01-01T00:00:00Z"}`},
+		{"create advert", &requests.CreateAdvert{},
+			`{"advert_code": "a", "advert_title": "t", "unpublish_at": "2030-01-01T00:00:00Z"}`},
+		{"create agreement", &requests.CreateAgreement{}, `{` + contributor + `, "effective_to": "2030-01-01T00:00:00Z"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := jsonContext(tt.body)
+			if !bindJSON(c, tt.request) {
+				t.Errorf("request refused: %v", c.Errors.Last())
+			}
+		})
+	}
+}
+
+func TestBindJSONRefusesInvalidRequest(t *testing.T) {
+	c, _ := jsonContext(`{"story_title": ""}`)
+	if bindJSON(c, &requests.CreateStory{}) {
+		t.Fatal("invalid request bound")
+	}
+	if !c.IsAborted() || len(c.Errors) != 1 {
+		t.Errorf("error not handed to the error middleware: %v", c.Errors)
+	}
+}
+
+func TestBindJSONCreateUser(t *testing.T) {
+	user := `"name": "Ada", "email": "ada@example.com", "role": ["employee"], "password": "long-enough"`
+	tests := []struct {
+		name  string
+		body  string
+		valid bool
+	}{
+		{"without salary", `{` + user + `}`, true},
+		{"with salary", `{` + user + `, "salary": {"amount": 1200, "salary_format": "Monthly"}}`, true},
+		{"without password", `{"name": "Ada", "email": "ada@example.com", "role": ["employee"]}`, false},
+		{"unknown role", `{"name": "Ada", "email": "ada@example.com", "role": ["owner"], "password": "long-enough"}`, false},
+		{"negative salary", `{` + user + `, "salary": {"amount": -1}}`, false},
+		{"unknown salary format", `{` + user + `, "salary": {"salary_format": "Weekly"}}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := jsonContext(tt.body)
+			if got := bindJSON(c, &requests.CreateUser{}); got != tt.valid {
+				t.Errorf("request bound %v, want %v: %v", got, tt.valid, c.Errors.Last())
+			}
+		})
+	}
+}
+
+func TestBindJSONRefusesDuplicateBookingsOfInvoice(t *testing.T) {
+	booking := `"3f1c2a4e-5b6d-4e7f-8a9b-0c1d2e3f4a5b"`
+	c, _ := jsonContext(`{"booking_ids": [` + booking + `, "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d", ` + booking + `]}`)
+	if bindJSON(c, &requests.CreateInvoice{}) {
+		t.Fatal("invoice of a booking twice bound")
+	}
+
+	var e *apperrors.Error
+	if !errors.As(c.Errors.Last().Err, &e) || e.Kind != apperrors.Validation {
+		t.Fatalf("got %v, want validation error", c.Errors.Last())
+	}
+	if len(e.Fields) != 1 || e.Fields[0].Field != "booking_ids" || e.Fields[0].Message != "must not contain duplicates" {
+		t.Errorf("got fields %v, want duplicates of booking_ids", e.Fields)
+	}
+}
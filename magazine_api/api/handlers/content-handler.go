@@ -1,43 +1,68 @@
 package handlers
 
 import (
+	"magazine_api/api/serializers/requests"
+	"magazine_api/api/serializers/responses"
 	"magazine_api/lib"
-	"magazine_api/models/magazine"
+	"magazine_api/orchestrators"
 	"magazine_api/services"
 	"time"
 
 	"github.com/danhper/structomap"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 )
 
 type ContentHandler struct {
-	logger  lib.Logger
-	service services.ContentService
+	logger       lib.Logger
+	service      services.ContentService
+	orchestrator orchestrators.IssueOrchestrator
+	resolver     services.URLResolver
 }
 
-func NewContentHandler(logger lib.Logger, service services.ContentService) ContentHandler {
+func NewContentHandler(
+	logger lib.Logger,
+	service services.ContentService,
+	orchestrator orchestrators.IssueOrchestrator,
+	resolver services.URLResolver,
+) ContentHandler {
 	return ContentHandler{
-		logger:  logger,
-		service: service,
+		logger:       logger,
+		service:      service,
+		orchestrator: orchestrator,
+		resolver:     resolver,
 	}
 }
 
 // CreateContent godoc
 // @Summary      Create Content
-// @Description  It creates Content structure
+// @Description  It creates Content structure, photographs whose licences don't allow placement in
+// @Description  issues of the content code are rejected
 // @Tags         Content
 // @Accept       json
 // @Produce      json
-// @Param        Content  body      magazine.Content  true  "Add Content"
-// @Success      200       {object}  object{data=magazine.Content}
+// @Param        Content  body      requests.CreateContent  true  "Add Content"
+// @Success      200       {object}  object{data=responses.Content}
+// @Failure      422       {object}  responses.Problem
+// @Security     BearerAuth
 // @Router       /content [post]
 //
 // Creates Content
 func (s ContentHandler) CreateContent(c *gin.Context) {
-	var content *magazine.Content
-	if err := c.ShouldBind(&content); err != nil {
-		handleError(s.logger, c, err)
+	userID, ok := currentUserID(c)
+	if !ok {
+		handleError(s.logger, c, errNotAuthenticated)
+		return
+	}
+
+	var request requests.CreateContent
+	if !bindJSON(c, &request) {
+		return
+	}
+	content := request.Content()
+	content.CreatedBy = &userID
+
+	if err := s.orchestrator.CheckContentLicences(content.ContentBase); err != nil {
+		handleLicenceError(s.logger, c, err)
 		return
 	}
 
@@ -48,7 +73,7 @@ func (s ContentHandler) CreateContent(c *gin.Context) {
 		return
 	}
 
-	c.JSON(200, gin.H{"data": content})
+	c.JSON(200, gin.H{"data": responses.NewContent(content)})
 }
 
 // ListAllContentss godoc
@@ -56,18 +81,24 @@ func (s ContentHandler) CreateContent(c *gin.Context) {
 // @Description  List contents
 // @Tags         Content
 // @Produce      json
-// @Success      200  {object}  object{data=[]magazine.Content}
+// @Param        filter  query     string  false  "filter[column]=value or filter[column][eq|ne|gt|gte|lt|lte|like|in|null]=value"
+// @Param        sort    query     string  false  "columns to sort by, - sorts descending"
+// @Param        fields  query     string  false  "fields to send"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200     {object}  object{data=[]responses.Content,next_cursor=string,total=int}
 // @Router       /content [get]
 //
 // List all suppliers from database
 func (s ContentHandler) ListContents(c *gin.Context) {
-	contents, err := s.service.ListContents(c)
+	contents, page, err := s.service.ListContents(c)
 	if err != nil {
 		handleError(s.logger, c, err)
 		return
 	}
 
-	c.JSON(200, contents)
+	respondPage(s.logger, s.resolver, c, responses.List(contents, responses.NewContent), page)
 }
 
 // ListContentFromUserId godoc
@@ -75,22 +106,31 @@ func (s ContentHandler) ListContents(c *gin.Context) {
 // @Description  List Content by creator id
 // @Tags         Content
 // @Produce      json
-// @Param        id   path      string  true  "ID"
-// @Success      200  {object}  object{data=[]magazine.Content}
+// @Param        id      path      string  true   "ID"
+// @Param        filter  query     string  false  "filter[column]=value or filter[column][eq|ne|gt|gte|lt|lte|like|in|null]=value"
+// @Param        sort    query     string  false  "columns to sort by, - sorts descending"
+// @Param        fields  query     string  false  "fields to send"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200     {object}  object{data=[]responses.Content,next_cursor=string,total=int}
 // @Router       /content/profile/{id} [get]
 //
 // List Content from creator ID controller
 func (a ContentHandler) ListContentByProfileId(c *gin.Context) {
 
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	Contents, err := a.service.ListContentsByProfileId(uuid.MustParse(id))
+	Contents, page, err := a.service.ListContentsByProfileId(id, services.PaginationFrom(c), services.ListQueryFrom(c))
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": Contents})
+	respondPage(a.logger, a.resolver, c, responses.List(Contents, responses.NewContent), page)
 }
 
 // ListContentByType godoc
@@ -98,23 +138,29 @@ func (a ContentHandler) ListContentByProfileId(c *gin.Context) {
 // @Description  List Content by type
 // @Tags         Content
 // @Produce      json
-// @Param        content_type   path      string  true  "TYPE"
-// @Success      200  {object}  object{data=[]magazine.Content}
+// @Param        content_type path      string  true   "TYPE"
+// @Param        filter  query     string  false  "filter[column]=value or filter[column][eq|ne|gt|gte|lt|lte|like|in|null]=value"
+// @Param        sort    query     string  false  "columns to sort by, - sorts descending"
+// @Param        fields  query     string  false  "fields to send"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200     {object}  object{data=[]responses.Content,next_cursor=string,total=int}
 // @Router       /content/type/{content_type} [get]
 //
 // List Content from creator ID controller
 func (a ContentHandler) ListContentsByType(c *gin.Context) {
 
-	content_type := c.Param("type")
+	content_type := c.Param("content_type")
 
-	Contents, err := a.service.ListContentsByType(content_type)
+	Contents, page, err := a.service.ListContentsByType(content_type, services.PaginationFrom(c), services.ListQueryFrom(c))
 
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": Contents})
+	respondPage(a.logger, a.resolver, c, responses.List(Contents, responses.NewContent), page)
 }
 
 // GetContentById godoc
@@ -123,20 +169,23 @@ func (a ContentHandler) ListContentsByType(c *gin.Context) {
 // @Tags         Content
 // @Produce      json
 // @Param        id   path      string  true  "ID"
-// @Success      200  {object}  object{data=models.Content}
+// @Success      200  {object}  object{data=responses.Content}
 // @Router       /content/id/{id} [get]
 //
 // Gets Content By Company ID controller
 func (a ContentHandler) GetContentById(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	Content, err := a.service.GetContentById(uuid.MustParse(id))
+	Content, err := a.service.GetContentById(id)
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": Content})
+	c.JSON(200, gin.H{"data": responses.NewContent(Content)})
 }
 
 // UpdateContent godoc
@@ -146,26 +195,28 @@ func (a ContentHandler) GetContentById(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Update Content"
-// @Success      200  {object}  object{data=models.Content}
+// @Success      200  {object}  object{data=responses.Content}
+// @Failure      422  {object}  responses.Problem
 // @Router       /content/{id} [patch]
 // Patch Content of creator by Id controller
 func (a ContentHandler) PatchContentById(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	Content, err := a.service.GetContentById(uuid.MustParse(id))
+	Content, err := a.service.GetContentById(id)
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	var newContent magazine.ContentBase
-	if err := c.ShouldBindJSON(&newContent); err != nil {
-		handleError(a.logger, c, err)
+	var newContent requests.PatchContent
+	if !bindJSON(c, &newContent) {
 		return
 	}
 
 	ContentMap := structomap.New().UseSnakeCase().PickAll().
-		Omit("CreatorId").
 		OmitIf(func(ch interface{}) bool {
 			return newContent.ContentCode == nil
 		}, "ContentCode").
@@ -181,6 +232,21 @@ func (a ContentHandler) PatchContentById(c *gin.Context) {
 		Transform(newContent)
 
 	if len(ContentMap) > 0 {
+		placed := Content.ContentBase
+		if newContent.ContentCode != nil {
+			placed.ContentCode = newContent.ContentCode
+		}
+		if newContent.StoryCode != nil {
+			placed.StoryCode = newContent.StoryCode
+		}
+		if newContent.PhotographCode != nil {
+			placed.PhotographCode = newContent.PhotographCode
+		}
+		if err := a.orchestrator.CheckContentLicences(placed); err != nil {
+			handleLicenceError(a.logger, c, err)
+			return
+		}
+
 		ContentMap["updated_on"] = time.Now()
 		ContentMap["id"] = Content.ID
 
@@ -190,7 +256,13 @@ func (a ContentHandler) PatchContentById(c *gin.Context) {
 			return
 		}
 
-		c.JSON(200, gin.H{"data": ContentMap})
+		updated, err := a.service.GetContentById(Content.ID)
+		if err != nil {
+			handleError(a.logger, c, err)
+			return
+		}
+
+		c.JSON(200, gin.H{"data": responses.NewContent(updated)})
 		return
 	}
 
@@ -208,9 +280,12 @@ func (a ContentHandler) PatchContentById(c *gin.Context) {
 //
 // Delete Content By ID controller
 func (a ContentHandler) DeleteContentByID(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	err := a.service.DeleteContent(uuid.MustParse(id))
+	err := a.service.DeleteContent(id)
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
@@ -1,25 +1,31 @@
 package handlers
 
 import (
+	"magazine_api/api/serializers/requests"
+	"magazine_api/api/serializers/responses"
 	"magazine_api/lib"
-	"magazine_api/models"
 	"magazine_api/orchestrators"
 	"magazine_api/services"
 	"time"
 
 	"github.com/danhper/structomap"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 )
 
 type EmployeeHandler struct {
 	logger            lib.Logger
 	service           services.UserService
 	user_orchestrator orchestrators.UserOrchestrator
+	resolver          services.URLResolver
 }
 
-func NewEmployeeHandler(logger lib.Logger, service services.UserService, u orchestrators.UserOrchestrator) EmployeeHandler {
-	return EmployeeHandler{logger: logger, service: service, user_orchestrator: u}
+func NewEmployeeHandler(
+	logger lib.Logger,
+	service services.UserService,
+	u orchestrators.UserOrchestrator,
+	resolver services.URLResolver,
+) EmployeeHandler {
+	return EmployeeHandler{logger: logger, service: service, user_orchestrator: u, resolver: resolver}
 }
 
 // ListUsers godoc
@@ -27,18 +33,21 @@ func NewEmployeeHandler(logger lib.Logger, service services.UserService, u orche
 // @Description  List users
 // @Tags         Employee
 // @Produce      json
-// @Success      200   {object}  object{data=[]models.User}
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200   {object}  object{data=[]responses.EmployeeSmall,next_cursor=string,total=int}
 // @Router       /employee [get]
 //
 // List Employees controller
 func (u EmployeeHandler) ListEmployees(c *gin.Context) {
-	users, err := u.service.ListEmployees(c)
+	users, page, err := u.service.ListEmployees(c)
 	if err != nil {
 		handleError(u.logger, c, err)
 		return
 	}
 
-	c.JSON(200, users)
+	respondPage(u.logger, u.resolver, c, users, page)
 }
 
 // ListDeletedUsers godoc
@@ -46,7 +55,7 @@ func (u EmployeeHandler) ListEmployees(c *gin.Context) {
 // @Description  Lists Deleted users
 // @Tags         Employee
 // @Produce      json
-// @Success      200  {object}  object{data=[]models.User}
+// @Success      200  {object}  object{data=[]responses.User}
 // @Router       /employee/deleted [get]
 //
 // List Deleted Employee controller
@@ -57,7 +66,7 @@ func (u EmployeeHandler) ListDeletedEmployee(c *gin.Context) {
 		return
 	}
 
-	c.JSON(200, gin.H{"data": users})
+	respondResolved(u.logger, u.resolver, c, gin.H{"data": responses.List(users, responses.NewUser)})
 }
 
 // GetProfielByEmployeeID godoc
@@ -70,15 +79,18 @@ func (u EmployeeHandler) ListDeletedEmployee(c *gin.Context) {
 // @Router       /employee/profile/{id} [get]
 // Gets Employee By ID controller
 func (u EmployeeHandler) GetProfileByID(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	user, err := u.service.GetProfileByID(uuid.MustParse(id))
+	user, err := u.service.GetProfileByID(id)
 	if err != nil {
 		handleError(u.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": user})
+	respondResolved(u.logger, u.resolver, c, gin.H{"data": user})
 }
 
 // GetOneUserByEmail godoc
@@ -87,7 +99,7 @@ func (u EmployeeHandler) GetProfileByID(c *gin.Context) {
 // @Tags         Employee
 // @Produce      json
 // @Param        email  path      string  true  "Email"  Format(email)
-// @Success      200    {object}  object{data=models.User}
+// @Success      200    {object}  object{data=responses.User}
 // @Router       /employee/email/{email} [get]
 // Gets Users By Email controller
 func (u EmployeeHandler) GetEmployeeByEmail(c *gin.Context) {
@@ -99,7 +111,7 @@ func (u EmployeeHandler) GetEmployeeByEmail(c *gin.Context) {
 		return
 	}
 
-	c.JSON(200, gin.H{"data": user})
+	respondResolved(u.logger, u.resolver, c, gin.H{"data": responses.NewUser(user)})
 }
 
 // ListUsersByType godoc
@@ -108,19 +120,22 @@ func (u EmployeeHandler) GetEmployeeByEmail(c *gin.Context) {
 // @Tags         Employee
 // @Produce      json
 // @Param        type  path      string  true  "Type"
-// @Success      200  {object}  object{data=[]models.User}
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200  {object}  object{data=[]responses.EmployeeSmall,next_cursor=string,total=int}
 // @Router       /employee/type/{type} [get]
 //
 // List Users By Type controller
 func (u EmployeeHandler) ListEmployeesByType(c *gin.Context) {
 	userType := c.Param("type")
-	users, err := u.service.ListEmployeesByType(c, userType)
+	users, page, err := u.service.ListEmployeesByType(c, userType)
 	if err != nil {
 		handleError(u.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": users})
+	respondPage(u.logger, u.resolver, c, users, page)
 }
 
 // GetOneUserByContactNumber godoc
@@ -129,7 +144,7 @@ func (u EmployeeHandler) ListEmployeesByType(c *gin.Context) {
 // @Tags         Employee
 // @Produce      json
 // @Param        contact_number  path      string  true  "Contact Number"
-// @Success      200             {object}  object{data=models.User}
+// @Success      200             {object}  object{data=responses.User}
 // @Router       /employee/contact/{contact} [get]
 // Gets Users By Contact Number controller
 func (u EmployeeHandler) GetEmployeeByContactNumber(c *gin.Context) {
@@ -141,7 +156,7 @@ func (u EmployeeHandler) GetEmployeeByContactNumber(c *gin.Context) {
 		return
 	}
 
-	c.JSON(200, gin.H{"data": user})
+	respondResolved(u.logger, u.resolver, c, gin.H{"data": responses.NewUser(user)})
 }
 
 // GetOneEmployeeByID godoc
@@ -150,19 +165,22 @@ func (u EmployeeHandler) GetEmployeeByContactNumber(c *gin.Context) {
 // @Tags         Employee
 // @Produce      json
 // @Param        id   path      string  true  "ID"
-// @Success      200  {object}  object{data=responses.EmployeeAll}
+// @Success      200  {object}  object{data=responses.User}
 // @Router       /employee/id/{id} [get]
 // Gets Employee By ID controller
 func (u EmployeeHandler) GetEmployeeByID(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	user, err := u.service.GetUserByID(uuid.MustParse(id))
+	user, err := u.service.GetUserByID(id)
 	if err != nil {
 		handleError(u.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": user})
+	respondResolved(u.logger, u.resolver, c, gin.H{"data": responses.NewUser(user)})
 }
 
 // DeleteUser godoc
@@ -175,9 +193,12 @@ func (u EmployeeHandler) GetEmployeeByID(c *gin.Context) {
 // @Router       /employee/{id} [delete]
 // Delete Users By ID controller
 func (u EmployeeHandler) DeleteEmployeeByID(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	err := u.service.DeleteUser(uuid.MustParse(id))
+	err := u.service.DeleteUser(id)
 	if err != nil {
 		handleError(u.logger, c, err)
 		return
@@ -193,22 +214,24 @@ func (u EmployeeHandler) DeleteEmployeeByID(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id    path      string           true  "User ID"
-// @Param        user  body      models.UserBase  true  "Update user"
-// @Success      200   {object}  object{data=models.User}
+// @Param        user  body      requests.PatchUser  true  "Update user"
+// @Success      200   {object}  object{data=responses.User}
 // @Router       /employee/{id} [patch]
 // Patch Users By ID controller
 func (u EmployeeHandler) PatchEmployee(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	user, err := u.service.GetUserByID(uuid.MustParse(id))
+	user, err := u.service.GetUserByID(id)
 	if err != nil {
 		handleError(u.logger, c, err)
 		return
 	}
 
-	var newUser models.UserBase
-	if err := c.ShouldBindJSON(&newUser); err != nil {
-		handleError(u.logger, c, err)
+	var newUser requests.PatchUser
+	if !bindJSON(c, &newUser) {
 		return
 	}
 
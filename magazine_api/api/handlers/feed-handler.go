@@ -0,0 +1,317 @@
+package handlers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"magazine_api/api/serializers/responses"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"magazine_api/services"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	feedRSS  = "rss"
+	feedAtom = "atom"
+	feedJSON = "json"
+)
+
+// FeedHandler serves feeds of published stories and issues for partners and aggregators
+type FeedHandler struct {
+	logger   lib.Logger
+	env      lib.Env
+	service  services.FeedService
+	resolver services.URLResolver
+}
+
+func NewFeedHandler(
+	logger lib.Logger,
+	env lib.Env,
+	service services.FeedService,
+	resolver services.URLResolver,
+) FeedHandler {
+	return FeedHandler{
+		logger:   logger,
+		env:      env,
+		service:  service,
+		resolver: resolver,
+	}
+}
+
+// StoriesRSS godoc
+// @Summary      RSS feed of stories
+// @Description  RSS 2.0 feed of newest published stories with summaries and lead images, of the story type when it is in the path
+// @Tags         Feed
+// @Produce      xml
+// @Param        story_type  path      string  false  "story type"
+// @Success      200
+// @Success      304
+// @Router       /feeds/stories.rss [get]
+// @Router       /feeds/type/{story_type}/stories.rss [get]
+//
+// Serves RSS feed of published stories
+func (f FeedHandler) StoriesRSS(c *gin.Context) {
+	f.storyFeed(c, feedRSS)
+}
+
+// StoriesAtom godoc
+// @Summary      Atom feed of stories
+// @Description  Atom 1.0 feed of newest published stories with summaries and lead images, of the story type when it is in the path
+// @Tags         Feed
+// @Produce      xml
+// @Param        story_type  path      string  false  "story type"
+// @Success      200
+// @Success      304
+// @Router       /feeds/stories.atom [get]
+// @Router       /feeds/type/{story_type}/stories.atom [get]
+//
+// Serves Atom feed of published stories
+func (f FeedHandler) StoriesAtom(c *gin.Context) {
+	f.storyFeed(c, feedAtom)
+}
+
+// IssuesJSON godoc
+// @Summary      JSON Feed of issues
+// @Description  JSON Feed 1.1 of newest published issues summarised by titles of their stories
+// @Tags         Feed
+// @Produce      json
+// @Success      200  {object}  responses.JSONFeed
+// @Success      304
+// @Router       /feeds/issues.json [get]
+//
+// Serves JSON Feed of published issues
+func (f FeedHandler) IssuesJSON(c *gin.Context) {
+	feed, err := f.service.IssueFeed(siteURL(f.env, c), f.feedURL(c))
+	if err != nil {
+		handleError(f.logger, c, err)
+		return
+	}
+
+	f.respondFeed(c, feed, feedJSON)
+}
+
+// Sitemap godoc
+// @Summary      Sitemap of published content
+// @Description  XML sitemap listing pages of every published story and issue with their last modification
+// @Tags         Feed
+// @Produce      xml
+// @Success      200
+// @Success      304
+// @Router       /sitemap.xml [get]
+//
+// Serves sitemap of published stories and issues
+func (f FeedHandler) Sitemap(c *gin.Context) {
+	urls, err := f.service.Sitemap(siteURL(f.env, c))
+	if err != nil {
+		handleError(f.logger, c, err)
+		return
+	}
+
+	version, err := json.Marshal(urls)
+	if err != nil {
+		handleError(f.logger, c, err)
+		return
+	}
+
+	var lastModified time.Time
+	sitemap := responses.Sitemap{URLs: make([]responses.SitemapURL, len(urls))}
+	for i, url := range urls {
+		sitemap.URLs[i] = responses.SitemapURL{Loc: url.Loc}
+		if !url.LastMod.IsZero() {
+			sitemap.URLs[i].LastMod = url.LastMod.UTC().Format(time.RFC3339)
+		}
+		if url.LastMod.After(lastModified) {
+			lastModified = url.LastMod
+		}
+	}
+	if notModified(c, version, lastModified) {
+		return
+	}
+
+	body, err := xml.MarshalIndent(sitemap, "", "  ")
+	if err != nil {
+		handleError(f.logger, c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", append([]byte(xml.Header), body...))
+}
+
+func (f FeedHandler) storyFeed(c *gin.Context, format string) {
+	feed, err := f.service.StoryFeed(siteURL(f.env, c), f.feedURL(c), c.Param("story_type"))
+	if err != nil {
+		handleError(f.logger, c, err)
+		return
+	}
+
+	f.respondFeed(c, feed, format)
+}
+
+// respondFeed renders the feed in the format, urls of images are resolved after its version is taken
+func (f FeedHandler) respondFeed(c *gin.Context, feed *models.Feed, format string) {
+	version, err := json.Marshal(feed)
+	if err != nil {
+		handleError(f.logger, c, err)
+		return
+	}
+	if notModified(c, version, feed.Updated) {
+		return
+	}
+
+	if err := f.resolver.Resolve(c, feed); err != nil {
+		handleError(f.logger, c, err)
+		return
+	}
+
+	var body []byte
+	var contentType string
+	switch format {
+	case feedRSS:
+		contentType = "application/rss+xml; charset=utf-8"
+		body, err = xml.MarshalIndent(rssFeed(feed), "", "  ")
+	case feedAtom:
+		contentType = "application/atom+xml; charset=utf-8"
+		body, err = xml.MarshalIndent(f.atomFeed(feed), "", "  ")
+	default:
+		contentType = "application/feed+json; charset=utf-8"
+		body, err = json.Marshal(jsonFeed(feed))
+	}
+	if err != nil {
+		handleError(f.logger, c, err)
+		return
+	}
+	if format != feedJSON {
+		body = append([]byte(xml.Header), body...)
+	}
+
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// siteURL website of readers, the host of the request when it is not configured
+func siteURL(env lib.Env, c *gin.Context) string {
+	if env.SiteURL != "" {
+		return strings.TrimSuffix(env.SiteURL, "/")
+	}
+	return requestOrigin(c)
+}
+
+func (f FeedHandler) feedURL(c *gin.Context) string {
+	return requestOrigin(c) + c.Request.URL.Path
+}
+
+// requestOrigin scheme and host the request was sent to, behind proxies forwarded proto is used
+func requestOrigin(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	return scheme + "://" + c.Request.Host
+}
+
+func rssFeed(feed *models.Feed) responses.RSS {
+	rss := responses.RSS{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		Media:   "http://search.yahoo.com/mrss/",
+		Channel: responses.RSSChannel{
+			Title:       feed.Title,
+			Link:        feed.Link,
+			Description: feed.Description,
+			Self:        responses.AtomLink{Href: feed.FeedURL, Rel: "self", Type: "application/rss+xml"},
+		},
+	}
+	if !feed.Updated.IsZero() {
+		rss.Channel.LastBuildDate = feed.Updated.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, item := range feed.Items {
+		rssItem := responses.RSSItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			Description: item.Summary,
+			GUID:        responses.RSSGUID{Value: item.ID},
+			PubDate:     item.Published.UTC().Format(time.RFC1123Z),
+			Category:    item.Category,
+		}
+		if item.Image != nil && *item.Image != "" {
+			rssItem.Image = &responses.RSSMedia{URL: string(*item.Image), Medium: "image"}
+		}
+		rss.Channel.Items = append(rss.Channel.Items, rssItem)
+	}
+
+	return rss
+}
+
+func (f FeedHandler) atomFeed(feed *models.Feed) responses.AtomFeed {
+	atom := responses.AtomFeed{
+		ID:       feed.FeedURL,
+		Title:    feed.Title,
+		Subtitle: feed.Description,
+		Updated:  feed.Updated.UTC().Format(time.RFC3339),
+		Author:   responses.AtomAuthor{Name: f.env.FeedTitle},
+		Links: []responses.AtomLink{
+			{Href: feed.FeedURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: feed.Link, Rel: "alternate", Type: "text/html"},
+		},
+	}
+
+	for _, item := range feed.Items {
+		entry := responses.AtomEntry{
+			ID:        "urn:uuid:" + item.ID,
+			Title:     item.Title,
+			Updated:   item.Updated.UTC().Format(time.RFC3339),
+			Published: item.Published.UTC().Format(time.RFC3339),
+			Summary:   item.Summary,
+			Links:     []responses.AtomLink{{Href: item.Link, Rel: "alternate", Type: "text/html"}},
+		}
+		if item.Image != nil && *item.Image != "" {
+			entry.Links = append(entry.Links, responses.AtomLink{Href: string(*item.Image), Rel: "enclosure"})
+		}
+		if item.Category != "" {
+			entry.Category = &responses.AtomCategory{Term: item.Category}
+		}
+		atom.Entries = append(atom.Entries, entry)
+	}
+
+	return atom
+}
+
+func jsonFeed(feed *models.Feed) responses.JSONFeed {
+	data := responses.JSONFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       feed.Title,
+		HomePageURL: feed.Link,
+		FeedURL:     feed.FeedURL,
+		Description: feed.Description,
+		Items:       []responses.JSONFeedItem{},
+	}
+
+	for _, item := range feed.Items {
+		jsonItem := responses.JSONFeedItem{
+			ID:            item.ID,
+			URL:           item.Link,
+			Title:         item.Title,
+			Summary:       item.Summary,
+			ContentText:   item.Summary,
+			DatePublished: item.Published.UTC().Format(time.RFC3339),
+			DateModified:  item.Updated.UTC().Format(time.RFC3339),
+		}
+		if item.Image != nil {
+			jsonItem.Image = string(*item.Image)
+		}
+		if item.Category != "" {
+			jsonItem.Tags = []string{item.Category}
+		}
+		data.Items = append(data.Items, jsonItem)
+	}
+
+	return data
+}
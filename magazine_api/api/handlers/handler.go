@@ -1,10 +1,21 @@
 package handlers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"magazine_api/api/serializers/responses"
+	"magazine_api/apperrors"
+	"magazine_api/constants"
 	"magazine_api/lib"
+	"magazine_api/models"
+	"magazine_api/services"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"go.uber.org/fx"
 )
 
@@ -21,11 +32,145 @@ var Module = fx.Options(
 	fx.Provide(NewMagazineIssueHandler),
 	fx.Provide(NewMagazineHandler),
 	fx.Provide(NewPhotoHandler),
+	fx.Provide(NewAdminUserHandler),
+	fx.Provide(NewSubscriptionHandler),
+	fx.Provide(NewAdvertisingHandler),
+	fx.Provide(NewRoyaltyHandler),
+	fx.Provide(NewStorageHandler),
+	fx.Provide(NewMediaHandler),
+	fx.Provide(NewScheduleHandler),
+	fx.Provide(NewPublicHandler),
+	fx.Provide(NewFeedHandler),
 )
 
+// errNotAuthenticated request of handler needing the user made without token
+var errNotAuthenticated = apperrors.NewUnauthorized("user not authenticated")
+
+// currentUserID gets id of the authenticated user from request context
+func currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	uid, ok := c.Get(constants.UID)
+	if !ok {
+		return uuid.Nil, false
+	}
+
+	username, _ := uid.(string)
+	id, err := uuid.Parse(username)
+	if err != nil {
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+// handleError hands err to the error middleware, which renders it as problem of its kind
 func handleError(logger lib.Logger, c *gin.Context, err error) {
-	logger.Error(err)
-	c.JSON(http.StatusInternalServerError, gin.H{
-		"error": err.Error(),
-	})
+	err = apperrors.From(err)
+	if kind := apperrors.KindOf(err); kind != apperrors.Internal {
+		logger.Debug(err)
+	}
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// respondResolved resolves urls of stored objects in data and responds with it
+func respondResolved(logger lib.Logger, resolver services.URLResolver, c *gin.Context, data interface{}) {
+	if err := resolver.Resolve(c, data); err != nil {
+		handleError(logger, c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, data)
+}
+
+// respondPage responds with page of rows like respondResolved, rows only have the fields asked for
+// when the list was queried by fields
+func respondPage(logger lib.Logger, resolver services.URLResolver, c *gin.Context, rows interface{}, page models.PageInfo) {
+	if err := resolver.Resolve(c, rows); err != nil {
+		handleError(logger, c, err)
+		return
+	}
+
+	data, err := pickFields(rows, services.ListQueryFrom(c).Fields)
+	if err != nil {
+		handleError(logger, c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.PageBody(data, page))
+}
+
+// pickFields rows with only the fields by their names in json, rows are returned as they are without fields
+func pickFields(rows interface{}, fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return rows, nil
+	}
+
+	by, err := json.Marshal(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []map[string]json.RawMessage
+	if err := json.Unmarshal(by, &all); err != nil {
+		return nil, err
+	}
+
+	picked := make([]map[string]json.RawMessage, len(all))
+	for i, row := range all {
+		picked[i] = make(map[string]json.RawMessage, len(fields))
+		for _, field := range fields {
+			if value, ok := row[field]; ok {
+				picked[i][field] = value
+			}
+		}
+	}
+
+	return picked, nil
+}
+
+// respondCached responds like respondResolved with ETag and Last-Modified headers, 304 is sent
+// when the client has the same version. ETag is taken before urls are resolved as presigned urls
+// differ on every request
+func respondCached(logger lib.Logger, resolver services.URLResolver, c *gin.Context, lastModified time.Time, data interface{}) {
+	version, err := json.Marshal(data)
+	if err != nil {
+		handleError(logger, c, err)
+		return
+	}
+
+	if notModified(c, version, lastModified) {
+		return
+	}
+
+	respondResolved(logger, resolver, c, data)
+}
+
+// notModified sets ETag of the version and Last-Modified headers and responds with 304
+// when the client has the same version
+func notModified(c *gin.Context, version []byte, lastModified time.Time) bool {
+	sum := sha256.Sum256(append(version, c.Query("variant")...))
+	etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Vary", "Authorization")
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if match := c.GetHeader("If-None-Match"); match != "" {
+		for _, tag := range strings.Split(match, ",") {
+			if tag = strings.TrimSpace(tag); tag == etag || tag == "*" {
+				c.Status(http.StatusNotModified)
+				return true
+			}
+		}
+	} else if since, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err == nil && !lastModified.IsZero() {
+		if !lastModified.Truncate(time.Second).After(since) {
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+
+	return false
 }
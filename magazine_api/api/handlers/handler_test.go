@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"errors"
+	"magazine_api/api/serializers/requests"
+	"magazine_api/apperrors"
+	"magazine_api/lib"
+	"magazine_api/services"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestHandleErrorKinds(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"schedule window", services.ErrScheduleWindow, http.StatusUnprocessableEntity},
+		{"not authenticated", errNotAuthenticated, http.StatusUnauthorized},
+		{"refused upload", services.UploadError(services.ErrUploadTooManyPixels), http.StatusRequestEntityTooLarge},
+		{"unknown", errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := jsonContext("")
+			handleError(lib.GetLogger(), c, tt.err)
+
+			if !c.IsAborted() || c.Errors.Last() == nil {
+				t.Fatal("error not handed to the error middleware")
+			}
+			if status := apperrors.Status(apperrors.KindOf(c.Errors.Last().Err)); status != tt.status {
+				t.Errorf("got status %d, want %d", status, tt.status)
+			}
+		})
+	}
+}
+
+func TestHandleStoryBodyErrorNamesBlock(t *testing.T) {
+	tests := []struct {
+		err   *services.StoryBodyError
+		field string
+	}{
+		{&services.StoryBodyError{Block: -1, Message: "blocks are required"}, "story_body"},
+		{&services.StoryBodyError{Block: 2, Message: "text is required"}, "story_body.blocks[2]"},
+	}
+
+	for _, tt := range tests {
+		c, _ := jsonContext("")
+		handleStoryBodyError(lib.GetLogger(), c, tt.err)
+
+		var e *apperrors.Error
+		if !errors.As(c.Errors.Last().Err, &e) || e.Kind != apperrors.Validation {
+			t.Fatalf("got %v, want validation error", c.Errors.Last())
+		}
+		if len(e.Fields) != 1 || e.Fields[0].Field != tt.field || e.Fields[0].Message != tt.err.Message {
+			t.Errorf("got fields %v, want %s: %s", e.Fields, tt.field, tt.err.Message)
+		}
+	}
+}
+
+func TestPathUUIDRefusesInvalidID(t *testing.T) {
+	c, _ := jsonContext("")
+	c.Params = append(c.Params, gin.Param{Key: "id", Value: "42"})
+
+	if _, ok := pathUUID(c, "id"); ok {
+		t.Fatal("id which is not a uuid parsed")
+	}
+	if kind := apperrors.KindOf(c.Errors.Last().Err); kind != apperrors.BadRequest {
+		t.Errorf("got kind %v, want bad request", kind)
+	}
+}
+
+func TestBindQueryLimitOfAdminUsers(t *testing.T) {
+	tests := []struct {
+		query string
+		valid bool
+		kind  apperrors.Kind
+	}{
+		{query: "", valid: true},
+		{query: "limit=1", valid: true},
+		{query: "limit=60&token=abc", valid: true},
+		{query: "limit=0", kind: apperrors.Validation},
+		{query: "limit=61", kind: apperrors.Validation},
+		{query: "limit=-5", kind: apperrors.Validation},
+		{query: "limit=99999999999", kind: apperrors.BadRequest},
+		{query: "limit=ten", kind: apperrors.BadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			c, _ := jsonContext("")
+			c.Request.URL.RawQuery = tt.query
+
+			var query requests.ListAdminUsers
+			if got := bindQuery(c, &query); got != tt.valid {
+				t.Fatalf("query bound %v, want %v: %v", got, tt.valid, c.Errors.Last())
+			}
+			if tt.valid {
+				return
+			}
+			if kind := apperrors.KindOf(c.Errors.Last().Err); kind != tt.kind {
+				t.Errorf("got kind %v, want %v", kind, tt.kind)
+			}
+		})
+	}
+}
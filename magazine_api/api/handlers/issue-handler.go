@@ -1,43 +1,79 @@
 package handlers
 
 import (
+	"magazine_api/api/serializers/requests"
+	"magazine_api/api/serializers/responses"
+	"magazine_api/apperrors"
 	"magazine_api/lib"
-	"magazine_api/models/magazine"
+	"magazine_api/orchestrators"
 	"magazine_api/services"
+	"net/http"
 	"time"
 
 	"github.com/danhper/structomap"
+	"github.com/georgysavva/scany/pgxscan"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 )
 
 type MagazineIssueHandler struct {
-	logger  lib.Logger
-	service services.MagazineIssueService
+	logger       lib.Logger
+	service      services.MagazineIssueService
+	orchestrator orchestrators.IssueOrchestrator
+	royalties    orchestrators.RoyaltyOrchestrator
+	entitlement  services.EntitlementService
+	resolver     services.URLResolver
 }
 
-func NewMagazineIssueHandler(logger lib.Logger, service services.MagazineIssueService) MagazineIssueHandler {
+func NewMagazineIssueHandler(
+	logger lib.Logger,
+	service services.MagazineIssueService,
+	orchestrator orchestrators.IssueOrchestrator,
+	royalties orchestrators.RoyaltyOrchestrator,
+	entitlement services.EntitlementService,
+	resolver services.URLResolver,
+) MagazineIssueHandler {
 	return MagazineIssueHandler{
-		logger:  logger,
-		service: service,
+		logger:       logger,
+		service:      service,
+		orchestrator: orchestrator,
+		royalties:    royalties,
+		entitlement:  entitlement,
+		resolver:     resolver,
 	}
 }
 
 // CreateMagazineIssue godoc
 // @Summary      Create MagazineIssue
-// @Description  It creates MagazineIssue structure
+// @Description  It creates MagazineIssue structure, photographs of its contents whose licences are
+// @Description  expired or don't cover editions and territory of the issue are rejected. It is published
+// @Description  when created unless publish_at is later, royalties of its contents are recorded when it is published
 // @Tags         MagazineIssue
 // @Accept       json
 // @Produce      json
-// @Param        MagazineIssue  body      magazine.MagazineIssue  true  "Add MagazineIssue"
-// @Success      200       {object}  object{data=magazine.MagazineIssue}
+// @Param        MagazineIssue  body      requests.CreateIssue  true  "Add MagazineIssue"
+// @Success      200       {object}  object{data=responses.Issue}
+// @Failure      400       {object}  responses.Problem
+// @Failure      422       {object}  responses.Problem
+// @Security     BearerAuth
 // @Router       /isssue [post]
 //
 // Creates MagazineIssue
 func (s MagazineIssueHandler) CreateMagazineIssue(c *gin.Context) {
-	var isssue *magazine.MagazineIssue
-	if err := c.ShouldBind(&isssue); err != nil {
-		handleError(s.logger, c, err)
+	userID, ok := currentUserID(c)
+	if !ok {
+		handleError(s.logger, c, errNotAuthenticated)
+		return
+	}
+
+	var request requests.CreateIssue
+	if !bindJSON(c, &request) {
+		return
+	}
+	isssue := request.Issue()
+	isssue.CreatedBy = &userID
+
+	if err := s.orchestrator.CheckIssueLicences(isssue); err != nil {
+		handleLicenceError(s.logger, c, err)
 		return
 	}
 
@@ -48,124 +84,158 @@ func (s MagazineIssueHandler) CreateMagazineIssue(c *gin.Context) {
 		return
 	}
 
-	c.JSON(200, gin.H{"data": isssue})
+	// issues published later record royalties when the schedule publishes them
+	if isssue.PublishedOn != nil {
+		s.royalties.IssuePublished(isssue.ID)
+	}
+
+	respondResolved(s.logger, s.resolver, c, gin.H{"data": responses.NewIssue(isssue)})
 }
 
 // ListAllIssuess godoc
 // @Summary      List all stories.
-// @Description  List stories
+// @Description  List issues, downloads are hidden for non-subscribers
 // @Tags         MagazineIssue
 // @Produce      json
-// @Success      200  {object}  object{data=[]magazine.MagazineIssue}
+// @Param        filter  query     string  false  "filter[column]=value or filter[column][eq|ne|gt|gte|lt|lte|like|in|null]=value"
+// @Param        sort    query     string  false  "columns to sort by, - sorts descending"
+// @Param        fields  query     string  false  "fields to send"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200     {object}  object{data=[]responses.Issue,next_cursor=string,total=int}
 // @Router       /isssue [get]
 //
 // List all suppliers from database
 func (s MagazineIssueHandler) ListIssues(c *gin.Context) {
-	stories, err := s.service.ListIssues(c)
+	stories, page, err := s.service.ListIssues(c)
 	if err != nil {
 		handleError(s.logger, c, err)
 		return
 	}
 
-	c.JSON(200, stories)
+	respondPage(s.logger, s.resolver, c, responses.List(s.entitlement.GateIssues(c, stories), responses.NewIssue), page)
 }
 
 // ListMagazineIssueFromUserId godoc
 // @Summary      Lists MagazineIssue from User Id
-// @Description  List MagazineIssue by creator id
+// @Description  List MagazineIssue by creator id, downloads are hidden for non-subscribers
 // @Tags         MagazineIssue
 // @Produce      json
-// @Param        id   path      string  true  "ID"
-// @Success      200  {object}  object{data=[]magazine.MagazineIssue}
+// @Param        id      path      string  true   "ID"
+// @Param        filter  query     string  false  "filter[column]=value or filter[column][eq|ne|gt|gte|lt|lte|like|in|null]=value"
+// @Param        sort    query     string  false  "columns to sort by, - sorts descending"
+// @Param        fields  query     string  false  "fields to send"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200     {object}  object{data=[]responses.Issue,next_cursor=string,total=int}
 // @Router       /isssue/profile/{id} [get]
 //
 // List MagazineIssue from creator ID controller
 func (a MagazineIssueHandler) ListMagazineIssueByProfileId(c *gin.Context) {
 
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	MagazineIssues, err := a.service.ListIssuesByProfileId(uuid.MustParse(id))
+	MagazineIssues, page, err := a.service.ListIssuesByProfileId(id, services.PaginationFrom(c), services.ListQueryFrom(c))
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": MagazineIssues})
+	respondPage(a.logger, a.resolver, c, responses.List(a.entitlement.GateIssues(c, MagazineIssues), responses.NewIssue), page)
 }
 
 // ListMagazineIssueByType godoc
 // @Summary      Lists MagazineIssue Type
-// @Description  List MagazineIssue by type
+// @Description  List MagazineIssue by type, downloads are hidden for non-subscribers
 // @Tags         MagazineIssue
 // @Produce      json
-// @Param        isssue_type   path      string  true  "TYPE"
-// @Success      200  {object}  object{data=[]magazine.MagazineIssue}
-// @Router       /isssue/type/{isssue_type} [get]
+// @Param        issue_type path      string  true   "TYPE"
+// @Param        filter  query     string  false  "filter[column]=value or filter[column][eq|ne|gt|gte|lt|lte|like|in|null]=value"
+// @Param        sort    query     string  false  "columns to sort by, - sorts descending"
+// @Param        fields  query     string  false  "fields to send"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200     {object}  object{data=[]responses.Issue,next_cursor=string,total=int}
+// @Router       /isssue/type/{issue_type} [get]
 //
 // List MagazineIssue from creator ID controller
 func (a MagazineIssueHandler) ListIssuesByType(c *gin.Context) {
 
-	isssue_type := c.Param("type")
+	isssue_type := c.Param("issue_type")
 
-	MagazineIssues, err := a.service.ListIssuesByType(isssue_type)
+	MagazineIssues, page, err := a.service.ListIssuesByType(isssue_type, services.PaginationFrom(c), services.ListQueryFrom(c))
 
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": MagazineIssues})
+	respondPage(a.logger, a.resolver, c, responses.List(a.entitlement.GateIssues(c, MagazineIssues), responses.NewIssue), page)
 }
 
 // GetMagazineIssueById godoc
 // @Summary      Gets One MagazineIssue by ID
-// @Description  Gets One MagazineIssue by D
+// @Description  Gets One MagazineIssue by ID with stories of its contents and their galleries,
+// @Description  PDF and EPUB are hidden and stories previewed for non-subscribers
 // @Tags         MagazineIssue
 // @Produce      json
 // @Param        id   path      string  true  "ID"
-// @Success      200  {object}  object{data=models.MagazineIssue}
-// @Router       /isssue/id/{id} [get]
+// @Success      200  {object}  object{data=responses.Issue}
+// @Router       /issue/id/{id} [get]
 //
 // Gets MagazineIssue By Company ID controller
 func (a MagazineIssueHandler) GetMagazineIssueById(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	MagazineIssue, err := a.service.GetMagazineIssueById(uuid.MustParse(id))
+	MagazineIssue, err := a.orchestrator.AssembleIssue(id)
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": MagazineIssue})
+	respondResolved(a.logger, a.resolver, c, gin.H{"data": responses.NewIssue(a.entitlement.GateIssue(c, MagazineIssue))})
 }
 
 // UpdateMagazineIssue godoc
 // @Summary      Update MagazineIssue
-// @Description  Updates MagazineIssue of employee
+// @Description  Updates MagazineIssue of employee, moving publish_at of an issue not yet published to
+// @Description  now or before publishes it and records royalties of its contents
 // @Tags         MagazineIssue
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Update MagazineIssue"
-// @Success      200  {object}  object{data=models.MagazineIssue}
+// @Success      200  {object}  object{data=responses.Issue}
+// @Failure      400  {object}  responses.Problem
+// @Failure      422  {object}  responses.Problem
 // @Router       /isssue/{id} [patch]
 // Patch MagazineIssue of creator by Id controller
 func (a MagazineIssueHandler) PatchMagazineIssueById(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	MagazineIssue, err := a.service.GetMagazineIssueById(uuid.MustParse(id))
+	MagazineIssue, err := a.service.GetMagazineIssueById(id)
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	var newMagazineIssue magazine.IssuseBase
-	if err := c.ShouldBindJSON(&newMagazineIssue); err != nil {
-		handleError(a.logger, c, err)
+	var newMagazineIssue requests.PatchIssue
+	if !bindJSON(c, &newMagazineIssue) {
 		return
 	}
 
 	MagazineIssueMap := structomap.New().UseSnakeCase().PickAll().
-		Omit("CreatorId").
 		OmitIf(func(ch interface{}) bool {
 			return newMagazineIssue.IssueCode == nil
 		}, "IssueCode").
@@ -175,12 +245,63 @@ func (a MagazineIssueHandler) PatchMagazineIssueById(c *gin.Context) {
 		OmitIf(func(ch interface{}) bool {
 			return newMagazineIssue.AdvertCode == nil
 		}, "AdvertCode").
+		OmitIf(func(ch interface{}) bool {
+			return newMagazineIssue.PdfURL == nil
+		}, "PdfURL").
+		OmitIf(func(ch interface{}) bool {
+			return newMagazineIssue.EpubURL == nil
+		}, "EpubURL").
+		OmitIf(func(ch interface{}) bool {
+			return newMagazineIssue.UsageScope == nil
+		}, "UsageScope").
+		OmitIf(func(ch interface{}) bool {
+			return newMagazineIssue.Territory == nil
+		}, "Territory").
+		OmitIf(func(ch interface{}) bool {
+			return newMagazineIssue.PublishAt == nil
+		}, "PublishAt").
+		OmitIf(func(ch interface{}) bool {
+			return newMagazineIssue.UnpublishAt == nil
+		}, "UnpublishAt").
+		OmitIf(func(ch interface{}) bool {
+			return newMagazineIssue.MetaTitle == nil
+		}, "MetaTitle").
+		OmitIf(func(ch interface{}) bool {
+			return newMagazineIssue.MetaDescription == nil
+		}, "MetaDescription").
+		OmitIf(func(ch interface{}) bool {
+			return newMagazineIssue.CanonicalURL == nil
+		}, "CanonicalURL").
+		OmitIf(func(ch interface{}) bool {
+			return newMagazineIssue.OgImage == nil
+		}, "OgImage").
 		OmitIf(func(ch interface{}) bool {
 			return newMagazineIssue.Remarks == nil
 		}, "Remarks").
 		Transform(newMagazineIssue)
 
 	if len(MagazineIssueMap) > 0 {
+		if err := services.SchedulePatch(&MagazineIssueMap, MagazineIssue.PublishAt, MagazineIssue.UnpublishAt); err != nil {
+			handleError(a.logger, c, err)
+			return
+		}
+		published := services.PublishPatch(&MagazineIssueMap, MagazineIssue.PublishedOn, MagazineIssue.UnpublishAt)
+
+		placed := *MagazineIssue
+		if newMagazineIssue.ContentCode != nil {
+			placed.ContentCode = newMagazineIssue.ContentCode
+		}
+		if newMagazineIssue.UsageScope != nil {
+			placed.UsageScope = newMagazineIssue.UsageScope
+		}
+		if newMagazineIssue.Territory != nil {
+			placed.Territory = newMagazineIssue.Territory
+		}
+		if err := a.orchestrator.CheckIssueLicences(&placed); err != nil {
+			handleLicenceError(a.logger, c, err)
+			return
+		}
+
 		MagazineIssueMap["updated_on"] = time.Now()
 		MagazineIssueMap["id"] = MagazineIssue.ID
 
@@ -189,8 +310,17 @@ func (a MagazineIssueHandler) PatchMagazineIssueById(c *gin.Context) {
 			handleError(a.logger, c, err)
 			return
 		}
+		if published {
+			a.royalties.IssuePublished(MagazineIssue.ID)
+		}
 
-		c.JSON(200, gin.H{"data": MagazineIssueMap})
+		updated, err := a.orchestrator.AssembleIssue(MagazineIssue.ID)
+		if err != nil {
+			handleError(a.logger, c, err)
+			return
+		}
+
+		respondResolved(a.logger, a.resolver, c, gin.H{"data": responses.NewIssue(updated)})
 		return
 	}
 
@@ -208,9 +338,12 @@ func (a MagazineIssueHandler) PatchMagazineIssueById(c *gin.Context) {
 //
 // Delete MagazineIssue By ID controller
 func (a MagazineIssueHandler) DeleteMagazineIssueByID(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	err := a.service.DeleteMagazineIssue(uuid.MustParse(id))
+	err := a.service.DeleteMagazineIssue(id)
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
@@ -218,3 +351,49 @@ func (a MagazineIssueHandler) DeleteMagazineIssueByID(c *gin.Context) {
 
 	c.JSON(200, gin.H{"data": "successfully deleted"})
 }
+
+// ChangeIssueSlug godoc
+// @Summary      Change slug of MagazineIssue
+// @Description  Changes slug of the issue to the slug given or a new one from its issue code when it is empty,
+// @Description  the old slug keeps redirecting to the issue on the public api
+// @Tags         MagazineIssue
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string              true  "MagazineIssue ID"
+// @Param        slug  body      requests.ChangeSlug  true  "Slug"
+// @Success      200   {object}  object{data=object{slug=string}}
+// @Failure      400   {object}  responses.Problem
+// @Failure      404   {object}  responses.Problem
+// @Failure      409   {object}  responses.Problem
+// @Router       /issue/id/{id}/slug [put]
+//
+// Change slug of issue controller
+func (a MagazineIssueHandler) ChangeIssueSlug(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var request requests.ChangeSlug
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	issue, err := a.service.GetMagazineIssueById(id)
+	if pgxscan.NotFound(err) || (err == nil && issue.DeletedOn != nil) {
+		handleError(a.logger, c, apperrors.NewNotFound("issue not found"))
+		return
+	}
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	slug, err := a.service.ChangeSlug(issue, request.Slug)
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"slug": slug}})
+}
@@ -1,25 +1,27 @@
 package handlers
 
 import (
+	"magazine_api/api/serializers/requests"
+	"magazine_api/api/serializers/responses"
 	"magazine_api/lib"
-	"magazine_api/models/magazine"
 	"magazine_api/services"
 	"time"
 
 	"github.com/danhper/structomap"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 )
 
 type MagazineHandler struct {
-	logger  lib.Logger
-	service services.MagazineService
+	logger   lib.Logger
+	service  services.MagazineService
+	resolver services.URLResolver
 }
 
-func NewMagazineHandler(logger lib.Logger, service services.MagazineService) MagazineHandler {
+func NewMagazineHandler(logger lib.Logger, service services.MagazineService, resolver services.URLResolver) MagazineHandler {
 	return MagazineHandler{
-		logger:  logger,
-		service: service,
+		logger:   logger,
+		service:  service,
+		resolver: resolver,
 	}
 }
 
@@ -29,18 +31,26 @@ func NewMagazineHandler(logger lib.Logger, service services.MagazineService) Mag
 // @Tags         Magazine
 // @Accept       json
 // @Produce      json
-// @Param        Magazine  body      magazine.Magazine  true  "Add Magazine"
-// @Success      200       {object}  object{data=magazine.Magazine}
+// @Param        Magazine  body      requests.CreateMagazine  true  "Add Magazine"
+// @Success      200       {object}  object{data=responses.Magazine}
+// @Security     BearerAuth
 // @Router       /magazine [post]
 //
 // Creates Magazine
 func (s MagazineHandler) CreateMagazine(c *gin.Context) {
-	var magazine *magazine.Magazine
-	if err := c.ShouldBind(&magazine); err != nil {
-		handleError(s.logger, c, err)
+	userID, ok := currentUserID(c)
+	if !ok {
+		handleError(s.logger, c, errNotAuthenticated)
 		return
 	}
 
+	var request requests.CreateMagazine
+	if !bindJSON(c, &request) {
+		return
+	}
+	magazine := request.Magazine()
+	magazine.CreatedBy = &userID
+
 	// Create Magazine in our Database
 	magazine, err := s.service.CreateMagazine(magazine)
 	if err != nil {
@@ -48,7 +58,7 @@ func (s MagazineHandler) CreateMagazine(c *gin.Context) {
 		return
 	}
 
-	c.JSON(200, gin.H{"data": magazine})
+	c.JSON(200, gin.H{"data": responses.NewMagazine(magazine)})
 }
 
 // ListAllMagaziness godoc
@@ -56,18 +66,24 @@ func (s MagazineHandler) CreateMagazine(c *gin.Context) {
 // @Description  List stories
 // @Tags         Magazine
 // @Produce      json
-// @Success      200  {object}  object{data=[]magazine.Magazine}
+// @Param        filter  query     string  false  "filter[column]=value or filter[column][eq|ne|gt|gte|lt|lte|like|in|null]=value"
+// @Param        sort    query     string  false  "columns to sort by, - sorts descending"
+// @Param        fields  query     string  false  "fields to send"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200     {object}  object{data=[]responses.Magazine,next_cursor=string,total=int}
 // @Router       /magazine [get]
 //
 // List all suppliers from database
 func (s MagazineHandler) ListMagazines(c *gin.Context) {
-	stories, err := s.service.ListMagazines(c)
+	stories, page, err := s.service.ListMagazines(c)
 	if err != nil {
 		handleError(s.logger, c, err)
 		return
 	}
 
-	c.JSON(200, stories)
+	respondPage(s.logger, s.resolver, c, responses.List(stories, responses.NewMagazine), page)
 }
 
 // ListMagazineFromUserId godoc
@@ -75,22 +91,31 @@ func (s MagazineHandler) ListMagazines(c *gin.Context) {
 // @Description  List Magazine by creator id
 // @Tags         Magazine
 // @Produce      json
-// @Param        id   path      string  true  "ID"
-// @Success      200  {object}  object{data=[]magazine.Magazine}
+// @Param        id      path      string  true   "ID"
+// @Param        filter  query     string  false  "filter[column]=value or filter[column][eq|ne|gt|gte|lt|lte|like|in|null]=value"
+// @Param        sort    query     string  false  "columns to sort by, - sorts descending"
+// @Param        fields  query     string  false  "fields to send"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200     {object}  object{data=[]responses.Magazine,next_cursor=string,total=int}
 // @Router       /magazine/profile/{id} [get]
 //
 // List Magazine from creator ID controller
 func (a MagazineHandler) ListMagazineByProfileId(c *gin.Context) {
 
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	Magazines, err := a.service.ListMagazinesByProfileId(uuid.MustParse(id))
+	Magazines, page, err := a.service.ListMagazinesByProfileId(id, services.PaginationFrom(c), services.ListQueryFrom(c))
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": Magazines})
+	respondPage(a.logger, a.resolver, c, responses.List(Magazines, responses.NewMagazine), page)
 }
 
 // ListMagazineByType godoc
@@ -98,23 +123,29 @@ func (a MagazineHandler) ListMagazineByProfileId(c *gin.Context) {
 // @Description  List Magazine by type
 // @Tags         Magazine
 // @Produce      json
-// @Param        magazine_type   path      string  true  "TYPE"
-// @Success      200  {object}  object{data=[]magazine.Magazine}
+// @Param        magazine_type path      string  true   "TYPE"
+// @Param        filter  query     string  false  "filter[column]=value or filter[column][eq|ne|gt|gte|lt|lte|like|in|null]=value"
+// @Param        sort    query     string  false  "columns to sort by, - sorts descending"
+// @Param        fields  query     string  false  "fields to send"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200     {object}  object{data=[]responses.Magazine,next_cursor=string,total=int}
 // @Router       /magazine/type/{magazine_type} [get]
 //
 // List Magazine from creator ID controller
 func (a MagazineHandler) ListMagazinesByType(c *gin.Context) {
 
-	magazine_type := c.Param("type")
+	magazine_type := c.Param("magazine_type")
 
-	Magazines, err := a.service.ListMagazinesByType(magazine_type)
+	Magazines, page, err := a.service.ListMagazinesByType(magazine_type, services.PaginationFrom(c), services.ListQueryFrom(c))
 
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": Magazines})
+	respondPage(a.logger, a.resolver, c, responses.List(Magazines, responses.NewMagazine), page)
 }
 
 // GetMagazineById godoc
@@ -123,20 +154,23 @@ func (a MagazineHandler) ListMagazinesByType(c *gin.Context) {
 // @Tags         Magazine
 // @Produce      json
 // @Param        id   path      string  true  "ID"
-// @Success      200  {object}  object{data=models.Magazine}
+// @Success      200  {object}  object{data=responses.Magazine}
 // @Router       /magazine/id/{id} [get]
 //
 // Gets Magazine By Company ID controller
 func (a MagazineHandler) GetMagazineById(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	Magazine, err := a.service.GetMagazineById(uuid.MustParse(id))
+	Magazine, err := a.service.GetMagazineById(id)
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": Magazine})
+	c.JSON(200, gin.H{"data": responses.NewMagazine(Magazine)})
 }
 
 // UpdateMagazine godoc
@@ -146,26 +180,27 @@ func (a MagazineHandler) GetMagazineById(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Update Magazine"
-// @Success      200  {object}  object{data=models.Magazine}
+// @Success      200  {object}  object{data=responses.Magazine}
 // @Router       /magazine/{id} [patch]
 // Patch Magazine of creator by Id controller
 func (a MagazineHandler) PatchMagazineById(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	Magazine, err := a.service.GetMagazineById(uuid.MustParse(id))
+	Magazine, err := a.service.GetMagazineById(id)
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	var newMagazine magazine.MagazineBase
-	if err := c.ShouldBindJSON(&newMagazine); err != nil {
-		handleError(a.logger, c, err)
+	var newMagazine requests.PatchMagazine
+	if !bindJSON(c, &newMagazine) {
 		return
 	}
 
 	MagazineMap := structomap.New().UseSnakeCase().PickAll().
-		Omit("CreatorId").
 		OmitIf(func(ch interface{}) bool {
 			return newMagazine.MagazineCode == nil
 		}, "MagazineCode").
@@ -190,7 +225,13 @@ func (a MagazineHandler) PatchMagazineById(c *gin.Context) {
 			return
 		}
 
-		c.JSON(200, gin.H{"data": MagazineMap})
+		updated, err := a.service.GetMagazineById(Magazine.ID)
+		if err != nil {
+			handleError(a.logger, c, err)
+			return
+		}
+
+		c.JSON(200, gin.H{"data": responses.NewMagazine(updated)})
 		return
 	}
 
@@ -208,9 +249,12 @@ func (a MagazineHandler) PatchMagazineById(c *gin.Context) {
 //
 // Delete Magazine By ID controller
 func (a MagazineHandler) DeleteMagazineByID(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	err := a.service.DeleteMagazine(uuid.MustParse(id))
+	err := a.service.DeleteMagazine(id)
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"magazine_api/api/serializers/responses"
+	"magazine_api/apperrors"
+	"magazine_api/lib"
+	"magazine_api/services"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/gin-gonic/gin"
+)
+
+type MediaHandler struct {
+	logger   lib.Logger
+	service  services.MediaService
+	resolver services.URLResolver
+}
+
+func NewMediaHandler(logger lib.Logger, service services.MediaService, resolver services.URLResolver) MediaHandler {
+	return MediaHandler{
+		logger:   logger,
+		service:  service,
+		resolver: resolver,
+	}
+}
+
+// GetMediaById godoc
+// @Summary      Gets One Media by ID
+// @Description  Gets uploaded image with status of processing and its variants
+// @Tags         Media
+// @Produce      json
+// @Param        id   path      string  true  "ID"
+// @Success      200  {object}  object{data=responses.Media}
+// @Failure      404  {object}  responses.Problem
+// @Security     BearerAuth
+// @Router       /media/id/{id} [get]
+//
+// Gets Media By ID controller
+func (m MediaHandler) GetMediaById(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	media, err := m.service.GetMediaByID(id)
+	if pgxscan.NotFound(err) {
+		handleError(m.logger, c, apperrors.NewNotFound("media not found"))
+		return
+	}
+	if err != nil {
+		handleError(m.logger, c, err)
+		return
+	}
+
+	respondResolved(m.logger, m.resolver, c, gin.H{"data": responses.NewMedia(media)})
+}
+
+// GetMediaByKey godoc
+// @Summary      Gets One Media by key
+// @Description  Gets uploaded image by key of the file with status of processing and its variants
+// @Tags         Media
+// @Produce      json
+// @Param        key  query     string  true  "Key of uploaded file"
+// @Success      200  {object}  object{data=responses.Media}
+// @Failure      404  {object}  responses.Problem
+// @Security     BearerAuth
+// @Router       /media [get]
+//
+// Gets Media By key controller
+func (m MediaHandler) GetMediaByKey(c *gin.Context) {
+	key := c.Query("key")
+	if key == "" {
+		handleError(m.logger, c, apperrors.NewBadRequest("key is required"))
+		return
+	}
+
+	media, err := m.service.GetMediaByKey(key)
+	if pgxscan.NotFound(err) {
+		handleError(m.logger, c, apperrors.NewNotFound("media not found"))
+		return
+	}
+	if err != nil {
+		handleError(m.logger, c, err)
+		return
+	}
+
+	respondResolved(m.logger, m.resolver, c, gin.H{"data": responses.NewMedia(media)})
+}
+
+// ListMediaReferences godoc
+// @Summary      Lists rows using the media
+// @Description  Lists photographs, adverts, profiles, documents and issues referencing the media
+// @Tags         Media
+// @Produce      json
+// @Param        id   path      string  true  "ID"
+// @Success      200  {object}  object{data=[]models.MediaReference}
+// @Security     BearerAuth
+// @Router       /media/id/{id}/references [get]
+//
+// List references of media controller
+func (m MediaHandler) ListMediaReferences(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	references, err := m.service.ListReferences(id)
+	if err != nil {
+		handleError(m.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": references})
+}
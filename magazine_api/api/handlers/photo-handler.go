@@ -1,25 +1,33 @@
 package handlers
 
 import (
+	"errors"
+	"magazine_api/api/serializers/requests"
+	"magazine_api/api/serializers/responses"
+	"magazine_api/apperrors"
 	"magazine_api/lib"
-	"magazine_api/models/magazine"
 	"magazine_api/services"
+	"strconv"
 	"time"
 
 	"github.com/danhper/structomap"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 )
 
+// defaultLicenceExpiryDays days ahead the licence expiry report looks by default
+const defaultLicenceExpiryDays = 30
+
 type PhotoHandler struct {
-	logger  lib.Logger
-	service services.PhotoService
+	logger   lib.Logger
+	service  services.PhotoService
+	resolver services.URLResolver
 }
 
-func NewPhotoHandler(logger lib.Logger, service services.PhotoService) PhotoHandler {
+func NewPhotoHandler(logger lib.Logger, service services.PhotoService, resolver services.URLResolver) PhotoHandler {
 	return PhotoHandler{
-		logger:  logger,
-		service: service,
+		logger:   logger,
+		service:  service,
+		resolver: resolver,
 	}
 }
 
@@ -29,17 +37,25 @@ func NewPhotoHandler(logger lib.Logger, service services.PhotoService) PhotoHand
 // @Tags         Photo
 // @Accept       json
 // @Produce      json
-// @Param        Photo  body      magazine.Photograph  true  "Add Photo"
-// @Success      200       {object}  object{data=magazine.Photograph}
+// @Param        Photo  body      requests.CreatePhoto  true  "Add Photo"
+// @Success      200       {object}  object{data=responses.Photograph}
+// @Security     BearerAuth
 // @Router       /photo [post]
 //
 // Creates Photo
 func (s PhotoHandler) CreatePhoto(c *gin.Context) {
-	var photo *magazine.Photograph
-	if err := c.ShouldBind(&photo); err != nil {
-		handleError(s.logger, c, err)
+	userID, ok := currentUserID(c)
+	if !ok {
+		handleError(s.logger, c, errNotAuthenticated)
+		return
+	}
+
+	var request requests.CreatePhoto
+	if !bindJSON(c, &request) {
 		return
 	}
+	photo := request.Photograph()
+	photo.CreatedBy = &userID
 
 	// Create Photo in our Database
 	photo, err := s.service.CreatePhoto(photo)
@@ -48,7 +64,7 @@ func (s PhotoHandler) CreatePhoto(c *gin.Context) {
 		return
 	}
 
-	c.JSON(200, gin.H{"data": photo})
+	respondResolved(s.logger, s.resolver, c, gin.H{"data": responses.NewPhotograph(photo)})
 }
 
 // ListAllPhotos godoc
@@ -56,18 +72,24 @@ func (s PhotoHandler) CreatePhoto(c *gin.Context) {
 // @Description  List stories
 // @Tags         Photo
 // @Produce      json
-// @Success      200  {object}  object{data=[]magazine.Photograph}
+// @Param        filter  query     string  false  "filter[column]=value or filter[column][eq|ne|gt|gte|lt|lte|like|in|null]=value"
+// @Param        sort    query     string  false  "columns to sort by, - sorts descending"
+// @Param        fields  query     string  false  "fields to send"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200     {object}  object{data=[]responses.Photograph,next_cursor=string,total=int}
 // @Router       /photo [get]
 //
 // List all suppliers from database
 func (s PhotoHandler) ListPhotos(c *gin.Context) {
-	stories, err := s.service.ListPhotos(c)
+	stories, page, err := s.service.ListPhotos(c)
 	if err != nil {
 		handleError(s.logger, c, err)
 		return
 	}
 
-	c.JSON(200, stories)
+	respondPage(s.logger, s.resolver, c, responses.List(stories, responses.NewPhotograph), page)
 }
 
 // ListPhotoFromUserId godoc
@@ -75,22 +97,31 @@ func (s PhotoHandler) ListPhotos(c *gin.Context) {
 // @Description  List Photo by creator id
 // @Tags         Photo
 // @Produce      json
-// @Param        id   path      string  true  "ID"
-// @Success      200  {object}  object{data=[]magazine.Photograph}
+// @Param        id      path      string  true   "ID"
+// @Param        filter  query     string  false  "filter[column]=value or filter[column][eq|ne|gt|gte|lt|lte|like|in|null]=value"
+// @Param        sort    query     string  false  "columns to sort by, - sorts descending"
+// @Param        fields  query     string  false  "fields to send"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200     {object}  object{data=[]responses.Photograph,next_cursor=string,total=int}
 // @Router       /photo/profile/{id} [get]
 //
 // List Photo from creator ID controller
 func (a PhotoHandler) ListPhotoByProfileId(c *gin.Context) {
 
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	Photos, err := a.service.ListPhotosByProfileId(uuid.MustParse(id))
+	Photos, page, err := a.service.ListPhotosByProfileId(id, services.PaginationFrom(c), services.ListQueryFrom(c))
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": Photos})
+	respondPage(a.logger, a.resolver, c, responses.List(Photos, responses.NewPhotograph), page)
 }
 
 // ListPhotoByType godoc
@@ -98,23 +129,29 @@ func (a PhotoHandler) ListPhotoByProfileId(c *gin.Context) {
 // @Description  List Photo by type
 // @Tags         Photo
 // @Produce      json
-// @Param        photo_type   path      string  true  "TYPE"
-// @Success      200  {object}  object{data=[]magazine.Photograph}
+// @Param        photo_type path      string  true   "TYPE"
+// @Param        filter  query     string  false  "filter[column]=value or filter[column][eq|ne|gt|gte|lt|lte|like|in|null]=value"
+// @Param        sort    query     string  false  "columns to sort by, - sorts descending"
+// @Param        fields  query     string  false  "fields to send"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200     {object}  object{data=[]responses.Photograph,next_cursor=string,total=int}
 // @Router       /photo/type/{photo_type} [get]
 //
 // List Photo from creator ID controller
 func (a PhotoHandler) ListPhotosByType(c *gin.Context) {
 
-	photo_type := c.Param("type")
+	photo_type := c.Param("photo_type")
 
-	Photos, err := a.service.ListPhotosByType(photo_type)
+	Photos, page, err := a.service.ListPhotosByType(photo_type, services.PaginationFrom(c), services.ListQueryFrom(c))
 
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": Photos})
+	respondPage(a.logger, a.resolver, c, responses.List(Photos, responses.NewPhotograph), page)
 }
 
 // GetPhotoById godoc
@@ -123,20 +160,54 @@ func (a PhotoHandler) ListPhotosByType(c *gin.Context) {
 // @Tags         Photo
 // @Produce      json
 // @Param        id   path      string  true  "ID"
-// @Success      200  {object}  object{data=models.Photograph}
+// @Success      200  {object}  object{data=responses.Photograph}
 // @Router       /photo/id/{id} [get]
 //
 // Gets Photo By Company ID controller
 func (a PhotoHandler) GetPhotoById(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	Photo, err := a.service.GetPhotoById(id)
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	respondResolved(a.logger, a.resolver, c, gin.H{"data": responses.NewPhotograph(Photo)})
+}
+
+// ListExpiringLicences godoc
+// @Summary      Lists Photos with licence expiring soon
+// @Description  Lists photographs whose licence expires within the days ordered by expiry,
+// @Description  photographs with licence expired already are listed too when expired is set
+// @Tags         Photo
+// @Produce      json
+// @Param        days     query     int   false  "Days from now, 30 by default"
+// @Param        expired  query     bool  false  "Include expired licences"
+// @Success      200  {object}  object{data=[]responses.Photograph}
+// @Failure      400  {object}  responses.Problem
+// @Security     BearerAuth
+// @Router       /photo/licences/expiring [get]
+//
+// List Photos with licence expiring controller
+func (a PhotoHandler) ListExpiringLicences(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", strconv.Itoa(defaultLicenceExpiryDays)))
+	if err != nil || days < 0 {
+		handleError(a.logger, c, apperrors.NewBadRequest("days must be a positive number"))
+		return
+	}
+	expired, _ := strconv.ParseBool(c.Query("expired"))
 
-	Photo, err := a.service.GetPhotoById(uuid.MustParse(id))
+	Photos, err := a.service.ListExpiringLicences(time.Duration(days)*24*time.Hour, expired)
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": Photo})
+	respondResolved(a.logger, a.resolver, c, gin.H{"data": responses.List(Photos, responses.NewPhotograph)})
 }
 
 // UpdatePhoto godoc
@@ -146,26 +217,27 @@ func (a PhotoHandler) GetPhotoById(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Update Photo"
-// @Success      200  {object}  object{data=models.Photograph}
+// @Success      200  {object}  object{data=responses.Photograph}
 // @Router       /photo/{id} [patch]
 // Patch Photo of creator by Id controller
 func (a PhotoHandler) PatchPhotoById(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	Photo, err := a.service.GetPhotoById(uuid.MustParse(id))
+	Photo, err := a.service.GetPhotoById(id)
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	var newPhoto magazine.PhotographBase
-	if err := c.ShouldBindJSON(&newPhoto); err != nil {
-		handleError(a.logger, c, err)
+	var newPhoto requests.PatchPhoto
+	if !bindJSON(c, &newPhoto) {
 		return
 	}
 
 	PhotoMap := structomap.New().UseSnakeCase().PickAll().
-		Omit("CreatorId").
 		OmitIf(func(ch interface{}) bool {
 			return newPhoto.PhotographID == nil
 		}, "PhotographID").
@@ -181,6 +253,24 @@ func (a PhotoHandler) PatchPhotoById(c *gin.Context) {
 		OmitIf(func(ch interface{}) bool {
 			return newPhoto.DocumentURL == nil
 		}, "DocumentURL").
+		OmitIf(func(ch interface{}) bool {
+			return newPhoto.PhotographerId == nil
+		}, "PhotographerId").
+		OmitIf(func(ch interface{}) bool {
+			return newPhoto.LicenceSource == nil
+		}, "LicenceSource").
+		OmitIf(func(ch interface{}) bool {
+			return newPhoto.LicenceType == nil
+		}, "LicenceType").
+		OmitIf(func(ch interface{}) bool {
+			return newPhoto.UsageScope == nil
+		}, "UsageScope").
+		OmitIf(func(ch interface{}) bool {
+			return newPhoto.Territories == nil
+		}, "Territories").
+		OmitIf(func(ch interface{}) bool {
+			return newPhoto.LicenceExpiresOn == nil
+		}, "LicenceExpiresOn").
 		OmitIf(func(ch interface{}) bool {
 			return newPhoto.Remarks == nil
 		}, "Remarks").
@@ -196,7 +286,13 @@ func (a PhotoHandler) PatchPhotoById(c *gin.Context) {
 			return
 		}
 
-		c.JSON(200, gin.H{"data": PhotoMap})
+		updated, err := a.service.GetPhotoById(Photo.ID)
+		if err != nil {
+			handleError(a.logger, c, err)
+			return
+		}
+
+		respondResolved(a.logger, a.resolver, c, gin.H{"data": responses.NewPhotograph(updated)})
 		return
 	}
 
@@ -214,9 +310,12 @@ func (a PhotoHandler) PatchPhotoById(c *gin.Context) {
 //
 // Delete Photo By ID controller
 func (a PhotoHandler) DeletePhotoByID(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	err := a.service.DeletePhoto(uuid.MustParse(id))
+	err := a.service.DeletePhoto(id)
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
@@ -224,3 +323,17 @@ func (a PhotoHandler) DeletePhotoByID(c *gin.Context) {
 
 	c.JSON(200, gin.H{"data": "successfully deleted"})
 }
+
+// handleLicenceError responds photographs blocked by their licences as unprocessable
+func handleLicenceError(logger lib.Logger, c *gin.Context, err error) {
+	var licence *services.LicenceError
+	if errors.As(err, &licence) {
+		fields := make([]apperrors.FieldError, 0, len(licence.Violations))
+		for _, violation := range licence.Violations {
+			fields = append(fields, apperrors.FieldError{Field: "photos." + violation.PhotographId.String(), Message: violation.Reason})
+		}
+		err = &apperrors.Error{Kind: apperrors.Validation, Message: licence.Error(), Fields: fields, Err: err}
+	}
+
+	handleError(logger, c, err)
+}
@@ -0,0 +1,412 @@
+package handlers
+
+import (
+	"magazine_api/api/serializers/responses"
+	"magazine_api/apperrors"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"magazine_api/models/magazine"
+	"magazine_api/orchestrators"
+	"magazine_api/services"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/gin-gonic/gin"
+)
+
+// PublicHandler read only api of published content for readers
+type PublicHandler struct {
+	logger      lib.Logger
+	env         lib.Env
+	stories     services.StoryService
+	issues      services.MagazineIssueService
+	assembler   orchestrators.IssueOrchestrator
+	photos      services.PhotoService
+	adverts     services.AdvertService
+	gallery     services.GalleryService
+	slugs       services.SlugService
+	entitlement services.EntitlementService
+	resolver    services.URLResolver
+}
+
+func NewPublicHandler(
+	logger lib.Logger,
+	env lib.Env,
+	stories services.StoryService,
+	issues services.MagazineIssueService,
+	assembler orchestrators.IssueOrchestrator,
+	photos services.PhotoService,
+	adverts services.AdvertService,
+	gallery services.GalleryService,
+	slugs services.SlugService,
+	entitlement services.EntitlementService,
+	resolver services.URLResolver,
+) PublicHandler {
+	return PublicHandler{
+		logger:      logger,
+		env:         env,
+		stories:     stories,
+		issues:      issues,
+		assembler:   assembler,
+		photos:      photos,
+		adverts:     adverts,
+		gallery:     gallery,
+		slugs:       slugs,
+		entitlement: entitlement,
+		resolver:    resolver,
+	}
+}
+
+// ListStories godoc
+// @Summary      List published stories
+// @Description  Lists published stories newest first with summaries and lead images, `type` filters by story type
+// @Tags         Public
+// @Produce      json
+// @Param        type    query     string  false  "story type"
+// @Param        filter  query     string  false  "filter[column]=value or filter[column][op]=value"
+// @Param        sort    query     string  false  "columns to sort by, - sorts descending"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Success      200     {object}  object{data=[]responses.PublicStory,next_cursor=string,total=int}
+// @Success      304
+// @Router       /public/v1/stories [get]
+//
+// Lists published stories
+func (p PublicHandler) ListStories(c *gin.Context) {
+	stories, page, err := p.stories.ListPublishedStories(c.Query("type"), services.PaginationFrom(c), readerQuery(c))
+	if err != nil {
+		handleError(p.logger, c, err)
+		return
+	}
+
+	if err := p.gallery.AttachGalleries(stories...); err != nil {
+		handleError(p.logger, c, err)
+		return
+	}
+
+	data := make([]responses.PublicStory, len(stories))
+	var lastModified time.Time
+	for i, story := range stories {
+		data[i] = p.publicStory(c, story, false)
+		lastModified = latest(lastModified, story.UpdatedOn, story.PublishedOn)
+	}
+
+	respondCached(p.logger, p.resolver, c, lastModified, responses.PageBody(data, page))
+}
+
+// GetStory godoc
+// @Summary      Get published story
+// @Description  Gets published story by slug with its body, gallery and SEO metadata, readers without subscription
+// @Description  get a preview. Slugs the story had before redirect to its current slug
+// @Tags         Public
+// @Produce      json
+// @Param        slug  path      string  true  "Slug"
+// @Success      200   {object}  object{data=responses.PublicStory}
+// @Success      301
+// @Success      304
+// @Failure      404   {object}  responses.Problem
+// @Router       /public/v1/stories/{slug} [get]
+//
+// Gets published story by slug
+func (p PublicHandler) GetStory(c *gin.Context) {
+	story, err := p.stories.GetPublishedStoryBySlug(c.Param("slug"))
+	if pgxscan.NotFound(err) {
+		p.redirectMoved(c, services.SlugStories, "story not found")
+		return
+	}
+	if err != nil {
+		handleError(p.logger, c, err)
+		return
+	}
+
+	if err := p.gallery.AttachGalleries(story); err != nil {
+		handleError(p.logger, c, err)
+		return
+	}
+
+	story = p.entitlement.GateStory(c, story)
+	lastModified := latest(time.Time{}, story.UpdatedOn, story.PublishedOn)
+	respondCached(p.logger, p.resolver, c, lastModified, gin.H{"data": p.publicStory(c, story, true)})
+}
+
+// ListIssues godoc
+// @Summary      List published issues
+// @Description  Lists published issues newest first, downloads are only sent to readers with subscription
+// @Tags         Public
+// @Produce      json
+// @Param        filter  query     string  false  "filter[column]=value or filter[column][op]=value"
+// @Param        sort    query     string  false  "columns to sort by, - sorts descending"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Success      200     {object}  object{data=[]responses.PublicIssue,next_cursor=string,total=int}
+// @Success      304
+// @Router       /public/v1/issues [get]
+//
+// Lists published issues
+func (p PublicHandler) ListIssues(c *gin.Context) {
+	issues, page, err := p.issues.ListPublishedIssues(services.PaginationFrom(c), readerQuery(c))
+	if err != nil {
+		handleError(p.logger, c, err)
+		return
+	}
+
+	data := make([]responses.PublicIssue, len(issues))
+	var lastModified time.Time
+	for i, issue := range issues {
+		data[i] = p.publicIssue(c, p.entitlement.GateIssue(c, issue))
+		lastModified = latest(lastModified, issue.UpdatedOn, issue.PublishedOn)
+	}
+
+	respondCached(p.logger, p.resolver, c, lastModified, responses.PageBody(data, page))
+}
+
+// GetIssue godoc
+// @Summary      Get published issue
+// @Description  Gets published issue by slug with summaries of its published stories and SEO metadata, downloads
+// @Description  are only sent to readers with subscription. Slugs the issue had before redirect to its current slug
+// @Tags         Public
+// @Produce      json
+// @Param        slug  path      string  true  "Slug"
+// @Success      200   {object}  object{data=responses.PublicIssue}
+// @Success      301
+// @Success      304
+// @Failure      404   {object}  responses.Problem
+// @Router       /public/v1/issues/{slug} [get]
+//
+// Gets published issue by slug
+func (p PublicHandler) GetIssue(c *gin.Context) {
+	issue, err := p.assembler.AssemblePublishedIssue(c.Param("slug"))
+	if pgxscan.NotFound(err) {
+		p.redirectMoved(c, services.SlugIssues, "issue not found")
+		return
+	}
+	if err != nil {
+		handleError(p.logger, c, err)
+		return
+	}
+
+	issue = p.entitlement.GateIssue(c, issue)
+	lastModified := latest(time.Time{}, issue.UpdatedOn, issue.PublishedOn)
+	for _, story := range issue.Stories {
+		lastModified = latest(lastModified, story.UpdatedOn, story.PublishedOn)
+	}
+
+	respondCached(p.logger, p.resolver, c, lastModified, gin.H{"data": p.publicIssue(c, issue)})
+}
+
+// GetPhoto godoc
+// @Summary      Get published photograph
+// @Description  Gets photograph placed by published stories or issues
+// @Tags         Public
+// @Produce      json
+// @Param        id   path      string  true  "ID"
+// @Success      200  {object}  object{data=responses.PublicPhoto}
+// @Success      304
+// @Failure      404  {object}  responses.Problem
+// @Router       /public/v1/photos/{id} [get]
+//
+// Gets published photograph by id
+func (p PublicHandler) GetPhoto(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	photo, err := p.photos.GetPublishedPhotoById(id)
+	if pgxscan.NotFound(err) {
+		handleError(p.logger, c, apperrors.NewNotFound("photograph not found"))
+		return
+	}
+	if err != nil {
+		handleError(p.logger, c, err)
+		return
+	}
+
+	data := responses.PublicPhoto{
+		ID:    photo.ID,
+		Title: photo.PhotographTitle,
+		URL:   photo.DocumentURL,
+	}
+	lastModified := latest(time.Time{}, photo.UpdatedOn)
+	respondCached(p.logger, p.resolver, c, lastModified, gin.H{"data": data})
+}
+
+// ListAdverts godoc
+// @Summary      List published adverts
+// @Description  Lists published adverts newest first
+// @Tags         Public
+// @Produce      json
+// @Param        filter  query     string  false  "filter[column]=value or filter[column][op]=value"
+// @Param        sort    query     string  false  "columns to sort by, - sorts descending"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Success      200     {object}  object{data=[]responses.PublicAdvert,next_cursor=string,total=int}
+// @Success      304
+// @Router       /public/v1/adverts [get]
+//
+// Lists published adverts
+func (p PublicHandler) ListAdverts(c *gin.Context) {
+	ads, page, err := p.adverts.ListPublishedAds(services.PaginationFrom(c), readerQuery(c))
+	if err != nil {
+		handleError(p.logger, c, err)
+		return
+	}
+
+	data := make([]responses.PublicAdvert, len(ads))
+	var lastModified time.Time
+	for i, ad := range ads {
+		data[i] = responses.PublicAdvert{
+			ID:          ad.ID,
+			Title:       ad.AdvertTitle,
+			Content:     ad.AdvertContent,
+			Type:        ad.AdvertType,
+			URL:         ad.AdvertURL,
+			Size:        ad.Size,
+			PublishedOn: ad.PublishedOn,
+		}
+		lastModified = latest(lastModified, ad.UpdatedOn, ad.PublishedOn)
+	}
+
+	respondCached(p.logger, p.resolver, c, lastModified, responses.PageBody(data, page))
+}
+
+// redirectMoved redirects to current slug of the row when the slug of the request was its slug before
+func (p PublicHandler) redirectMoved(c *gin.Context, table string, notFound string) {
+	slug := c.Param("slug")
+	moved, err := p.slugs.FindMovedSlug(table, slug)
+	if pgxscan.NotFound(err) {
+		handleError(p.logger, c, apperrors.NewNotFound(notFound))
+		return
+	}
+	if err != nil {
+		handleError(p.logger, c, err)
+		return
+	}
+
+	c.Redirect(http.StatusMovedPermanently, strings.TrimSuffix(c.Request.URL.Path, slug)+moved)
+}
+
+// publicStory maps the story for readers, content, body and gallery are left out of summaries
+func (p PublicHandler) publicStory(c *gin.Context, story *magazine.Story, full bool) responses.PublicStory {
+	data := responses.PublicStory{
+		ID:          story.ID,
+		Slug:        story.Slug,
+		Title:       story.StoryTitle,
+		Type:        story.StoryType,
+		WordCount:   story.WordCount,
+		ReadingTime: story.ReadingTime,
+		Preview:     story.Preview,
+		PublishedOn: story.PublishedOn,
+		UpdatedOn:   story.UpdatedOn,
+		Summary:     services.Summary(story),
+	}
+	if lead := story.LeadPhoto(); lead != nil {
+		mapped := publicGalleryPhoto(lead)
+		data.LeadImage = &mapped
+	}
+
+	data.SEO = responses.PublicSEO{
+		MetaTitle:       firstOf(story.MetaTitle, story.StoryTitle),
+		MetaDescription: firstOf(story.MetaDescription, &data.Summary),
+		CanonicalURL:    services.CanonicalURL(siteURL(p.env, c), "/stories/", story.Slug, story.CanonicalURL),
+		OgImage:         story.OgImage,
+	}
+	if data.SEO.OgImage == nil && data.LeadImage != nil {
+		data.SEO.OgImage = data.LeadImage.URL
+	}
+
+	if full {
+		data.Content = story.StoryContent
+		data.Body = story.StoryBody
+		for _, photo := range story.Gallery {
+			data.Gallery = append(data.Gallery, publicGalleryPhoto(photo))
+		}
+	}
+
+	return data
+}
+
+func publicGalleryPhoto(photo *magazine.StoryPhoto) responses.PublicPhoto {
+	data := responses.PublicPhoto{
+		Title:     photo.PhotoTitle,
+		URL:       photo.URL,
+		Caption:   photo.Caption,
+		Credit:    photo.CreditText,
+		CropHints: photo.CropHints,
+	}
+	if photo.PhotographId != nil {
+		data.ID = *photo.PhotographId
+	}
+	if photo.CreditName != nil {
+		data.Credit = photo.CreditName
+	}
+
+	return data
+}
+
+// publicIssue maps the gated issue for readers with summaries of its stories
+func (p PublicHandler) publicIssue(c *gin.Context, issue *magazine.MagazineIssue) responses.PublicIssue {
+	data := responses.PublicIssue{
+		ID:          issue.ID,
+		Slug:        issue.Slug,
+		IssueCode:   issue.IssueCode,
+		PdfURL:      issue.PdfURL,
+		EpubURL:     issue.EpubURL,
+		Preview:     issue.Preview,
+		PublishedOn: issue.PublishedOn,
+		UpdatedOn:   issue.UpdatedOn,
+	}
+
+	var titles []string
+	ogImage := issue.OgImage
+	for _, story := range issue.Stories {
+		mapped := p.publicStory(c, story, false)
+		data.Stories = append(data.Stories, mapped)
+		if story.StoryTitle != nil {
+			titles = append(titles, *story.StoryTitle)
+		}
+		if ogImage == nil && mapped.LeadImage != nil {
+			ogImage = mapped.LeadImage.URL
+		}
+	}
+
+	description := strings.Join(titles, ", ")
+	data.SEO = responses.PublicSEO{
+		MetaTitle:       firstOf(issue.MetaTitle, issue.IssueCode),
+		MetaDescription: firstOf(issue.MetaDescription, &description),
+		CanonicalURL:    services.CanonicalURL(siteURL(p.env, c), "/issues/", issue.Slug, issue.CanonicalURL),
+		OgImage:         ogImage,
+	}
+
+	return data
+}
+
+// firstOf returns the first of the values set and not empty
+func firstOf(values ...*string) string {
+	for _, value := range values {
+		if value != nil && *value != "" {
+			return *value
+		}
+	}
+	return ""
+}
+
+// readerQuery filters and sort of the list, pages for readers are always sent whole
+func readerQuery(c *gin.Context) models.ListQuery {
+	query := services.ListQueryFrom(c)
+	query.Fields, query.Columns = nil, nil
+	return query
+}
+
+// latest returns the latest of the times
+func latest(t time.Time, times ...*time.Time) time.Time {
+	for _, other := range times {
+		if other != nil && other.After(t) {
+			t = *other
+		}
+	}
+
+	return t
+}
@@ -0,0 +1,375 @@
+package handlers
+
+import (
+	"magazine_api/api/serializers/requests"
+	"magazine_api/api/serializers/responses"
+	"magazine_api/lib"
+	"magazine_api/orchestrators"
+	"magazine_api/services"
+	"time"
+
+	"github.com/danhper/structomap"
+	"github.com/gin-gonic/gin"
+)
+
+type RoyaltyHandler struct {
+	logger       lib.Logger
+	service      services.RoyaltyService
+	orchestrator orchestrators.RoyaltyOrchestrator
+	resolver     services.URLResolver
+}
+
+func NewRoyaltyHandler(
+	logger lib.Logger,
+	service services.RoyaltyService,
+	orchestrator orchestrators.RoyaltyOrchestrator,
+	resolver services.URLResolver,
+) RoyaltyHandler {
+	return RoyaltyHandler{
+		logger:       logger,
+		service:      service,
+		orchestrator: orchestrator,
+		resolver:     resolver,
+	}
+}
+
+// CreateAgreement godoc
+// @Summary      Create Royalty Agreement
+// @Description  Creates rates per word, story and photo for contributor
+// @Tags         Royalty
+// @Accept       json
+// @Produce      json
+// @Param        agreement  body      requests.CreateAgreement  true  "Add Agreement"
+// @Success      200        {object}  object{data=responses.Agreement}
+// @Security     BearerAuth
+// @Router       /royalty/agreement [post]
+//
+// Creates royalty agreement
+func (r RoyaltyHandler) CreateAgreement(c *gin.Context) {
+	var request requests.CreateAgreement
+	if !bindJSON(c, &request) {
+		return
+	}
+	agreement := request.Agreement()
+
+	created, err := r.service.CreateAgreement(agreement)
+	if err != nil {
+		handleError(r.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": responses.NewAgreement(created)})
+}
+
+// ListContributorAgreements godoc
+// @Summary      Lists Royalty Agreements of contributor
+// @Description  Lists royalty agreements of contributor by contributor id
+// @Tags         Royalty
+// @Produce      json
+// @Param        id   path      string  true  "Contributor ID"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200  {object}  object{data=[]responses.Agreement,next_cursor=string,total=int}
+// @Security     BearerAuth
+// @Router       /royalty/agreement/contributor/{id} [get]
+//
+// List royalty agreements of contributor controller
+func (r RoyaltyHandler) ListContributorAgreements(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	agreements, page, err := r.service.ListAgreementsByContributorID(id, services.PaginationFrom(c), services.ListQueryFrom(c))
+	if err != nil {
+		handleError(r.logger, c, err)
+		return
+	}
+
+	respondPage(r.logger, r.resolver, c, responses.List(agreements, responses.NewAgreement), page)
+}
+
+// PatchAgreement godoc
+// @Summary      Update Royalty Agreement
+// @Description  Updates royalty agreement
+// @Tags         Royalty
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string                       true  "Agreement ID"
+// @Param        agreement  body      requests.PatchAgreement  true  "Update Agreement"
+// @Success      200        {object}  object{data=responses.Agreement}
+// @Security     BearerAuth
+// @Router       /royalty/agreement/{id} [patch]
+//
+// Patch royalty agreement controller
+func (r RoyaltyHandler) PatchAgreement(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	agreement, err := r.service.GetAgreementByID(id)
+	if err != nil {
+		handleError(r.logger, c, err)
+		return
+	}
+
+	var newAgreement requests.PatchAgreement
+	if !bindJSON(c, &newAgreement) {
+		return
+	}
+
+	agreementMap := structomap.New().UseSnakeCase().PickAll().
+		Omit("ContributorId").
+		OmitIf(func(ch interface{}) bool {
+			return newAgreement.PerWord == nil
+		}, "PerWord").
+		OmitIf(func(ch interface{}) bool {
+			return newAgreement.PerStory == nil
+		}, "PerStory").
+		OmitIf(func(ch interface{}) bool {
+			return newAgreement.PerPhoto == nil
+		}, "PerPhoto").
+		OmitIf(func(ch interface{}) bool {
+			return newAgreement.EffectiveFrom == nil
+		}, "EffectiveFrom").
+		OmitIf(func(ch interface{}) bool {
+			return newAgreement.EffectiveTo == nil
+		}, "EffectiveTo").
+		OmitIf(func(ch interface{}) bool {
+			return newAgreement.Remarks == nil
+		}, "Remarks").
+		Transform(newAgreement)
+
+	if len(agreementMap) > 0 {
+		if err := services.AgreementPatch(&agreementMap, agreement.EffectiveFrom, agreement.EffectiveTo); err != nil {
+			handleError(r.logger, c, err)
+			return
+		}
+
+		agreementMap["updated_on"] = time.Now()
+
+		err := r.service.UpdateAgreement(agreement.ID, &agreementMap)
+		if err != nil {
+			handleError(r.logger, c, err)
+			return
+		}
+
+		agreementMap["id"] = agreement.ID
+		c.JSON(200, gin.H{"data": agreementMap})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": "nothing to update"})
+}
+
+// DeleteAgreement godoc
+// @Summary      Soft Delete Royalty Agreement
+// @Description  Delete by agreement ID
+// @Tags         Royalty
+// @Produce      json
+// @Param        id   path      string  true  "Agreement ID"
+// @Success      200  {object}  object{data=string}
+// @Security     BearerAuth
+// @Router       /royalty/agreement/{id} [delete]
+//
+// Delete royalty agreement controller
+func (r RoyaltyHandler) DeleteAgreement(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := r.service.DeleteAgreement(id); err != nil {
+		handleError(r.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": "successfully deleted"})
+}
+
+// PublishRoyalties godoc
+// @Summary      Record royalties of published issue
+// @Description  Computes royalties of stories and photographs the contents of the published issue place
+// @Description  in it, royalties are recorded when the issue is published and this records those missing
+// @Tags         Royalty
+// @Produce      json
+// @Param        id   path      string  true  "Issue ID"
+// @Success      200  {object}  object{data=[]responses.Royalty,skipped=[]string}
+// @Failure      422  {object}  responses.Problem
+// @Security     BearerAuth
+// @Router       /royalty/issue/{id}/publish [post]
+//
+// Record royalties of published issue controller
+func (r RoyaltyHandler) PublishRoyalties(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	royalties, skipped, err := r.orchestrator.Publish(id)
+	if err != nil {
+		handleError(r.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": responses.List(royalties, responses.NewRoyalty), "skipped": skipped})
+}
+
+// ListIssueRoyalties godoc
+// @Summary      Lists royalties of issue
+// @Description  Lists royalties recorded for the issue
+// @Tags         Royalty
+// @Produce      json
+// @Param        id   path      string  true  "Issue ID"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200  {object}  object{data=[]responses.Royalty,next_cursor=string,total=int}
+// @Security     BearerAuth
+// @Router       /royalty/issue/{id} [get]
+//
+// List royalties of issue controller
+func (r RoyaltyHandler) ListIssueRoyalties(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	royalties, page, err := r.service.ListRoyaltiesByIssueID(id, services.PaginationFrom(c), services.ListQueryFrom(c))
+	if err != nil {
+		handleError(r.logger, c, err)
+		return
+	}
+
+	respondPage(r.logger, r.resolver, c, responses.List(royalties, responses.NewRoyalty), page)
+}
+
+// ListContributorRoyalties godoc
+// @Summary      Lists royalties of contributor
+// @Description  Lists royalties of contributor by contributor id
+// @Tags         Royalty
+// @Produce      json
+// @Param        id   path      string  true  "Contributor ID"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200  {object}  object{data=[]responses.Royalty,next_cursor=string,total=int}
+// @Security     BearerAuth
+// @Router       /royalty/contributor/{id} [get]
+//
+// List royalties of contributor controller
+func (r RoyaltyHandler) ListContributorRoyalties(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	royalties, page, err := r.service.ListRoyaltiesByContributorID(id, services.PaginationFrom(c), services.ListQueryFrom(c))
+	if err != nil {
+		handleError(r.logger, c, err)
+		return
+	}
+
+	respondPage(r.logger, r.resolver, c, responses.List(royalties, responses.NewRoyalty), page)
+}
+
+// ListMyRoyalties godoc
+// @Summary      Lists my royalties
+// @Description  Lists royalties of authenticated contributor
+// @Tags         Royalty
+// @Produce      json
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200  {object}  object{data=[]responses.Royalty,next_cursor=string,total=int}
+// @Security     BearerAuth
+// @Router       /royalty/me [get]
+//
+// List royalties of authenticated contributor controller
+func (r RoyaltyHandler) ListMyRoyalties(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		handleError(r.logger, c, errNotAuthenticated)
+		return
+	}
+
+	royalties, page, err := r.service.ListRoyaltiesByContributorID(userID, services.PaginationFrom(c), services.ListQueryFrom(c))
+	if err != nil {
+		handleError(r.logger, c, err)
+		return
+	}
+
+	respondPage(r.logger, r.resolver, c, responses.List(royalties, responses.NewRoyalty), page)
+}
+
+// ListBalances godoc
+// @Summary      Lists contributor balances
+// @Description  Lists outstanding and paid out royalties per contributor
+// @Tags         Royalty
+// @Produce      json
+// @Success      200  {object}  object{data=[]responses.ContributorBalance}
+// @Security     BearerAuth
+// @Router       /royalty/balance [get]
+//
+// List contributor balances controller
+func (r RoyaltyHandler) ListBalances(c *gin.Context) {
+	balances, err := r.service.ListBalances()
+	if err != nil {
+		handleError(r.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": balances})
+}
+
+// Payout godoc
+// @Summary      Pay out royalties
+// @Description  Pays outstanding royalties in a batch with one transaction per contributor
+// @Tags         Royalty
+// @Accept       json
+// @Produce      json
+// @Param        payout  body      requests.RoyaltyPayout  true  "Payout"
+// @Success      200     {object}  object{data=responses.Payout}
+// @Security     BearerAuth
+// @Router       /royalty/payout [post]
+//
+// Pay out royalties controller
+func (r RoyaltyHandler) Payout(c *gin.Context) {
+	var request requests.RoyaltyPayout
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	payout, err := r.orchestrator.Payout(request)
+	if err != nil {
+		handleError(r.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": responses.NewPayout(payout)})
+}
+
+// ListPayouts godoc
+// @Summary      Lists royalty payouts
+// @Description  Lists royalty payout batches
+// @Tags         Royalty
+// @Produce      json
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200  {object}  object{data=[]responses.Payout,next_cursor=string,total=int}
+// @Security     BearerAuth
+// @Router       /royalty/payout [get]
+//
+// List royalty payouts controller
+func (r RoyaltyHandler) ListPayouts(c *gin.Context) {
+	payouts, page, err := r.service.ListPayouts(services.PaginationFrom(c), services.ListQueryFrom(c))
+	if err != nil {
+		handleError(r.logger, c, err)
+		return
+	}
+
+	respondPage(r.logger, r.resolver, c, responses.List(payouts, responses.NewPayout), page)
+}
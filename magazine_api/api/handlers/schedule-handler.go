@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"magazine_api/apperrors"
+	"magazine_api/lib"
+	"magazine_api/services"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ScheduleHandler struct {
+	logger    lib.Logger
+	scheduler services.Scheduler
+}
+
+func NewScheduleHandler(logger lib.Logger, scheduler services.Scheduler) ScheduleHandler {
+	return ScheduleHandler{
+		logger:    logger,
+		scheduler: scheduler,
+	}
+}
+
+// ListSchedule godoc
+// @Summary      Lists scheduled publishing
+// @Description  Dry run of the scheduler, lists stories, issues and adverts due to be published or
+// @Description  unpublished now and actions scheduled within the duration, a week by default
+// @Tags         Schedule
+// @Produce      json
+// @Param        within  query     string  false  "Duration like 24h"
+// @Success      200     {object}  object{data=object{due=[]models.ScheduledAction,upcoming=[]models.ScheduledAction}}
+// @Failure      400     {object}  responses.Problem
+// @Security     BearerAuth
+// @Router       /admin/schedule [get]
+//
+// List scheduled publishing controller
+func (s ScheduleHandler) ListSchedule(c *gin.Context) {
+	within, err := time.ParseDuration(c.DefaultQuery("within", "168h"))
+	if err != nil || within <= 0 {
+		handleError(s.logger, c, apperrors.NewBadRequest("within must be a positive duration like 24h"))
+		return
+	}
+
+	due, err := s.scheduler.RunDue(true)
+	if err != nil {
+		handleError(s.logger, c, err)
+		return
+	}
+
+	upcoming, err := s.scheduler.ListUpcoming(within)
+	if err != nil {
+		handleError(s.logger, c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"due": due, "upcoming": upcoming}})
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"io"
+	"magazine_api/apperrors"
+	"magazine_api/lib"
+	"magazine_api/services"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StorageHandler struct {
+	logger  lib.Logger
+	service services.StorageService
+}
+
+func NewStorageHandler(logger lib.Logger, service services.StorageService) StorageHandler {
+	return StorageHandler{
+		logger:  logger,
+		service: service,
+	}
+}
+
+// DownloadFile godoc
+// @Summary      Download stored file
+// @Description  Serves file of local storage through HMAC signed, expiring url
+// @Tags         Upload
+// @Produce      octet-stream
+// @Param        key        path      string  true  "Object key"
+// @Param        expires    query     int     true  "Expiry unix time"
+// @Param        signature  query     string  true  "HMAC signature"
+// @Success      200
+// @Failure      403  {object}  responses.Problem
+// @Failure      404  {object}  responses.Problem
+// @Router       /storage/{key} [get]
+//
+// Download file of local storage
+func (s StorageHandler) DownloadFile(c *gin.Context) {
+	if !s.service.IsServedLocally() {
+		handleError(s.logger, c, apperrors.NewNotFound("storage is not served by the API"))
+		return
+	}
+
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		handleError(s.logger, c, apperrors.Wrap(apperrors.Forbidden, lib.ErrInvalidSignature.Error(), lib.ErrInvalidSignature))
+		return
+	}
+
+	if err := s.service.VerifySignedKey(key, expires, c.Query("signature")); err != nil {
+		handleError(s.logger, c, apperrors.Wrap(apperrors.Forbidden, err.Error(), err))
+		return
+	}
+
+	file, err := s.service.DownloadFile(c.Request.Context(), key)
+	if errors.Is(err, lib.ErrObjectNotFound) {
+		handleError(s.logger, c, apperrors.Wrap(apperrors.NotFound, err.Error(), err))
+		return
+	}
+	if err != nil {
+		handleError(s.logger, c, err)
+		return
+	}
+	defer file.Close()
+
+	contentType := mime.TypeByExtension(filepath.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	c.DataFromReader(http.StatusOK, -1, contentType, file, map[string]string{
+		"Cache-Control": "private",
+	})
+}
+
+// UploadFile godoc
+// @Summary      Upload file to storage
+// @Description  Receives file or part of multipart upload of local storage through HMAC signed, expiring url
+// @Tags         Upload
+// @Accept       octet-stream
+// @Param        key        path      string  true  "Object key"
+// @Param        expires    query     int     true  "Expiry unix time"
+// @Param        max_size   query     int     true  "Largest accepted size"
+// @Param        signature  query     string  true  "HMAC signature"
+// @Success      200
+// @Failure      403  {object}  responses.Problem
+// @Failure      413  {object}  responses.Problem
+// @Router       /storage/{key} [put]
+//
+// Upload file to local storage
+func (s StorageHandler) UploadFile(c *gin.Context) {
+	if !s.service.IsServedLocally() {
+		handleError(s.logger, c, apperrors.NewNotFound("storage is not served by the API"))
+		return
+	}
+
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		handleError(s.logger, c, apperrors.Wrap(apperrors.Forbidden, lib.ErrInvalidSignature.Error(), lib.ErrInvalidSignature))
+		return
+	}
+	maxSize, err := strconv.ParseInt(c.Query("max_size"), 10, 64)
+	if err != nil {
+		handleError(s.logger, c, apperrors.Wrap(apperrors.Forbidden, lib.ErrInvalidSignature.Error(), lib.ErrInvalidSignature))
+		return
+	}
+
+	if err := s.service.VerifySignedUpload(key, maxSize, expires, c.Query("signature")); err != nil {
+		handleError(s.logger, c, apperrors.Wrap(apperrors.Forbidden, err.Error(), err))
+		return
+	}
+
+	if c.Request.ContentLength > maxSize {
+		handleError(s.logger, c, apperrors.Wrap(apperrors.TooLarge, errBodyTooLarge.Error(), errBodyTooLarge))
+		return
+	}
+
+	hash := md5.New()
+	body := &limitedBody{reader: io.TeeReader(c.Request.Body, hash), remaining: maxSize}
+	if err := s.service.UploadFile(c.Request.Context(), body, key); err != nil {
+		if errors.Is(err, errBodyTooLarge) {
+			if err := s.service.DeleteFile(c.Request.Context(), key); err != nil {
+				s.logger.Error("storage-upload-delete-error: ", err)
+			}
+			handleError(s.logger, c, apperrors.Wrap(apperrors.TooLarge, err.Error(), err))
+			return
+		}
+		handleError(s.logger, c, err)
+		return
+	}
+
+	// etag of parts is listed back to complete multipart upload like S3 does
+	c.Header("ETag", `"`+hex.EncodeToString(hash.Sum(nil))+`"`)
+	c.Status(http.StatusOK)
+}
+
+var errBodyTooLarge = errors.New("file is too large")
+
+// limitedBody fails reading once more than remaining bytes are read
+type limitedBody struct {
+	reader    io.Reader
+	remaining int64
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	n, err := l.reader.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, errBodyTooLarge
+	}
+	return n, err
+}
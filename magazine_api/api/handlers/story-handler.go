@@ -1,142 +1,211 @@
 package handlers
 
 import (
+	"errors"
+	"magazine_api/api/serializers/requests"
+	"magazine_api/api/serializers/responses"
+	"magazine_api/apperrors"
 	"magazine_api/lib"
 	"magazine_api/models/magazine"
+	"magazine_api/orchestrators"
 	"magazine_api/services"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/danhper/structomap"
+	"github.com/georgysavva/scany/pgxscan"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type StoryHandler struct {
-	logger  lib.Logger
-	service services.StoryService
+	logger      lib.Logger
+	service     services.StoryService
+	gallery     services.GalleryService
+	issues      orchestrators.IssueOrchestrator
+	entitlement services.EntitlementService
+	resolver    services.URLResolver
 }
 
-func NewStoryHandler(logger lib.Logger, service services.StoryService) StoryHandler {
+func NewStoryHandler(
+	logger lib.Logger,
+	service services.StoryService,
+	gallery services.GalleryService,
+	issues orchestrators.IssueOrchestrator,
+	entitlement services.EntitlementService,
+	resolver services.URLResolver,
+) StoryHandler {
 	return StoryHandler{
-		logger:  logger,
-		service: service,
+		logger:      logger,
+		service:     service,
+		gallery:     gallery,
+		issues:      issues,
+		entitlement: entitlement,
+		resolver:    resolver,
 	}
 }
 
 // CreateStory godoc
 // @Summary      Create Story
-// @Description  It creates Story structure
+// @Description  It creates Story structure, story body is validated against the block schema and
+// @Description  word count and reading time are computed from it. It is published when created unless
+// @Description  publish_at is later
 // @Tags         Story
 // @Accept       json
 // @Produce      json
-// @Param        Story  body      magazine.Story  true  "Add Story"
-// @Success      200       {object}  object{data=magazine.Story}
+// @Param        Story  body      requests.CreateStory  true  "Add Story"
+// @Success      200       {object}  object{data=responses.Story}
+// @Failure      400       {object}  responses.Problem
+// @Failure      422       {object}  responses.Problem
+// @Security     BearerAuth
 // @Router       /story [post]
 //
 // Creates Story
 func (s StoryHandler) CreateStory(c *gin.Context) {
-	var story *magazine.Story
-	if err := c.ShouldBind(&story); err != nil {
-		handleError(s.logger, c, err)
+	userID, ok := currentUserID(c)
+	if !ok {
+		handleError(s.logger, c, errNotAuthenticated)
+		return
+	}
+
+	var request requests.CreateStory
+	if !bindJSON(c, &request) {
 		return
 	}
+	story := request.Story(userID)
 
 	// Create Story in our Database
 	story, err := s.service.CreateStory(story)
 	if err != nil {
-		handleError(s.logger, c, err)
+		handleStoryBodyError(s.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": story})
+	respondResolved(s.logger, s.resolver, c, gin.H{"data": responses.NewStory(story)})
 }
 
 // ListAllStoriess godoc
 // @Summary      List all stories.
-// @Description  List stories
+// @Description  List stories, non-subscribers only get previews of the content
 // @Tags         Story
 // @Produce      json
-// @Success      200  {object}  object{data=[]magazine.Story}
+// @Param        filter  query     string  false  "filter[column]=value or filter[column][eq|ne|gt|gte|lt|lte|like|in|null]=value"
+// @Param        sort    query     string  false  "columns to sort by, - sorts descending"
+// @Param        fields  query     string  false  "fields to send"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200     {object}  object{data=[]responses.Story,next_cursor=string,total=int}
 // @Router       /story [get]
 //
 // List all suppliers from database
 func (s StoryHandler) ListStories(c *gin.Context) {
-	stories, err := s.service.ListStories(c)
+	stories, page, err := s.service.ListStories(c)
 	if err != nil {
 		handleError(s.logger, c, err)
 		return
 	}
 
-	c.JSON(200, stories)
+	if err := s.gallery.AttachGalleries(stories...); err != nil {
+		handleError(s.logger, c, err)
+		return
+	}
+
+	respondPage(s.logger, s.resolver, c, responses.List(s.entitlement.GateStories(c, stories), responses.NewStory), page)
 }
 
 // ListStoryFromUserId godoc
 // @Summary      Lists Story from User Id
-// @Description  List Story by creator id
+// @Description  List Story by creator id, non-subscribers only get previews of the content
 // @Tags         Story
 // @Produce      json
-// @Param        id   path      string  true  "ID"
-// @Success      200  {object}  object{data=[]magazine.Story}
+// @Param        id      path      string  true   "ID"
+// @Param        filter  query     string  false  "filter[column]=value or filter[column][eq|ne|gt|gte|lt|lte|like|in|null]=value"
+// @Param        sort    query     string  false  "columns to sort by, - sorts descending"
+// @Param        fields  query     string  false  "fields to send"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200     {object}  object{data=[]responses.Story,next_cursor=string,total=int}
 // @Router       /story/profile/{id} [get]
 //
 // List Story from creator ID controller
 func (a StoryHandler) ListStoryByProfileId(c *gin.Context) {
 
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	Storys, err := a.service.ListStoriesByProfileId(uuid.MustParse(id))
+	Storys, page, err := a.service.ListStoriesByProfileId(id, services.PaginationFrom(c), services.ListQueryFrom(c))
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": Storys})
+	respondPage(a.logger, a.resolver, c, responses.List(a.entitlement.GateStories(c, Storys), responses.NewStory), page)
 }
 
 // ListStoryByType godoc
 // @Summary      Lists Story Type
-// @Description  List Story by type
+// @Description  List Story by type, non-subscribers only get previews of the content
 // @Tags         Story
 // @Produce      json
-// @Param        story_type   path      string  true  "TYPE"
-// @Success      200  {object}  object{data=[]magazine.Story}
+// @Param        story_type path      string  true   "TYPE"
+// @Param        filter  query     string  false  "filter[column]=value or filter[column][eq|ne|gt|gte|lt|lte|like|in|null]=value"
+// @Param        sort    query     string  false  "columns to sort by, - sorts descending"
+// @Param        fields  query     string  false  "fields to send"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200     {object}  object{data=[]responses.Story,next_cursor=string,total=int}
 // @Router       /story/type/{story_type} [get]
 //
 // List Story from creator ID controller
 func (a StoryHandler) ListStoriesByType(c *gin.Context) {
 
-	story_type := c.Param("type")
+	story_type := c.Param("story_type")
 
-	Storys, err := a.service.ListStoriesByType(story_type)
+	Storys, page, err := a.service.ListStoriesByType(story_type, services.PaginationFrom(c), services.ListQueryFrom(c))
 
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": Storys})
+	respondPage(a.logger, a.resolver, c, responses.List(a.entitlement.GateStories(c, Storys), responses.NewStory), page)
 }
 
 // GetStoryById godoc
 // @Summary      Gets One Story by ID
-// @Description  Gets One Story by D
+// @Description  Gets One Story by ID with its ordered gallery, non-subscribers only get a preview of the content
 // @Tags         Story
 // @Produce      json
 // @Param        id   path      string  true  "ID"
-// @Success      200  {object}  object{data=models.Story}
+// @Success      200  {object}  object{data=responses.Story}
 // @Router       /story/id/{id} [get]
 //
 // Gets Story By Company ID controller
 func (a StoryHandler) GetStoryById(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	Story, err := a.service.GetStoryById(uuid.MustParse(id))
+	Story, err := a.service.GetStoryById(id)
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": Story})
+	if err := a.gallery.AttachGalleries(Story); err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	respondResolved(a.logger, a.resolver, c, gin.H{"data": responses.NewStory(a.entitlement.GateStory(c, Story))})
 }
 
 // UpdateStory godoc
@@ -146,26 +215,29 @@ func (a StoryHandler) GetStoryById(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Update Story"
-// @Success      200  {object}  object{data=models.Story}
+// @Success      200  {object}  object{data=responses.Story}
+// @Failure      400  {object}  responses.Problem
+// @Failure      422  {object}  responses.Problem
 // @Router       /story/{id} [patch]
 // Patch Story of creator by Id controller
 func (a StoryHandler) PatchStoryById(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	Story, err := a.service.GetStoryById(uuid.MustParse(id))
+	Story, err := a.service.GetStoryById(id)
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	var newStory magazine.StoryBase
-	if err := c.ShouldBindJSON(&newStory); err != nil {
-		handleError(a.logger, c, err)
+	var newStory requests.PatchStory
+	if !bindJSON(c, &newStory) {
 		return
 	}
 
 	StoryMap := structomap.New().UseSnakeCase().PickAll().
-		Omit("CreatorId").
 		OmitIf(func(ch interface{}) bool {
 			return newStory.StoryCode == nil
 		}, "StoryCode").
@@ -178,19 +250,63 @@ func (a StoryHandler) PatchStoryById(c *gin.Context) {
 		OmitIf(func(ch interface{}) bool {
 			return newStory.StoryContent == nil
 		}, "StoryContent").
+		OmitIf(func(ch interface{}) bool {
+			return newStory.StoryBody == nil
+		}, "StoryBody").
+		OmitIf(func(ch interface{}) bool {
+			return newStory.PublishAt == nil
+		}, "PublishAt").
+		OmitIf(func(ch interface{}) bool {
+			return newStory.UnpublishAt == nil
+		}, "UnpublishAt").
+		OmitIf(func(ch interface{}) bool {
+			return newStory.MetaTitle == nil
+		}, "MetaTitle").
+		OmitIf(func(ch interface{}) bool {
+			return newStory.MetaDescription == nil
+		}, "MetaDescription").
+		OmitIf(func(ch interface{}) bool {
+			return newStory.CanonicalURL == nil
+		}, "CanonicalURL").
+		OmitIf(func(ch interface{}) bool {
+			return newStory.OgImage == nil
+		}, "OgImage").
+		OmitIf(func(ch interface{}) bool {
+			return newStory.Remarks == nil
+		}, "Remarks").
 		Transform(newStory)
 
+	if newStory.StoryBody != nil {
+		StoryMap["story_body"] = newStory.StoryBody
+	}
+
 	if len(StoryMap) > 0 {
+		if err := services.SchedulePatch(&StoryMap, Story.PublishAt, Story.UnpublishAt); err != nil {
+			handleError(a.logger, c, err)
+			return
+		}
+		services.PublishPatch(&StoryMap, Story.PublishedOn, Story.UnpublishAt)
+
 		StoryMap["updated_on"] = time.Now()
 		StoryMap["id"] = Story.ID
 
 		err := a.service.UpdateStory(Story.ID, &StoryMap)
 		if err != nil {
+			handleStoryBodyError(a.logger, c, err)
+			return
+		}
+
+		updated, err := a.service.GetStoryById(Story.ID)
+		if err != nil {
+			handleError(a.logger, c, err)
+			return
+		}
+		if err := a.gallery.AttachGalleries(updated); err != nil {
 			handleError(a.logger, c, err)
 			return
 		}
 
-		c.JSON(200, gin.H{"data": StoryMap})
+		respondResolved(a.logger, a.resolver, c, gin.H{"data": responses.NewStory(updated)})
 		return
 	}
 
@@ -208,9 +324,12 @@ func (a StoryHandler) PatchStoryById(c *gin.Context) {
 //
 // Delete Story By ID controller
 func (a StoryHandler) DeleteStoryByID(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	err := a.service.DeleteStory(uuid.MustParse(id))
+	err := a.service.DeleteStory(id)
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
@@ -218,3 +337,340 @@ func (a StoryHandler) DeleteStoryByID(c *gin.Context) {
 
 	c.JSON(200, gin.H{"data": "successfully deleted"})
 }
+
+// RenderStoryBody godoc
+// @Summary      Renders body of Story
+// @Description  Renders story body as html or plain text, stories without body are rendered from their
+// @Description  content. Non-subscribers only get a preview
+// @Tags         Story
+// @Produce      json
+// @Param        id      path      string  true   "Story ID"
+// @Param        format  query     string  false  "html or text, html by default"
+// @Success      200  {object}  object{data=string,format=string,word_count=int,reading_time=int,preview=bool}
+// @Failure      400  {object}  responses.Problem
+// @Failure      404  {object}  responses.Problem
+// @Router       /story/id/{id}/body [get]
+//
+// Render body of story controller
+func (a StoryHandler) RenderStoryBody(c *gin.Context) {
+	format := c.DefaultQuery("format", "html")
+	if format != "html" && format != "text" {
+		handleError(a.logger, c, apperrors.NewBadRequest("format must be html or text"))
+		return
+	}
+
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	story, err := a.service.GetStoryById(id)
+	if pgxscan.NotFound(err) {
+		handleError(a.logger, c, apperrors.NewNotFound("story not found"))
+		return
+	}
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+	story = a.entitlement.GateStory(c, story)
+
+	body := story.StoryBody
+	if body == nil {
+		body = &magazine.StoryBody{Version: magazine.StoryBodyVersion}
+		if story.StoryContent != nil {
+			for _, paragraph := range strings.Split(*story.StoryContent, "\n\n") {
+				if text := strings.TrimSpace(paragraph); text != "" {
+					body.Blocks = append(body.Blocks, magazine.StoryBlock{Type: magazine.BlockParagraph, Text: &text})
+				}
+			}
+		}
+	}
+
+	if err := a.resolver.Resolve(c, body); err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	rendered := services.StoryBodyText(body)
+	if format == "html" {
+		rendered = services.StoryBodyHTML(body)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":         rendered,
+		"format":       format,
+		"word_count":   story.WordCount,
+		"reading_time": story.ReadingTime,
+		"preview":      story.Preview,
+	})
+}
+
+// ListGallery godoc
+// @Summary      Lists gallery of Story
+// @Description  Lists photographs of the story ordered by position with captions, credits and crop hints
+// @Tags         Story
+// @Produce      json
+// @Param        id   path      string  true  "Story ID"
+// @Success      200  {object}  object{data=[]magazine.StoryPhoto}
+// @Router       /story/id/{id}/gallery [get]
+//
+// List gallery of story controller
+func (a StoryHandler) ListGallery(c *gin.Context) {
+	storyID, ok := a.galleryStory(c)
+	if !ok {
+		return
+	}
+
+	gallery, err := a.gallery.ListGallery(storyID)
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	respondResolved(a.logger, a.resolver, c, gin.H{"data": gallery})
+}
+
+// AddGalleryPhoto godoc
+// @Summary      Adds photograph to gallery of Story
+// @Description  Adds photograph at the position or at the end, lead photograph replaces the previous lead.
+// @Description  Photographs whose licences don't allow placement in issues of the story are rejected
+// @Tags         Story
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                 true  "Story ID"
+// @Param        data  body      requests.GalleryPhoto  true  "Gallery photograph"
+// @Success      200   {object}  object{data=[]magazine.StoryPhoto}
+// @Failure      400   {object}  responses.Problem
+// @Failure      404   {object}  responses.Problem
+// @Failure      422   {object}  responses.Problem
+// @Security     BearerAuth
+// @Router       /story/id/{id}/gallery [post]
+//
+// Add photograph to gallery controller
+func (a StoryHandler) AddGalleryPhoto(c *gin.Context) {
+	storyID, ok := a.galleryStory(c)
+	if !ok {
+		return
+	}
+
+	var request requests.GalleryPhoto
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	if err := a.issues.CheckGalleryLicences(storyID, request.PhotographId); err != nil {
+		handleLicenceError(a.logger, c, err)
+		return
+	}
+
+	gallery, err := a.gallery.AddPhoto(storyID, request)
+	if err != nil {
+		a.handleGalleryError(c, err)
+		return
+	}
+
+	respondResolved(a.logger, a.resolver, c, gin.H{"data": gallery})
+}
+
+// PatchGalleryPhoto godoc
+// @Summary      Updates photograph in gallery of Story
+// @Description  Updates caption, credit, crop hints or lead flag of the photograph
+// @Tags         Story
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                      true  "Story ID"
+// @Param        photo_id  path      string                      true  "Photograph ID"
+// @Param        data      body      requests.PatchGalleryPhoto  true  "Updated fields"
+// @Success      200       {object}  object{data=[]magazine.StoryPhoto}
+// @Failure      400       {object}  responses.Problem
+// @Failure      404       {object}  responses.Problem
+// @Security     BearerAuth
+// @Router       /story/id/{id}/gallery/{photo_id} [patch]
+//
+// Patch photograph in gallery controller
+func (a StoryHandler) PatchGalleryPhoto(c *gin.Context) {
+	storyID, ok := a.galleryStory(c)
+	if !ok {
+		return
+	}
+
+	photographID, ok := pathUUID(c, "photo_id")
+	if !ok {
+		return
+	}
+
+	var request requests.PatchGalleryPhoto
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	gallery, err := a.gallery.PatchPhoto(storyID, photographID, request)
+	if err != nil {
+		a.handleGalleryError(c, err)
+		return
+	}
+
+	respondResolved(a.logger, a.resolver, c, gin.H{"data": gallery})
+}
+
+// RemoveGalleryPhoto godoc
+// @Summary      Removes photograph from gallery of Story
+// @Description  Removes the photograph, photographs after it move one up
+// @Tags         Story
+// @Produce      json
+// @Param        id        path      string  true  "Story ID"
+// @Param        photo_id  path      string  true  "Photograph ID"
+// @Success      200       {object}  object{data=[]magazine.StoryPhoto}
+// @Failure      404       {object}  responses.Problem
+// @Security     BearerAuth
+// @Router       /story/id/{id}/gallery/{photo_id} [delete]
+//
+// Remove photograph from gallery controller
+func (a StoryHandler) RemoveGalleryPhoto(c *gin.Context) {
+	storyID, ok := a.galleryStory(c)
+	if !ok {
+		return
+	}
+
+	photographID, ok := pathUUID(c, "photo_id")
+	if !ok {
+		return
+	}
+
+	gallery, err := a.gallery.RemovePhoto(storyID, photographID)
+	if err != nil {
+		a.handleGalleryError(c, err)
+		return
+	}
+
+	respondResolved(a.logger, a.resolver, c, gin.H{"data": gallery})
+}
+
+// ReorderGallery godoc
+// @Summary      Reorders gallery of Story
+// @Description  Orders gallery as listed, every photograph of the gallery is listed once
+// @Tags         Story
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                   true  "Story ID"
+// @Param        data  body      requests.ReorderGallery  true  "Photographs in order"
+// @Success      200   {object}  object{data=[]magazine.StoryPhoto}
+// @Failure      400   {object}  responses.Problem
+// @Security     BearerAuth
+// @Router       /story/id/{id}/gallery/order [put]
+//
+// Reorder gallery controller
+func (a StoryHandler) ReorderGallery(c *gin.Context) {
+	storyID, ok := a.galleryStory(c)
+	if !ok {
+		return
+	}
+
+	var request requests.ReorderGallery
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	gallery, err := a.gallery.Reorder(storyID, request.PhotographIds)
+	if err != nil {
+		a.handleGalleryError(c, err)
+		return
+	}
+
+	respondResolved(a.logger, a.resolver, c, gin.H{"data": gallery})
+}
+
+// galleryStory parses id of the story whose gallery is requested and checks the story exists
+func (a StoryHandler) galleryStory(c *gin.Context) (uuid.UUID, bool) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return uuid.Nil, false
+	}
+
+	story, err := a.service.GetStoryById(id)
+	if pgxscan.NotFound(err) || (err == nil && story.DeletedOn != nil) {
+		handleError(a.logger, c, apperrors.NewNotFound("story not found"))
+		return uuid.Nil, false
+	}
+	if err != nil {
+		handleError(a.logger, c, err)
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+func (a StoryHandler) handleGalleryError(c *gin.Context, err error) {
+	if pgxscan.NotFound(err) {
+		handleError(a.logger, c, apperrors.NewNotFound("photograph is not in the gallery"))
+		return
+	}
+
+	handleError(a.logger, c, err)
+}
+
+// handleStoryBodyError responds story body not matching its schema as invalid with the block at fault
+func handleStoryBodyError(logger lib.Logger, c *gin.Context, err error) {
+	var body *services.StoryBodyError
+	if errors.As(err, &body) {
+		field := "story_body"
+		if body.Block >= 0 {
+			field += ".blocks[" + strconv.Itoa(body.Block) + "]"
+		}
+		err = &apperrors.Error{
+			Kind:    apperrors.Validation,
+			Message: "story body is not valid",
+			Fields:  []apperrors.FieldError{{Field: field, Message: body.Message}},
+			Err:     err,
+		}
+	}
+
+	handleError(logger, c, err)
+}
+
+// ChangeStorySlug godoc
+// @Summary      Change slug of Story
+// @Description  Changes slug of the story to the slug given or a new one from its title when it is empty,
+// @Description  the old slug keeps redirecting to the story on the public api
+// @Tags         Story
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string              true  "Story ID"
+// @Param        slug  body      requests.ChangeSlug  true  "Slug"
+// @Success      200   {object}  object{data=object{slug=string}}
+// @Failure      400   {object}  responses.Problem
+// @Failure      404   {object}  responses.Problem
+// @Failure      409   {object}  responses.Problem
+// @Router       /story/id/{id}/slug [put]
+//
+// Change slug of story controller
+func (a StoryHandler) ChangeStorySlug(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var request requests.ChangeSlug
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	story, err := a.service.GetStoryById(id)
+	if pgxscan.NotFound(err) || (err == nil && story.DeletedOn != nil) {
+		handleError(a.logger, c, apperrors.NewNotFound("story not found"))
+		return
+	}
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	slug, err := a.service.ChangeSlug(story, request.Slug)
+	if err != nil {
+		handleError(a.logger, c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"slug": slug}})
+}
@@ -0,0 +1,330 @@
+package handlers
+
+import (
+	"magazine_api/api/serializers/requests"
+	"magazine_api/api/serializers/responses"
+	"magazine_api/apperrors"
+	"magazine_api/constants"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"magazine_api/orchestrators"
+	"magazine_api/services"
+	"strings"
+	"time"
+
+	"github.com/danhper/structomap"
+	"github.com/gin-gonic/gin"
+)
+
+type SubscriptionHandler struct {
+	logger       lib.Logger
+	service      services.SubscriptionService
+	entitlement  services.EntitlementService
+	orchestrator orchestrators.SubscriptionOrchestrator
+	resolver     services.URLResolver
+}
+
+func NewSubscriptionHandler(
+	logger lib.Logger,
+	service services.SubscriptionService,
+	entitlement services.EntitlementService,
+	orchestrator orchestrators.SubscriptionOrchestrator,
+	resolver services.URLResolver,
+) SubscriptionHandler {
+	return SubscriptionHandler{
+		logger:       logger,
+		service:      service,
+		entitlement:  entitlement,
+		orchestrator: orchestrator,
+		resolver:     resolver,
+	}
+}
+
+// CreatePlan godoc
+// @Summary      Create Subscription Plan
+// @Description  It creates subscription plan
+// @Tags         Subscription
+// @Accept       json
+// @Produce      json
+// @Param        plan  body      requests.CreatePlan  true  "Add Plan"
+// @Success      200   {object}  object{data=responses.Plan}
+// @Security     BearerAuth
+// @Router       /subscription_plan [post]
+//
+// Creates subscription plan
+func (s SubscriptionHandler) CreatePlan(c *gin.Context) {
+	var request requests.CreatePlan
+	if !bindJSON(c, &request) {
+		return
+	}
+	plan := request.Plan()
+
+	created, err := s.service.CreatePlan(plan)
+	if err != nil {
+		handleError(s.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": responses.NewPlan(created)})
+}
+
+// ListPlans godoc
+// @Summary      Lists Subscription Plans
+// @Description  List subscription plans
+// @Tags         Subscription
+// @Produce      json
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200  {object}  object{data=[]responses.Plan,next_cursor=string,total=int}
+// @Router       /subscription_plan [get]
+//
+// List subscription plans controller
+func (s SubscriptionHandler) ListPlans(c *gin.Context) {
+	plans, page, err := s.service.ListPlans(services.PaginationFrom(c), services.ListQueryFrom(c))
+	if err != nil {
+		handleError(s.logger, c, err)
+		return
+	}
+
+	respondPage(s.logger, s.resolver, c, responses.List(plans, responses.NewPlan), page)
+}
+
+// PatchPlan godoc
+// @Summary      Update Subscription Plan
+// @Description  Updates subscription plan
+// @Tags         Subscription
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                       true  "Plan ID"
+// @Param        plan  body      requests.PatchPlan  true  "Update Plan"
+// @Success      200   {object}  object{data=responses.Plan}
+// @Security     BearerAuth
+// @Router       /subscription_plan/{id} [patch]
+//
+// Patch subscription plan controller
+func (s SubscriptionHandler) PatchPlan(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	plan, err := s.service.GetPlanByID(id)
+	if err != nil {
+		handleError(s.logger, c, err)
+		return
+	}
+
+	var newPlan requests.PatchPlan
+	if !bindJSON(c, &newPlan) {
+		return
+	}
+
+	planMap := structomap.New().UseSnakeCase().PickAll().
+		OmitIf(func(ch interface{}) bool {
+			return newPlan.PlanName == nil
+		}, "PlanName").
+		OmitIf(func(ch interface{}) bool {
+			return newPlan.Interval == nil
+		}, "Interval").
+		OmitIf(func(ch interface{}) bool {
+			return newPlan.Format == nil
+		}, "Format").
+		OmitIf(func(ch interface{}) bool {
+			return newPlan.Price == nil
+		}, "Price").
+		OmitIf(func(ch interface{}) bool {
+			return newPlan.Remarks == nil
+		}, "Remarks").
+		Transform(newPlan)
+
+	if len(planMap) > 0 {
+		planMap["updated_on"] = time.Now()
+
+		err := s.service.UpdatePlan(plan.ID, &planMap)
+		if err != nil {
+			handleError(s.logger, c, err)
+			return
+		}
+
+		planMap["id"] = plan.ID
+		c.JSON(200, gin.H{"data": planMap})
+		return
+	}
+
+	c.JSON(200, gin.H{"data": "nothing to update"})
+}
+
+// DeletePlan godoc
+// @Summary      Soft Delete Subscription Plan
+// @Description  Delete by plan ID
+// @Tags         Subscription
+// @Produce      json
+// @Param        id   path      string  true  "Plan ID"
+// @Success      200  {object}  object{data=string}
+// @Security     BearerAuth
+// @Router       /subscription_plan/{id} [delete]
+//
+// Delete subscription plan controller
+func (s SubscriptionHandler) DeletePlan(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := s.service.DeletePlan(id); err != nil {
+		handleError(s.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": "successfully deleted"})
+}
+
+// Subscribe godoc
+// @Summary      Subscribe to a plan
+// @Description  Subscribes authenticated reader to a plan and posts the payment as transaction
+// @Tags         Subscription
+// @Accept       json
+// @Produce      json
+// @Param        subscription  body      requests.Subscribe  true  "Subscribe"
+// @Success      200           {object}  object{data=responses.Subscription}
+// @Security     BearerAuth
+// @Router       /subscription [post]
+//
+// Subscribe controller
+func (s SubscriptionHandler) Subscribe(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		handleError(s.logger, c, errNotAuthenticated)
+		return
+	}
+
+	var request requests.Subscribe
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	subscription, err := s.orchestrator.Subscribe(userID, request)
+	if err != nil {
+		handleError(s.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": responses.NewSubscription(subscription)})
+}
+
+// ListMySubscriptions godoc
+// @Summary      Lists my subscriptions
+// @Description  Lists subscriptions of authenticated reader
+// @Tags         Subscription
+// @Produce      json
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200  {object}  object{data=[]responses.Subscription,next_cursor=string,total=int}
+// @Security     BearerAuth
+// @Router       /subscription/me [get]
+//
+// List subscriptions of authenticated user controller
+func (s SubscriptionHandler) ListMySubscriptions(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		handleError(s.logger, c, errNotAuthenticated)
+		return
+	}
+
+	subscriptions, page, err := s.service.PageSubscriptionsByUserID(userID, services.PaginationFrom(c), services.ListQueryFrom(c))
+	if err != nil {
+		handleError(s.logger, c, err)
+		return
+	}
+
+	respondPage(s.logger, s.resolver, c, responses.List(subscriptions, responses.NewSubscription), page)
+}
+
+// ListUserSubscriptions godoc
+// @Summary      Lists subscriptions of user
+// @Description  Lists subscriptions of user by user id
+// @Tags         Subscription
+// @Produce      json
+// @Param        id   path      string  true  "User ID"
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200  {object}  object{data=[]responses.Subscription,next_cursor=string,total=int}
+// @Security     BearerAuth
+// @Router       /subscription/user/{id} [get]
+//
+// List subscriptions of user controller
+func (s SubscriptionHandler) ListUserSubscriptions(c *gin.Context) {
+	userID, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	subscriptions, page, err := s.service.PageSubscriptionsByUserID(userID, services.PaginationFrom(c), services.ListQueryFrom(c))
+	if err != nil {
+		handleError(s.logger, c, err)
+		return
+	}
+
+	respondPage(s.logger, s.resolver, c, responses.List(subscriptions, responses.NewSubscription), page)
+}
+
+// CancelSubscription godoc
+// @Summary      Cancel subscription
+// @Description  Cancels subscription, readers can only cancel their own subscription
+// @Tags         Subscription
+// @Produce      json
+// @Param        id   path      string  true  "Subscription ID"
+// @Success      200  {object}  object{data=string}
+// @Security     BearerAuth
+// @Router       /subscription/{id}/cancel [patch]
+//
+// Cancel subscription controller
+func (s SubscriptionHandler) CancelSubscription(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	subscription, err := s.service.GetSubscriptionByID(id)
+	if err != nil {
+		handleError(s.logger, c, err)
+		return
+	}
+
+	userID, _ := currentUserID(c)
+	if !s.isAdmin(c) && (subscription.UserId == nil || *subscription.UserId != userID) {
+		handleError(s.logger, c, apperrors.NewForbidden("subscription does not belong to user"))
+		return
+	}
+
+	if err := s.service.CancelSubscription(id); err != nil {
+		handleError(s.logger, c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": "successfully cancelled"})
+}
+
+// GetEntitlement godoc
+// @Summary      Check entitlement
+// @Description  Checks if authenticated user can access full stories and issue downloads
+// @Tags         Subscription
+// @Produce      json
+// @Success      200  {object}  object{data=object{entitled=bool}}
+// @Security     BearerAuth
+// @Router       /subscription/entitlement [get]
+//
+// Entitlement check controller
+func (s SubscriptionHandler) GetEntitlement(c *gin.Context) {
+	c.JSON(200, gin.H{"data": gin.H{"entitled": s.entitlement.IsEntitled(c)}})
+}
+
+func (s SubscriptionHandler) isAdmin(c *gin.Context) bool {
+	claims, _ := c.Get(constants.Claims)
+	claimMap, _ := claims.(map[string]interface{})
+	roles, _ := claimMap["custom:role"].(string)
+	return models.UserRole(strings.Split(roles, ",")).Contains("admin")
+}
@@ -1,23 +1,24 @@
 package handlers
 
 import (
+	"magazine_api/api/serializers/requests"
+	"magazine_api/api/serializers/responses"
 	"magazine_api/lib"
-	"magazine_api/models"
 	"magazine_api/services"
 	"time"
 
 	"github.com/danhper/structomap"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 )
 
 type TransactionHandler struct {
-	logger  lib.Logger
-	service services.TransactionService
+	logger   lib.Logger
+	service  services.TransactionService
+	resolver services.URLResolver
 }
 
-func NewTransactionHandler(logger lib.Logger, service services.TransactionService) TransactionHandler {
-	return TransactionHandler{logger: logger, service: service}
+func NewTransactionHandler(logger lib.Logger, service services.TransactionService, resolver services.URLResolver) TransactionHandler {
+	return TransactionHandler{logger: logger, service: service, resolver: resolver}
 }
 
 //CreateTransaction godoc
@@ -26,17 +27,17 @@ func NewTransactionHandler(logger lib.Logger, service services.TransactionServic
 // @Tags         Transaction
 // @Accept       json
 // @Produce      json
-// @Param        transaction  body      models.TransactionBase  true  "Add Transaction"
-// @Success      200          {object}  object{data=models.Transaction}
+// @Param        transaction  body      requests.CreateTransaction  true  "Add Transaction"
+// @Success      200          {object}  object{data=responses.Transaction}
 // @Router       /transaction [post]
 //
 //Creates Transaction
 func (t TransactionHandler) CreateTransaction(c *gin.Context) {
-	var assign *models.Transaction
-	if err := c.ShouldBind(&assign); err != nil {
-		handleError(t.logger, c, err)
+	var request requests.CreateTransaction
+	if !bindJSON(c, &request) {
 		return
 	}
+	assign := request.Transaction()
 
 	// Create Transaction in our Database
 	assign, err := t.service.CreateTransaction(assign)
@@ -45,7 +46,7 @@ func (t TransactionHandler) CreateTransaction(c *gin.Context) {
 		return
 	}
 
-	c.JSON(200, gin.H{"data": assign})
+	c.JSON(200, gin.H{"data": responses.NewTransaction(assign)})
 }
 
 // ListTransaction godoc
@@ -53,18 +54,21 @@ func (t TransactionHandler) CreateTransaction(c *gin.Context) {
 // @Description  List assigns for cutting.
 // @Tags         Transaction
 // @Produce      json
-// @Success      200      {object}  object{data=[]models.Transaction}
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200      {object}  object{data=[]responses.Transaction,next_cursor=string,total=int}
 // @Router       /transaction [get]
 //
 // List Transaction controller
 func (t TransactionHandler) ListTransaction(c *gin.Context) {
-	assigns, err := t.service.ListsTransaction(c)
+	assigns, page, err := t.service.ListsTransaction(c)
 	if err != nil {
 		handleError(t.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": assigns})
+	respondPage(t.logger, t.resolver, c, responses.List(assigns, responses.NewTransaction), page)
 }
 
 // ListDeletedCuttingassign godoc
@@ -72,18 +76,21 @@ func (t TransactionHandler) ListTransaction(c *gin.Context) {
 // @Description  List assigns for cutting.
 // @Tags         Transaction
 // @Produce      json
-// @Success      200      {object}  object{data=[]models.Transaction}
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200      {object}  object{data=[]responses.Transaction,next_cursor=string,total=int}
 // @Router       /transaction/deleted [get]
 //
 // List Transaction controller
 func (t TransactionHandler) ListDeletedTransaction(c *gin.Context) {
-	assigns, err := t.service.ListsDeletedTransactions(c)
+	assigns, page, err := t.service.ListsDeletedTransactions(c)
 	if err != nil {
 		handleError(t.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": assigns})
+	respondPage(t.logger, t.resolver, c, responses.List(assigns, responses.NewTransaction), page)
 }
 
 // GetTransactionByID godoc
@@ -92,19 +99,22 @@ func (t TransactionHandler) ListDeletedTransaction(c *gin.Context) {
 // @Tags         Transaction
 // @Produce      json
 // @Param        id   path      string  true  "ID"
-// @Success      200  {object}  object{data=models.Transaction}
+// @Success      200  {object}  object{data=responses.Transaction}
 // @Router       /transaction/id/{id} [get]
 // Gets Transaction By ID controller
 func (t TransactionHandler) GetTransactionByID(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	assign, err := t.service.GetTransactionByID(uuid.MustParse(id))
+	assign, err := t.service.GetTransactionByID(id)
 	if err != nil {
 		handleError(t.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": assign})
+	c.JSON(200, gin.H{"data": responses.NewTransaction(assign)})
 }
 
 // GetTransactionByType godoc
@@ -113,19 +123,22 @@ func (t TransactionHandler) GetTransactionByID(c *gin.Context) {
 // @Tags         Transaction
 // @Produce      json
 // @Param        paid_type  path      string  true  "Type"
-// @Success      200        {object}  object{data=[]models.Transaction}
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200        {object}  object{data=[]responses.Transaction,next_cursor=string,total=int}
 // @Router       /transaction/type/{paid_type} [get]
 // Gets Transaction By Paid type controller
 func (t TransactionHandler) GetTransactionByType(c *gin.Context) {
 	paid_type := c.Param("paid_type")
 
-	assign, err := t.service.GetTransactionByType(paid_type, c)
+	assign, page, err := t.service.GetTransactionByType(paid_type, c)
 	if err != nil {
 		handleError(t.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": assign})
+	respondPage(t.logger, t.resolver, c, responses.List(assign, responses.NewTransaction), page)
 }
 
 // GetTransactionByMedium godoc
@@ -134,19 +147,22 @@ func (t TransactionHandler) GetTransactionByType(c *gin.Context) {
 // @Tags         Transaction
 // @Produce      json
 // @Param        paid_medium  path      string  true  "Type"
-// @Success      200          {object}  object{data=[]models.Transaction}
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200          {object}  object{data=[]responses.Transaction,next_cursor=string,total=int}
 // @Router       /transaction/medium/{paid_medium} [get]
 // Gets Transaction By Paid medium controller
 func (t TransactionHandler) GetTransactionByMedium(c *gin.Context) {
 	paid_medium := c.Param("paid_medium")
 
-	assign, err := t.service.GetTransactionByMedium(paid_medium, c)
+	assign, page, err := t.service.GetTransactionByMedium(paid_medium, c)
 	if err != nil {
 		handleError(t.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": assign})
+	respondPage(t.logger, t.resolver, c, responses.List(assign, responses.NewTransaction), page)
 }
 
 // GetTransactionByDate godoc
@@ -155,19 +171,22 @@ func (t TransactionHandler) GetTransactionByMedium(c *gin.Context) {
 // @Tags         Transaction
 // @Produce      json
 // @Param        date  path      string  true  "Date"
-// @Success      200   {object}  object{data=[]models.Transaction}
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200   {object}  object{data=[]responses.Transaction,next_cursor=string,total=int}
 // @Router       /transaction/date/{date} [get]
 // Gets Transaction By date controller
 func (t TransactionHandler) GetTransactionByDate(c *gin.Context) {
 	date := c.Param("date")
 
-	assign, err := t.service.GetTransactionByMedium(date, c)
+	assign, page, err := t.service.GetTransactionByMedium(date, c)
 	if err != nil {
 		handleError(t.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": assign})
+	respondPage(t.logger, t.resolver, c, responses.List(assign, responses.NewTransaction), page)
 }
 
 // GetTransactionByMonth godoc
@@ -176,19 +195,22 @@ func (t TransactionHandler) GetTransactionByDate(c *gin.Context) {
 // @Tags         Transaction
 // @Produce      json
 // @Param        month  path      string  true  "Month"
-// @Success      200    {object}  object{data=[]models.Transaction}
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200    {object}  object{data=[]responses.Transaction,next_cursor=string,total=int}
 // @Router       /transaction/month/{month} [get]
 // Gets Transaction By month controller
 func (t TransactionHandler) GetTransactionByMonth(c *gin.Context) {
 	month := c.Param("month")
 
-	assign, err := t.service.GetTransactionByMedium(month, c)
+	assign, page, err := t.service.GetTransactionByMedium(month, c)
 	if err != nil {
 		handleError(t.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": assign})
+	respondPage(t.logger, t.resolver, c, responses.List(assign, responses.NewTransaction), page)
 }
 
 // GetTransactionFromAccount godoc
@@ -197,19 +219,25 @@ func (t TransactionHandler) GetTransactionByMonth(c *gin.Context) {
 // @Tags         Transaction
 // @Produce      json
 // @Param        account  path      string  true  "ID"
-// @Success      200  {object}  object{data=[]models.Transaction}
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200  {object}  object{data=[]responses.Transaction,next_cursor=string,total=int}
 // @Router       /transaction/fromaccount/{account} [get]
 // Gets Transaction From Account controller
 func (t TransactionHandler) GetTransactionFromAccount(c *gin.Context) {
-	account := c.Param("account")
+	account, ok := pathUUID(c, "account")
+	if !ok {
+		return
+	}
 
-	assign, err := t.service.GetTransactionFromAccount(uuid.MustParse(account), c)
+	assign, page, err := t.service.GetTransactionFromAccount(account, c)
 	if err != nil {
 		handleError(t.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": assign})
+	respondPage(t.logger, t.resolver, c, responses.List(assign, responses.NewTransaction), page)
 }
 
 // GetTransactionToAccount godoc
@@ -218,19 +246,25 @@ func (t TransactionHandler) GetTransactionFromAccount(c *gin.Context) {
 // @Tags         Transaction
 // @Produce      json
 // @Param        account  path      string  true  "ID"
-// @Success      200  {object}  object{data=[]models.Transaction}
+// @Param        cursor  query     string  false  "next_cursor of the page before"
+// @Param        limit   query     int     false  "rows of the page"
+// @Param        offset  query     int     false  "rows to skip instead of cursor"
+// @Success      200  {object}  object{data=[]responses.Transaction,next_cursor=string,total=int}
 // @Router       /transaction/toaccount/{account} [get]
 // Gets Transaction to the Account controller
 func (t TransactionHandler) GetTransactionToAccount(c *gin.Context) {
-	account := c.Param("account")
+	account, ok := pathUUID(c, "account")
+	if !ok {
+		return
+	}
 
-	assign, err := t.service.GetTransactionToAccount(uuid.MustParse(account), c)
+	assign, page, err := t.service.GetTransactionToAccount(account, c)
 	if err != nil {
 		handleError(t.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": assign})
+	respondPage(t.logger, t.resolver, c, responses.List(assign, responses.NewTransaction), page)
 }
 
 //UpdateTransaction godoc
@@ -240,21 +274,23 @@ func (t TransactionHandler) GetTransactionToAccount(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Update Transaction"
-// @Success      200  {object}  object{data=models.Transaction}
+// @Success      200  {object}  object{data=responses.Transaction}
 // @Router       /transaction/{id} [patch]
 // Patch Transaction by Id controller
 func (t TransactionHandler) PatchTransaction(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	assign, err := t.service.GetTransactionByID(uuid.MustParse(id))
+	assign, err := t.service.GetTransactionByID(id)
 	if err != nil {
 		handleError(t.logger, c, err)
 		return
 	}
 
-	var newassign models.Transaction
-	if err := c.ShouldBindJSON(&newassign); err != nil {
-		handleError(t.logger, c, err)
+	var newassign requests.PatchTransaction
+	if !bindJSON(c, &newassign) {
 		return
 	}
 
@@ -310,6 +346,12 @@ func (t TransactionHandler) PatchTransaction(c *gin.Context) {
 		OmitIf(func(ch interface{}) bool {
 			return newassign.Remarks == nil
 		}, "Remarks").
+		OmitIf(func(ch interface{}) bool {
+			return newassign.PaymentDate == nil
+		}, "PaymentDate").
+		OmitIf(func(ch interface{}) bool {
+			return newassign.BankPaymentFrom == nil
+		}, "BankPaymentFrom").
 		Transform(newassign)
 
 	if len(assignMap) > 0 {
@@ -340,9 +382,12 @@ func (t TransactionHandler) PatchTransaction(c *gin.Context) {
 //
 // Delete Transaction By ID controller
 func (t TransactionHandler) DeleteTransactionByID(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	err := t.service.DeleteTransaction(uuid.MustParse(id))
+	err := t.service.DeleteTransaction(id)
 	if err != nil {
 		handleError(t.logger, c, err)
 		return
@@ -361,9 +406,12 @@ func (t TransactionHandler) DeleteTransactionByID(c *gin.Context) {
 // @Router       /transaction/forcedelete/{id} [delete]
 // Delete Transaction By ID controller
 func (t TransactionHandler) PermanentDeleteTransactionByID(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	err := t.service.PermanentDeleteTransaction(uuid.MustParse(id))
+	err := t.service.PermanentDeleteTransaction(id)
 	if err != nil {
 		handleError(t.logger, c, err)
 		return
@@ -2,42 +2,243 @@ package handlers
 
 import (
 	"errors"
+	"magazine_api/api/serializers/requests"
+	"magazine_api/api/serializers/responses"
+	"magazine_api/apperrors"
 	"magazine_api/constants"
 	"magazine_api/lib"
+	"magazine_api/models"
+	"magazine_api/services"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type UploadHandler struct {
-	logger lib.Logger
+	logger   lib.Logger
+	service  services.UploadService
+	media    services.MediaService
+	resolver services.URLResolver
 }
 
-func NewUploadHandler(logger lib.Logger) UploadHandler {
-	return UploadHandler{logger: logger}
+func NewUploadHandler(
+	logger lib.Logger,
+	service services.UploadService,
+	media services.MediaService,
+	resolver services.URLResolver,
+) UploadHandler {
+	return UploadHandler{
+		logger:   logger,
+		service:  service,
+		media:    media,
+		resolver: resolver,
+	}
 }
 
-// UploadSingleFile godoc
-// @Summary      Upload Single File
-// @Description  It uploads the file to S3
+// UploadFile godoc
+// @Summary      Upload Files
+// @Description  It stores every file of the file field in the media library, images are queued for
+// @Description  generating their variants. url and media_id are of the first file
 // @Tags         Upload
-// @Accept       json
+// @Accept       multipart/form-data
 // @Produce      json
-// @Param        file  formData  file  true  "Upload file"
-// @Success      200
+// @Param        file  formData  file  true  "Upload files"
+// @Success      200   {object}  object{url=string,media_id=string,data=[]responses.UploadedFile}
+// @Failure      400   {object}  responses.Problem
+// @Failure      413   {object}  responses.Problem
+// @Failure      415   {object}  responses.Problem
+// @Failure      422   {object}  responses.Problem
 // @Router       /upload [post]
 //
 // Upload files
 func (u UploadHandler) UploadFile(c *gin.Context) {
+	metadata, _ := c.Get(constants.File)
+	uploaded, _ := metadata.(lib.UploadedFiles)
+	if len(uploaded) == 0 {
+		handleError(u.logger, c, apperrors.NewBadRequest("no file uploaded"))
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(uploaded))
+	for _, file := range uploaded {
+		if id, err := uuid.Parse(file.MediaID); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	media, err := u.media.ListMediaByIDs(ids)
+	if err != nil {
+		handleError(u.logger, c, err)
+		return
+	}
+
+	byID := map[uuid.UUID]*models.Media{}
+	for _, m := range media {
+		byID[m.ID] = m
+	}
+
+	files := make([]responses.UploadedFile, len(uploaded))
+	for i, file := range uploaded {
+		files[i] = responses.UploadedFile{
+			FieldName: file.FieldName,
+			FileName:  file.FileName,
+			Size:      file.Size,
+			MimeType:  file.MimeType,
+			URL:       lib.SignedURL(file.URL),
+		}
+		id, _ := uuid.Parse(file.MediaID)
+		if m, ok := byID[id]; ok {
+			files[i].MediaId = m.ID
+			files[i].Status = m.Status
+			files[i].Variants = m.Variants
+		}
+	}
+
+	respondResolved(u.logger, u.resolver, c, gin.H{
+		"url":      uploaded[0].URL,
+		"media_id": uploaded[0].MediaID,
+		"data":     files,
+	})
+}
+
+// PresignUpload godoc
+// @Summary      Presign direct upload
+// @Description  Creates presigned PUT url of the declared file, or urls of its parts when it is above
+// @Description  the multipart threshold or multipart is asked. Etag headers of part responses complete the upload
+// @Tags         Upload
+// @Accept       json
+// @Produce      json
+// @Param        data  body      requests.PresignUpload  true  "Declared file"
+// @Success      200   {object}  object{data=responses.PresignedUpload}
+// @Failure      400   {object}  responses.Problem
+// @Failure      413   {object}  responses.Problem
+// @Failure      415   {object}  responses.Problem
+// @Security     BearerAuth
+// @Router       /upload/presign [post]
+//
+// Presign upload controller
+func (u UploadHandler) PresignUpload(c *gin.Context) {
+	var request requests.PresignUpload
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	presigned, err := u.service.Presign(c.Request.Context(), request, uploaderID(c))
+	if err != nil {
+		u.handleUploadError(c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": presigned})
+}
+
+// ResumeUpload godoc
+// @Summary      Resume direct upload
+// @Description  Creates new urls of pending upload, parts already uploaded are listed and left out
+// @Tags         Upload
+// @Produce      json
+// @Param        id   path      string  true  "Upload ID"
+// @Success      200  {object}  object{data=responses.PresignedUpload}
+// @Failure      404  {object}  responses.Problem
+// @Failure      410  {object}  responses.Problem
+// @Security     BearerAuth
+// @Router       /upload/{id}/resume [post]
+//
+// Resume upload controller
+func (u UploadHandler) ResumeUpload(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	presigned, err := u.service.Resume(c.Request.Context(), id, uploaderID(c))
+	if err != nil {
+		u.handleUploadError(c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{"data": presigned})
+}
+
+// CompleteUpload godoc
+// @Summary      Complete direct upload
+// @Description  Validates uploaded file against the declared one and records it as media, images are
+// @Description  queued for generating their variants
+// @Tags         Upload
+// @Accept       json
+// @Produce      json
+// @Param        data  body      requests.CompleteUpload  true  "Completed upload"
+// @Success      200   {object}  object{data=responses.Media}
+// @Failure      400   {object}  responses.Problem
+// @Failure      404   {object}  responses.Problem
+// @Failure      410   {object}  responses.Problem
+// @Failure      413   {object}  responses.Problem
+// @Failure      415   {object}  responses.Problem
+// @Failure      422   {object}  responses.Problem
+// @Security     BearerAuth
+// @Router       /upload/complete [post]
+//
+// Complete upload controller
+func (u UploadHandler) CompleteUpload(c *gin.Context) {
+	var request requests.CompleteUpload
+	if !bindJSON(c, &request) {
+		return
+	}
 
-	metadata, isFile := c.Get(constants.File)
+	media, err := u.service.Complete(c.Request.Context(), request, uploaderID(c))
+	if err != nil {
+		u.handleUploadError(c, err)
+		return
+	}
 
-	if isFile {
-		file_metadata := metadata.(lib.UploadedFiles)
-		url := file_metadata[0].URL
+	respondResolved(u.logger, u.resolver, c, gin.H{"data": responses.NewMedia(media)})
+}
 
-		c.JSON(200, gin.H{"url": url})
+// AbortUpload godoc
+// @Summary      Abort direct upload
+// @Description  Discards pending upload and what was uploaded of it
+// @Tags         Upload
+// @Produce      json
+// @Param        id   path      string  true  "Upload ID"
+// @Success      200  {object}  object{msg=string}
+// @Failure      404  {object}  responses.Problem
+// @Security     BearerAuth
+// @Router       /upload/{id} [delete]
+//
+// Abort upload controller
+func (u UploadHandler) AbortUpload(c *gin.Context) {
+	id, ok := pathUUID(c, "id")
+	if !ok {
 		return
 	}
 
-	handleError(u.logger, c, errors.New("no image uploaded"))
+	if err := u.service.Abort(c.Request.Context(), id, uploaderID(c)); err != nil {
+		u.handleUploadError(c, err)
+		return
+	}
+
+	responses.SuccessJSON(c, http.StatusOK, "upload aborted")
+}
+
+func (u UploadHandler) handleUploadError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, lib.ErrObjectNotFound):
+		handleError(u.logger, c, apperrors.NewBadRequest("file is not uploaded"))
+	case errors.Is(err, lib.ErrUploadNotFound):
+		handleError(u.logger, c, apperrors.Wrap(apperrors.NotFound, err.Error(), err))
+	case errors.Is(err, services.ErrUploadExpired):
+		handleError(u.logger, c, apperrors.Wrap(apperrors.Gone, err.Error(), err))
+	default:
+		handleError(u.logger, c, services.UploadError(err))
+	}
+}
+
+// uploaderID id of the signed in user uploading the file
+func uploaderID(c *gin.Context) *uuid.UUID {
+	id, ok := currentUserID(c)
+	if !ok {
+		return nil
+	}
+	return &id
 }
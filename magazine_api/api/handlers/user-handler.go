@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"magazine_api/api/serializers/requests"
+	"magazine_api/api/serializers/responses"
 	"magazine_api/lib"
 	"magazine_api/orchestrators"
 	"magazine_api/services"
@@ -26,23 +27,22 @@ func NewUserHandler(logger lib.Logger, service services.UserService, u orchestra
 // @Accept       json
 // @Produce      json
 // @Param        user  body      requests.CreateUser  true  "Add user"
-// @Success      200   {object}  object{data=requests.CreateUser}
+// @Success      200   {object}  object{data=responses.User}
 // @Router       /user [post]
 //
 // Creates user
 func (u UserHandler) CreateUser(c *gin.Context) {
 	var user requests.CreateUser
 
-	if err := c.ShouldBindJSON(&user); err != nil {
-		handleError(u.logger, c, err)
+	if !bindJSON(c, &user) {
 		return
 	}
 
-	users, err := u.user_orchestrator.CreateUser(user)
+	created, err := u.user_orchestrator.CreateUser(user)
 	if err != nil {
 		handleError(u.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": users})
+	c.JSON(200, gin.H{"data": responses.NewUser(created)})
 }
@@ -1,25 +1,27 @@
 package handlers
 
 import (
+	"magazine_api/api/serializers/requests"
+	"magazine_api/api/serializers/responses"
 	"magazine_api/lib"
-	"magazine_api/models"
 	"magazine_api/services"
 	"time"
 
 	"github.com/danhper/structomap"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 )
 
 type UserProfileHandler struct {
-	logger  lib.Logger
-	service services.UserProfileService
+	logger   lib.Logger
+	service  services.UserProfileService
+	resolver services.URLResolver
 }
 
-func NewUserProfileHandler(logger lib.Logger, service services.UserProfileService) UserProfileHandler {
+func NewUserProfileHandler(logger lib.Logger, service services.UserProfileService, resolver services.URLResolver) UserProfileHandler {
 	return UserProfileHandler{
-		logger:  logger,
-		service: service,
+		logger:   logger,
+		service:  service,
+		resolver: resolver,
 	}
 }
 
@@ -29,17 +31,17 @@ func NewUserProfileHandler(logger lib.Logger, service services.UserProfileServic
 // @Tags         UserProfile
 // @Accept       json
 // @Produce      json
-// @Param        profile  body      models.UserProfile  true  "Add UserProfile"
-// @Success      200      {object}  object{data=models.UserProfile}
+// @Param        profile  body      requests.CreateUserProfile  true  "Add UserProfile"
+// @Success      200      {object}  object{data=responses.UserProfile}
 // @Router       /user_profile [post]
 //
 // Creates user profile for employee
 func (s UserProfileHandler) CreateUserProfile(c *gin.Context) {
-	var user_profile *models.UserProfile
-	if err := c.ShouldBind(&user_profile); err != nil {
-		handleError(s.logger, c, err)
+	var request requests.CreateUserProfile
+	if !bindJSON(c, &request) {
 		return
 	}
+	user_profile := request.UserProfile()
 
 	// Create user_profile for employee in our Database
 	user_profile, err := s.service.CreateUserProfile(user_profile)
@@ -48,7 +50,7 @@ func (s UserProfileHandler) CreateUserProfile(c *gin.Context) {
 		return
 	}
 
-	c.JSON(200, gin.H{"data": user_profile})
+	respondResolved(s.logger, s.resolver, c, gin.H{"data": responses.NewUserProfile(user_profile)})
 }
 
 // GetOneUserProfileByID godoc
@@ -57,19 +59,22 @@ func (s UserProfileHandler) CreateUserProfile(c *gin.Context) {
 // @Tags         UserProfile
 // @Produce      json
 // @Param        id   path      string  true  "ID"
-// @Success      200  {object}  models.UserProfile
+// @Success      200  {object}  object{data=responses.UserProfile}
 // @Router       /user_profile/id/{id} [get]
 // Gets Users Profile By ID controller
 func (u UserProfileHandler) GetUserProfileByID(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	user, err := u.service.GetUserProfileByID(uuid.MustParse(id))
+	user, err := u.service.GetUserProfileByID(id)
 	if err != nil {
 		handleError(u.logger, c, err)
 		return
 	}
 
-	c.JSON(200, gin.H{"data": user})
+	respondResolved(u.logger, u.resolver, c, gin.H{"data": responses.NewUserProfile(user)})
 }
 
 // UpdateUserProfile godoc
@@ -79,21 +84,23 @@ func (u UserProfileHandler) GetUserProfileByID(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Update user_profile"
-// @Success      200  {object}  object{data=models.UserProfile}
+// @Success      200  {object}  object{data=responses.UserProfile}
 // @Router       /user_profile/{id} [patch]
 // Patch user_profile of creator by Id controller
 func (a UserProfileHandler) PatchUserProfile(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	user_profile, err := a.service.GetUserProfileByID(uuid.MustParse(id))
+	user_profile, err := a.service.GetUserProfileByID(id)
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
 	}
 
-	var newUserProfile models.UserProfileBase
-	if err := c.ShouldBindJSON(&newUserProfile); err != nil {
-		handleError(a.logger, c, err)
+	var newUserProfile requests.PatchUserProfile
+	if !bindJSON(c, &newUserProfile) {
 		return
 	}
 
@@ -141,9 +148,12 @@ func (a UserProfileHandler) PatchUserProfile(c *gin.Context) {
 //
 // Delete UserProfile By ID controller
 func (a UserProfileHandler) DeleteUserProfileByID(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathUUID(c, "id")
+	if !ok {
+		return
+	}
 
-	err := a.service.DeleteUserProfile(uuid.MustParse(id))
+	err := a.service.DeleteUserProfile(id)
 	if err != nil {
 		handleError(a.logger, c, err)
 		return
@@ -1,10 +1,9 @@
 package middlewares
 
 import (
-	"magazine_api/api/serializers/responses"
+	"magazine_api/apperrors"
 	"magazine_api/constants"
 	"magazine_api/services"
-	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -24,8 +23,7 @@ func (m CognitoAuthMiddleware) Handle() gin.HandlerFunc {
 		token, err := m.getTokenFromHeader(c)
 
 		if err != nil {
-			responses.ErrorJSON(c, http.StatusUnauthorized, err.Error())
-			c.Abort()
+			abort(c, apperrors.Wrap(apperrors.Unauthorized, err.Error(), err))
 			return
 		}
 
@@ -36,6 +34,63 @@ func (m CognitoAuthMiddleware) Handle() gin.HandlerFunc {
 	}
 }
 
+// HandleOptional verifies the token if present, requests without token pass as anonymous
+func (m CognitoAuthMiddleware) HandleOptional() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+
+		token, err := m.getTokenFromHeader(c)
+
+		if err != nil {
+			abort(c, apperrors.Wrap(apperrors.Unauthorized, err.Error(), err))
+			return
+		}
+
+		c.Set(constants.Claims, token.PrivateClaims())
+		c.Set(constants.UID, token.PrivateClaims()["username"])
+
+		c.Next()
+	}
+}
+
+// HandleRole verifies the token and only allows users having one of the roles
+func (m CognitoAuthMiddleware) HandleRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, err := m.getTokenFromHeader(c)
+
+		if err != nil {
+			abort(c, apperrors.Wrap(apperrors.Unauthorized, err.Error(), err))
+			return
+		}
+
+		claims := token.PrivateClaims()
+		userRoles, _ := claims["custom:role"].(string)
+		if !m.hasRole(strings.Split(userRoles, ","), roles) {
+			abort(c, apperrors.NewForbidden("user does not have permission to access the resource"))
+			return
+		}
+
+		c.Set(constants.Claims, claims)
+		c.Set(constants.UID, claims["username"])
+
+		c.Next()
+	}
+}
+
+func (m CognitoAuthMiddleware) hasRole(userRoles []string, roles []string) bool {
+	for _, role := range roles {
+		for _, userRole := range userRoles {
+			if strings.TrimSpace(userRole) == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (m CognitoAuthMiddleware) getTokenFromHeader(c *gin.Context) (jwt.Token, error) {
 	header := c.GetHeader("Authorization")
 	idToken := strings.TrimSpace(strings.Replace(header, "Bearer", "", 1))
@@ -0,0 +1,66 @@
+package middlewares
+
+import (
+	"magazine_api/api/serializers/responses"
+	"magazine_api/apperrors"
+	"magazine_api/constants"
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader header of the request id, taken from the request when the client sends it
+const RequestIDHeader = "X-Request-ID"
+
+type ErrorMiddleware struct {
+	router infrastructure.Router
+	logger lib.Logger
+}
+
+func NewErrorMiddleware(router infrastructure.Router, logger lib.Logger) ErrorMiddleware {
+	return ErrorMiddleware{router: router, logger: logger}
+}
+
+// Setup applies the middleware to all routes
+func (m ErrorMiddleware) Setup() {
+	m.logger.Info("Setting up error middleware")
+	m.router.Use(m.Handle())
+}
+
+// Handle tags the request with an id and renders the last error handlers added to the context as
+// problem, when nothing was written. Internal errors are logged and not detailed to the client
+func (m ErrorMiddleware) Handle() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = uuid.NewString()
+		}
+		c.Set(constants.RequestID, requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		c.Next()
+
+		last := c.Errors.Last()
+		if last == nil {
+			return
+		}
+
+		err := apperrors.From(last.Err)
+		if apperrors.KindOf(err) == apperrors.Internal {
+			m.logger.Error("request ", requestID, ": ", err)
+		}
+
+		if !c.Writer.Written() {
+			responses.ErrorProblem(c, err)
+		}
+	}
+}
+
+// abort hands err to the error middleware and stops the request, errors of the middlewares are
+// rendered as problems like errors of handlers
+func abort(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
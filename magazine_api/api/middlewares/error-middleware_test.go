@@ -0,0 +1,85 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"magazine_api/api/serializers/responses"
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// listRouter router of a list paged and queried by the middlewares, problems rendered by the error middleware
+func listRouter() *gin.Engine {
+	router := gin.New()
+	router.Use(NewErrorMiddleware(infrastructure.Router{Engine: router}, lib.GetLogger()).Handle())
+
+	pagination := NewPaginationMiddleware(lib.Env{PaginationDefaultLimit: 20, PaginationMaxLimit: 100})
+	columns := models.QueryColumns{
+		"title":      {Name: "title", Kind: models.ColumnText},
+		"created_on": {Name: "created_on", Kind: models.ColumnTime},
+	}
+	router.GET("/list", pagination.Handle(), NewQueryMiddleware().Handle(columns), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	return router
+}
+
+func TestListMiddlewaresRespondWithProblems(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		field string
+	}{
+		{"limit not a number", "limit=ten", "limit"},
+		{"zero page", "page=0", "page"},
+		{"cursor with offset", "offset=20&cursor=abc", "cursor"},
+		{"cursor not decodable", "cursor=abc", "cursor"},
+		{"filter of unknown column", "filter[secret]=x", "filter[secret]"},
+		{"sort by unknown column", "sort=-secret", "sort"},
+	}
+
+	router := listRouter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/list?"+tt.query, nil))
+
+			if recorder.Code != http.StatusBadRequest {
+				t.Fatalf("got status %d, want %d", recorder.Code, http.StatusBadRequest)
+			}
+			if contentType := recorder.Header().Get("Content-Type"); contentType != "application/problem+json" {
+				t.Errorf("got content type %q, want problem", contentType)
+			}
+
+			var problem responses.Problem
+			if err := json.Unmarshal(recorder.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("body is not a problem: %v", err)
+			}
+			if len(problem.Errors) != 1 || problem.Errors[0].Field != tt.field {
+				t.Errorf("got errors %v, want one of field %s", problem.Errors, tt.field)
+			}
+			if problem.RequestID == "" {
+				t.Error("problem has no request id")
+			}
+		})
+	}
+}
+
+func TestListMiddlewaresPassValidQuery(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	listRouter().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/list?limit=5&sort=-created_on&filter[title][like]=a", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("got status %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body)
+	}
+}
@@ -5,8 +5,10 @@ import "go.uber.org/fx"
 // Module Middleware exported
 var Module = fx.Options(
 	fx.Provide(NewMiddlewares),
+	fx.Provide(NewErrorMiddleware),
 	fx.Provide(NewCognitoAuthMiddleware),
 	fx.Provide(NewPaginationMiddleware),
+	fx.Provide(NewQueryMiddleware),
 	fx.Provide(NewUploadMiddleware),
 )
 
@@ -20,8 +22,10 @@ type Middlewares []IMiddleware
 
 // NewMiddlewares creates new middlewares
 // Register the middleware that should be applied directly (globally)
-func NewMiddlewares() Middlewares {
-	return Middlewares{}
+func NewMiddlewares(errorMiddleware ErrorMiddleware) Middlewares {
+	return Middlewares{
+		errorMiddleware,
+	}
 }
 
 // Setup sets up middlewares
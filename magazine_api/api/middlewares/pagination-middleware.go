@@ -1,41 +1,96 @@
 package middlewares
 
 import (
+	"magazine_api/apperrors"
 	"magazine_api/constants"
+	"magazine_api/lib"
+	"magazine_api/models"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
 type PaginationMiddleware struct {
+	env lib.Env
 }
 
-func NewPaginationMiddleware() PaginationMiddleware {
-	return PaginationMiddleware{}
+func NewPaginationMiddleware(env lib.Env) PaginationMiddleware {
+	return PaginationMiddleware{env: env}
 }
 
+// Handle reads page of the list from `?cursor=&limit=`, or `?offset=` or `?page=` for offset
+// pages. `per_page` and `pageSize` are read as limit, which is capped to the max page size
 func (p PaginationMiddleware) Handle() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		per_page, err := strconv.ParseInt(c.Query("per_page"), 10, 0)
+		pagination, err := p.parse(c)
 		if err != nil {
-			per_page, err = strconv.ParseInt(c.Query("pageSize"), 10, 0)
+			abort(c, err)
+			return
+		}
+
+		page := int64(1)
+		if pagination.Limit > 0 {
+			page = int64(pagination.Offset/pagination.Limit) + 1
+		}
+
+		c.Set(constants.Pagination, pagination)
+		c.Set(constants.Limit, int64(pagination.Limit))
+		c.Set(constants.Page, page)
+		c.Set(constants.Offset, int64(pagination.Offset))
+
+		c.Next()
+	}
+}
 
-			if err != nil {
-				per_page = 10
+func (p PaginationMiddleware) parse(c *gin.Context) (models.Pagination, error) {
+	pagination := models.Pagination{Limit: p.env.PaginationDefaultLimit}
+
+	for _, name := range []string{"limit", "per_page", "pageSize"} {
+		if value := c.Query(name); value != "" {
+			limit, err := strconv.ParseUint(value, 10, 0)
+			if err != nil || limit == 0 {
+				return pagination, invalidParam(name, "must be a positive number")
 			}
+			pagination.Limit = limit
+			break
 		}
+	}
+	if p.env.PaginationMaxLimit > 0 && pagination.Limit > p.env.PaginationMaxLimit {
+		pagination.Limit = p.env.PaginationMaxLimit
+	}
 
-		page, err := strconv.ParseInt(c.Query("page"), 10, 0)
+	if value := c.Query("offset"); value != "" {
+		offset, err := strconv.ParseUint(value, 10, 0)
 		if err != nil {
-			page = 0
+			return pagination, invalidParam("offset", "must be a number")
 		}
+		pagination.Offset = offset
+		pagination.OffsetMode = true
+	} else if value := c.Query("page"); value != "" {
+		page, err := strconv.ParseUint(value, 10, 0)
+		if err != nil || page == 0 {
+			return pagination, invalidParam("page", "must be a positive number")
+		}
+		pagination.Offset = (page - 1) * pagination.Limit
+		pagination.OffsetMode = true
+	}
 
-		offset := (page - 1) * per_page
-
-		c.Set(constants.Limit, per_page)
-		c.Set(constants.Page, page)
-		c.Set(constants.Offset, offset)
+	if value := c.Query("cursor"); value != "" {
+		if pagination.OffsetMode {
+			return pagination, invalidParam("cursor", "can not be used with offset or page")
+		}
 
-		c.Next()
+		cursor, err := models.DecodeCursor(value)
+		if err != nil {
+			return pagination, invalidParam("cursor", "is not a cursor of this list")
+		}
+		pagination.Cursor = cursor
 	}
+
+	return pagination, nil
+}
+
+// invalidParam bad request of the query parameter
+func invalidParam(name, message string) error {
+	return apperrors.NewBadRequest("invalid "+name, apperrors.FieldError{Field: name, Message: message})
 }
@@ -0,0 +1,52 @@
+package middlewares
+
+import (
+	"errors"
+	"magazine_api/apperrors"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestPaginationCursorWithOffsetOrPage(t *testing.T) {
+	cursor := models.Cursor{At: time.Now(), ID: uuid.New()}.Encode()
+
+	tests := []struct {
+		query  string
+		wantOK bool
+	}{
+		{"cursor=" + cursor, true},
+		{"limit=5&cursor=" + cursor, true},
+		{"offset=20&cursor=" + cursor, false},
+		{"offset=0&cursor=" + cursor, false},
+		{"page=2&cursor=" + cursor, false},
+		{"page=1&limit=5&cursor=" + cursor, false},
+	}
+
+	pagination := NewPaginationMiddleware(lib.Env{PaginationDefaultLimit: 20, PaginationMaxLimit: 100})
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/list?"+tt.query, nil)
+
+			page, err := pagination.parse(c)
+			if tt.wantOK {
+				if err != nil || page.Cursor == nil || page.OffsetMode {
+					t.Errorf("got page %+v and error %v, want page after the cursor", page, err)
+				}
+				return
+			}
+
+			var e *apperrors.Error
+			if err == nil || !errors.As(err, &e) || len(e.Fields) != 1 || e.Fields[0].Field != "cursor" {
+				t.Errorf("got %v, want cursor refused", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package middlewares
+
+import (
+	"fmt"
+	"magazine_api/constants"
+	"magazine_api/models"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// filterParam matches `filter[column]` and `filter[column][op]`
+var filterParam = regexp.MustCompile(`^filter\[(\w+)\](?:\[(\w+)\])?$`)
+
+// opsOfKind comparisons columns of the kind can be filtered by
+var opsOfKind = map[models.ColumnKind][]models.FilterOp{
+	models.ColumnText:   {models.FilterEq, models.FilterNe, models.FilterLike, models.FilterIn, models.FilterNull},
+	models.ColumnUUID:   {models.FilterEq, models.FilterNe, models.FilterIn, models.FilterNull},
+	models.ColumnTime:   {models.FilterEq, models.FilterNe, models.FilterGt, models.FilterGte, models.FilterLt, models.FilterLte, models.FilterNull},
+	models.ColumnNumber: {models.FilterEq, models.FilterNe, models.FilterGt, models.FilterGte, models.FilterLt, models.FilterLte, models.FilterIn, models.FilterNull},
+	models.ColumnBool:   {models.FilterEq, models.FilterNe, models.FilterNull},
+}
+
+type QueryMiddleware struct{}
+
+func NewQueryMiddleware() QueryMiddleware {
+	return QueryMiddleware{}
+}
+
+// Handle reads filters, sort and fields of the list from `?filter[column]=`, `?filter[column][op]=`,
+// `?sort=-column,column` and `?fields=column,column`, only columns of the whitelist are accepted.
+// Cursors can only page lists sorted by one time column, pagination middleware has to run first
+func (q QueryMiddleware) Handle(columns models.QueryColumns) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query, err := parseListQuery(c, columns)
+		if err != nil {
+			abort(c, err)
+			return
+		}
+
+		if page, ok := c.Get(constants.Pagination); ok && page.(models.Pagination).Cursor != nil && !query.Keyset() {
+			abort(c, invalidParam("cursor", "can only be used when sorted by one time, use offset"))
+			return
+		}
+
+		c.Set(constants.ListQuery, query)
+		c.Next()
+	}
+}
+
+func parseListQuery(c *gin.Context, columns models.QueryColumns) (models.ListQuery, error) {
+	var query models.ListQuery
+
+	for param, values := range c.Request.URL.Query() {
+		if !strings.HasPrefix(param, "filter") {
+			continue
+		}
+
+		match := filterParam.FindStringSubmatch(param)
+		if match == nil {
+			return query, invalidParam(param, "is not a filter")
+		}
+		column, ok := columns[match[1]]
+		if !ok {
+			return query, invalidParam(param, "can not filter by "+match[1])
+		}
+
+		op := models.FilterEq
+		if match[2] != "" {
+			op = models.FilterOp(match[2])
+		}
+		if !allowedOp(column.Kind, op) {
+			return query, invalidParam(param, fmt.Sprintf("can not filter %s by %s", match[1], op))
+		}
+
+		for _, value := range values {
+			parsed, err := parseFilterValue(column.Kind, op, value)
+			if err != nil {
+				return query, invalidParam(param, "value "+value+" is not valid")
+			}
+			query.Filters = append(query.Filters, models.Filter{Column: column.Name, Op: op, Value: parsed})
+		}
+	}
+
+	if value := c.Query("sort"); value != "" {
+		for _, name := range strings.Split(value, ",") {
+			sort := models.Sort{}
+			if strings.HasPrefix(name, "-") {
+				sort.Descending = true
+				name = name[1:]
+			}
+
+			column, ok := columns[name]
+			if !ok || column.Kind == models.ColumnData {
+				return query, invalidParam("sort", "can not sort by "+name)
+			}
+			sort.Column, sort.Kind = column.Name, column.Kind
+			query.Sort = append(query.Sort, sort)
+		}
+	}
+
+	if value := c.Query("fields"); value != "" {
+		for _, name := range strings.Split(value, ",") {
+			column, ok := columns[name]
+			if !ok {
+				return query, invalidParam("fields", "can not select "+name)
+			}
+			query.Fields = append(query.Fields, name)
+			query.Columns = append(query.Columns, column.Name)
+		}
+	}
+
+	return query, nil
+}
+
+func allowedOp(kind models.ColumnKind, op models.FilterOp) bool {
+	for _, allowed := range opsOfKind[kind] {
+		if allowed == op {
+			return true
+		}
+	}
+	return false
+}
+
+func parseFilterValue(kind models.ColumnKind, op models.FilterOp, value string) (interface{}, error) {
+	switch op {
+	case models.FilterNull:
+		return strconv.ParseBool(value)
+	case models.FilterLike:
+		return value, nil
+	case models.FilterIn:
+		parts := strings.Split(value, ",")
+		values := make([]interface{}, len(parts))
+		for i, part := range parts {
+			parsed, err := parseValue(kind, part)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = parsed
+		}
+		return values, nil
+	}
+
+	return parseValue(kind, value)
+}
+
+func parseValue(kind models.ColumnKind, value string) (interface{}, error) {
+	switch kind {
+	case models.ColumnUUID:
+		return uuid.Parse(value)
+	case models.ColumnTime:
+		if at, err := time.Parse(time.RFC3339, value); err == nil {
+			return at, nil
+		}
+		return time.Parse("2006-01-02", value)
+	case models.ColumnNumber:
+		return strconv.ParseFloat(value, 64)
+	case models.ColumnBool:
+		return strconv.ParseBool(value)
+	}
+
+	return value, nil
+}
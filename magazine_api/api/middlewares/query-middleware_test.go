@@ -0,0 +1,130 @@
+package middlewares
+
+import (
+	"errors"
+	"magazine_api/apperrors"
+	"magazine_api/models"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// storyColumns whitelist of columns named in json other than in database
+var storyColumns = models.QueryColumns{
+	"title":      {Name: "story_title", Kind: models.ColumnText},
+	"author":     {Name: "creator_id", Kind: models.ColumnUUID},
+	"created_on": {Name: "created_on", Kind: models.ColumnTime},
+	"words":      {Name: "word_count", Kind: models.ColumnNumber},
+	"featured":   {Name: "is_featured", Kind: models.ColumnBool},
+	"body":       {Name: "story_body", Kind: models.ColumnData},
+}
+
+func queryContext(query string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/stories?"+query, nil)
+	return c
+}
+
+func TestParseListQueryRefusesColumnsOutOfWhitelist(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		field string
+	}{
+		{"filter of unknown column", "filter[password]=x", "filter[password]"},
+		{"filter of database name", "filter[story_title]=x", "filter[story_title]"},
+		{"filter not of a column", "filter[title)or(1]=x", "filter[title)or(1]"},
+		{"filter without column", "filter=x", "filter"},
+		{"sort by unknown column", "sort=title,-password", "sort"},
+		{"sort by data column", "sort=body", "sort"},
+		{"select unknown column", "fields=title,password", "fields"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseListQuery(queryContext(tt.query), storyColumns)
+
+			var e *apperrors.Error
+			if !errors.As(err, &e) || e.Kind != apperrors.BadRequest {
+				t.Fatalf("got %v, want bad request", err)
+			}
+			if len(e.Fields) != 1 || e.Fields[0].Field != tt.field {
+				t.Errorf("got fields %v, want one of %s", e.Fields, tt.field)
+			}
+		})
+	}
+}
+
+func TestParseListQueryRefusesOperators(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"unknown operator", "filter[title][regex]=a.*"},
+		{"operator in upper case", "filter[title][EQ]=a"},
+		{"text compared by order", "filter[title][gt]=a"},
+		{"uuid matched by like", "filter[author][like]=3f1c"},
+		{"time in list", "filter[created_on][in]=2026-01-01"},
+		{"bool compared by order", "filter[featured][lt]=true"},
+		{"data column filtered", "filter[body]=x"},
+		{"uuid value not uuid", "filter[author]=42"},
+		{"time value not time", "filter[created_on][gte]=yesterday"},
+		{"number in list not number", "filter[words][in]=1,two"},
+		{"null value not bool", "filter[title][null]=maybe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseListQuery(queryContext(tt.query), storyColumns); !apperrors.Is(err, apperrors.BadRequest) {
+				t.Errorf("got %v, want bad request", err)
+			}
+		})
+	}
+}
+
+func TestParseListQueryNamesDatabaseColumns(t *testing.T) {
+	author := uuid.New()
+	day := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		query string
+		want  models.ListQuery
+	}{
+		{"equal by default", "filter[title]=spring", models.ListQuery{
+			Filters: []models.Filter{{Column: "story_title", Op: models.FilterEq, Value: "spring"}}}},
+		{"like keeps value as given", "filter[title][like]=50%25_off", models.ListQuery{
+			Filters: []models.Filter{{Column: "story_title", Op: models.FilterLike, Value: "50%_off"}}}},
+		{"uuid", "filter[author][ne]=" + author.String(), models.ListQuery{
+			Filters: []models.Filter{{Column: "creator_id", Op: models.FilterNe, Value: author}}}},
+		{"date", "filter[created_on][gte]=2026-01-02", models.ListQuery{
+			Filters: []models.Filter{{Column: "created_on", Op: models.FilterGte, Value: day}}}},
+		{"numbers in list", "filter[words][in]=100,250", models.ListQuery{
+			Filters: []models.Filter{{Column: "word_count", Op: models.FilterIn, Value: []interface{}{100.0, 250.0}}}}},
+		{"null", "filter[featured][null]=true", models.ListQuery{
+			Filters: []models.Filter{{Column: "is_featured", Op: models.FilterNull, Value: true}}}},
+		{"sort", "sort=-created_on,title", models.ListQuery{Sort: []models.Sort{
+			{Column: "created_on", Kind: models.ColumnTime, Descending: true},
+			{Column: "story_title", Kind: models.ColumnText},
+		}}},
+		{"fields", "fields=title,body", models.ListQuery{
+			Fields: []string{"title", "body"}, Columns: []string{"story_title", "story_body"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseListQuery(queryContext(tt.query), storyColumns)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
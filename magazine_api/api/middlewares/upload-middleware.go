@@ -1,25 +1,21 @@
 package middlewares
 
 import (
-	"bytes"
+	"context"
 	"errors"
 	"fmt"
-	"image"
-	"image/jpeg"
-	"image/png"
-	"io"
 	"io/ioutil"
-	"magazine_api/api/serializers/responses"
+	"magazine_api/apperrors"
 	"magazine_api/constants"
 	"magazine_api/lib"
 	"magazine_api/services"
-	"net/http"
+	"mime"
+	"mime/multipart"
 	"path/filepath"
+	"strings"
 
-	"github.com/chai2010/webp"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/nfnt/resize"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -29,12 +25,15 @@ const (
 	JPEGFile Extension = ".jpeg"
 	JPGFile  Extension = ".jpg"
 	PNGFile  Extension = ".png"
+	GIFFile  Extension = ".gif"
 )
 
 var (
 	ErrExtensionMismatch      = errors.New("file extension not supported")
 	ErrThumbExtensionMismatch = errors.New("file extension not supported for thumbnail")
 	ErrFileRead               = errors.New("file read error")
+	ErrFileTooLarge           = errors.New("file is too large")
+	ErrTooManyFiles           = errors.New("too many files")
 )
 
 type UploadConfig struct {
@@ -47,40 +46,47 @@ type UploadConfig struct {
 	// Extensions array of extensions
 	Extensions []Extension
 
-	// ThumbnailEnabled set whether thumbnail is enabled or nor
-	ThumbnailEnabled bool
+	// VariantsEnabled set whether to queue images for generating variants or not
+	VariantsEnabled bool
 
-	// ThumbnailWidth set thumbnail width
-	ThumbnailWidth uint
+	// MaxSize largest file accepted in bytes
+	MaxSize int64
 
-	// WebpEnabled set whether to generate webp images or not.
-	WebpEnabled bool
+	// MaxFiles most files accepted in the field
+	MaxFiles int
 }
 
 type UploadMiddleware struct {
-	logger lib.Logger
-	bucket services.S3BucketService
-	config []UploadConfig
+	logger    lib.Logger
+	env       lib.Env
+	media     services.MediaService
+	validator services.UploadValidator
+	config    []UploadConfig
 }
 
 func NewUploadMiddleware(
 	logger lib.Logger,
-	bucket services.S3BucketService,
+	env lib.Env,
+	media services.MediaService,
+	validator services.UploadValidator,
 ) UploadMiddleware {
 	m := UploadMiddleware{
-		bucket: bucket,
-		logger: logger,
+		media:     media,
+		logger:    logger,
+		env:       env,
+		validator: validator,
 	}
 	return m
 }
 
 func (u UploadMiddleware) Config() UploadConfig {
 	return UploadConfig{
-		FieldName:        "file",
-		BucketFolder:     "",
-		Extensions:       []Extension{JPEGFile, PNGFile, JPGFile},
-		ThumbnailEnabled: false,
-		ThumbnailWidth:   100,
+		FieldName:       "file",
+		BucketFolder:    "",
+		Extensions:      []Extension{JPEGFile, PNGFile, JPGFile, GIFFile},
+		VariantsEnabled: false,
+		MaxSize:         u.env.UploadFileMaxSize,
+		MaxFiles:        1,
 	}
 }
 
@@ -102,15 +108,21 @@ func (cfg UploadConfig) Extension(ext ...Extension) UploadConfig {
 	return cfg
 }
 
-// ThumbEnable enable thumbnail generation
-func (cfg UploadConfig) ThumbEnable(enable bool) UploadConfig {
-	cfg.ThumbnailEnabled = enable
+// VariantsEnable enable generating image variants in background after upload
+func (cfg UploadConfig) VariantsEnable(enable bool) UploadConfig {
+	cfg.VariantsEnabled = enable
 	return cfg
 }
 
-// WebpEnable enable webp image generation
-func (cfg UploadConfig) WebpEnable(enable bool) UploadConfig {
-	cfg.WebpEnabled = enable
+// Limit modify largest file accepted in bytes
+func (cfg UploadConfig) Limit(size int64) UploadConfig {
+	cfg.MaxSize = size
+	return cfg
+}
+
+// Files modify most files accepted in the field
+func (cfg UploadConfig) Files(count int) UploadConfig {
+	cfg.MaxFiles = count
 	return cfg
 }
 
@@ -120,7 +132,11 @@ func (u UploadMiddleware) Push(config UploadConfig) UploadMiddleware {
 	return u
 }
 
-// Handle handles file upload
+// uploadConcurrency files of one request validated and stored at the same time
+const uploadConcurrency = 4
+
+// Handle handles file upload, every file of configured fields is validated and stored
+// and metadata of all of them is set in the same order as in the request
 func (u UploadMiddleware) Handle() gin.HandlerFunc {
 	return func(c *gin.Context) {
 
@@ -128,131 +144,82 @@ func (u UploadMiddleware) Handle() gin.HandlerFunc {
 			u.logger.Info("no file upload configuration has been attached")
 		}
 
-		errGroup, ctx := errgroup.WithContext(c.Request.Context())
+		var form *multipart.Form
+		if c.ContentType() == "multipart/form-data" {
+			var err error
+			if form, err = c.MultipartForm(); err != nil {
+				u.logger.Error("file-upload-error: ", err)
+				abort(c, apperrors.NewBadRequest(ErrFileRead.Error()))
+				return
+			}
+		}
 
-		uploadedFiles := []lib.UploadMetadata{}
+		type upload struct {
+			conf   UploadConfig
+			header *multipart.FileHeader
+		}
 
-		for i := range u.config {
-			conf := u.config[i]
-			file, fileHeader, _ := c.Request.FormFile(conf.FieldName)
+		uploads := []upload{}
+		for _, conf := range u.config {
+			if form == nil {
+				break
+			}
 
-			if file != nil && fileHeader != nil {
+			headers := form.File[conf.FieldName]
+			if conf.MaxFiles > 0 && len(headers) > conf.MaxFiles {
+				u.logger.Error("file-upload-error: ", ErrTooManyFiles)
+				abort(c, apperrors.NewBadRequest(
+					fmt.Sprintf("%s, %s takes at most %d", ErrTooManyFiles, conf.FieldName, conf.MaxFiles)))
+				return
+			}
 
-				ext := filepath.Ext(fileHeader.Filename)
-				if !u.matchesExtension(conf, ext) {
+			for _, header := range headers {
+				if !u.matchesExtension(conf, filepath.Ext(header.Filename)) {
 					u.logger.Error("file-upload-error: ", ErrExtensionMismatch)
-					responses.ErrorJSON(c, http.StatusInternalServerError, ErrExtensionMismatch.Error())
-					c.Abort()
+					abort(c, apperrors.NewUnsupportedMedia(fmt.Sprintf("%s: %s", ErrExtensionMismatch, header.Filename)))
 					return
 				}
 
-				fileByte, err := ioutil.ReadAll(file)
-				if err != nil {
-					u.logger.Error("file-upload-error: ", ErrFileRead)
-					responses.ErrorJSON(c, http.StatusInternalServerError, ErrFileRead)
-					c.Abort()
+				if conf.MaxSize > 0 && header.Size > conf.MaxSize {
+					u.logger.Error("file-upload-error: ", ErrFileTooLarge)
+					abort(c, apperrors.NewTooLarge(
+						fmt.Sprintf("%s: %s, limit is %d bytes", ErrFileTooLarge, header.Filename, conf.MaxSize)))
 					return
 				}
 
-				uploadFileName, fileUID := u.randomFileName(conf, ext)
-				fileReader := bytes.NewReader(fileByte)
-				errGroup.Go(func() error {
-					response, err := u.bucket.UploadFile(ctx, fileReader, uploadFileName)
-					uploadedFiles = append(uploadedFiles, lib.UploadMetadata{
-						FieldName:      conf.FieldName,
-						FileName:       fileHeader.Filename,
-						URL:            uploadFileName,
-						FileUID:        fileUID,
-						Size:           fileHeader.Size,
-						UploadResponse: response,
-					})
+				uploads = append(uploads, upload{conf: conf, header: header})
+			}
+		}
+
+		var uploader *uuid.UUID
+		if uid, err := uuid.Parse(c.GetString(constants.UID)); err == nil {
+			uploader = &uid
+		}
+
+		errGroup, ctx := errgroup.WithContext(c.Request.Context())
+		errGroup.SetLimit(uploadConcurrency)
+
+		// every goroutine writes only metadata of its own file
+		uploadedFiles := make([]lib.UploadMetadata, len(uploads))
+		for i := range uploads {
+			i, conf, header := i, uploads[i].conf, uploads[i].header
+			errGroup.Go(func() error {
+				metadata, err := u.upload(ctx, conf, header, uploader)
+				if err != nil {
 					return err
-				})
-
-				if conf.WebpEnabled && u.properExtension(ext) {
-					origWebpReader := bytes.NewReader(fileByte)
-					errGroup.Go(func() error {
-						var webpBuf bytes.Buffer
-						img, err := u.getImage(origWebpReader, ext)
-						if err != nil {
-							return err
-						}
-
-						if err := webp.Encode(&webpBuf, img, &webp.Options{Lossless: true}); err != nil {
-							return err
-						}
-
-						webpReader := bytes.NewReader(webpBuf.Bytes())
-						resizeFileName := u.bucketPath(conf, fileUID+".webp")
-
-						_, err = u.bucket.UploadFile(ctx, webpReader, resizeFileName)
-						if err != nil {
-							return err
-						}
-
-						return nil
-					})
 				}
 
-				if conf.ThumbnailEnabled {
-					thumbReader := bytes.NewReader(fileByte)
-					errGroup.Go(func() error {
-						if !u.properExtension(ext) {
-							return ErrExtensionMismatch
-						}
-
-						img, err := u.createThumbnail(conf, thumbReader, ext)
-						if err != nil {
-							return err
-						}
-
-						resizeFileName := u.bucketPath(conf, fmt.Sprintf("%s_thumb%s", fileUID, ext))
-						_, err = u.bucket.UploadFile(ctx, img, resizeFileName)
-						if err != nil {
-							return err
-						}
-						return nil
-					})
-
-					if conf.WebpEnabled && u.properExtension(ext) {
-						webpReader := bytes.NewReader(fileByte)
-						errGroup.Go(func() error {
-							var webpBuf bytes.Buffer
-							img, err := u.getImage(webpReader, ext)
-							if err != nil {
-								return err
-							}
-
-							resizeImage := resize.Resize(conf.ThumbnailWidth, 0, img, resize.Lanczos3)
-							if err := webp.Encode(&webpBuf, resizeImage, &webp.Options{Lossless: true}); err != nil {
-								return err
-							}
-
-							webpReader := bytes.NewReader(webpBuf.Bytes())
-							resizeFileName := u.bucketPath(conf, fmt.Sprintf("%s_thumb%s", fileUID, ".webp"))
-
-							_, err = u.bucket.UploadFile(ctx, webpReader, resizeFileName)
-							if err != nil {
-								return err
-							}
-
-							return nil
-						})
-					}
-				}
-			} else {
-				c.Next()
-			}
+				uploadedFiles[i] = *metadata
+				return nil
+			})
 		}
 
 		if err := errGroup.Wait(); err != nil {
 			u.logger.Error("file-upload-error: ", err.Error())
-			if err == ErrThumbExtensionMismatch {
-				responses.ErrorJSON(c, http.StatusBadRequest, err.Error())
-			} else {
-				responses.ErrorJSON(c, http.StatusInternalServerError, err.Error())
+			if errors.Is(err, ErrThumbExtensionMismatch) {
+				err = apperrors.NewBadRequest(err.Error())
 			}
-			c.Abort()
+			abort(c, services.UploadError(err))
 			return
 		}
 
@@ -262,9 +229,66 @@ func (u UploadMiddleware) Handle() gin.HandlerFunc {
 	}
 }
 
-func (u UploadMiddleware) properExtension(ext string) bool {
-	e := Extension(ext)
-	return e == JPEGFile || e == JPGFile || e == PNGFile
+// upload validates the file and stores it in the media library
+func (u UploadMiddleware) upload(
+	ctx context.Context,
+	conf UploadConfig,
+	header *multipart.FileHeader,
+	uploader *uuid.UUID,
+) (*lib.UploadMetadata, error) {
+	file, err := header.Open()
+	if err != nil {
+		return nil, ErrFileRead
+	}
+	defer file.Close()
+
+	fileByte, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, ErrFileRead
+	}
+
+	// content is trusted over the extension, which only has to be configured
+	contentType, err := u.validator.Validate(ctx, fileByte, u.contentTypes(conf)...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", header.Filename, err)
+	}
+
+	if contentType == "image/jpeg" {
+		lib.StripExifLocation(fileByte)
+	}
+
+	uploadFileName, fileUID := u.randomFileName(conf, lib.ContentTypeExtensions[contentType])
+	media, err := u.media.Upload(ctx, services.MediaUpload{
+		Data:       fileByte,
+		Key:        uploadFileName,
+		MimeType:   contentType,
+		UploadedBy: uploader,
+		Process:    conf.VariantsEnabled && lib.VariantContentTypes[contentType],
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &lib.UploadMetadata{
+		FieldName: conf.FieldName,
+		FileName:  header.Filename,
+		URL:       media.Key.Key(),
+		FileUID:   fileUID,
+		Size:      header.Size,
+		MimeType:  contentType,
+		MediaID:   media.ID.String(),
+	}, nil
+}
+
+// contentTypes content types of the configured extensions
+func (u UploadMiddleware) contentTypes(c UploadConfig) []string {
+	var types []string
+	for _, e := range c.Extensions {
+		if t := mime.TypeByExtension(string(e)); t != "" {
+			types = append(types, strings.SplitN(t, ";", 2)[0])
+		}
+	}
+	return types
 }
 
 func (u UploadMiddleware) matchesExtension(c UploadConfig, ext string) bool {
@@ -288,36 +312,3 @@ func (u UploadMiddleware) bucketPath(c UploadConfig, fileName string) string {
 	}
 	return fileName
 }
-
-func (u UploadMiddleware) getImage(file io.Reader, ext string) (image.Image, error) {
-	if Extension(ext) == JPGFile || Extension(ext) == JPEGFile {
-		return jpeg.Decode(file)
-	}
-	if Extension(ext) == PNGFile {
-		return png.Decode(file)
-	}
-	return nil, ErrExtensionMismatch
-}
-
-// createThumbnail creates thumbnail from multipart file
-func (u UploadMiddleware) createThumbnail(c UploadConfig, file io.Reader, ext string) (*bytes.Buffer, error) {
-	img, err := u.getImage(file, ext)
-	if err != nil {
-		return nil, err
-	}
-
-	resizeImage := resize.Resize(c.ThumbnailWidth, 0, img, resize.Lanczos3)
-	buff := new(bytes.Buffer)
-	if Extension(ext) == JPGFile || Extension(ext) == JPEGFile {
-		if err := jpeg.Encode(buff, resizeImage, nil); err != nil {
-			return nil, err
-		}
-	}
-	if Extension(ext) == PNGFile {
-		if err := png.Encode(buff, resizeImage); err != nil {
-			return nil, err
-		}
-	}
-
-	return buff, nil
-}
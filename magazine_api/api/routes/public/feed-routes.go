@@ -0,0 +1,39 @@
+package public
+
+import (
+	"magazine_api/api/handlers"
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+)
+
+// FeedRoutes feeds and sitemap of published content served outside of the api
+type FeedRoutes struct {
+	logger      lib.Logger
+	handler     infrastructure.Router
+	feedHandler handlers.FeedHandler
+}
+
+func NewFeedRoutes(logger lib.Logger,
+	handler infrastructure.Router,
+	feedHandler handlers.FeedHandler) FeedRoutes {
+	return FeedRoutes{
+		handler:     handler,
+		logger:      logger,
+		feedHandler: feedHandler,
+	}
+}
+
+// Setup feed and sitemap routes
+func (a FeedRoutes) Setup() {
+	a.logger.Info("Setting up feed routes")
+	a.handler.GET("/sitemap.xml", a.feedHandler.Sitemap)
+
+	api := a.handler.Group("/feeds")
+	{
+		api.GET("/stories.rss", a.feedHandler.StoriesRSS)
+		api.GET("/stories.atom", a.feedHandler.StoriesAtom)
+		api.GET("/issues.json", a.feedHandler.IssuesJSON)
+		api.GET("/type/:story_type/stories.rss", a.feedHandler.StoriesRSS)
+		api.GET("/type/:story_type/stories.atom", a.feedHandler.StoriesAtom)
+	}
+}
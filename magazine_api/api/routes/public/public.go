@@ -0,0 +1,43 @@
+package public
+
+import (
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+
+	"go.uber.org/fx"
+)
+
+var Module = fx.Options(
+	fx.Provide(NewPublicRoutes),
+	fx.Provide(NewReaderRoutes),
+	fx.Provide(NewFeedRoutes),
+)
+
+// PublicRoutes read only routes of published content for readers
+type PublicRoutes struct {
+	logger  lib.Logger
+	handler infrastructure.Router
+	routes  []infrastructure.SubRoute
+}
+
+func NewPublicRoutes(
+	logger lib.Logger,
+	handler infrastructure.Router,
+	reader_routes ReaderRoutes,
+) PublicRoutes {
+	return PublicRoutes{
+		handler: handler,
+		logger:  logger,
+		routes: []infrastructure.SubRoute{
+			reader_routes,
+		},
+	}
+}
+
+func (s PublicRoutes) Setup() {
+	s.logger.Info("Setting up public v1 api routes")
+	api := s.handler.Group("/api/public/v1")
+	for _, v := range s.routes {
+		v.Setup(api)
+	}
+}
@@ -0,0 +1,50 @@
+package public
+
+import (
+	"magazine_api/api/handlers"
+	"magazine_api/api/middlewares"
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+	"magazine_api/models/magazine"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReaderRoutes struct {
+	logger         lib.Logger
+	handler        infrastructure.Router
+	authMiddleware middlewares.CognitoAuthMiddleware
+	pagination     middlewares.PaginationMiddleware
+	query          middlewares.QueryMiddleware
+	publicHandler  handlers.PublicHandler
+}
+
+func NewReaderRoutes(logger lib.Logger,
+	handler infrastructure.Router,
+	authMiddleware middlewares.CognitoAuthMiddleware,
+	pagination middlewares.PaginationMiddleware,
+	query middlewares.QueryMiddleware,
+	publicHandler handlers.PublicHandler) ReaderRoutes {
+	return ReaderRoutes{
+		handler:        handler,
+		logger:         logger,
+		authMiddleware: authMiddleware,
+		pagination:     pagination,
+		query:          query,
+		publicHandler:  publicHandler,
+	}
+}
+
+// Setup reader routes, signing in is optional and only decides access to full content
+func (a ReaderRoutes) Setup(handler *gin.RouterGroup) {
+	a.logger.Info("Setting up reader routes")
+	api := handler.Group("", a.authMiddleware.HandleOptional())
+	{
+		api.GET("/stories", a.pagination.Handle(), a.query.Handle(magazine.PublicStoryColumns), a.publicHandler.ListStories)
+		api.GET("/stories/:slug", a.publicHandler.GetStory)
+		api.GET("/issues", a.pagination.Handle(), a.query.Handle(magazine.PublicIssueColumns), a.publicHandler.ListIssues)
+		api.GET("/issues/:slug", a.publicHandler.GetIssue)
+		api.GET("/photos/:id", a.publicHandler.GetPhoto)
+		api.GET("/adverts", a.pagination.Handle(), a.query.Handle(magazine.PublicAdvertColumns), a.publicHandler.ListAdverts)
+	}
+}
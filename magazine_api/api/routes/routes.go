@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"magazine_api/api/routes/public"
 	v1 "magazine_api/api/routes/v1"
 	"magazine_api/infrastructure"
 
@@ -10,15 +11,18 @@ import (
 // Module exports dependency to container
 var Module = fx.Options(
 	v1.Module,
+	public.Module,
 	fx.Provide(NewRoutes),
 )
 
 type Routes []infrastructure.Route
 
 // NewRoutes sets up routes
-func NewRoutes(v1Routes v1.V1Routes) Routes {
+func NewRoutes(v1Routes v1.V1Routes, publicRoutes public.PublicRoutes, feedRoutes public.FeedRoutes) Routes {
 	return Routes{
 		v1Routes,
+		publicRoutes,
+		feedRoutes,
 	}
 }
 
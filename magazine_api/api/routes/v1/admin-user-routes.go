@@ -0,0 +1,43 @@
+package v1
+
+import (
+	"magazine_api/api/handlers"
+	"magazine_api/api/middlewares"
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminUserRoutes struct
+type AdminUserRoutes struct {
+	logger           lib.Logger
+	handler          infrastructure.Router
+	authMiddleware   middlewares.CognitoAuthMiddleware
+	adminUserHandler handlers.AdminUserHandler
+}
+
+func NewAdminUserRoutes(logger lib.Logger,
+	handler infrastructure.Router,
+	authMiddleware middlewares.CognitoAuthMiddleware,
+	adminUserHandler handlers.AdminUserHandler) AdminUserRoutes {
+	return AdminUserRoutes{
+		handler:          handler,
+		logger:           logger,
+		authMiddleware:   authMiddleware,
+		adminUserHandler: adminUserHandler,
+	}
+}
+
+// Setup admin user routes
+func (s AdminUserRoutes) Setup(handler *gin.RouterGroup) {
+	s.logger.Info("Setting up Admin User routes")
+	api := handler.Group("/admin/users", s.authMiddleware.HandleRole("admin"))
+	{
+		api.GET("", s.adminUserHandler.ListUsers)
+		api.PATCH("/:id/role", s.adminUserHandler.ChangeUserRole)
+		api.POST("/:id/disable", s.adminUserHandler.DisableUser)
+		api.POST("/:id/enable", s.adminUserHandler.EnableUser)
+		api.POST("/:id/reset-password", s.adminUserHandler.ResetPassword)
+	}
+}
@@ -5,26 +5,33 @@ import (
 	"magazine_api/api/middlewares"
 	"magazine_api/infrastructure"
 	"magazine_api/lib"
+	"magazine_api/models/magazine"
 
 	"github.com/gin-gonic/gin"
 )
 
 type AdvertRoutes struct {
-	logger        lib.Logger
-	handler       infrastructure.Router
-	pagination    middlewares.PaginationMiddleware
-	advertHandler handlers.AdvertHandler
+	logger         lib.Logger
+	handler        infrastructure.Router
+	pagination     middlewares.PaginationMiddleware
+	query          middlewares.QueryMiddleware
+	authMiddleware middlewares.CognitoAuthMiddleware
+	advertHandler  handlers.AdvertHandler
 }
 
 func NewAdvertRoutes(logger lib.Logger,
 	handler infrastructure.Router,
 	pagination middlewares.PaginationMiddleware,
+	query middlewares.QueryMiddleware,
+	authMiddleware middlewares.CognitoAuthMiddleware,
 	advertHandler handlers.AdvertHandler) AdvertRoutes {
 	return AdvertRoutes{
-		handler:       handler,
-		logger:        logger,
-		pagination:    pagination,
-		advertHandler: advertHandler,
+		handler:        handler,
+		logger:         logger,
+		pagination:     pagination,
+		query:          query,
+		authMiddleware: authMiddleware,
+		advertHandler:  advertHandler,
 	}
 }
 
@@ -33,9 +40,9 @@ func (a AdvertRoutes) Setup(handler *gin.RouterGroup) {
 	a.logger.Info("Setting up Document routes")
 	api := handler.Group("/advert")
 	{
-		api.POST("", a.advertHandler.CreateAdvert)
-		api.GET("", a.pagination.Handle(), a.advertHandler.ListAdvert)
-		api.GET("/profile/:id", a.advertHandler.ListAdvertByProfileId)
+		api.POST("", a.authMiddleware.Handle(), a.advertHandler.CreateAdvert)
+		api.GET("", a.pagination.Handle(), a.query.Handle(magazine.AdvertColumns), a.advertHandler.ListAdvert)
+		api.GET("/profile/:id", a.pagination.Handle(), a.query.Handle(magazine.AdvertColumns), a.advertHandler.ListAdvertByProfileId)
 
 		api.PATCH("/:id", a.advertHandler.PatchAdvertById)
 		api.DELETE("/:id", a.advertHandler.DeleteAdvertByID)
@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"magazine_api/api/handlers"
+	"magazine_api/api/middlewares"
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdvertisingRoutes struct
+type AdvertisingRoutes struct {
+	logger             lib.Logger
+	handler            infrastructure.Router
+	authMiddleware     middlewares.CognitoAuthMiddleware
+	pagination         middlewares.PaginationMiddleware
+	advertisingHandler handlers.AdvertisingHandler
+}
+
+func NewAdvertisingRoutes(logger lib.Logger,
+	handler infrastructure.Router,
+	authMiddleware middlewares.CognitoAuthMiddleware,
+	pagination middlewares.PaginationMiddleware,
+	advertisingHandler handlers.AdvertisingHandler) AdvertisingRoutes {
+	return AdvertisingRoutes{
+		handler:            handler,
+		logger:             logger,
+		authMiddleware:     authMiddleware,
+		pagination:         pagination,
+		advertisingHandler: advertisingHandler,
+	}
+}
+
+// Setup advertiser, rate card, booking and invoice routes
+func (a AdvertisingRoutes) Setup(handler *gin.RouterGroup) {
+	a.logger.Info("Setting up Advertising routes")
+	staff := a.authMiddleware.HandleRole("admin", "marketing")
+	accounts := a.authMiddleware.HandleRole("admin", "marketing", "accountant")
+
+	advertisers := handler.Group("/advertiser", a.authMiddleware.Handle())
+	{
+		advertisers.GET("/me", a.advertisingHandler.GetMyAdvertiser)
+		advertisers.GET("/me/booking", a.pagination.Handle(), a.advertisingHandler.ListMyBookings)
+		advertisers.GET("/me/invoice", a.pagination.Handle(), a.advertisingHandler.ListMyInvoices)
+
+		advertisers.POST("", staff, a.advertisingHandler.CreateAdvertiser)
+		advertisers.GET("", accounts, a.pagination.Handle(), a.advertisingHandler.ListAdvertisers)
+		advertisers.GET("/:id", accounts, a.advertisingHandler.GetAdvertiserById)
+		advertisers.PATCH("/:id", staff, a.advertisingHandler.PatchAdvertiser)
+		advertisers.DELETE("/:id", staff, a.advertisingHandler.DeleteAdvertiser)
+		advertisers.GET("/:id/booking", accounts, a.pagination.Handle(), a.advertisingHandler.ListAdvertiserBookings)
+		advertisers.POST("/:id/invoice", accounts, a.advertisingHandler.CreateInvoice)
+		advertisers.GET("/:id/invoice", accounts, a.pagination.Handle(), a.advertisingHandler.ListAdvertiserInvoices)
+	}
+
+	cards := handler.Group("/rate_card", a.authMiddleware.Handle())
+	{
+		cards.GET("", a.pagination.Handle(), a.advertisingHandler.ListRateCards)
+		cards.POST("", staff, a.advertisingHandler.CreateRateCard)
+		cards.PATCH("/:id", staff, a.advertisingHandler.PatchRateCard)
+		cards.DELETE("/:id", staff, a.advertisingHandler.DeleteRateCard)
+	}
+
+	bookings := handler.Group("/ad_booking", a.authMiddleware.Handle(), staff)
+	{
+		bookings.POST("", a.advertisingHandler.BookAdvert)
+		bookings.GET("/issue/:id", a.pagination.Handle(), a.advertisingHandler.ListIssueBookings)
+		bookings.PATCH("/:id/cancel", a.advertisingHandler.CancelBooking)
+	}
+
+	invoices := handler.Group("/ad_invoice", a.authMiddleware.Handle(), a.authMiddleware.HandleRole("admin", "accountant"))
+	{
+		invoices.GET("", a.pagination.Handle(), a.advertisingHandler.ListInvoices)
+		invoices.PATCH("/:id/pay", a.advertisingHandler.PayInvoice)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"magazine_api/api/middlewares"
 	"magazine_api/infrastructure"
 	"magazine_api/lib"
+	"magazine_api/models/magazine"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,17 +14,23 @@ type ContentRoutes struct {
 	logger         lib.Logger
 	handler        infrastructure.Router
 	pagination     middlewares.PaginationMiddleware
+	query          middlewares.QueryMiddleware
+	authMiddleware middlewares.CognitoAuthMiddleware
 	contentHandler handlers.ContentHandler
 }
 
 func NewContentRoutes(logger lib.Logger,
 	handler infrastructure.Router,
 	pagination middlewares.PaginationMiddleware,
+	query middlewares.QueryMiddleware,
+	authMiddleware middlewares.CognitoAuthMiddleware,
 	contentHandler handlers.ContentHandler) ContentRoutes {
 	return ContentRoutes{
 		handler:        handler,
 		logger:         logger,
 		pagination:     pagination,
+		query:          query,
+		authMiddleware: authMiddleware,
 		contentHandler: contentHandler,
 	}
 }
@@ -33,10 +40,10 @@ func (a ContentRoutes) Setup(handler *gin.RouterGroup) {
 	a.logger.Info("Setting up Content routes")
 	api := handler.Group("/content")
 	{
-		api.POST("", a.contentHandler.CreateContent)
-		api.GET("", a.pagination.Handle(), a.contentHandler.ListContents)
-		api.GET("/profile/:id", a.contentHandler.ListContentByProfileId)
-		api.GET("/type/:content_type", a.contentHandler.ListContentsByType)
+		api.POST("", a.authMiddleware.Handle(), a.contentHandler.CreateContent)
+		api.GET("", a.pagination.Handle(), a.query.Handle(magazine.ContentColumns), a.contentHandler.ListContents)
+		api.GET("/profile/:id", a.pagination.Handle(), a.query.Handle(magazine.ContentColumns), a.contentHandler.ListContentByProfileId)
+		api.GET("/type/:content_type", a.pagination.Handle(), a.query.Handle(magazine.ContentColumns), a.contentHandler.ListContentsByType)
 
 		api.PATCH("/:id", a.contentHandler.PatchContentById)
 		api.DELETE("/:id", a.contentHandler.DeleteContentByID)
@@ -5,26 +5,33 @@ import (
 	"magazine_api/api/middlewares"
 	"magazine_api/infrastructure"
 	"magazine_api/lib"
+	"magazine_api/models/magazine"
 
 	"github.com/gin-gonic/gin"
 )
 
 type MagazineIssueRoutes struct {
-	logger       lib.Logger
-	handler      infrastructure.Router
-	pagination   middlewares.PaginationMiddleware
-	issueHandler handlers.MagazineIssueHandler
+	logger         lib.Logger
+	handler        infrastructure.Router
+	pagination     middlewares.PaginationMiddleware
+	query          middlewares.QueryMiddleware
+	authMiddleware middlewares.CognitoAuthMiddleware
+	issueHandler   handlers.MagazineIssueHandler
 }
 
 func NewMagazineIssueRoutes(logger lib.Logger,
 	handler infrastructure.Router,
 	pagination middlewares.PaginationMiddleware,
+	query middlewares.QueryMiddleware,
+	authMiddleware middlewares.CognitoAuthMiddleware,
 	issueHandler handlers.MagazineIssueHandler) MagazineIssueRoutes {
 	return MagazineIssueRoutes{
-		handler:      handler,
-		logger:       logger,
-		pagination:   pagination,
-		issueHandler: issueHandler,
+		handler:        handler,
+		logger:         logger,
+		pagination:     pagination,
+		query:          query,
+		authMiddleware: authMiddleware,
+		issueHandler:   issueHandler,
 	}
 }
 
@@ -33,10 +40,12 @@ func (a MagazineIssueRoutes) Setup(handler *gin.RouterGroup) {
 	a.logger.Info("Setting up MagazineIssue routes")
 	api := handler.Group("/issue")
 	{
-		api.POST("", a.issueHandler.CreateMagazineIssue)
-		api.GET("", a.pagination.Handle(), a.issueHandler.ListIssues)
-		api.GET("/profile/:id", a.issueHandler.ListMagazineIssueByProfileId)
-		api.GET("/type/:issue_type", a.issueHandler.ListIssuesByType)
+		api.POST("", a.authMiddleware.Handle(), a.issueHandler.CreateMagazineIssue)
+		api.GET("", a.authMiddleware.HandleOptional(), a.pagination.Handle(), a.query.Handle(magazine.IssueColumns), a.issueHandler.ListIssues)
+		api.GET("/id/:id", a.authMiddleware.HandleOptional(), a.issueHandler.GetMagazineIssueById)
+		api.PUT("/id/:id/slug", a.authMiddleware.Handle(), a.issueHandler.ChangeIssueSlug)
+		api.GET("/profile/:id", a.authMiddleware.HandleOptional(), a.pagination.Handle(), a.query.Handle(magazine.IssueColumns), a.issueHandler.ListMagazineIssueByProfileId)
+		api.GET("/type/:issue_type", a.authMiddleware.HandleOptional(), a.pagination.Handle(), a.query.Handle(magazine.IssueColumns), a.issueHandler.ListIssuesByType)
 
 		api.PATCH("/:id", a.issueHandler.PatchMagazineIssueById)
 		api.DELETE("/:id", a.issueHandler.DeleteMagazineIssueByID)
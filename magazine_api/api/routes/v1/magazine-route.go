@@ -5,6 +5,7 @@ import (
 	"magazine_api/api/middlewares"
 	"magazine_api/infrastructure"
 	"magazine_api/lib"
+	"magazine_api/models/magazine"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,17 +14,23 @@ type MagazineRoutes struct {
 	logger          lib.Logger
 	handler         infrastructure.Router
 	pagination      middlewares.PaginationMiddleware
+	query           middlewares.QueryMiddleware
+	authMiddleware  middlewares.CognitoAuthMiddleware
 	magazineHandler handlers.MagazineHandler
 }
 
 func NewMagazineRoutes(logger lib.Logger,
 	handler infrastructure.Router,
 	pagination middlewares.PaginationMiddleware,
+	query middlewares.QueryMiddleware,
+	authMiddleware middlewares.CognitoAuthMiddleware,
 	magazineHandler handlers.MagazineHandler) MagazineRoutes {
 	return MagazineRoutes{
 		handler:         handler,
 		logger:          logger,
 		pagination:      pagination,
+		query:           query,
+		authMiddleware:  authMiddleware,
 		magazineHandler: magazineHandler,
 	}
 }
@@ -33,10 +40,10 @@ func (a MagazineRoutes) Setup(handler *gin.RouterGroup) {
 	a.logger.Info("Setting up Magazine routes")
 	api := handler.Group("/magazine")
 	{
-		api.POST("", a.magazineHandler.CreateMagazine)
-		api.GET("", a.pagination.Handle(), a.magazineHandler.ListMagazines)
-		api.GET("/profile/:id", a.magazineHandler.ListMagazineByProfileId)
-		api.GET("/type/:magazine_type", a.magazineHandler.ListMagazinesByType)
+		api.POST("", a.authMiddleware.Handle(), a.magazineHandler.CreateMagazine)
+		api.GET("", a.pagination.Handle(), a.query.Handle(magazine.MagazineColumns), a.magazineHandler.ListMagazines)
+		api.GET("/profile/:id", a.pagination.Handle(), a.query.Handle(magazine.MagazineColumns), a.magazineHandler.ListMagazineByProfileId)
+		api.GET("/type/:magazine_type", a.pagination.Handle(), a.query.Handle(magazine.MagazineColumns), a.magazineHandler.ListMagazinesByType)
 
 		api.PATCH("/:id", a.magazineHandler.PatchMagazineById)
 		api.DELETE("/:id", a.magazineHandler.DeleteMagazineByID)
@@ -0,0 +1,41 @@
+package v1
+
+import (
+	"magazine_api/api/handlers"
+	"magazine_api/api/middlewares"
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MediaRoutes struct
+type MediaRoutes struct {
+	logger         lib.Logger
+	handler        infrastructure.Router
+	authMiddleware middlewares.CognitoAuthMiddleware
+	mediaHandler   handlers.MediaHandler
+}
+
+func NewMediaRoutes(logger lib.Logger,
+	handler infrastructure.Router,
+	authMiddleware middlewares.CognitoAuthMiddleware,
+	mediaHandler handlers.MediaHandler) MediaRoutes {
+	return MediaRoutes{
+		handler:        handler,
+		logger:         logger,
+		authMiddleware: authMiddleware,
+		mediaHandler:   mediaHandler,
+	}
+}
+
+// Setup media routes
+func (m MediaRoutes) Setup(handler *gin.RouterGroup) {
+	m.logger.Info("Setting up Media routes")
+	api := handler.Group("/media", m.authMiddleware.Handle())
+	{
+		api.GET("", m.mediaHandler.GetMediaByKey)
+		api.GET("/id/:id", m.mediaHandler.GetMediaById)
+		api.GET("/id/:id/references", m.mediaHandler.ListMediaReferences)
+	}
+}
@@ -5,26 +5,33 @@ import (
 	"magazine_api/api/middlewares"
 	"magazine_api/infrastructure"
 	"magazine_api/lib"
+	"magazine_api/models/magazine"
 
 	"github.com/gin-gonic/gin"
 )
 
 type PhotoRoutes struct {
-	logger       lib.Logger
-	handler      infrastructure.Router
-	pagination   middlewares.PaginationMiddleware
-	photoHandler handlers.PhotoHandler
+	logger         lib.Logger
+	handler        infrastructure.Router
+	pagination     middlewares.PaginationMiddleware
+	query          middlewares.QueryMiddleware
+	authMiddleware middlewares.CognitoAuthMiddleware
+	photoHandler   handlers.PhotoHandler
 }
 
 func NewPhotoRoutes(logger lib.Logger,
 	handler infrastructure.Router,
 	pagination middlewares.PaginationMiddleware,
+	query middlewares.QueryMiddleware,
+	authMiddleware middlewares.CognitoAuthMiddleware,
 	photoHandler handlers.PhotoHandler) PhotoRoutes {
 	return PhotoRoutes{
-		handler:      handler,
-		logger:       logger,
-		pagination:   pagination,
-		photoHandler: photoHandler,
+		handler:        handler,
+		logger:         logger,
+		pagination:     pagination,
+		query:          query,
+		authMiddleware: authMiddleware,
+		photoHandler:   photoHandler,
 	}
 }
 
@@ -33,10 +40,11 @@ func (a PhotoRoutes) Setup(handler *gin.RouterGroup) {
 	a.logger.Info("Setting up Photo routes")
 	api := handler.Group("/photo")
 	{
-		api.POST("", a.photoHandler.CreatePhoto)
-		api.GET("", a.pagination.Handle(), a.photoHandler.ListPhotos)
-		api.GET("/profile/:id", a.photoHandler.ListPhotoByProfileId)
-		api.GET("/type/:photo_type", a.photoHandler.ListPhotosByType)
+		api.POST("", a.authMiddleware.Handle(), a.photoHandler.CreatePhoto)
+		api.GET("", a.pagination.Handle(), a.query.Handle(magazine.PhotographColumns), a.photoHandler.ListPhotos)
+		api.GET("/profile/:id", a.pagination.Handle(), a.query.Handle(magazine.PhotographColumns), a.photoHandler.ListPhotoByProfileId)
+		api.GET("/type/:photo_type", a.pagination.Handle(), a.query.Handle(magazine.PhotographColumns), a.photoHandler.ListPhotosByType)
+		api.GET("/licences/expiring", a.authMiddleware.Handle(), a.photoHandler.ListExpiringLicences)
 
 		api.PATCH("/:id", a.photoHandler.PatchPhotoById)
 		api.DELETE("/:id", a.photoHandler.DeletePhotoByID)
@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"magazine_api/api/handlers"
+	"magazine_api/api/middlewares"
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoyaltyRoutes struct
+type RoyaltyRoutes struct {
+	logger         lib.Logger
+	handler        infrastructure.Router
+	authMiddleware middlewares.CognitoAuthMiddleware
+	pagination     middlewares.PaginationMiddleware
+	royaltyHandler handlers.RoyaltyHandler
+}
+
+func NewRoyaltyRoutes(logger lib.Logger,
+	handler infrastructure.Router,
+	authMiddleware middlewares.CognitoAuthMiddleware,
+	pagination middlewares.PaginationMiddleware,
+	royaltyHandler handlers.RoyaltyHandler) RoyaltyRoutes {
+	return RoyaltyRoutes{
+		handler:        handler,
+		logger:         logger,
+		authMiddleware: authMiddleware,
+		pagination:     pagination,
+		royaltyHandler: royaltyHandler,
+	}
+}
+
+// Setup royalty routes
+func (r RoyaltyRoutes) Setup(handler *gin.RouterGroup) {
+	r.logger.Info("Setting up Royalty routes")
+	accounts := r.authMiddleware.HandleRole("admin", "accountant")
+	editorial := r.authMiddleware.HandleRole("admin", "magazine_manager")
+
+	api := handler.Group("/royalty", r.authMiddleware.Handle())
+	{
+		api.GET("/me", r.pagination.Handle(), r.royaltyHandler.ListMyRoyalties)
+
+		api.POST("/agreement", accounts, r.royaltyHandler.CreateAgreement)
+		api.GET("/agreement/contributor/:id", accounts, r.pagination.Handle(), r.royaltyHandler.ListContributorAgreements)
+		api.PATCH("/agreement/:id", accounts, r.royaltyHandler.PatchAgreement)
+		api.DELETE("/agreement/:id", accounts, r.royaltyHandler.DeleteAgreement)
+
+		api.POST("/issue/:id/publish", editorial, r.royaltyHandler.PublishRoyalties)
+		api.GET("/issue/:id", r.authMiddleware.HandleRole("admin", "accountant", "magazine_manager"), r.pagination.Handle(), r.royaltyHandler.ListIssueRoyalties)
+		api.GET("/contributor/:id", accounts, r.pagination.Handle(), r.royaltyHandler.ListContributorRoyalties)
+
+		api.GET("/balance", accounts, r.royaltyHandler.ListBalances)
+		api.POST("/payout", accounts, r.royaltyHandler.Payout)
+		api.GET("/payout", accounts, r.pagination.Handle(), r.royaltyHandler.ListPayouts)
+	}
+}
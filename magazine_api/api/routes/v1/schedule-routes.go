@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"magazine_api/api/handlers"
+	"magazine_api/api/middlewares"
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ScheduleRoutes struct
+type ScheduleRoutes struct {
+	logger          lib.Logger
+	handler         infrastructure.Router
+	authMiddleware  middlewares.CognitoAuthMiddleware
+	scheduleHandler handlers.ScheduleHandler
+}
+
+func NewScheduleRoutes(logger lib.Logger,
+	handler infrastructure.Router,
+	authMiddleware middlewares.CognitoAuthMiddleware,
+	scheduleHandler handlers.ScheduleHandler) ScheduleRoutes {
+	return ScheduleRoutes{
+		handler:         handler,
+		logger:          logger,
+		authMiddleware:  authMiddleware,
+		scheduleHandler: scheduleHandler,
+	}
+}
+
+// Setup schedule routes
+func (s ScheduleRoutes) Setup(handler *gin.RouterGroup) {
+	s.logger.Info("Setting up Schedule routes")
+	api := handler.Group("/admin/schedule", s.authMiddleware.HandleRole("admin"))
+	{
+		api.GET("", s.scheduleHandler.ListSchedule)
+	}
+}
@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"magazine_api/api/handlers"
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StorageRoutes struct
+type StorageRoutes struct {
+	logger         lib.Logger
+	handler        infrastructure.Router
+	storageHandler handlers.StorageHandler
+}
+
+func NewStorageRoutes(logger lib.Logger,
+	handler infrastructure.Router,
+	storageHandler handlers.StorageHandler) StorageRoutes {
+	return StorageRoutes{
+		handler:        handler,
+		logger:         logger,
+		storageHandler: storageHandler,
+	}
+}
+
+// Setup storage routes, signature of the url authorizes the download or upload
+func (s StorageRoutes) Setup(handler *gin.RouterGroup) {
+	s.logger.Info("Setting up Storage routes")
+	api := handler.Group("/storage")
+	{
+		api.GET("/*key", s.storageHandler.DownloadFile)
+		api.PUT("/*key", s.storageHandler.UploadFile)
+	}
+}
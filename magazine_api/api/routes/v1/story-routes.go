@@ -5,26 +5,33 @@ import (
 	"magazine_api/api/middlewares"
 	"magazine_api/infrastructure"
 	"magazine_api/lib"
+	"magazine_api/models/magazine"
 
 	"github.com/gin-gonic/gin"
 )
 
 type StoryRoutes struct {
-	logger       lib.Logger
-	handler      infrastructure.Router
-	pagination   middlewares.PaginationMiddleware
-	storyHandler handlers.StoryHandler
+	logger         lib.Logger
+	handler        infrastructure.Router
+	pagination     middlewares.PaginationMiddleware
+	query          middlewares.QueryMiddleware
+	authMiddleware middlewares.CognitoAuthMiddleware
+	storyHandler   handlers.StoryHandler
 }
 
 func NewStoryRoutes(logger lib.Logger,
 	handler infrastructure.Router,
 	pagination middlewares.PaginationMiddleware,
+	query middlewares.QueryMiddleware,
+	authMiddleware middlewares.CognitoAuthMiddleware,
 	storyHandler handlers.StoryHandler) StoryRoutes {
 	return StoryRoutes{
-		handler:      handler,
-		logger:       logger,
-		pagination:   pagination,
-		storyHandler: storyHandler,
+		handler:        handler,
+		logger:         logger,
+		pagination:     pagination,
+		query:          query,
+		authMiddleware: authMiddleware,
+		storyHandler:   storyHandler,
 	}
 }
 
@@ -33,10 +40,19 @@ func (a StoryRoutes) Setup(handler *gin.RouterGroup) {
 	a.logger.Info("Setting up Story routes")
 	api := handler.Group("/story")
 	{
-		api.POST("", a.storyHandler.CreateStory)
-		api.GET("", a.pagination.Handle(), a.storyHandler.ListStories)
-		api.GET("/profile/:id", a.storyHandler.ListStoryByProfileId)
-		api.GET("/type/:story_type", a.storyHandler.ListStoriesByType)
+		api.POST("", a.authMiddleware.Handle(), a.storyHandler.CreateStory)
+		api.GET("", a.authMiddleware.HandleOptional(), a.pagination.Handle(), a.query.Handle(magazine.StoryColumns), a.storyHandler.ListStories)
+		api.GET("/id/:id", a.authMiddleware.HandleOptional(), a.storyHandler.GetStoryById)
+		api.GET("/profile/:id", a.authMiddleware.HandleOptional(), a.pagination.Handle(), a.query.Handle(magazine.StoryColumns), a.storyHandler.ListStoryByProfileId)
+		api.GET("/type/:story_type", a.authMiddleware.HandleOptional(), a.pagination.Handle(), a.query.Handle(magazine.StoryColumns), a.storyHandler.ListStoriesByType)
+
+		api.PUT("/id/:id/slug", a.authMiddleware.Handle(), a.storyHandler.ChangeStorySlug)
+		api.GET("/id/:id/body", a.authMiddleware.HandleOptional(), a.storyHandler.RenderStoryBody)
+		api.GET("/id/:id/gallery", a.storyHandler.ListGallery)
+		api.POST("/id/:id/gallery", a.authMiddleware.Handle(), a.storyHandler.AddGalleryPhoto)
+		api.PUT("/id/:id/gallery/order", a.authMiddleware.Handle(), a.storyHandler.ReorderGallery)
+		api.PATCH("/id/:id/gallery/:photo_id", a.authMiddleware.Handle(), a.storyHandler.PatchGalleryPhoto)
+		api.DELETE("/id/:id/gallery/:photo_id", a.authMiddleware.Handle(), a.storyHandler.RemoveGalleryPhoto)
 
 		api.PATCH("/:id", a.storyHandler.PatchStoryById)
 		api.DELETE("/:id", a.storyHandler.DeleteStoryByID)
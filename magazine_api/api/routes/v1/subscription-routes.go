@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"magazine_api/api/handlers"
+	"magazine_api/api/middlewares"
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SubscriptionRoutes struct
+type SubscriptionRoutes struct {
+	logger              lib.Logger
+	handler             infrastructure.Router
+	authMiddleware      middlewares.CognitoAuthMiddleware
+	pagination          middlewares.PaginationMiddleware
+	subscriptionHandler handlers.SubscriptionHandler
+}
+
+func NewSubscriptionRoutes(logger lib.Logger,
+	handler infrastructure.Router,
+	authMiddleware middlewares.CognitoAuthMiddleware,
+	pagination middlewares.PaginationMiddleware,
+	subscriptionHandler handlers.SubscriptionHandler) SubscriptionRoutes {
+	return SubscriptionRoutes{
+		handler:             handler,
+		logger:              logger,
+		authMiddleware:      authMiddleware,
+		pagination:          pagination,
+		subscriptionHandler: subscriptionHandler,
+	}
+}
+
+// Setup subscription routes
+func (s SubscriptionRoutes) Setup(handler *gin.RouterGroup) {
+	s.logger.Info("Setting up Subscription routes")
+	plans := handler.Group("/subscription_plan")
+	{
+		plans.GET("", s.pagination.Handle(), s.subscriptionHandler.ListPlans)
+		plans.POST("", s.authMiddleware.HandleRole("admin"), s.subscriptionHandler.CreatePlan)
+		plans.PATCH("/:id", s.authMiddleware.HandleRole("admin"), s.subscriptionHandler.PatchPlan)
+		plans.DELETE("/:id", s.authMiddleware.HandleRole("admin"), s.subscriptionHandler.DeletePlan)
+	}
+
+	api := handler.Group("/subscription", s.authMiddleware.Handle())
+	{
+		api.POST("", s.subscriptionHandler.Subscribe)
+		api.GET("/me", s.pagination.Handle(), s.subscriptionHandler.ListMySubscriptions)
+		api.GET("/entitlement", s.subscriptionHandler.GetEntitlement)
+		api.GET("/user/:id", s.authMiddleware.HandleRole("admin", "accountant"), s.pagination.Handle(), s.subscriptionHandler.ListUserSubscriptions)
+		api.PATCH("/:id/cancel", s.subscriptionHandler.CancelSubscription)
+	}
+}
@@ -13,17 +13,20 @@ import (
 type UploadRoutes struct {
 	logger           lib.Logger
 	handler          infrastructure.Router
+	authMiddleware   middlewares.CognitoAuthMiddleware
 	uploadMiddleware middlewares.UploadMiddleware
 	uploadHandler    handlers.UploadHandler
 }
 
 func NewUploadRoutes(logger lib.Logger,
 	handler infrastructure.Router,
+	authMiddleware middlewares.CognitoAuthMiddleware,
 	uploadMiddleware middlewares.UploadMiddleware,
 	uploadHandler handlers.UploadHandler) UploadRoutes {
 	return UploadRoutes{
 		handler:          handler,
 		logger:           logger,
+		authMiddleware:   authMiddleware,
 		uploadMiddleware: uploadMiddleware,
 		uploadHandler:    uploadHandler,
 	}
@@ -36,9 +39,18 @@ func (s UploadRoutes) Setup(handler *gin.RouterGroup) {
 	{
 		api.POST("", s.uploadMiddleware.Push(
 			s.uploadMiddleware.Config().
-				WebpEnable(true).
-				ThumbEnable(true).
+				VariantsEnable(true).
+				Files(20).
 				Folder("docs_upload")).
 			Handle(), s.uploadHandler.UploadFile)
 	}
+
+	// files uploaded straight to storage through presigned urls
+	direct := api.Group("", s.authMiddleware.Handle())
+	{
+		direct.POST("/presign", s.uploadHandler.PresignUpload)
+		direct.POST("/complete", s.uploadHandler.CompleteUpload)
+		direct.POST("/:id/resume", s.uploadHandler.ResumeUpload)
+		direct.DELETE("/:id", s.uploadHandler.AbortUpload)
+	}
 }
@@ -39,8 +39,7 @@ func (s UserProfileRoutes) Setup(handler *gin.RouterGroup) {
 	{
 		api.POST("", s.uploadMiddleware.Push(
 			s.uploadMiddleware.Config().
-				WebpEnable(true).
-				ThumbEnable(true).
+				VariantsEnable(true).
 				Folder("profile_image")).
 			Handle(), s.userController.CreateUserProfile)
 
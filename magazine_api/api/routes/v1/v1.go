@@ -18,6 +18,13 @@ var Module = fx.Options(
 	fx.Provide(NewMagazineIssueRoutes),
 	fx.Provide(NewMagazineRoutes),
 	fx.Provide(NewPhotoRoutes),
+	fx.Provide(NewAdminUserRoutes),
+	fx.Provide(NewSubscriptionRoutes),
+	fx.Provide(NewAdvertisingRoutes),
+	fx.Provide(NewRoyaltyRoutes),
+	fx.Provide(NewStorageRoutes),
+	fx.Provide(NewMediaRoutes),
+	fx.Provide(NewScheduleRoutes),
 )
 
 type V1Routes struct {
@@ -38,6 +45,13 @@ func NewV1Routes(
 	issue_routes MagazineIssueRoutes,
 	magazine_routes MagazineRoutes,
 	photo_routes PhotoRoutes,
+	admin_user_routes AdminUserRoutes,
+	subscription_routes SubscriptionRoutes,
+	advertising_routes AdvertisingRoutes,
+	royalty_routes RoyaltyRoutes,
+	storage_routes StorageRoutes,
+	media_routes MediaRoutes,
+	schedule_routes ScheduleRoutes,
 ) V1Routes {
 	return V1Routes{
 		handler: handler,
@@ -52,6 +66,13 @@ func NewV1Routes(
 			issue_routes,
 			magazine_routes,
 			photo_routes,
+			admin_user_routes,
+			subscription_routes,
+			advertising_routes,
+			royalty_routes,
+			storage_routes,
+			media_routes,
+			schedule_routes,
 		},
 	}
 }
@@ -0,0 +1,22 @@
+package requests
+
+import "magazine_api/models"
+
+// UpdateUserRole request for changing roles of user
+type UpdateUserRole struct {
+	Role models.UserRole `json:"role" binding:"required,enum"`
+}
+
+// ListAdminUsers query of the page of users of the identity provider
+type ListAdminUsers struct {
+	Limit *int32 `form:"limit" binding:"omitempty,min=1,max=60"`
+	Token string `form:"token"`
+}
+
+// PaginationToken token of the page, nil for the first page
+func (r ListAdminUsers) PaginationToken() *string {
+	if r.Token == "" {
+		return nil
+	}
+	return &r.Token
+}
@@ -0,0 +1,60 @@
+package requests
+
+import (
+	"magazine_api/lib"
+	"magazine_api/models"
+	"magazine_api/models/magazine"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateAdvert request for creating an advert, it is published when created without publish_at
+type CreateAdvert struct {
+	AdvertCode    *string        `json:"advert_code" binding:"required,min=1,max=100"`
+	AdvertTitle   *string        `json:"advert_title" binding:"required,min=1,max=255"`
+	AdvertContent *string        `json:"advert_content"`
+	AdvertType    *string        `json:"advert_type" binding:"omitempty,max=100"`
+	AdvertURL     *lib.SignedURL `json:"url"`
+
+	AdvertiserId *uuid.UUID     `json:"advertiser_id"`
+	Size         *models.AdSize `json:"size"`
+
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+
+	Remarks *string `json:"remarks" binding:"omitempty,max=1000"`
+}
+
+// Advert advert of the request
+func (r CreateAdvert) Advert() *magazine.Advert {
+	return &magazine.Advert{AdvertBase: magazine.AdvertBase{
+		AdvertCode:    r.AdvertCode,
+		AdvertTitle:   r.AdvertTitle,
+		AdvertContent: r.AdvertContent,
+		AdvertType:    r.AdvertType,
+		AdvertURL:     r.AdvertURL,
+		AdvertiserId:  r.AdvertiserId,
+		Size:          r.Size,
+		PublishAt:     r.PublishAt,
+		UnpublishAt:   r.UnpublishAt,
+		Remarks:       r.Remarks,
+	}}
+}
+
+// PatchAdvert request for updating an advert, only given fields are updated
+type PatchAdvert struct {
+	AdvertCode    *string        `json:"advert_code" binding:"omitempty,min=1,max=100"`
+	AdvertTitle   *string        `json:"advert_title" binding:"omitempty,min=1,max=255"`
+	AdvertContent *string        `json:"advert_content"`
+	AdvertType    *string        `json:"advert_type" binding:"omitempty,max=100"`
+	AdvertURL     *lib.SignedURL `json:"url"`
+
+	AdvertiserId *uuid.UUID     `json:"advertiser_id"`
+	Size         *models.AdSize `json:"size"`
+
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+
+	Remarks *string `json:"remarks" binding:"omitempty,max=1000"`
+}
@@ -0,0 +1,122 @@
+package requests
+
+import (
+	"magazine_api/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateInvoice request for invoicing bookings of an advertiser, each booking is invoiced once
+type CreateInvoice struct {
+	BookingIds []uuid.UUID `json:"booking_ids" binding:"required,min=1,unique"`
+	DueDate    *time.Time  `json:"due_date"`
+	Remarks    *string     `json:"remarks" binding:"omitempty,max=1000"`
+}
+
+// PayInvoice request for recording payment of an invoice
+type PayInvoice struct {
+	PaidMedium *models.PaidMedium `json:"paid_medium" binding:"omitempty,enum"`
+
+	BankPaymentTransactionId *string `json:"bank_payment_transaction_id"`
+
+	OnlinePaymentName          *string `json:"online_payment_name"`
+	OnlinePaymentFrom          *string `json:"online_payment_from"`
+	OnlinePaymentTransactionId *string `json:"online_payment_transaction_id"`
+}
+
+// CreateAdvertiser request for creating advertiser account of a user with advertiser role
+type CreateAdvertiser struct {
+	UserId *uuid.UUID `json:"user_id" binding:"required"`
+
+	CompanyName    *string `json:"company_name" binding:"required,min=1,max=255"`
+	ContactName    *string `json:"contact_name" binding:"omitempty,max=255"`
+	Email          *string `json:"email" binding:"omitempty,email,max=255"`
+	ContactNumber  *string `json:"contact_number" binding:"omitempty,e164"`
+	BillingAddress *string `json:"billing_address" binding:"omitempty,max=1000"`
+	PanNumber      *string `json:"pan_number" binding:"omitempty,max=50"`
+
+	Remarks *string `json:"remarks" binding:"omitempty,max=1000"`
+}
+
+// Advertiser advertiser of the request
+func (r CreateAdvertiser) Advertiser() *models.Advertiser {
+	return &models.Advertiser{AdvertiserBase: models.AdvertiserBase{
+		UserId:         r.UserId,
+		CompanyName:    r.CompanyName,
+		ContactName:    r.ContactName,
+		Email:          r.Email,
+		ContactNumber:  r.ContactNumber,
+		BillingAddress: r.BillingAddress,
+		PanNumber:      r.PanNumber,
+		Remarks:        r.Remarks,
+	}}
+}
+
+// PatchAdvertiser request for updating advertiser account, only given fields are updated
+type PatchAdvertiser struct {
+	CompanyName    *string `json:"company_name" binding:"omitempty,min=1,max=255"`
+	ContactName    *string `json:"contact_name" binding:"omitempty,max=255"`
+	Email          *string `json:"email" binding:"omitempty,email,max=255"`
+	ContactNumber  *string `json:"contact_number" binding:"omitempty,e164"`
+	BillingAddress *string `json:"billing_address" binding:"omitempty,max=1000"`
+	PanNumber      *string `json:"pan_number" binding:"omitempty,max=50"`
+
+	Remarks *string `json:"remarks" binding:"omitempty,max=1000"`
+}
+
+// CreateRateCard request for creating price of advert size at placement
+type CreateRateCard struct {
+	Size      *models.AdSize      `json:"size" binding:"required"`
+	Placement *models.AdPlacement `json:"placement" binding:"required"`
+	Price     *float32            `json:"price" binding:"required,gte=0"`
+
+	Remarks *string `json:"remarks" binding:"omitempty,max=1000"`
+}
+
+// RateCard rate card of the request
+func (r CreateRateCard) RateCard() *models.RateCard {
+	return &models.RateCard{RateCardBase: models.RateCardBase{
+		Size:      r.Size,
+		Placement: r.Placement,
+		Price:     r.Price,
+		Remarks:   r.Remarks,
+	}}
+}
+
+// PatchRateCard request for updating a rate card, only given fields are updated
+type PatchRateCard struct {
+	Size      *models.AdSize      `json:"size"`
+	Placement *models.AdPlacement `json:"placement"`
+	Price     *float32            `json:"price" binding:"omitempty,gte=0"`
+
+	Remarks *string `json:"remarks" binding:"omitempty,max=1000"`
+}
+
+// BookAdvert request for booking advert into an issue, price of the rate card is used when price is not given
+type BookAdvert struct {
+	AdvertiserId *uuid.UUID `json:"advertiser_id"`
+	AdvertId     *uuid.UUID `json:"advert_id"`
+	IssueId      *uuid.UUID `json:"issue_id" binding:"required"`
+
+	Placement *models.AdPlacement `json:"placement" binding:"required"`
+	Size      *models.AdSize      `json:"size" binding:"required"`
+	Page      *int                `json:"page" binding:"omitempty,gte=1"`
+	Price     *float32            `json:"price" binding:"omitempty,gte=0"`
+
+	Remarks *string `json:"remarks" binding:"omitempty,max=1000"`
+}
+
+// Booking booking of the request
+func (r BookAdvert) Booking() *models.AdBooking {
+	return &models.AdBooking{AdBookingBase: models.AdBookingBase{
+		AdvertiserId: r.AdvertiserId,
+		AdvertId:     r.AdvertId,
+		IssueId:      r.IssueId,
+		Placement:    r.Placement,
+		Size:         r.Size,
+		Page:         r.Page,
+		Price:        r.Price,
+		Remarks:      r.Remarks,
+	}}
+}
@@ -0,0 +1,33 @@
+package requests
+
+import (
+	"magazine_api/models/magazine"
+
+	"github.com/google/uuid"
+)
+
+// CreateContent request for placing a story or photograph in contents of an issue
+type CreateContent struct {
+	ContentCode    *string    `json:"content_code" binding:"required,min=1,max=100"`
+	StoryCode      *uuid.UUID `json:"story_code" binding:"required_without=PhotographCode"`
+	PhotographCode *string    `json:"photo_code" binding:"omitempty,max=100"`
+	Remarks        *string    `json:"remarks" binding:"omitempty,max=1000"`
+}
+
+// Content content of the request
+func (r CreateContent) Content() *magazine.Content {
+	return &magazine.Content{ContentBase: magazine.ContentBase{
+		ContentCode:    r.ContentCode,
+		StoryCode:      r.StoryCode,
+		PhotographCode: r.PhotographCode,
+		Remarks:        r.Remarks,
+	}}
+}
+
+// PatchContent request for updating a content, only given fields are updated
+type PatchContent struct {
+	ContentCode    *string    `json:"content_code" binding:"omitempty,min=1,max=100"`
+	StoryCode      *uuid.UUID `json:"story_code"`
+	PhotographCode *string    `json:"photo_code" binding:"omitempty,max=100"`
+	Remarks        *string    `json:"remarks" binding:"omitempty,max=1000"`
+}
@@ -0,0 +1,32 @@
+package requests
+
+import (
+	"magazine_api/models/magazine"
+
+	"github.com/google/uuid"
+)
+
+// GalleryPhoto request for adding photograph to gallery of a story, it is added at the end without position
+type GalleryPhoto struct {
+	PhotographId uuid.UUID           `json:"photograph_id" binding:"required"`
+	Position     *int                `json:"position" binding:"omitempty,gte=1"`
+	Caption      *string             `json:"caption" binding:"omitempty,max=1000"`
+	CreditUserId *uuid.UUID          `json:"credit_user_id"`
+	CreditText   *string             `json:"credit_text" binding:"omitempty,max=255"`
+	CropHints    []magazine.CropHint `json:"crop_hints"`
+	IsLead       bool                `json:"is_lead"`
+}
+
+// PatchGalleryPhoto request for updating photograph in gallery of a story, only given fields are updated
+type PatchGalleryPhoto struct {
+	Caption      *string              `json:"caption" binding:"omitempty,max=1000"`
+	CreditUserId *uuid.UUID           `json:"credit_user_id"`
+	CreditText   *string              `json:"credit_text" binding:"omitempty,max=255"`
+	CropHints    *[]magazine.CropHint `json:"crop_hints"`
+	IsLead       *bool                `json:"is_lead"`
+}
+
+// ReorderGallery request for ordering gallery of a story, every photograph of the gallery is listed once
+type ReorderGallery struct {
+	PhotographIds []uuid.UUID `json:"photograph_ids" binding:"required,min=1"`
+}
@@ -0,0 +1,72 @@
+package requests
+
+import (
+	"magazine_api/lib"
+	"magazine_api/models"
+	"magazine_api/models/magazine"
+	"time"
+)
+
+// CreateIssue request for creating an issue of a magazine, it is published when created without publish_at
+type CreateIssue struct {
+	IssueCode   *string        `json:"issue_code" binding:"required,min=1,max=100"`
+	ContentCode *string        `json:"content_code" binding:"omitempty,max=100"`
+	AdvertCode  *string        `json:"advert_code" binding:"omitempty,max=100"`
+	PdfURL      *lib.SignedURL `json:"pdf_url"`
+	EpubURL     *lib.SignedURL `json:"epub_url"`
+
+	UsageScope *models.UsageScope `json:"usage_scope"`
+	Territory  *string            `json:"territory" binding:"omitempty,max=100"`
+
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+
+	MetaTitle       *string        `json:"meta_title" binding:"omitempty,max=255"`
+	MetaDescription *string        `json:"meta_description" binding:"omitempty,max=500"`
+	CanonicalURL    *string        `json:"canonical_url" binding:"omitempty,url"`
+	OgImage         *lib.SignedURL `json:"og_image"`
+
+	Remarks *string `json:"remarks" binding:"omitempty,max=1000"`
+}
+
+// Issue issue of the request
+func (r CreateIssue) Issue() *magazine.MagazineIssue {
+	return &magazine.MagazineIssue{IssuseBase: magazine.IssuseBase{
+		IssueCode:       r.IssueCode,
+		ContentCode:     r.ContentCode,
+		AdvertCode:      r.AdvertCode,
+		PdfURL:          r.PdfURL,
+		EpubURL:         r.EpubURL,
+		UsageScope:      r.UsageScope,
+		Territory:       r.Territory,
+		PublishAt:       r.PublishAt,
+		UnpublishAt:     r.UnpublishAt,
+		MetaTitle:       r.MetaTitle,
+		MetaDescription: r.MetaDescription,
+		CanonicalURL:    r.CanonicalURL,
+		OgImage:         r.OgImage,
+		Remarks:         r.Remarks,
+	}}
+}
+
+// PatchIssue request for updating an issue, only given fields are updated
+type PatchIssue struct {
+	IssueCode   *string        `json:"issue_code" binding:"omitempty,min=1,max=100"`
+	ContentCode *string        `json:"content_code" binding:"omitempty,max=100"`
+	AdvertCode  *string        `json:"advert_code" binding:"omitempty,max=100"`
+	PdfURL      *lib.SignedURL `json:"pdf_url"`
+	EpubURL     *lib.SignedURL `json:"epub_url"`
+
+	UsageScope *models.UsageScope `json:"usage_scope"`
+	Territory  *string            `json:"territory" binding:"omitempty,max=100"`
+
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+
+	MetaTitle       *string        `json:"meta_title" binding:"omitempty,max=255"`
+	MetaDescription *string        `json:"meta_description" binding:"omitempty,max=500"`
+	CanonicalURL    *string        `json:"canonical_url" binding:"omitempty,url"`
+	OgImage         *lib.SignedURL `json:"og_image"`
+
+	Remarks *string `json:"remarks" binding:"omitempty,max=1000"`
+}
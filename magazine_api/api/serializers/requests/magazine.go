@@ -0,0 +1,29 @@
+package requests
+
+import "magazine_api/models/magazine"
+
+// CreateMagazine request for creating a magazine
+type CreateMagazine struct {
+	MagazineCode *string `json:"magazine_code" binding:"required,min=1,max=100"`
+	IssueCode    *string `json:"issue_code" binding:"omitempty,max=100"`
+	Placement    *string `json:"placement" binding:"omitempty,max=100"`
+	Remarks      *string `json:"remarks" binding:"omitempty,max=1000"`
+}
+
+// Magazine magazine of the request
+func (r CreateMagazine) Magazine() *magazine.Magazine {
+	return &magazine.Magazine{MagazineBase: magazine.MagazineBase{
+		MagazineCode: r.MagazineCode,
+		IssueCode:    r.IssueCode,
+		Placement:    r.Placement,
+		Remarks:      r.Remarks,
+	}}
+}
+
+// PatchMagazine request for updating a magazine, only given fields are updated
+type PatchMagazine struct {
+	MagazineCode *string `json:"magazine_code" binding:"omitempty,min=1,max=100"`
+	IssueCode    *string `json:"issue_code" binding:"omitempty,max=100"`
+	Placement    *string `json:"placement" binding:"omitempty,max=100"`
+	Remarks      *string `json:"remarks" binding:"omitempty,max=1000"`
+}
@@ -0,0 +1,66 @@
+package requests
+
+import (
+	"magazine_api/lib"
+	"magazine_api/models"
+	"magazine_api/models/magazine"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreatePhoto request for creating a photograph, photographs without licence type are owned by the magazine
+type CreatePhoto struct {
+	PhotographID   *uuid.UUID `json:"photo_id"`
+	PhotographCode *string    `json:"photo_code" binding:"required,min=1,max=100"`
+
+	PhotographTitle *string        `json:"photo_title" binding:"required,min=1,max=255"`
+	PhotographType  *string        `json:"photo_type" binding:"omitempty,max=100"`
+	DocumentURL     *lib.SignedURL `json:"url" binding:"required"`
+
+	PhotographerId   *uuid.UUID          `json:"photographer_id"`
+	LicenceSource    *string             `json:"licence_source" binding:"omitempty,max=255"`
+	LicenceType      *models.LicenceType `json:"licence_type"`
+	UsageScope       *models.UsageScope  `json:"usage_scope"`
+	Territories      []string            `json:"territories" binding:"omitempty,dive,min=1,max=100"`
+	LicenceExpiresOn *time.Time          `json:"licence_expires_on"`
+
+	Remarks *string `json:"remarks" binding:"omitempty,max=1000"`
+}
+
+// Photograph photograph of the request
+func (r CreatePhoto) Photograph() *magazine.Photograph {
+	return &magazine.Photograph{PhotographBase: magazine.PhotographBase{
+		PhotographID:     r.PhotographID,
+		PhotographCode:   r.PhotographCode,
+		PhotographTitle:  r.PhotographTitle,
+		PhotographType:   r.PhotographType,
+		DocumentURL:      r.DocumentURL,
+		PhotographerId:   r.PhotographerId,
+		LicenceSource:    r.LicenceSource,
+		LicenceType:      r.LicenceType,
+		UsageScope:       r.UsageScope,
+		Territories:      r.Territories,
+		LicenceExpiresOn: r.LicenceExpiresOn,
+		Remarks:          r.Remarks,
+	}}
+}
+
+// PatchPhoto request for updating a photograph, only given fields are updated
+type PatchPhoto struct {
+	PhotographID   *uuid.UUID `json:"photo_id"`
+	PhotographCode *string    `json:"photo_code" binding:"omitempty,min=1,max=100"`
+
+	PhotographTitle *string        `json:"photo_title" binding:"omitempty,min=1,max=255"`
+	PhotographType  *string        `json:"photo_type" binding:"omitempty,max=100"`
+	DocumentURL     *lib.SignedURL `json:"url"`
+
+	PhotographerId   *uuid.UUID          `json:"photographer_id"`
+	LicenceSource    *string             `json:"licence_source" binding:"omitempty,max=255"`
+	LicenceType      *models.LicenceType `json:"licence_type"`
+	UsageScope       *models.UsageScope  `json:"usage_scope"`
+	Territories      []string            `json:"territories" binding:"omitempty,dive,min=1,max=100"`
+	LicenceExpiresOn *time.Time          `json:"licence_expires_on"`
+
+	Remarks *string `json:"remarks" binding:"omitempty,max=1000"`
+}
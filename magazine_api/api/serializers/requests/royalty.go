@@ -0,0 +1,54 @@
+package requests
+
+import (
+	"magazine_api/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RoyaltyPayout request for paying out outstanding royalties, of all contributors when none given
+type RoyaltyPayout struct {
+	ContributorIds []uuid.UUID        `json:"contributor_ids"`
+	PaidMedium     *models.PaidMedium `json:"paid_medium" binding:"omitempty,enum"`
+	Remarks        *string            `json:"remarks" binding:"omitempty,max=1000"`
+}
+
+// CreateAgreement request for creating royalty agreement of a contributor
+type CreateAgreement struct {
+	ContributorId *uuid.UUID `json:"contributor_id" binding:"required"`
+
+	PerWord  *float32 `json:"per_word" binding:"omitempty,gte=0"`
+	PerStory *float32 `json:"per_story" binding:"omitempty,gte=0"`
+	PerPhoto *float32 `json:"per_photo" binding:"omitempty,gte=0"`
+
+	EffectiveFrom *time.Time `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to"`
+
+	Remarks *string `json:"remarks" binding:"omitempty,max=1000"`
+}
+
+// Agreement royalty agreement of the request
+func (r CreateAgreement) Agreement() *models.RoyaltyAgreement {
+	return &models.RoyaltyAgreement{RoyaltyAgreementBase: models.RoyaltyAgreementBase{
+		ContributorId: r.ContributorId,
+		PerWord:       r.PerWord,
+		PerStory:      r.PerStory,
+		PerPhoto:      r.PerPhoto,
+		EffectiveFrom: r.EffectiveFrom,
+		EffectiveTo:   r.EffectiveTo,
+		Remarks:       r.Remarks,
+	}}
+}
+
+// PatchAgreement request for updating royalty agreement, only given fields are updated
+type PatchAgreement struct {
+	PerWord  *float32 `json:"per_word" binding:"omitempty,gte=0"`
+	PerStory *float32 `json:"per_story" binding:"omitempty,gte=0"`
+	PerPhoto *float32 `json:"per_photo" binding:"omitempty,gte=0"`
+
+	EffectiveFrom *time.Time `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to"`
+
+	Remarks *string `json:"remarks" binding:"omitempty,max=1000"`
+}
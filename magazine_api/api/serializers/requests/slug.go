@@ -0,0 +1,7 @@
+package requests
+
+// ChangeSlug request for changing slug of a story or issue, a new slug is made from its title
+// or issue code when slug is empty
+type ChangeSlug struct {
+	Slug string `json:"slug" binding:"omitempty,max=255"`
+}
@@ -0,0 +1,73 @@
+package requests
+
+import (
+	"magazine_api/lib"
+	"magazine_api/models/magazine"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateStory request for creating a story, story content is made from story body when it is given.
+// Its creator, who is paid royalties of it, is the authenticated user
+type CreateStory struct {
+	StoryID   *uuid.UUID `json:"story_id"`
+	StoryCode *string    `json:"story_code" binding:"omitempty,max=100"`
+
+	StoryTitle   *string `json:"story_title" binding:"required,min=1,max=255"`
+	StoryType    *string `json:"story_type" binding:"required,min=1,max=100"`
+	StoryContent *string `json:"story_content" binding:"required_without=StoryBody"`
+
+	StoryBody *magazine.StoryBody `json:"story_body"`
+
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+
+	MetaTitle       *string        `json:"meta_title" binding:"omitempty,max=255"`
+	MetaDescription *string        `json:"meta_description" binding:"omitempty,max=500"`
+	CanonicalURL    *string        `json:"canonical_url" binding:"omitempty,url"`
+	OgImage         *lib.SignedURL `json:"og_image"`
+
+	Remarks *string `json:"remarks" binding:"omitempty,max=1000"`
+}
+
+// Story story of the request created by the user
+func (r CreateStory) Story(creatorID uuid.UUID) *magazine.Story {
+	return &magazine.Story{StoryBase: magazine.StoryBase{
+		StoryID:         r.StoryID,
+		StoryCode:       r.StoryCode,
+		CreatorId:       &creatorID,
+		StoryTitle:      r.StoryTitle,
+		StoryType:       r.StoryType,
+		StoryContent:    r.StoryContent,
+		StoryBody:       r.StoryBody,
+		PublishAt:       r.PublishAt,
+		UnpublishAt:     r.UnpublishAt,
+		MetaTitle:       r.MetaTitle,
+		MetaDescription: r.MetaDescription,
+		CanonicalURL:    r.CanonicalURL,
+		OgImage:         r.OgImage,
+		Remarks:         r.Remarks,
+	}}
+}
+
+// PatchStory request for updating a story, only given fields are updated
+type PatchStory struct {
+	StoryCode *string `json:"story_code" binding:"omitempty,max=100"`
+
+	StoryTitle   *string `json:"story_title" binding:"omitempty,min=1,max=255"`
+	StoryType    *string `json:"story_type" binding:"omitempty,min=1,max=100"`
+	StoryContent *string `json:"story_content"`
+
+	StoryBody *magazine.StoryBody `json:"story_body"`
+
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+
+	MetaTitle       *string        `json:"meta_title" binding:"omitempty,max=255"`
+	MetaDescription *string        `json:"meta_description" binding:"omitempty,max=500"`
+	CanonicalURL    *string        `json:"canonical_url" binding:"omitempty,url"`
+	OgImage         *lib.SignedURL `json:"og_image"`
+
+	Remarks *string `json:"remarks" binding:"omitempty,max=1000"`
+}
@@ -0,0 +1,50 @@
+package requests
+
+import (
+	"magazine_api/models"
+
+	"github.com/google/uuid"
+)
+
+// Subscribe request for subscribing to a plan
+type Subscribe struct {
+	PlanId     uuid.UUID          `json:"plan_id" binding:"required"`
+	PaidMedium *models.PaidMedium `json:"paid_medium" binding:"omitempty,enum"`
+
+	BankPaymentTransactionId *string `json:"bank_payment_transaction_id"`
+
+	OnlinePaymentName          *string `json:"online_payment_name"`
+	OnlinePaymentFrom          *string `json:"online_payment_from"`
+	OnlinePaymentTransactionId *string `json:"online_payment_transaction_id"`
+}
+
+// CreatePlan request for creating a subscription plan
+type CreatePlan struct {
+	PlanName *string                      `json:"plan_name" binding:"required,min=1,max=255"`
+	Interval *models.SubscriptionInterval `json:"interval" binding:"required"`
+	Format   *models.SubscriptionFormat   `json:"format" binding:"required"`
+	Price    *float32                     `json:"price" binding:"required,gte=0"`
+
+	Remarks *string `json:"remarks" binding:"omitempty,max=1000"`
+}
+
+// Plan subscription plan of the request
+func (r CreatePlan) Plan() *models.SubscriptionPlan {
+	return &models.SubscriptionPlan{SubscriptionPlanBase: models.SubscriptionPlanBase{
+		PlanName: r.PlanName,
+		Interval: r.Interval,
+		Format:   r.Format,
+		Price:    r.Price,
+		Remarks:  r.Remarks,
+	}}
+}
+
+// PatchPlan request for updating a subscription plan, only given fields are updated
+type PatchPlan struct {
+	PlanName *string                      `json:"plan_name" binding:"omitempty,min=1,max=255"`
+	Interval *models.SubscriptionInterval `json:"interval"`
+	Format   *models.SubscriptionFormat   `json:"format"`
+	Price    *float32                     `json:"price" binding:"omitempty,gte=0"`
+
+	Remarks *string `json:"remarks" binding:"omitempty,max=1000"`
+}
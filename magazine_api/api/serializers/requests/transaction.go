@@ -0,0 +1,90 @@
+package requests
+
+import (
+	"magazine_api/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateTransaction request for recording a transaction, payment details are of the paid medium
+type CreateTransaction struct {
+	Title *string `json:"title" binding:"required,min=1,max=255"`
+
+	TransactionCost *float32 `json:"transaction_cost" binding:"omitempty,gte=0"`
+	DebitAmount     *float32 `json:"debit_amount" binding:"omitempty,gte=0"`
+	CreditAmount    *float32 `json:"credit_amount" binding:"omitempty,gte=0"`
+
+	PaymentTo   *uuid.UUID `json:"payment_to"`
+	PaymentFrom *uuid.UUID `json:"payment_from"`
+
+	PaymentDate  *time.Time    `json:"payment_date"`
+	PaymentMonth *models.Month `json:"payment_month" binding:"omitempty,enum"`
+
+	PaidType   *uuid.UUID         `json:"payment_type"`
+	PaidMedium *models.PaidMedium `json:"paid_medium" binding:"omitempty,enum"`
+
+	BankPaymentFrom          *uuid.UUID `json:"bank_payment_from"`
+	BankPaymentTo            *uuid.UUID `json:"bank_payment_to"`
+	BankPaymentTransactionId *string    `json:"bank_payment_transaction_id" binding:"omitempty,max=255"`
+
+	OnlinePaymentName          *string `json:"online_payment_name" binding:"omitempty,max=255"`
+	OnlinePaymentFrom          *string `json:"online_payment_from" binding:"omitempty,max=255"`
+	OnlinePaymentTo            *string `json:"online_payment_to" binding:"omitempty,max=255"`
+	OnlinePaymentTransactionId *string `json:"online_payment_transaction_id" binding:"omitempty,max=255"`
+
+	Remarks *string `json:"remarks" binding:"omitempty,max=1000"`
+}
+
+// Transaction transaction of the request
+func (r CreateTransaction) Transaction() *models.Transaction {
+	return &models.Transaction{TransactionBase: models.TransactionBase{
+		Title:                      r.Title,
+		TransactionCost:            r.TransactionCost,
+		DebitAmount:                r.DebitAmount,
+		CreditAmount:               r.CreditAmount,
+		PaymentTo:                  r.PaymentTo,
+		PaymentFrom:                r.PaymentFrom,
+		PaymentDate:                r.PaymentDate,
+		PaymentMonth:               r.PaymentMonth,
+		PaidType:                   r.PaidType,
+		PaidMedium:                 r.PaidMedium,
+		BankPaymentFrom:            r.BankPaymentFrom,
+		BankPaymentTo:              r.BankPaymentTo,
+		BankPaymentTransactionId:   r.BankPaymentTransactionId,
+		OnlinePaymentName:          r.OnlinePaymentName,
+		OnlinePaymentFrom:          r.OnlinePaymentFrom,
+		OnlinePaymentTo:            r.OnlinePaymentTo,
+		OnlinePaymentTransactionId: r.OnlinePaymentTransactionId,
+		Remarks:                    r.Remarks,
+	}}
+}
+
+// PatchTransaction request for updating a transaction, only given fields are updated
+type PatchTransaction struct {
+	Title *string `json:"title" binding:"omitempty,min=1,max=255"`
+
+	TransactionCost *float32 `json:"transaction_cost" binding:"omitempty,gte=0"`
+	DebitAmount     *float32 `json:"debit_amount" binding:"omitempty,gte=0"`
+	CreditAmount    *float32 `json:"credit_amount" binding:"omitempty,gte=0"`
+
+	PaymentTo   *uuid.UUID `json:"payment_to"`
+	PaymentFrom *uuid.UUID `json:"payment_from"`
+
+	PaymentDate  *time.Time    `json:"payment_date"`
+	PaymentMonth *models.Month `json:"payment_month" binding:"omitempty,enum"`
+
+	PaidType   *uuid.UUID         `json:"payment_type"`
+	PaidMedium *models.PaidMedium `json:"paid_medium" binding:"omitempty,enum"`
+
+	BankPaymentFrom          *uuid.UUID `json:"bank_payment_from"`
+	BankPaymentTo            *uuid.UUID `json:"bank_payment_to"`
+	BankPaymentTransactionId *string    `json:"bank_payment_transaction_id" binding:"omitempty,max=255"`
+
+	OnlinePaymentName          *string `json:"online_payment_name" binding:"omitempty,max=255"`
+	OnlinePaymentFrom          *string `json:"online_payment_from" binding:"omitempty,max=255"`
+	OnlinePaymentTo            *string `json:"online_payment_to" binding:"omitempty,max=255"`
+	OnlinePaymentTransactionId *string `json:"online_payment_transaction_id" binding:"omitempty,max=255"`
+
+	Remarks *string `json:"remarks" binding:"omitempty,max=1000"`
+}
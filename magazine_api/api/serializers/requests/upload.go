@@ -0,0 +1,25 @@
+package requests
+
+import (
+	"magazine_api/lib"
+
+	"github.com/google/uuid"
+)
+
+// PresignUpload request for urls to upload file of declared content type and size straight to storage
+type PresignUpload struct {
+	FileName    string `json:"file_name" binding:"required,max=255"`
+	ContentType string `json:"content_type" binding:"required,max=255"`
+	Size        int64  `json:"size" binding:"required,gt=0"`
+	Folder      string `json:"folder" binding:"omitempty,max=255"`
+
+	// Multipart uploads file in parts even when it is below the multipart threshold
+	Multipart bool `json:"multipart"`
+}
+
+// CompleteUpload request for recording file uploaded straight to storage, parts of multipart
+// upload are listed from storage when none given
+type CompleteUpload struct {
+	UploadId uuid.UUID        `json:"upload_id" binding:"required"`
+	Parts    []lib.UploadPart `json:"parts"`
+}
@@ -0,0 +1,37 @@
+package requests
+
+import (
+	"magazine_api/lib"
+	"magazine_api/models"
+
+	"github.com/google/uuid"
+)
+
+// CreateUserProfile request for creating profile of a user
+type CreateUserProfile struct {
+	UserId uuid.UUID `json:"user_id" binding:"required"`
+
+	Name          *string        `json:"name" binding:"required,min=1,max=255"`
+	Email         *string        `json:"email" binding:"omitempty,email,max=255"`
+	ContactNumber *string        `json:"contact_number" binding:"omitempty,e164"`
+	Picture       *lib.SignedURL `json:"picture"`
+}
+
+// UserProfile user profile of the request
+func (r CreateUserProfile) UserProfile() *models.UserProfile {
+	return &models.UserProfile{UserProfileBase: models.UserProfileBase{
+		UserId:        r.UserId,
+		Name:          r.Name,
+		Email:         r.Email,
+		ContactNumber: r.ContactNumber,
+		Picture:       r.Picture,
+	}}
+}
+
+// PatchUserProfile request for updating profile of a user, only given fields are updated
+type PatchUserProfile struct {
+	Name          *string        `json:"name" binding:"omitempty,min=1,max=255"`
+	Email         *string        `json:"email" binding:"omitempty,email,max=255"`
+	ContactNumber *string        `json:"contact_number" binding:"omitempty,e164"`
+	Picture       *lib.SignedURL `json:"picture"`
+}
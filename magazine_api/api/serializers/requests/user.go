@@ -5,13 +5,49 @@ import (
 	"magazine_api/models"
 )
 
+// CreateUser request for creating a user with the profiles of its roles
 type CreateUser struct {
-	models.UserBase
+	Name          *string         `json:"name" binding:"required,min=1,max=255"`
+	Email         *string         `json:"email" binding:"required,email,max=255"`
+	Role          models.UserRole `json:"role" binding:"required,enum"`
+	Password      *string         `json:"password" binding:"required,min=8,max=256"`
+	ContactNumber *string         `json:"contact_number" binding:"omitempty,e164"`
+
 	Picture          *lib.SignedURL        `json:"picture"`
 	Address          *models.AddressBase   `json:"address"`
 	EmergencyContact *models.ContactBase   `json:"emergency_contact"`
 	CompanyId        *string               `json:"company_id"`
-	Salary           *models.SalaryBase    `json:"salary"`
+	Salary           *Salary               `json:"salary"`
 	BankAccount      []models.AccountBase  `json:"bank_account"`
 	Documents        []models.DocumentBase `json:"documents"`
 }
+
+// User user of the request
+func (r CreateUser) User() *models.User {
+	return &models.User{UserBase: models.UserBase{
+		Name:          r.Name,
+		Email:         r.Email,
+		Role:          r.Role,
+		Password:      r.Password,
+		ContactNumber: r.ContactNumber,
+	}}
+}
+
+// Salary request for the salary of an employee created with the user
+type Salary struct {
+	Amount       *float32             `json:"amount" binding:"omitempty,gte=0"`
+	SalaryFormat *models.SalaryFormat `json:"salary_format" binding:"omitempty,enum"`
+
+	TailorRate []models.TailorRate `json:"tailor_rate"`
+
+	EffectiveFrom *models.Month `json:"effective_from" binding:"omitempty,enum"`
+	EffectiveTo   *models.Month `json:"effective_to" binding:"omitempty,enum"`
+}
+
+// PatchUser request for updating a user, only given fields are updated. Password is changed through cognito
+type PatchUser struct {
+	Name          *string         `json:"name" binding:"omitempty,min=1,max=255"`
+	Email         *string         `json:"email" binding:"omitempty,email,max=255"`
+	Role          models.UserRole `json:"role" binding:"omitempty,enum"`
+	ContactNumber *string         `json:"contact_number" binding:"omitempty,e164"`
+}
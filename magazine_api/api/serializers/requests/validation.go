@@ -0,0 +1,39 @@
+package requests
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// enum values are valid when they are one of the values of their type
+type enum interface {
+	Valid() bool
+}
+
+// init sets up the validator of `binding` tags, fields failing validation are named by their
+// json names and `enum` checks values of types with Valid are valid
+func init() {
+	validate, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	_ = validate.RegisterValidation("enum", func(fl validator.FieldLevel) bool {
+		value, ok := fl.Field().Interface().(enum)
+		return ok && value.Valid()
+	})
+}
@@ -0,0 +1,20 @@
+package responses
+
+import (
+	"magazine_api/models"
+	"time"
+)
+
+// AdminUser user of identity provider merged with user from our database
+type AdminUser struct {
+	Username      *string         `json:"username"`
+	Name          *string         `json:"name"`
+	Email         *string         `json:"email"`
+	Role          models.UserRole `json:"role"`
+	ContactNumber *string         `json:"contact_number"`
+
+	Enabled bool   `json:"enabled"`
+	Status  string `json:"status"`
+
+	CreatedOn *time.Time `json:"created_on"`
+}
@@ -0,0 +1,91 @@
+package responses
+
+import "magazine_api/models"
+
+// Advertiser advertiser as sent to clients
+type Advertiser struct {
+	models.Base
+	models.AdvertiserBase
+	Timestamps
+	Audit
+}
+
+// NewAdvertiser response of the advertiser
+func NewAdvertiser(advertiser *models.Advertiser) *Advertiser {
+	if advertiser == nil {
+		return nil
+	}
+
+	return &Advertiser{
+		Base:           advertiser.Base,
+		AdvertiserBase: advertiser.AdvertiserBase,
+		Timestamps:     NewTimestamps(advertiser.BaseDate),
+		Audit:          NewAudit(advertiser.BaseCreatedBy),
+	}
+}
+
+// RateCard rate card as sent to clients
+type RateCard struct {
+	models.Base
+	models.RateCardBase
+	Timestamps
+	Audit
+}
+
+// NewRateCard response of the rate card
+func NewRateCard(card *models.RateCard) *RateCard {
+	if card == nil {
+		return nil
+	}
+
+	return &RateCard{
+		Base:         card.Base,
+		RateCardBase: card.RateCardBase,
+		Timestamps:   NewTimestamps(card.BaseDate),
+		Audit:        NewAudit(card.BaseCreatedBy),
+	}
+}
+
+// Booking advert booking as sent to clients
+type Booking struct {
+	models.Base
+	models.AdBookingBase
+	Timestamps
+	Audit
+}
+
+// NewBooking response of the booking
+func NewBooking(booking *models.AdBooking) *Booking {
+	if booking == nil {
+		return nil
+	}
+
+	return &Booking{
+		Base:          booking.Base,
+		AdBookingBase: booking.AdBookingBase,
+		Timestamps:    NewTimestamps(booking.BaseDate),
+		Audit:         NewAudit(booking.BaseCreatedBy),
+	}
+}
+
+// Invoice advert invoice as sent to clients
+type Invoice struct {
+	models.Base
+	models.AdInvoiceBase
+	Timestamps
+	Audit
+}
+
+// NewInvoice response of the invoice
+func NewInvoice(invoice *models.AdInvoice) *Invoice {
+	if invoice == nil {
+		return nil
+	}
+
+	return &Invoice{
+		Base:          invoice.Base,
+		AdInvoiceBase: invoice.AdInvoiceBase,
+		Timestamps:    NewTimestamps(invoice.BaseDate),
+		Audit:         NewAudit(invoice.BaseCreatedBy),
+	}
+}
@@ -0,0 +1,166 @@
+package responses
+
+import (
+	"magazine_api/models"
+	"magazine_api/models/magazine"
+)
+
+// Story story as sent to clients
+type Story struct {
+	models.Base
+	magazine.StoryBase
+	Timestamps
+	models.BasePublished
+
+	Slug        *string `json:"slug"`
+	WordCount   *int    `json:"word_count"`
+	ReadingTime *int    `json:"reading_time"`
+	Preview     bool    `json:"preview"`
+
+	Gallery []*magazine.StoryPhoto `json:"gallery,omitempty"`
+}
+
+// NewStory response of the story
+func NewStory(story *magazine.Story) *Story {
+	if story == nil {
+		return nil
+	}
+
+	return &Story{
+		Base:          story.Base,
+		StoryBase:     story.StoryBase,
+		Timestamps:    NewTimestamps(story.BaseDate),
+		BasePublished: story.BasePublished,
+		Slug:          story.Slug,
+		WordCount:     story.WordCount,
+		ReadingTime:   story.ReadingTime,
+		Preview:       story.Preview,
+		Gallery:       story.Gallery,
+	}
+}
+
+// Content content as sent to clients
+type Content struct {
+	models.Base
+	magazine.ContentBase
+	Timestamps
+	Audit
+}
+
+// NewContent response of the content
+func NewContent(content *magazine.Content) *Content {
+	if content == nil {
+		return nil
+	}
+
+	return &Content{
+		Base:        content.Base,
+		ContentBase: content.ContentBase,
+		Timestamps:  NewTimestamps(content.BaseDate),
+		Audit:       NewAudit(content.BaseCreatedBy),
+	}
+}
+
+// Magazine magazine as sent to clients
+type Magazine struct {
+	models.Base
+	magazine.MagazineBase
+	Timestamps
+	Audit
+}
+
+// NewMagazine response of the magazine
+func NewMagazine(m *magazine.Magazine) *Magazine {
+	if m == nil {
+		return nil
+	}
+
+	return &Magazine{
+		Base:         m.Base,
+		MagazineBase: m.MagazineBase,
+		Timestamps:   NewTimestamps(m.BaseDate),
+		Audit:        NewAudit(m.BaseCreatedBy),
+	}
+}
+
+// Issue magazine issue as sent to clients
+type Issue struct {
+	models.Base
+	magazine.IssuseBase
+	Timestamps
+	models.BasePublished
+	Audit
+
+	Slug    *string `json:"slug"`
+	Preview bool    `json:"preview"`
+
+	Stories []*Story `json:"stories,omitempty"`
+}
+
+// NewIssue response of the issue with its stories
+func NewIssue(issue *magazine.MagazineIssue) *Issue {
+	if issue == nil {
+		return nil
+	}
+
+	response := &Issue{
+		Base:          issue.Base,
+		IssuseBase:    issue.IssuseBase,
+		Timestamps:    NewTimestamps(issue.BaseDate),
+		BasePublished: issue.BasePublished,
+		Audit:         NewAudit(issue.BaseCreatedBy),
+		Slug:          issue.Slug,
+		Preview:       issue.Preview,
+	}
+	if len(issue.Stories) > 0 {
+		response.Stories = List(issue.Stories, NewStory)
+	}
+
+	return response
+}
+
+// Advert advert as sent to clients
+type Advert struct {
+	models.Base
+	magazine.AdvertBase
+	Timestamps
+	models.BasePublished
+	Audit
+}
+
+// NewAdvert response of the advert
+func NewAdvert(advert *magazine.Advert) *Advert {
+	if advert == nil {
+		return nil
+	}
+
+	return &Advert{
+		Base:          advert.Base,
+		AdvertBase:    advert.AdvertBase,
+		Timestamps:    NewTimestamps(advert.BaseDate),
+		BasePublished: advert.BasePublished,
+		Audit:         NewAudit(advert.BaseCreatedBy),
+	}
+}
+
+// Photograph photograph as sent to clients
+type Photograph struct {
+	models.Base
+	magazine.PhotographBase
+	Timestamps
+	Audit
+}
+
+// NewPhotograph response of the photograph
+func NewPhotograph(photo *magazine.Photograph) *Photograph {
+	if photo == nil {
+		return nil
+	}
+
+	return &Photograph{
+		Base:           photo.Base,
+		PhotographBase: photo.PhotographBase,
+		Timestamps:     NewTimestamps(photo.BaseDate),
+		Audit:          NewAudit(photo.BaseCreatedBy),
+	}
+}
@@ -0,0 +1,50 @@
+package responses
+
+import (
+	"errors"
+	"magazine_api/apperrors"
+	"magazine_api/constants"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Problem : body of error responses, see RFC 7807
+type Problem struct {
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Status    int                    `json:"status"`
+	Detail    string                 `json:"detail,omitempty"`
+	Errors    []apperrors.FieldError `json:"errors,omitempty"`
+	RequestID string                 `json:"request_id,omitempty"`
+}
+
+// ProblemJSON : problem response function
+func ProblemJSON(c *gin.Context, statusCode int, detail string, fields ...apperrors.FieldError) {
+	c.Header("Content-Type", "application/problem+json")
+	c.JSON(statusCode, Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(statusCode),
+		Status:    statusCode,
+		Detail:    detail,
+		Errors:    fields,
+		RequestID: c.GetString(constants.RequestID),
+	})
+}
+
+// ErrorProblem : problem response function of the error, internal errors are not detailed
+func ErrorProblem(c *gin.Context, err error) {
+	err = apperrors.From(err)
+	kind := apperrors.KindOf(err)
+	if kind == apperrors.Internal {
+		ProblemJSON(c, http.StatusInternalServerError, "")
+		return
+	}
+
+	var fields []apperrors.FieldError
+	var e *apperrors.Error
+	if errors.As(err, &e) {
+		fields = e.Fields
+	}
+	ProblemJSON(c, apperrors.Status(kind), err.Error(), fields...)
+}
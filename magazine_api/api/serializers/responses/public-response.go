@@ -0,0 +1,74 @@
+package responses
+
+import (
+	"magazine_api/lib"
+	"magazine_api/models"
+	"magazine_api/models/magazine"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PublicStory published story as readers see it, content and body are only sent when the story
+// is opened and are previews for readers without subscription
+type PublicStory struct {
+	ID          uuid.UUID           `json:"id"`
+	Slug        *string             `json:"slug"`
+	Title       *string             `json:"title"`
+	Type        *string             `json:"type"`
+	Summary     string              `json:"summary"`
+	Content     *string             `json:"content,omitempty"`
+	Body        *magazine.StoryBody `json:"body,omitempty"`
+	WordCount   *int                `json:"word_count"`
+	ReadingTime *int                `json:"reading_time"`
+	LeadImage   *PublicPhoto        `json:"lead_image,omitempty"`
+	Gallery     []PublicPhoto       `json:"gallery,omitempty"`
+	Preview     bool                `json:"preview"`
+	SEO         PublicSEO           `json:"seo"`
+	PublishedOn *time.Time          `json:"published_on"`
+	UpdatedOn   *time.Time          `json:"updated_on"`
+}
+
+// PublicSEO metadata of the page of a story or issue for search engines and link previews
+type PublicSEO struct {
+	MetaTitle       string         `json:"meta_title"`
+	MetaDescription string         `json:"meta_description"`
+	CanonicalURL    string         `json:"canonical_url"`
+	OgImage         *lib.SignedURL `json:"og_image,omitempty" url_expiry:"168h"`
+}
+
+// PublicPhoto photograph placed by published stories or issues with its credit
+type PublicPhoto struct {
+	ID        uuid.UUID           `json:"id"`
+	Title     *string             `json:"title"`
+	URL       *lib.SignedURL      `json:"url"`
+	Caption   *string             `json:"caption,omitempty"`
+	Credit    *string             `json:"credit,omitempty"`
+	CropHints []magazine.CropHint `json:"crop_hints,omitempty"`
+}
+
+// PublicIssue published issue with summaries of its published stories, downloads are
+// only sent to readers with subscription
+type PublicIssue struct {
+	ID          uuid.UUID      `json:"id"`
+	Slug        *string        `json:"slug"`
+	IssueCode   *string        `json:"issue_code"`
+	PdfURL      *lib.SignedURL `json:"pdf_url,omitempty" url_expiry:"1h"`
+	EpubURL     *lib.SignedURL `json:"epub_url,omitempty" url_expiry:"1h"`
+	Stories     []PublicStory  `json:"stories,omitempty"`
+	Preview     bool           `json:"preview"`
+	SEO         PublicSEO      `json:"seo"`
+	PublishedOn *time.Time     `json:"published_on"`
+	UpdatedOn   *time.Time     `json:"updated_on"`
+}
+
+// PublicAdvert published advert
+type PublicAdvert struct {
+	ID          uuid.UUID      `json:"id"`
+	Title       *string        `json:"title"`
+	Content     *string        `json:"content"`
+	Type        *string        `json:"type"`
+	URL         *lib.SignedURL `json:"url"`
+	Size        *models.AdSize `json:"size"`
+	PublishedOn *time.Time     `json:"published_on"`
+}
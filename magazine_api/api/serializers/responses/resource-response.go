@@ -0,0 +1,46 @@
+package responses
+
+import (
+	"magazine_api/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Timestamps of a resource, it is never sent when or whether the resource was deleted
+type Timestamps struct {
+	CreatedOn *time.Time `json:"created_on"`
+	UpdatedOn *time.Time `json:"updated_on"`
+}
+
+// NewTimestamps timestamps of the model dates
+func NewTimestamps(date models.BaseDate) Timestamps {
+	return Timestamps{CreatedOn: date.CreatedOn, UpdatedOn: date.UpdatedOn}
+}
+
+// Audit users who created and last updated a resource
+type Audit struct {
+	CreatedBy   *uuid.UUID `json:"created_by"`
+	CreatorName *string    `json:"creator_name"`
+	UpdatedBy   *uuid.UUID `json:"updated_by"`
+	UpdatedName *string    `json:"updator_name"`
+}
+
+// NewAudit audit of the model creators
+func NewAudit(by models.BaseCreatedBy) Audit {
+	return Audit{
+		CreatedBy:   by.CreatedBy,
+		CreatorName: by.CreatorName,
+		UpdatedBy:   by.UpdatedBy,
+		UpdatedName: by.UpdatedName,
+	}
+}
+
+// List maps rows of models to responses, nil rows are sent as empty list
+func List[M any, R any](rows []M, mapper func(M) R) []R {
+	list := make([]R, 0, len(rows))
+	for _, row := range rows {
+		list = append(list, mapper(row))
+	}
+	return list
+}
@@ -1,6 +1,8 @@
 package responses
 
 import (
+	"magazine_api/models"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -9,11 +11,6 @@ func JSON(c *gin.Context, statusCode int, data interface{}) {
 	c.JSON(statusCode, gin.H{"data": data})
 }
 
-// ErrorJSON : json error response function
-func ErrorJSON(c *gin.Context, statusCode int, data interface{}) {
-	c.JSON(statusCode, gin.H{"error": data})
-}
-
 // SuccessJSON : json error response function
 func SuccessJSON(c *gin.Context, statusCode int, data interface{}) {
 	c.JSON(statusCode, gin.H{"msg": data})
@@ -23,3 +20,17 @@ func SuccessJSON(c *gin.Context, statusCode int, data interface{}) {
 func JSONCount(c *gin.Context, statusCode int, data interface{}, count int) {
 	c.JSON(statusCode, gin.H{"data": data, "count": count})
 }
+
+// PageBody : body of page of a list, next_cursor is null on the last page
+func PageBody(data interface{}, page models.PageInfo) gin.H {
+	var next interface{}
+	if page.NextCursor != "" {
+		next = page.NextCursor
+	}
+	return gin.H{"data": data, "next_cursor": next, "total": page.Total}
+}
+
+// JSONPage : json response function for page of a list
+func JSONPage(c *gin.Context, statusCode int, data interface{}, page models.PageInfo) {
+	c.JSON(statusCode, PageBody(data, page))
+}
@@ -0,0 +1,79 @@
+package responses
+
+import (
+	"magazine_api/models"
+
+	"github.com/google/uuid"
+)
+
+// ContributorBalance royalties of contributor paid out and outstanding
+type ContributorBalance struct {
+	ContributorId uuid.UUID `json:"contributor_id"`
+	Outstanding   float32   `json:"outstanding"`
+	Paid          float32   `json:"paid"`
+	UnpaidCount   int       `json:"unpaid_count"`
+}
+
+// Agreement royalty agreement as sent to clients
+type Agreement struct {
+	models.Base
+	models.RoyaltyAgreementBase
+	Timestamps
+	Audit
+}
+
+// NewAgreement response of the agreement
+func NewAgreement(agreement *models.RoyaltyAgreement) *Agreement {
+	if agreement == nil {
+		return nil
+	}
+
+	return &Agreement{
+		Base:                 agreement.Base,
+		RoyaltyAgreementBase: agreement.RoyaltyAgreementBase,
+		Timestamps:           NewTimestamps(agreement.BaseDate),
+		Audit:                NewAudit(agreement.BaseCreatedBy),
+	}
+}
+
+// Royalty royalty as sent to clients
+type Royalty struct {
+	models.Base
+	models.RoyaltyBase
+	Timestamps
+}
+
+// NewRoyalty response of the royalty
+func NewRoyalty(royalty *models.Royalty) *Royalty {
+	if royalty == nil {
+		return nil
+	}
+
+	return &Royalty{
+		Base:        royalty.Base,
+		RoyaltyBase: royalty.RoyaltyBase,
+		Timestamps:  NewTimestamps(royalty.BaseDate),
+	}
+}
+
+// Payout royalty payout as sent to clients
+type Payout struct {
+	models.Base
+	models.RoyaltyPayoutBase
+	Timestamps
+	Audit
+}
+
+// NewPayout response of the payout
+func NewPayout(payout *models.RoyaltyPayout) *Payout {
+	if payout == nil {
+		return nil
+	}
+
+	return &Payout{
+		Base:              payout.Base,
+		RoyaltyPayoutBase: payout.RoyaltyPayoutBase,
+		Timestamps:        NewTimestamps(payout.BaseDate),
+		Audit:             NewAudit(payout.BaseCreatedBy),
+	}
+}
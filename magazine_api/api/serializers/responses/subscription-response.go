@@ -0,0 +1,45 @@
+package responses
+
+import "magazine_api/models"
+
+// Plan subscription plan as sent to clients
+type Plan struct {
+	models.Base
+	models.SubscriptionPlanBase
+	Timestamps
+	Audit
+}
+
+// NewPlan response of the plan
+func NewPlan(plan *models.SubscriptionPlan) *Plan {
+	if plan == nil {
+		return nil
+	}
+
+	return &Plan{
+		Base:                 plan.Base,
+		SubscriptionPlanBase: plan.SubscriptionPlanBase,
+		Timestamps:           NewTimestamps(plan.BaseDate),
+		Audit:                NewAudit(plan.BaseCreatedBy),
+	}
+}
+
+// Subscription subscription as sent to clients
+type Subscription struct {
+	models.Base
+	models.SubscriptionBase
+	Timestamps
+}
+
+// NewSubscription response of the subscription
+func NewSubscription(subscription *models.Subscription) *Subscription {
+	if subscription == nil {
+		return nil
+	}
+
+	return &Subscription{
+		Base:             subscription.Base,
+		SubscriptionBase: subscription.SubscriptionBase,
+		Timestamps:       NewTimestamps(subscription.BaseDate),
+	}
+}
@@ -0,0 +1,25 @@
+package responses
+
+import "magazine_api/models"
+
+// Transaction transaction as sent to clients
+type Transaction struct {
+	models.Base
+	models.TransactionBase
+	Timestamps
+	Audit
+}
+
+// NewTransaction response of the transaction
+func NewTransaction(transaction *models.Transaction) *Transaction {
+	if transaction == nil {
+		return nil
+	}
+
+	return &Transaction{
+		Base:            transaction.Base,
+		TransactionBase: transaction.TransactionBase,
+		Timestamps:      NewTimestamps(transaction.BaseDate),
+		Audit:           NewAudit(transaction.BaseCreatedBy),
+	}
+}
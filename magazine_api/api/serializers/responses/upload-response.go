@@ -0,0 +1,64 @@
+package responses
+
+import (
+	"magazine_api/lib"
+	"magazine_api/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PresignedUpload urls the client uploads the file to with PUT, single url or one per part
+type PresignedUpload struct {
+	UploadId      uuid.UUID         `json:"upload_id"`
+	Key           string            `json:"key"`
+	Multipart     bool              `json:"multipart"`
+	URL           string            `json:"url,omitempty"`
+	Headers       map[string]string `json:"headers,omitempty"`
+	PartSize      int64             `json:"part_size,omitempty"`
+	Parts         []PresignedPart   `json:"parts,omitempty"`
+	UploadedParts []lib.UploadPart  `json:"uploaded_parts,omitempty"`
+	URLExpiresOn  time.Time         `json:"url_expires_on"`
+	ExpiresOn     time.Time         `json:"expires_on"`
+}
+
+// PresignedPart url of part of multipart upload, etag header of its response completes the upload
+type PresignedPart struct {
+	PartNumber int32  `json:"part_number"`
+	Size       int64  `json:"size"`
+	URL        string `json:"url"`
+}
+
+// UploadedFile file stored by upload with status of generating its variants
+type UploadedFile struct {
+	FieldName string                `json:"field_name"`
+	FileName  string                `json:"file_name"`
+	Size      int64                 `json:"size"`
+	MimeType  string                `json:"mime_type"`
+	MediaId   uuid.UUID             `json:"media_id"`
+	URL       lib.SignedURL         `json:"url"`
+	Status    *models.MediaStatus   `json:"status"`
+	Variants  []models.MediaVariant `json:"variants"`
+}
+
+// Media uploaded file as sent to clients with status of generating its variants
+type Media struct {
+	models.Base
+	models.MediaBase
+	Timestamps
+	LastError *string `json:"last_error"`
+}
+
+// NewMedia response of the media
+func NewMedia(media *models.Media) *Media {
+	if media == nil {
+		return nil
+	}
+
+	return &Media{
+		Base:       media.Base,
+		MediaBase:  media.MediaBase,
+		Timestamps: NewTimestamps(media.BaseDate),
+		LastError:  media.LastError,
+	}
+}
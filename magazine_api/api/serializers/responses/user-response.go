@@ -18,6 +18,8 @@ type EmployeeSmall struct {
 
 	EmployeeProfileId *uuid.UUID `json:"employee_profile_id"`
 	CompanyId         *string    `json:"company_id"`
+
+	CreatedOn *time.Time `json:"created_on" form:"created_on"`
 }
 
 type EmployeeAll struct {
@@ -41,6 +43,52 @@ type EmployeeAll struct {
 
 	Document []*models.DocumentBase `json:"document"`
 
-	DeletedOn *time.Time `json:"deleted_on" form:"deleted_on"`
-	CreatedOn *time.Time `json:"created_on" form:"created_on"`
+	// DeletedOn is scanned from the employee view but never sent
+	DeletedOn *time.Time `json:"-" form:"deleted_on"`
+}
+
+// User user as sent to clients, the password is never sent
+type User struct {
+	models.Base
+	Name          *string         `json:"name"`
+	Email         *string         `json:"email"`
+	Role          models.UserRole `json:"role"`
+	ContactNumber *string         `json:"contact_number"`
+	Timestamps
+}
+
+// NewUser response of the user
+func NewUser(user *models.User) *User {
+	if user == nil {
+		return nil
+	}
+
+	return &User{
+		Base:          user.Base,
+		Name:          user.Name,
+		Email:         user.Email,
+		Role:          user.Role,
+		ContactNumber: user.ContactNumber,
+		Timestamps:    NewTimestamps(user.BaseDate),
+	}
+}
+
+// UserProfile profile of user as sent to clients
+type UserProfile struct {
+	models.Base
+	models.UserProfileBase
+	Timestamps
+}
+
+// NewUserProfile response of the profile
+func NewUserProfile(profile *models.UserProfile) *UserProfile {
+	if profile == nil {
+		return nil
+	}
+
+	return &UserProfile{
+		Base:            profile.Base,
+		UserProfileBase: profile.UserProfileBase,
+		Timestamps:      NewTimestamps(profile.BaseDate),
+	}
 }
@@ -0,0 +1,143 @@
+package apperrors
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Kind of failure, it decides the status code of the response
+type Kind int
+
+const (
+	// Internal failures not caused by the request
+	Internal Kind = iota
+	NotFound
+	Conflict
+	Validation
+	Forbidden
+	Unauthorized
+	// BadRequest requests which could not be read, e.g. malformed json or path parameters
+	BadRequest
+	// Gone resources which existed but are not available anymore, e.g. expired uploads
+	Gone
+	TooLarge
+	UnsupportedMedia
+	// Unavailable failures of services the request depends on which may pass when retried
+	Unavailable
+)
+
+// FieldError problem with one field of the request
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error failure of the kind with message safe to send to clients, err is the cause
+type Error struct {
+	Kind    Kind
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil && e.Message == "" {
+		return e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// NewNotFound error of resource missing
+func NewNotFound(message string) *Error {
+	return &Error{Kind: NotFound, Message: message}
+}
+
+// NewConflict error of request conflicting with state of the resource
+func NewConflict(message string) *Error {
+	return &Error{Kind: Conflict, Message: message}
+}
+
+// NewValidation error of invalid request with problems of its fields
+func NewValidation(message string, fields ...FieldError) *Error {
+	return &Error{Kind: Validation, Message: message, Fields: fields}
+}
+
+// NewForbidden error of user not allowed to do the request
+func NewForbidden(message string) *Error {
+	return &Error{Kind: Forbidden, Message: message}
+}
+
+// NewUnauthorized error of request without valid authentication
+func NewUnauthorized(message string) *Error {
+	return &Error{Kind: Unauthorized, Message: message}
+}
+
+// NewBadRequest error of request which could not be read
+func NewBadRequest(message string, fields ...FieldError) *Error {
+	return &Error{Kind: BadRequest, Message: message, Fields: fields}
+}
+
+// NewGone error of resource not available anymore
+func NewGone(message string) *Error {
+	return &Error{Kind: Gone, Message: message}
+}
+
+// NewTooLarge error of request or file over its size limit
+func NewTooLarge(message string) *Error {
+	return &Error{Kind: TooLarge, Message: message}
+}
+
+// NewUnsupportedMedia error of file of type which is not accepted
+func NewUnsupportedMedia(message string) *Error {
+	return &Error{Kind: UnsupportedMedia, Message: message}
+}
+
+// Wrap err as error of the kind
+func Wrap(kind Kind, message string, err error) *Error {
+	return &Error{Kind: kind, Message: message, Err: err}
+}
+
+// KindOf kind of err, errors not translated are internal
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(From(err), &e) {
+		return e.Kind
+	}
+	return Internal
+}
+
+// Is whether err is of the kind
+func Is(err error, kind Kind) bool {
+	return KindOf(err) == kind
+}
+
+// Status http status code of the kind
+func Status(kind Kind) int {
+	switch kind {
+	case NotFound:
+		return http.StatusNotFound
+	case Conflict:
+		return http.StatusConflict
+	case Validation:
+		return http.StatusUnprocessableEntity
+	case Forbidden:
+		return http.StatusForbidden
+	case Unauthorized:
+		return http.StatusUnauthorized
+	case BadRequest:
+		return http.StatusBadRequest
+	case Gone:
+		return http.StatusGone
+	case TooLarge:
+		return http.StatusRequestEntityTooLarge
+	case UnsupportedMedia:
+		return http.StatusUnsupportedMediaType
+	case Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
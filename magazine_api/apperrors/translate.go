@@ -0,0 +1,125 @@
+package apperrors
+
+import (
+	"errors"
+	"magazine_api/utils"
+	"strings"
+
+	"github.com/aws/smithy-go"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgNotNullViolation    = "23502"
+	pgCheckViolation      = "23514"
+	pgInvalidText         = "22P02"
+	pgStringTooLong       = "22001"
+)
+
+// aws exception types by kind, exception types are matched by prefix of the name
+var awsKinds = []struct {
+	exception string
+	kind      Kind
+}{
+	{"NotAuthorized", Unauthorized},
+	{"UserNotConfirmed", Unauthorized},
+	{"PasswordResetRequired", Unauthorized},
+	{"AccessDenied", Forbidden},
+	{"UserNotFound", NotFound},
+	{"ResourceNotFound", NotFound},
+	{"NoSuchKey", NotFound},
+	{"NotFound", NotFound},
+	{"UsernameExists", Conflict},
+	{"AliasExists", Conflict},
+	{"ResourceInUse", Conflict},
+	{"InvalidParameter", Validation},
+	{"InvalidPassword", Validation},
+	{"CodeMismatch", Validation},
+	{"ExpiredCode", Validation},
+}
+
+// From translates err into domain error, errors of the database and aws are given their kind and
+// everything else is left as it is
+func From(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return err
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Wrap(NotFound, "resource not found", err)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return fromPostgres(pgErr, err)
+	}
+
+	var awsErr *utils.AWSError
+	if errors.As(err, &awsErr) {
+		return fromAWS(awsErr.ExceptionType, awsErr.Error(), err)
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return fromAWS(apiErr.ErrorCode(), apiErr.ErrorMessage(), err)
+	}
+
+	return err
+}
+
+func fromPostgres(pgErr *pgconn.PgError, err error) error {
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		return &Error{Kind: Conflict, Message: "resource already exists", Fields: columnField(pgErr, "already taken"), Err: err}
+	case pgForeignKeyViolation:
+		if strings.Contains(pgErr.Detail, "still referenced") {
+			return Wrap(Conflict, "resource is still referenced", err)
+		}
+		return &Error{Kind: Validation, Message: "referenced resource does not exist", Fields: columnField(pgErr, "does not exist"), Err: err}
+	case pgNotNullViolation:
+		return &Error{Kind: Validation, Message: "required field is missing", Fields: columnField(pgErr, "is required"), Err: err}
+	case pgCheckViolation:
+		return &Error{Kind: Validation, Message: "field is not valid", Fields: columnField(pgErr, "is not valid"), Err: err}
+	case pgInvalidText:
+		return Wrap(Validation, "field is not valid", err)
+	case pgStringTooLong:
+		return &Error{Kind: Validation, Message: "field is too long", Fields: columnField(pgErr, "is too long"), Err: err}
+	}
+
+	return err
+}
+
+// columnField field of the column of the error, column of constraints over keys is taken from
+// the detail e.g. Key (slug)=(x) already exists.
+func columnField(pgErr *pgconn.PgError, message string) []FieldError {
+	column := pgErr.ColumnName
+	if column == "" {
+		if key := strings.TrimPrefix(pgErr.Detail, "Key ("); key != pgErr.Detail {
+			column = strings.SplitN(key, ")", 2)[0]
+		}
+	}
+	if column == "" {
+		return nil
+	}
+
+	return []FieldError{{Field: column, Message: message}}
+}
+
+func fromAWS(exception, message string, err error) error {
+	for _, aws := range awsKinds {
+		if strings.Contains(exception, aws.exception) {
+			return Wrap(aws.kind, message, err)
+		}
+	}
+
+	return err
+}
@@ -40,6 +40,8 @@ func bootstrap(
 	database infrastructure.Database,
 	rootCmd cmd.RootCommand,
 	migration infrastructure.Migrations,
+	mediaWorker services.MediaWorker,
+	scheduler services.Scheduler,
 ) {
 	lifecycle.Append(
 		fx.Hook{
@@ -51,6 +53,8 @@ func bootstrap(
 					// migration.Migrate()
 					middlewares.Setup()
 					routes.Setup()
+					mediaWorker.Start()
+					scheduler.Start()
 					if env.ServerPort == "" {
 						router.Run()
 					} else {
@@ -60,8 +64,14 @@ func bootstrap(
 				go rootCmd.Execute()
 				return nil
 			},
-			OnStop: func(context.Context) error {
+			OnStop: func(ctx context.Context) error {
 				logger.Info("Stopping Application")
+				if err := mediaWorker.Stop(ctx); err != nil {
+					logger.Error("media-worker-stop-error: ", err)
+				}
+				if err := scheduler.Stop(ctx); err != nil {
+					logger.Error("scheduler-stop-error: ", err)
+				}
 				conn := database.Pool
 				conn.Close()
 				return nil
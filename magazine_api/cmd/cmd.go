@@ -7,4 +7,7 @@ import (
 // Module exports dependency
 var Module = fx.Options(
 	fx.Provide(NewRootCommand),
+	fx.Provide(NewSeedCommand),
+	fx.Provide(NewMediaCommand),
+	fx.Provide(NewScheduleCommand),
 )
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"magazine_api/lib"
+	"magazine_api/services"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+)
+
+// MediaCommand maintains the media library
+type MediaCommand struct {
+	*cobra.Command
+	logger        lib.Logger
+	shutdowner    fx.Shutdowner
+	mediaService  services.MediaService
+	uploadService services.UploadService
+
+	grace     time.Duration
+	untracked bool
+	dryRun    bool
+}
+
+// NewMediaCommand creates new media command
+func NewMediaCommand(
+	logger lib.Logger,
+	shutdowner fx.Shutdowner,
+	mediaService services.MediaService,
+	uploadService services.UploadService,
+) *MediaCommand {
+	return &MediaCommand{
+		Command: &cobra.Command{
+			Use:   "media",
+			Short: "Maintains the media library",
+		},
+		logger:        logger,
+		shutdowner:    shutdowner,
+		mediaService:  mediaService,
+		uploadService: uploadService,
+	}
+}
+
+// Init adds gc sub command
+func (m *MediaCommand) Init() {
+	gc := &cobra.Command{
+		Use:   "gc",
+		Short: "Deletes stored files of media no longer referenced and aborts expired uploads",
+		Run:   m.collectGarbage,
+	}
+	gc.Flags().DurationVar(&m.grace, "grace", 24*time.Hour, "only collect media uploaded longer ago than this")
+	gc.Flags().BoolVar(&m.untracked, "untracked", false, "also delete stored files not recorded in the media library")
+	gc.Flags().BoolVar(&m.dryRun, "dry-run", false, "only list what would be deleted")
+
+	m.AddCommand(gc)
+}
+
+// GetCommand gets the underlying cobra instance
+func (m *MediaCommand) GetCommand() *cobra.Command {
+	return m.Command
+}
+
+// Run runs the command
+func (m *MediaCommand) Run(cmd *cobra.Command, args []string) {
+	cmd.Help()
+	m.shutdowner.Shutdown()
+}
+
+func (m *MediaCommand) collectGarbage(cmd *cobra.Command, args []string) {
+	if !m.dryRun {
+		aborted, err := m.uploadService.AbortExpired(context.Background())
+		m.logger.Infof("%d expired uploads aborted", aborted)
+		if err != nil {
+			m.logger.Error("media-gc-error: ", err.Error())
+			m.shutdowner.Shutdown(fx.ExitCode(1))
+			return
+		}
+	}
+
+	garbage, err := m.mediaService.CollectGarbage(context.Background(), time.Now().Add(-m.grace), m.untracked, m.dryRun)
+	if garbage != nil {
+		for _, id := range garbage.OrphanMedia {
+			m.logger.Info("orphan media: ", id)
+		}
+		for _, key := range garbage.UntrackedObjects {
+			m.logger.Info("untracked object: ", key)
+		}
+		m.logger.Infof("%d orphan media, %d untracked objects, %d objects deleted",
+			len(garbage.OrphanMedia), len(garbage.UntrackedObjects), garbage.DeletedObjects)
+	}
+
+	if err != nil {
+		m.logger.Error("media-gc-error: ", err.Error())
+		m.shutdowner.Shutdown(fx.ExitCode(1))
+		return
+	}
+	m.shutdowner.Shutdown()
+}
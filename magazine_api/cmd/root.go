@@ -39,11 +39,18 @@ type RootCommand struct {
 // NewRootCommand creates new root command
 func NewRootCommand(
 	logger lib.Logger,
+	seedCommand SeedCommand,
+	mediaCommand *MediaCommand,
+	scheduleCommand *ScheduleCommand,
 ) RootCommand {
 	cmd := RootCommand{
-		Command:  rootCmd,
-		logger:   logger,
-		commands: []Command{},
+		Command: rootCmd,
+		logger:  logger,
+		commands: []Command{
+			seedCommand,
+			mediaCommand,
+			scheduleCommand,
+		},
 	}
 	cmd.InitCommands()
 	return cmd
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"magazine_api/lib"
+	"magazine_api/models"
+	"magazine_api/services"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+)
+
+// ScheduleCommand runs scheduled publishing once and lists what is scheduled
+type ScheduleCommand struct {
+	*cobra.Command
+	logger     lib.Logger
+	shutdowner fx.Shutdowner
+	scheduler  services.Scheduler
+
+	within time.Duration
+	dryRun bool
+}
+
+// NewScheduleCommand creates new schedule command
+func NewScheduleCommand(
+	logger lib.Logger,
+	shutdowner fx.Shutdowner,
+	scheduler services.Scheduler,
+) *ScheduleCommand {
+	return &ScheduleCommand{
+		Command: &cobra.Command{
+			Use:   "schedule",
+			Short: "Publishes and unpublishes stories, issues and adverts by schedule",
+		},
+		logger:     logger,
+		shutdowner: shutdowner,
+		scheduler:  scheduler,
+	}
+}
+
+// Init adds run and list sub commands
+func (s *ScheduleCommand) Init() {
+	run := &cobra.Command{
+		Use:   "run",
+		Short: "Publishes and unpublishes everything due now",
+		Run:   s.runDue,
+	}
+	run.Flags().BoolVar(&s.dryRun, "dry-run", false, "only list what would be published and unpublished")
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "Lists publishing and unpublishing scheduled ahead",
+		Run:   s.listUpcoming,
+	}
+	list.Flags().DurationVar(&s.within, "within", 7*24*time.Hour, "how far ahead to list")
+
+	s.AddCommand(run, list)
+}
+
+// GetCommand gets the underlying cobra instance
+func (s *ScheduleCommand) GetCommand() *cobra.Command {
+	return s.Command
+}
+
+// Run runs the command
+func (s *ScheduleCommand) Run(cmd *cobra.Command, args []string) {
+	cmd.Help()
+	s.shutdowner.Shutdown()
+}
+
+func (s *ScheduleCommand) runDue(cmd *cobra.Command, args []string) {
+	actions, err := s.scheduler.RunDue(s.dryRun)
+	if err != nil {
+		s.logger.Error("schedule-run-error: ", err.Error())
+		s.shutdowner.Shutdown(fx.ExitCode(1))
+		return
+	}
+
+	s.logActions(actions)
+	s.logger.Infof("%d scheduled actions due", len(actions))
+	s.shutdowner.Shutdown()
+}
+
+func (s *ScheduleCommand) listUpcoming(cmd *cobra.Command, args []string) {
+	actions, err := s.scheduler.ListUpcoming(s.within)
+	if err != nil {
+		s.logger.Error("schedule-list-error: ", err.Error())
+		s.shutdowner.Shutdown(fx.ExitCode(1))
+		return
+	}
+
+	s.logActions(actions)
+	s.logger.Infof("%d scheduled actions within %s", len(actions), s.within)
+	s.shutdowner.Shutdown()
+}
+
+func (s *ScheduleCommand) logActions(actions []*models.ScheduledAction) {
+	for _, action := range actions {
+		title := ""
+		if action.Title != nil {
+			title = *action.Title
+		}
+		s.logger.Infof("%s %s %s %s %q", action.At.Format(time.RFC3339), action.Action, action.Entity, action.EntityId, title)
+	}
+}
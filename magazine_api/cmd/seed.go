@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"magazine_api/lib"
+	"magazine_api/models"
+	"magazine_api/models/magazine"
+	"magazine_api/orchestrators"
+	"magazine_api/services"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+)
+
+const demoMagazineCode = "DEMO-MAG"
+
+// SeedCommand seeds initial data to the application
+type SeedCommand struct {
+	*cobra.Command
+	logger             lib.Logger
+	env                lib.Env
+	shutdowner         fx.Shutdowner
+	adminOrchestrator  orchestrators.AdminUserOrchestrator
+	magazineService    services.MagazineService
+	issueService       services.MagazineIssueService
+	storyService       services.StoryService
+	transactionService services.TransactionService
+}
+
+// NewSeedCommand creates new seed command
+func NewSeedCommand(
+	logger lib.Logger,
+	env lib.Env,
+	shutdowner fx.Shutdowner,
+	adminOrchestrator orchestrators.AdminUserOrchestrator,
+	magazineService services.MagazineService,
+	issueService services.MagazineIssueService,
+	storyService services.StoryService,
+	transactionService services.TransactionService,
+) SeedCommand {
+	return SeedCommand{
+		Command: &cobra.Command{
+			Use:   "seed",
+			Short: "Seeds initial data",
+		},
+		logger:             logger,
+		env:                env,
+		shutdowner:         shutdowner,
+		adminOrchestrator:  adminOrchestrator,
+		magazineService:    magazineService,
+		issueService:       issueService,
+		storyService:       storyService,
+		transactionService: transactionService,
+	}
+}
+
+// Init adds admin and demo sub commands
+func (s SeedCommand) Init() {
+	s.AddCommand(&cobra.Command{
+		Use:   "admin",
+		Short: "Creates admin user from ADMIN_EMAIL and ADMIN_PASSWORD",
+		Run:   s.withShutdown(s.seedAdmin),
+	})
+
+	s.AddCommand(&cobra.Command{
+		Use:   "demo",
+		Short: "Loads sample magazines, issues, stories and transactions",
+		Run:   s.withShutdown(s.seedDemo),
+	})
+}
+
+// GetCommand gets the underlying cobra instance
+func (s SeedCommand) GetCommand() *cobra.Command {
+	return s.Command
+}
+
+// Run runs the command
+func (s SeedCommand) Run(cmd *cobra.Command, args []string) {
+	cmd.Help()
+	s.shutdowner.Shutdown()
+}
+
+func (s SeedCommand) withShutdown(run func() error) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if err := run(); err != nil {
+			s.logger.Error("seed-error: ", err.Error())
+			s.shutdowner.Shutdown(fx.ExitCode(1))
+			return
+		}
+		s.shutdowner.Shutdown()
+	}
+}
+
+func (s SeedCommand) seedAdmin() error {
+	user, err := s.adminOrchestrator.BootstrapAdmin(s.env.AdminEmail, s.env.AdminPassword)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("admin user ready: ", user.ID)
+	return nil
+}
+
+func (s SeedCommand) seedDemo() error {
+	existing, _, err := s.magazineService.ListMagazinesByType(demoMagazineCode, models.Pagination{Limit: 1}, models.ListQuery{})
+	if err != nil {
+		return err
+	}
+
+	if len(existing) > 0 {
+		s.logger.Info("demo data already present, skipping")
+		return nil
+	}
+
+	for _, mag := range demoMagazines() {
+		if _, err := s.magazineService.CreateMagazine(mag); err != nil {
+			return err
+		}
+	}
+
+	for _, issue := range demoIssues() {
+		if _, err := s.issueService.CreateMagazineIssue(issue); err != nil {
+			return err
+		}
+	}
+
+	for _, story := range demoStories() {
+		if _, err := s.storyService.CreateStory(story); err != nil {
+			return err
+		}
+	}
+
+	for _, transaction := range demoTransactions() {
+		if _, err := s.transactionService.CreateTransaction(transaction); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Info("demo data seeded")
+	return nil
+}
+
+func demoMagazines() []*magazine.Magazine {
+	return []*magazine.Magazine{
+		{MagazineBase: magazine.MagazineBase{
+			MagazineCode: str(demoMagazineCode),
+			IssueCode:    str("DEMO-ISSUE-1"),
+			Placement:    str("cover"),
+			Remarks:      str("demo"),
+		}},
+		{MagazineBase: magazine.MagazineBase{
+			MagazineCode: str(demoMagazineCode),
+			IssueCode:    str("DEMO-ISSUE-2"),
+			Placement:    str("cover"),
+			Remarks:      str("demo"),
+		}},
+	}
+}
+
+func demoIssues() []*magazine.MagazineIssue {
+	return []*magazine.MagazineIssue{
+		{IssuseBase: magazine.IssuseBase{
+			IssueCode:   str("DEMO-ISSUE-1"),
+			ContentCode: str("DEMO-CONTENT-1"),
+			AdvertCode:  str("DEMO-AD-1"),
+			Remarks:     str("demo"),
+		}},
+		{IssuseBase: magazine.IssuseBase{
+			IssueCode:   str("DEMO-ISSUE-2"),
+			ContentCode: str("DEMO-CONTENT-2"),
+			AdvertCode:  str("DEMO-AD-2"),
+			Remarks:     str("demo"),
+		}},
+	}
+}
+
+func demoStories() []*magazine.Story {
+	return []*magazine.Story{
+		{StoryBase: magazine.StoryBase{
+			StoryCode:    str("DEMO-STORY-1"),
+			StoryTitle:   str("Trekking the Annapurna Circuit"),
+			StoryType:    str("feature"),
+			StoryContent: str("A journey around the Annapurna massif through villages, passes and valleys."),
+			Remarks:      str("demo"),
+		}},
+		{StoryBase: magazine.StoryBase{
+			StoryCode:    str("DEMO-STORY-2"),
+			StoryTitle:   str("Kathmandu Street Food"),
+			StoryType:    str("food"),
+			StoryContent: str("From momo to sel roti, a guide to the flavours of the valley."),
+			Remarks:      str("demo"),
+		}},
+	}
+}
+
+func demoTransactions() []*models.Transaction {
+	month := models.Baisakh
+	medium := models.Bank
+	return []*models.Transaction{
+		{TransactionBase: models.TransactionBase{
+			Title:           str("Demo advert payment"),
+			TransactionCost: amount(15000),
+			CreditAmount:    amount(15000),
+			PaymentMonth:    &month,
+			PaidMedium:      &medium,
+			Remarks:         str("demo"),
+		}},
+		{TransactionBase: models.TransactionBase{
+			Title:           str("Demo printing cost"),
+			TransactionCost: amount(8000),
+			DebitAmount:     amount(8000),
+			PaymentMonth:    &month,
+			PaidMedium:      &medium,
+			Remarks:         str("demo"),
+		}},
+	}
+}
+
+func str(s string) *string {
+	return &s
+}
+
+func amount(a float32) *float32 {
+	return &a
+}
@@ -0,0 +1,294 @@
+package component
+
+import (
+	"context"
+	"fmt"
+	"magazine_api/apperrors"
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"time"
+
+	"github.com/elgris/sqrl"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrSlotConflict is returned when booking does not fit in the page of the issue
+	ErrSlotConflict = apperrors.NewConflict("slot already booked")
+	// ErrBookingInvoiced is returned when invoiced booking is cancelled or invoiced again
+	ErrBookingInvoiced = apperrors.NewConflict("booking already invoiced")
+	// ErrInvalidInvoice is returned when invoiced bookings are empty or not booked by the advertiser
+	ErrInvalidInvoice = apperrors.NewValidation("invoice needs booked adverts of the advertiser")
+)
+
+// tables of advertising, creators are the users who created rows
+var (
+	advertisersTable = Table{Name: "advertisers", Creator: "created_by"}
+	rateCardsTable   = Table{Name: "rate_cards", Creator: "created_by"}
+	bookingsTable    = Table{Name: "ad_bookings", Creator: "created_by"}
+	invoicesTable    = Table{Name: "ad_invoices", Creator: "created_by"}
+)
+
+// AdBookingComponent database structure for advertisers, rate cards, bookings and invoices
+type AdBookingComponent struct {
+	infrastructure.Database
+	advertisers Repository[models.Advertiser]
+	rateCards   Repository[models.RateCard]
+	bookings    Repository[models.AdBooking]
+	invoices    Repository[models.AdInvoice]
+}
+
+// NewAdBookingComponent creates a new ad booking component
+func NewAdBookingComponent(db infrastructure.Database, logger lib.Logger) AdBookingComponent {
+	return AdBookingComponent{
+		Database:    db,
+		advertisers: NewRepository[models.Advertiser](db, advertisersTable),
+		rateCards:   NewRepository[models.RateCard](db, rateCardsTable),
+		bookings:    NewRepository[models.AdBooking](db, bookingsTable),
+		invoices:    NewRepository[models.AdInvoice](db, invoicesTable),
+	}
+}
+
+// Creates advertiser account in our database
+func (a AdBookingComponent) CreateAdvertiser(advertiser models.Advertiser) error {
+	return a.advertisers.Insert(map[string]interface{}{
+		"id": advertiser.ID, "user_id": advertiser.UserId, "company_name": advertiser.CompanyName,
+		"contact_name": advertiser.ContactName, "email": advertiser.Email, "contact_number": advertiser.ContactNumber,
+		"billing_address": advertiser.BillingAddress, "pan_number": advertiser.PanNumber, "remarks": advertiser.Remarks,
+		"created_on": advertiser.CreatedOn, "created_by": advertiser.CreatedBy, "creator_name": advertiser.CreatorName,
+	})
+}
+
+// Lists page of advertiser accounts from our database
+func (a AdBookingComponent) ListAdvertisers(page models.Pagination, list models.ListQuery) ([]*models.Advertiser, models.PageInfo, error) {
+	return a.advertisers.Page(page, list, byCreatedOn)
+}
+
+// Get One advertiser account from our database based on id
+func (a AdBookingComponent) GetAdvertiserFromID(id uuid.UUID) (*models.Advertiser, error) {
+	return a.advertisers.GetFromID(id)
+}
+
+// Get One advertiser account from our database based on linked user id
+func (a AdBookingComponent) GetAdvertiserFromUserID(userID uuid.UUID) (*models.Advertiser, error) {
+	return a.advertisers.Get(sqrl.Eq{"user_id": userID})
+}
+
+// PatchAdvertiser updates the advertiser account in our database
+func (a AdBookingComponent) PatchAdvertiser(id uuid.UUID, patch *map[string]interface{}) error {
+	return a.advertisers.Patch(id, *patch)
+}
+
+// DeleteAdvertiser soft deletes the advertiser account in our database
+func (a AdBookingComponent) DeleteAdvertiser(id uuid.UUID) error {
+	return a.advertisers.Delete(id)
+}
+
+// Creates rate card in our database
+func (a AdBookingComponent) CreateRateCard(card models.RateCard) error {
+	return a.rateCards.Insert(map[string]interface{}{
+		"id": card.ID, "size": card.Size, "placement": card.Placement, "price": card.Price, "remarks": card.Remarks,
+		"created_on": card.CreatedOn, "created_by": card.CreatedBy, "creator_name": card.CreatorName,
+	})
+}
+
+// Lists page of rate cards from our database
+func (a AdBookingComponent) ListRateCards(page models.Pagination, list models.ListQuery) ([]*models.RateCard, models.PageInfo, error) {
+	return a.rateCards.Page(page, list, byCreatedOn)
+}
+
+// Get rate card of the size and placement from our database
+func (a AdBookingComponent) GetRateCard(size models.AdSize, placement models.AdPlacement) (*models.RateCard, error) {
+	return a.rateCards.Get(sqrl.Eq{"size": size, "placement": placement})
+}
+
+// PatchRateCard updates the rate card in our database
+func (a AdBookingComponent) PatchRateCard(id uuid.UUID, patch *map[string]interface{}) error {
+	return a.rateCards.Patch(id, *patch)
+}
+
+// DeleteRateCard soft deletes the rate card in our database
+func (a AdBookingComponent) DeleteRateCard(id uuid.UUID) error {
+	return a.rateCards.Delete(id)
+}
+
+// CreateBooking creates booking in our database if it fits in the page of the issue,
+// bookings of the same page are serialised with transaction scoped advisory lock
+func (a AdBookingComponent) CreateBooking(booking models.AdBooking) error {
+	ctx := context.Background()
+	tx, err := a.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	slot := fmt.Sprintf("ad_booking:%s:%d:%d", booking.IssueId, *booking.Placement, *booking.Page)
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", slot); err != nil {
+		return err
+	}
+
+	var sizes []models.AdSize
+	sql, args, err := sqrl.Select("size").From("ad_bookings").
+		Where(sqrl.Eq{
+			"issue_id":   booking.IssueId,
+			"placement":  booking.Placement,
+			"page":       booking.Page,
+			"status":     models.BookingBooked,
+			"deleted_on": nil,
+		}).
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	if err := pgxscan.Select(ctx, tx, &sizes, sql, args[:]...); err != nil {
+		return err
+	}
+
+	used := booking.Size.Quarters()
+	for _, size := range sizes {
+		used += size.Quarters()
+	}
+	if used > models.AdSizeFullPage.Quarters() {
+		return ErrSlotConflict
+	}
+
+	err = NewRepository[models.AdBooking](tx, bookingsTable).Insert(map[string]interface{}{
+		"id": booking.ID, "advertiser_id": booking.AdvertiserId, "advert_id": booking.AdvertId,
+		"issue_id": booking.IssueId, "placement": booking.Placement, "size": booking.Size, "page": booking.Page,
+		"price": booking.Price, "status": booking.Status, "remarks": booking.Remarks, "created_on": booking.CreatedOn,
+		"created_by": booking.CreatedBy, "creator_name": booking.CreatorName,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Get One booking from our database based on id
+func (a AdBookingComponent) GetBookingFromID(id uuid.UUID) (*models.AdBooking, error) {
+	return a.bookings.GetFromID(id)
+}
+
+// Lists page of bookings into the issue from our database
+func (a AdBookingComponent) ListBookingsFromIssueID(issueID uuid.UUID, page models.Pagination, list models.ListQuery) ([]*models.AdBooking, models.PageInfo, error) {
+	return a.bookings.Page(page, list, byCreatedOn, sqrl.Eq{"issue_id": issueID})
+}
+
+// Lists page of bookings of the advertiser from our database
+func (a AdBookingComponent) ListBookingsFromAdvertiserID(advertiserID uuid.UUID, page models.Pagination, list models.ListQuery) ([]*models.AdBooking, models.PageInfo, error) {
+	return a.bookings.Page(page, list, byCreatedOn, sqrl.Eq{"advertiser_id": advertiserID})
+}
+
+// PatchBooking updates the booking in our database
+func (a AdBookingComponent) PatchBooking(id uuid.UUID, patch *map[string]interface{}) error {
+	return a.bookings.Patch(id, *patch)
+}
+
+// CreateInvoice invoices the bookings of the advertiser in one transaction. Bookings are locked and
+// have to be booked by the advertiser and not invoiced, the receivable transaction and the invoice
+// are inserted for their total and the bookings are linked to the invoice
+func (a AdBookingComponent) CreateInvoice(invoice *models.AdInvoice, transaction *models.Transaction, bookingIDs []uuid.UUID) error {
+	ctx := context.Background()
+	tx, err := a.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var bookings []*models.AdBooking
+	sql, args, err := sqrl.Select("*").From("ad_bookings").
+		Where(sqrl.Eq{"id": bookingIDs, "deleted_on": nil}).
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	if err := pgxscan.Select(ctx, tx, &bookings, sql, args[:]...); err != nil {
+		return err
+	}
+
+	if len(bookings) != len(bookingIDs) {
+		return ErrInvalidInvoice
+	}
+
+	var total float32
+	for _, booking := range bookings {
+		if booking.AdvertiserId == nil || invoice.AdvertiserId == nil || *booking.AdvertiserId != *invoice.AdvertiserId ||
+			booking.Status == nil || *booking.Status != models.BookingBooked {
+			return ErrInvalidInvoice
+		}
+		if booking.InvoiceId != nil {
+			return ErrBookingInvoiced
+		}
+		if booking.Price != nil {
+			total += *booking.Price
+		}
+	}
+	invoice.Amount = &total
+	transaction.TransactionCost = &total
+	transaction.CreditAmount = &total
+
+	sql, args, err = insertTransaction(*transaction)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, sql, args[:]...); err != nil {
+		return err
+	}
+
+	err = NewRepository[models.AdInvoice](tx, invoicesTable).Insert(map[string]interface{}{
+		"id": invoice.ID, "invoice_number": invoice.InvoiceNumber, "advertiser_id": invoice.AdvertiserId,
+		"amount": invoice.Amount, "due_date": invoice.DueDate, "paid_status": invoice.PaidStatus,
+		"transaction_id": invoice.TransactionId, "remarks": invoice.Remarks, "created_on": invoice.CreatedOn,
+		"created_by": invoice.CreatedBy, "creator_name": invoice.CreatorName,
+	})
+	if err != nil {
+		return err
+	}
+
+	sql, args, err = sqrl.Update("ad_bookings").
+		SetMap(gin.H{"invoice_id": invoice.ID, "updated_on": time.Now()}).
+		Where(sqrl.Eq{"id": bookingIDs}).
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, sql, args[:]...); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Get One invoice from our database based on id
+func (a AdBookingComponent) GetInvoiceFromID(id uuid.UUID) (*models.AdInvoice, error) {
+	return a.invoices.GetFromID(id)
+}
+
+// Lists page of invoices from our database newest first, filtered by paid status when given
+func (a AdBookingComponent) ListInvoices(status *models.PaidStatus, page models.Pagination, list models.ListQuery) ([]*models.AdInvoice, models.PageInfo, error) {
+	var where []sqrl.Sqlizer
+	if status != nil {
+		where = append(where, sqrl.Eq{"paid_status": *status})
+	}
+
+	return a.invoices.Page(page, list, byCreatedOnLatest, where...)
+}
+
+// Lists page of invoices of the advertiser from our database newest first
+func (a AdBookingComponent) ListInvoicesFromAdvertiserID(advertiserID uuid.UUID, page models.Pagination, list models.ListQuery) ([]*models.AdInvoice, models.PageInfo, error) {
+	return a.invoices.Page(page, list, byCreatedOnLatest, sqrl.Eq{"advertiser_id": advertiserID})
+}
+
+// PatchInvoice updates the invoice in our database
+func (a AdBookingComponent) PatchInvoice(id uuid.UUID, patch *map[string]interface{}) error {
+	return a.invoices.Patch(id, *patch)
+}
@@ -1,175 +1,70 @@
 package component
 
 import (
-	"context"
-	"errors"
 	"magazine_api/infrastructure"
 	"magazine_api/lib"
+	"magazine_api/models"
 	"magazine_api/models/magazine"
-	"time"
 
 	"github.com/elgris/sqrl"
-	"github.com/georgysavva/scany/pgxscan"
-	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 //Advertisement Management Component structure
 type IAdMgmtComp struct {
-	infrastructure.Database
+	Repository[magazine.Advert]
 }
 
 //NewIAdMgmtComp create new Advertisement Management component
 func NewAdMgmtComp(db infrastructure.Database, logger lib.Logger) IAdMgmtComp {
-	return IAdMgmtComp{db}
+	return IAdMgmtComp{NewRepository[magazine.Advert](db, Table{Name: "adverts", Creator: "created_by"})}
 }
 
 //Create Advert in our Database
 func (i IAdMgmtComp) CreateAd(ad magazine.Advert) error {
-	sql, args, err := sqrl.Insert("adverts").
-		Columns("id", "advert_code", "advert_title", "advert_content", "advert_type", "url", "created_on", "created_by", "creator_name").
-		Values(ad.ID, ad.AdvertCode, ad.AdvertTitle, ad.AdvertContent, ad.AdvertType, ad.AdvertURL, ad.CreatedOn, ad.CreatedBy, ad.CreatorName).
-		PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return err
-	}
-
-	exec, err := i.Exec(context.Background(), sql, args[:]...)
-	if err != nil {
-		return err
-	}
-
-	if exec.RowsAffected() != 1 {
-		return errors.New("not inserted")
-	}
-
-	return nil
+	return i.Insert(map[string]interface{}{
+		"id": ad.ID, "advert_code": ad.AdvertCode, "advert_title": ad.AdvertTitle, "advert_content": ad.AdvertContent,
+		"advert_type": ad.AdvertType, "url": ad.AdvertURL, "advertiser_id": ad.AdvertiserId, "size": ad.Size,
+		"created_on": ad.CreatedOn, "created_by": ad.CreatedBy, "creator_name": ad.CreatorName,
+		"publish_at": ad.PublishAt, "unpublish_at": ad.UnpublishAt, "published_on": ad.PublishedOn,
+	})
 }
 
 //Lists deleted adverts from database
 func (i IAdMgmtComp) ListDeletedAd() ([]*magazine.Advert, error) {
-	var ads []*magazine.Advert
-	sql, args, err := sqrl.Select("*").From("adverts").Where(sqrl.NotEq{"deleted_on": nil}).PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), i, &ads, sql, args[:]...); err != nil {
-		return nil, err
-	}
-
-	return ads, nil
-
+	return i.ListDeleted()
 }
 
 //Lists adverts from database
-func (i IAdMgmtComp) ListAds() ([]*magazine.Advert, error) {
-	var ads []*magazine.Advert
-	sql, args, err := sqrl.Select("*").From("adverts").Where(sqrl.Eq{"deleted_on": nil}).PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), i, &ads, sql, args[:]...); err != nil {
-		return nil, err
-	}
-
-	return ads, nil
-
+func (i IAdMgmtComp) ListAds(page models.Pagination, list models.ListQuery) ([]*magazine.Advert, models.PageInfo, error) {
+	return i.Page(page, list, byCreatedOn)
 }
 
 // Get Adverts from our database based on profile id
-func (a IAdMgmtComp) GetAdFromCreatorId(id uuid.UUID) ([]*magazine.Advert, error) {
-	var ad []*magazine.Advert
-	sql, args, err := sqrl.Select("*").From("adverts").Where(sqrl.Eq{"creator_id": id}).
-		PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), a, &ad, sql, args[:]...); err != nil {
-		return nil, err
-	}
-
-	return ad, nil
+func (a IAdMgmtComp) GetAdFromCreatorId(id uuid.UUID, page models.Pagination, list models.ListQuery) ([]*magazine.Advert, models.PageInfo, error) {
+	return a.ListFromCreator(id, page, list)
 }
 
 //Gets single ad from datbase using ID
 func (i IAdMgmtComp) GetAdFromID(id uuid.UUID) (*magazine.Advert, error) {
-	var ad magazine.Advert
-
-	sql, args, err := sqrl.Select("*").From("adverts").Where(sqrl.Eq{"id": id}).PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Get(context.Background(), i, &ad, sql, args[:]...); err != nil {
-		return nil, err
-	}
-
-	return &ad, nil
+	return i.GetFromID(id)
 }
 
 //Update advert in  our database
 func (i IAdMgmtComp) PatchAd(id uuid.UUID, patch *map[string]interface{}) error {
-
-	sql, args, err := sqrl.Update("adverts").SetMap(*patch).Where(sqrl.Eq{"id": id}).PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return nil
-	}
-
-	exec, err := i.Exec(context.Background(), sql, args[:]...)
-	if err != nil {
-		return err
-	}
-
-	if exec.RowsAffected() != 1 {
-		return errors.New("not updated")
-	}
-
-	return nil
+	return i.Patch(id, *patch)
 }
 
 // Delete Advert in our database
 func (i IAdMgmtComp) DeleteAd(id uuid.UUID) error {
-
-	sql, arg, err := sqrl.Update("adverts").SetMap(gin.H{"deleted_on": time.Now()}).
-		Where(sqrl.Eq{"id": id}).PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return err
-	}
-
-	exec, err := i.Exec(context.Background(), sql, arg[:]...)
-	if err != nil {
-		return err
-	}
-
-	count := exec.RowsAffected()
-	if count != 1 {
-		return err
-	}
-
-	return nil
+	return i.Delete(id)
 }
 
 func (i IAdMgmtComp) PermanentDeleteAd(id uuid.UUID) error {
-	sql, arg, err := sqrl.Delete("adverts").
-		Where(sqrl.Eq{"id": id}).
-		PlaceholderFormat(sqrl.Dollar).
-		ToSql()
-	if err != nil {
-		return err
-	}
-
-	exec, err := i.Exec(context.Background(), sql, arg[:]...)
-	if err != nil {
-		return err
-	}
-
-	count := exec.RowsAffected()
-	if count != 1 {
-		return err
-	}
+	return i.PermanentDelete(id)
+}
 
-	return nil
+// ListPublishedAds lists published adverts newest first
+func (i IAdMgmtComp) ListPublishedAds(page models.Pagination, list models.ListQuery) ([]*magazine.Advert, models.PageInfo, error) {
+	return i.Page(page, list, byPublishedOn, sqrl.NotEq{"published_on": nil})
 }
@@ -0,0 +1,209 @@
+package component
+
+import (
+	"context"
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+	"magazine_api/models/magazine"
+	"time"
+
+	"github.com/elgris/sqrl"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// GalleryComponent database structure for photographs in galleries of stories
+type GalleryComponent struct {
+	infrastructure.Database
+}
+
+// NewGalleryComponent creates a new gallery component
+func NewGalleryComponent(db infrastructure.Database, logger lib.Logger) GalleryComponent {
+	return GalleryComponent{db}
+}
+
+// ListGalleries lists photographs in galleries of the stories ordered by position,
+// title and url of the photograph and name of credited user are joined
+func (g GalleryComponent) ListGalleries(storyIDs []uuid.UUID) ([]*magazine.StoryPhoto, error) {
+	var photos []*magazine.StoryPhoto
+	if len(storyIDs) == 0 {
+		return photos, nil
+	}
+
+	sql, args, err := sqrl.Select("sp.*", "p.photo_title", "p.url", "u.name AS credit_name").
+		From("story_photographs sp").
+		Join("photographs p ON p.id = sp.photograph_id").
+		LeftJoin("users u ON u.id = sp.credit_user_id").
+		Where(sqrl.Eq{"sp.story_id": storyIDs, "p.deleted_on": nil}).
+		OrderBy("sp.story_id", "sp.position").
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pgxscan.Select(context.Background(), g, &photos, sql, args[:]...); err != nil {
+		return nil, err
+	}
+
+	return photos, nil
+}
+
+// AddPhoto adds photograph to gallery of the story at its position, photographs from the
+// position on move one down. Photograph without position is added at the end
+func (g GalleryComponent) AddPhoto(photo magazine.StoryPhoto) error {
+	ctx := context.Background()
+	tx, err := g.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if photo.IsLead != nil && *photo.IsLead {
+		if err := g.unsetLead(ctx, tx, *photo.StoryId); err != nil {
+			return err
+		}
+	}
+
+	if photo.Position == nil {
+		var position int
+		err := tx.QueryRow(ctx, `SELECT COALESCE(MAX(position), 0) + 1 FROM story_photographs WHERE story_id = $1`,
+			photo.StoryId).Scan(&position)
+		if err != nil {
+			return err
+		}
+		photo.Position = &position
+	} else {
+		_, err := tx.Exec(ctx, `UPDATE story_photographs SET position = position + 1
+			WHERE story_id = $1 AND position >= $2`, photo.StoryId, photo.Position)
+		if err != nil {
+			return err
+		}
+	}
+
+	sql, args, err := sqrl.Insert("story_photographs").
+		Columns("story_id", "photograph_id", "position", "caption", "credit_user_id", "credit_text", "crop_hints",
+			"is_lead", "created_on", "updated_on").
+		Values(photo.StoryId, photo.PhotographId, photo.Position, photo.Caption, photo.CreditUserId, photo.CreditText,
+			photo.CropHints, photo.IsLead != nil && *photo.IsLead, photo.CreatedOn, photo.UpdatedOn).
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, sql, args[:]...); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// PatchPhoto updates photograph in gallery of the story, other photographs stop
+// being lead when it becomes lead
+func (g GalleryComponent) PatchPhoto(storyID, photographID uuid.UUID, patch gin.H) error {
+	ctx := context.Background()
+	tx, err := g.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if lead, ok := patch["is_lead"].(bool); ok && lead {
+		if err := g.unsetLead(ctx, tx, storyID); err != nil {
+			return err
+		}
+	}
+
+	patch["updated_on"] = time.Now()
+	sql, args, err := sqrl.Update("story_photographs").SetMap(patch).
+		Where(sqrl.Eq{"story_id": storyID, "photograph_id": photographID}).
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	exec, err := tx.Exec(ctx, sql, args[:]...)
+	if err != nil {
+		return err
+	}
+	if exec.RowsAffected() != 1 {
+		return ErrNotFound
+	}
+
+	return tx.Commit(ctx)
+}
+
+// RemovePhoto removes photograph from gallery of the story, photographs after it move one up
+func (g GalleryComponent) RemovePhoto(storyID, photographID uuid.UUID) error {
+	ctx := context.Background()
+	tx, err := g.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var position int
+	err = tx.QueryRow(ctx, `DELETE FROM story_photographs WHERE story_id = $1 AND photograph_id = $2
+		RETURNING position`, storyID, photographID).Scan(&position)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE story_photographs SET position = position - 1
+		WHERE story_id = $1 AND position > $2`, storyID, position)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ReorderGallery sets positions of photographs in gallery of the story in the given order
+func (g GalleryComponent) ReorderGallery(storyID uuid.UUID, photographIDs []uuid.UUID) error {
+	ctx := context.Background()
+	tx, err := g.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for i, photographID := range photographIDs {
+		_, err := tx.Exec(ctx, `UPDATE story_photographs SET position = $1, updated_on = NOW()
+			WHERE story_id = $2 AND photograph_id = $3`, i+1, storyID, photographID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// PhotographExists checks that photograph of the id is not deleted
+func (g GalleryComponent) PhotographExists(id uuid.UUID) (bool, error) {
+	return g.exists("photographs", id)
+}
+
+// UserExists checks that user of the id is not deleted
+func (g GalleryComponent) UserExists(id uuid.UUID) (bool, error) {
+	return g.exists("users", id)
+}
+
+func (g GalleryComponent) exists(table string, id uuid.UUID) (bool, error) {
+	sql, args, err := sqrl.Select("1").From(table).Where(sqrl.Eq{"id": id, "deleted_on": nil}).
+		Prefix("SELECT EXISTS (").Suffix(")").
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	var exists bool
+	err = g.QueryRow(context.Background(), sql, args[:]...).Scan(&exists)
+	return exists, err
+}
+
+func (g GalleryComponent) unsetLead(ctx context.Context, tx pgx.Tx, storyID uuid.UUID) error {
+	_, err := tx.Exec(ctx, `UPDATE story_photographs SET is_lead = FALSE, updated_on = NOW()
+		WHERE story_id = $1 AND is_lead`, storyID)
+	return err
+}
@@ -1,173 +1,89 @@
 package component
 
 import (
-	"context"
-	"errors"
 	"magazine_api/infrastructure"
 	"magazine_api/lib"
+	"magazine_api/models"
 	"magazine_api/models/magazine"
-	"time"
 
 	"github.com/elgris/sqrl"
-	"github.com/georgysavva/scany/pgxscan"
-	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 //Issue management component structure
 type IIssueMgmtComp struct {
-	infrastructure.Database
+	Repository[magazine.MagazineIssue]
 }
 
 //New Issue Management Component creates a new Issue component
 func NewIssueComp(db infrastructure.Database, logger lib.Logger) IIssueMgmtComp {
-	return IIssueMgmtComp{db}
+	return IIssueMgmtComp{NewRepository[magazine.MagazineIssue](db, Table{Name: "magazine_issues", Creator: "created_by"})}
 }
 
 //Creates Issue in our database
 func (a IIssueMgmtComp) CreateIssue(issue magazine.MagazineIssue) error {
-	sql, args, err := sqrl.Insert("magazine_issues").
-		Columns("id", "issue_code", "content_code", "advert_code", "remarks").
-		Values(issue.ID, issue.IssueCode, issue.ContentCode, issue.AdvertCode, issue.Remarks).
-		PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return err
-	}
-
-	exec, err := a.Exec(context.Background(), sql, args[:]...)
-	if err != nil {
-		return err
-	}
-
-	if exec.RowsAffected() != 1 {
-		return errors.New("not inserted")
-	}
-
-	return nil
+	return a.Insert(map[string]interface{}{
+		"id": issue.ID, "created_by": issue.CreatedBy, "issue_code": issue.IssueCode, "content_code": issue.ContentCode, "advert_code": issue.AdvertCode,
+		"pdf_url": issue.PdfURL, "epub_url": issue.EpubURL, "usage_scope": issue.UsageScope, "territory": issue.Territory,
+		"remarks": issue.Remarks, "publish_at": issue.PublishAt, "unpublish_at": issue.UnpublishAt, "published_on": issue.PublishedOn,
+		"slug": issue.Slug, "meta_title": issue.MetaTitle, "meta_description": issue.MetaDescription,
+		"canonical_url": issue.CanonicalURL, "og_image": issue.OgImage,
+	})
 }
 
 // Lists all the Issues from our database
-func (s IIssueMgmtComp) ListIssues() ([]*magazine.MagazineIssue, error) {
-	var stores []*magazine.MagazineIssue
-	sql, args, err := sqrl.Select("*").From("magazine_issues").Where(sqrl.Eq{"deleted_on": nil}).PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), s, &stores, sql, args[:]...); err != nil {
-		return nil, err
-	}
-
-	return stores, nil
+func (s IIssueMgmtComp) ListIssues(page models.Pagination, list models.ListQuery) ([]*magazine.MagazineIssue, models.PageInfo, error) {
+	return s.Page(page, list, byCreatedOn)
 }
 
 //Get One Issue from our database based on id
 func (a IIssueMgmtComp) GetIssueFromID(id uuid.UUID) (*magazine.MagazineIssue, error) {
-	var Issue magazine.MagazineIssue
-
-	sql, args, err := sqrl.Select("*").From("magazine_issues").Where(sqrl.Eq{"id": id}).PlaceholderFormat(sqrl.Dollar).ToSql()
-
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Get(context.Background(), a, &Issue, sql, args[:]...); err != nil {
-		return nil, err
-	}
-
-	return &Issue, nil
+	return a.GetFromID(id)
 }
 
 // Get Issues from our database based on profile id
-func (a IIssueMgmtComp) GetIssueFromCreatorId(id uuid.UUID) ([]*magazine.MagazineIssue, error) {
-	var issue []*magazine.MagazineIssue
-	sql, args, err := sqrl.Select("*").From("magazine_issues").Where(sqrl.Eq{"creator_id": id}).
-		PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), a, &issue, sql, args[:]...); err != nil {
-		return nil, err
-	}
-
-	return issue, nil
+func (a IIssueMgmtComp) GetIssueFromCreatorId(id uuid.UUID, page models.Pagination, list models.ListQuery) ([]*magazine.MagazineIssue, models.PageInfo, error) {
+	return a.ListFromCreator(id, page, list)
 }
 
 // Get Issues from our database based on type
-func (a IIssueMgmtComp) GetIssueFromType(issue_type string) ([]*magazine.MagazineIssue, error) {
-	var issue []*magazine.MagazineIssue
-	sql, args, err := sqrl.Select("*").From("magazine_issues").Where(sqrl.Eq{"issue_type": issue_type}).
-		PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), a, &issue, sql, args[:]...); err != nil {
-		return nil, err
-	}
-
-	return issue, nil
+func (a IIssueMgmtComp) GetIssueFromType(issue_type string, page models.Pagination, list models.ListQuery) ([]*magazine.MagazineIssue, models.PageInfo, error) {
+	return a.Page(page, list, byCreatedOn, sqrl.Eq{"issue_type": issue_type})
 }
 
 // Delete Issue soft deletes the Issue in our database
 func (a IIssueMgmtComp) DeleteIssue(id uuid.UUID) error {
-	sql, arg, err := sqrl.Update("magazine_issues").SetMap(gin.H{"deleted_on": time.Now()}).
-		Where(sqrl.Eq{"id": id}).PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return err
-	}
-
-	exec, err := a.Exec(context.Background(), sql, arg[:]...)
-	if err != nil {
-		return err
-	}
-
-	count := exec.RowsAffected()
-	if count != 1 {
-		return err
-	}
-
-	return nil
+	return a.Delete(id)
 }
 
 //Permanent Delete Issue permanently deletes the Issue in our database
 func (a IIssueMgmtComp) PermanentDeleteIssue(id uuid.UUID) error {
-	sql, arg, err := sqrl.Delete("magazine_issues").Where(sqrl.Eq{"id": id}).PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return err
-	}
-
-	exec, err := a.Exec(context.Background(), sql, arg[:]...)
-	if err != nil {
-		return err
-	}
-
-	count := exec.RowsAffected()
-	if count != 1 {
-		return err
-	}
-
-	return nil
+	return a.PermanentDelete(id)
 }
 
 //PatchIssue updates the Issue in our database
 func (a IIssueMgmtComp) PatchIssue(id uuid.UUID, patch *map[string]interface{}) error {
-	sql, args, err := sqrl.Update("magazine_issues").SetMap(*patch).Where(sqrl.Eq{"id": id}).
-		PlaceholderFormat(sqrl.Dollar).ToSql()
+	return a.Patch(id, *patch)
+}
 
-	if err != nil {
-		return nil
-	}
+// ListIssuesFromContentCode lists issues of the content code
+func (a IIssueMgmtComp) ListIssuesFromContentCode(contentCode string) ([]*magazine.MagazineIssue, error) {
+	return a.List(sqrl.Eq{"content_code": contentCode})
+}
 
-	exec, err := a.Exec(context.Background(), sql, args[:]...)
-	if err != nil {
-		return err
-	}
+// ListIssuesFromStoryID lists issues whose contents have the story
+func (a IIssueMgmtComp) ListIssuesFromStoryID(storyID uuid.UUID) ([]*magazine.MagazineIssue, error) {
+	return a.Select(sqrl.Select("DISTINCT i.*").From("magazine_issues i").
+		Join("contents c ON c.content_code = i.content_code").
+		Where(sqrl.Eq{"c.story_code": storyID, "c.deleted_on": nil, "i.deleted_on": nil}))
+}
 
-	if exec.RowsAffected() != 1 {
-		return errors.New("not updated")
-	}
+// ListPublishedIssues lists published issues newest first
+func (a IIssueMgmtComp) ListPublishedIssues(page models.Pagination, list models.ListQuery) ([]*magazine.MagazineIssue, models.PageInfo, error) {
+	return a.Page(page, list, byPublishedOn, sqrl.NotEq{"published_on": nil})
+}
 
-	return nil
+// GetPublishedIssueFromSlug gets the published issue of the slug
+func (a IIssueMgmtComp) GetPublishedIssueFromSlug(slug string) (*magazine.MagazineIssue, error) {
+	return a.Get(sqrl.Eq{"slug": slug}, sqrl.NotEq{"published_on": nil})
 }
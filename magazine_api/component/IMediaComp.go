@@ -0,0 +1,305 @@
+package component
+
+import (
+	"context"
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"time"
+
+	"github.com/elgris/sqrl"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// MediaComponent database structure for uploaded media and queue of their processing
+type MediaComponent struct {
+	infrastructure.Database
+}
+
+// NewMediaComponent creates a new media component
+func NewMediaComponent(db infrastructure.Database, logger lib.Logger) MediaComponent {
+	return MediaComponent{db}
+}
+
+// CreateMedia creates media in our database, false is returned when media
+// with the same key or content hash is already recorded
+func (m MediaComponent) CreateMedia(media models.Media) (bool, error) {
+	sql, args, err := sqrl.Insert("media_assets").
+		Columns("id", "key", "mime_type", "size", "sha256", "uploaded_by", "status", "run_after", "created_on").
+		Values(media.ID, media.Key, media.MimeType, media.Size, media.Sha256, media.UploadedBy, media.Status,
+			media.RunAfter, media.CreatedOn).
+		Suffix("ON CONFLICT DO NOTHING").
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	exec, err := m.Exec(context.Background(), sql, args[:]...)
+	if err != nil {
+		return false, err
+	}
+
+	return exec.RowsAffected() == 1, nil
+}
+
+// Get One media from our database based on id
+func (m MediaComponent) GetMediaFromID(id uuid.UUID) (*models.Media, error) {
+	return m.getMedia(sqrl.Eq{"id": id, "deleted_on": nil})
+}
+
+// Get One media from our database based on key of the uploaded file
+func (m MediaComponent) GetMediaFromKey(key string) (*models.Media, error) {
+	return m.getMedia(sqrl.Eq{"key": key, "deleted_on": nil})
+}
+
+// Get One media from our database based on SHA-256 of its content
+func (m MediaComponent) GetMediaFromHash(sha256 string) (*models.Media, error) {
+	return m.getMedia(sqrl.Eq{"sha256": sha256, "deleted_on": nil})
+}
+
+func (m MediaComponent) getMedia(where sqrl.Eq) (*models.Media, error) {
+	var media models.Media
+
+	sql, args, err := sqrl.Select("*").From("media_assets").Where(where).
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pgxscan.Get(context.Background(), m, &media, sql, args[:]...); err != nil {
+		return nil, err
+	}
+
+	return &media, nil
+}
+
+// ClaimMedia marks the oldest media due for processing as processing and returns it,
+// media left processing since before staleBefore by a stopped worker are claimed again.
+// Rows locked by other workers are skipped so that workers never claim the same media
+func (m MediaComponent) ClaimMedia(staleBefore time.Time) (*models.Media, error) {
+	var media models.Media
+
+	sql := `UPDATE media_assets SET status = $1, attempts = attempts + 1, locked_on = NOW(), updated_on = NOW()
+		WHERE id = (
+			SELECT id FROM media_assets
+			WHERE deleted_on IS NULL
+				AND ((status = $2 AND run_after <= NOW()) OR (status = $1 AND locked_on < $3))
+			ORDER BY created_on
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		)
+		RETURNING *`
+
+	if err := pgxscan.Get(context.Background(), m, &media, sql, models.MediaProcessing, models.MediaPending,
+		staleBefore); err != nil {
+		return nil, err
+	}
+
+	return &media, nil
+}
+
+// CompleteMedia records dimensions and variants of processed media
+func (m MediaComponent) CompleteMedia(id uuid.UUID, width, height int, variants []models.MediaVariant) error {
+	return m.patch(id, gin.H{
+		"status":     models.MediaReady,
+		"width":      width,
+		"height":     height,
+		"variants":   variants,
+		"last_error": nil,
+		"locked_on":  nil,
+		"updated_on": time.Now(),
+	})
+}
+
+// RetryMedia puts media back in the queue to be processed after runAfter
+func (m MediaComponent) RetryMedia(id uuid.UUID, lastError string, runAfter time.Time) error {
+	return m.patch(id, gin.H{
+		"status":     models.MediaPending,
+		"last_error": lastError,
+		"run_after":  runAfter,
+		"locked_on":  nil,
+		"updated_on": time.Now(),
+	})
+}
+
+// RequeueMedia puts processed media back in the queue to generate its variants
+func (m MediaComponent) RequeueMedia(id uuid.UUID) error {
+	return m.patch(id, gin.H{
+		"status":     models.MediaPending,
+		"attempts":   0,
+		"run_after":  time.Now(),
+		"updated_on": time.Now(),
+	})
+}
+
+// FailMedia takes media out of the queue after it failed for the last time
+func (m MediaComponent) FailMedia(id uuid.UUID, lastError string) error {
+	return m.patch(id, gin.H{
+		"status":     models.MediaFailed,
+		"last_error": lastError,
+		"locked_on":  nil,
+		"updated_on": time.Now(),
+	})
+}
+
+func (m MediaComponent) patch(id uuid.UUID, patch gin.H) error {
+	sql, args, err := sqrl.Update("media_assets").SetMap(patch).Where(sqrl.Eq{"id": id}).
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	exec, err := m.Exec(context.Background(), sql, args[:]...)
+	if err != nil {
+		return err
+	}
+
+	if exec.RowsAffected() != 1 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteMedia soft deletes the media in our database
+func (m MediaComponent) DeleteMedia(id uuid.UUID) error {
+	return m.patch(id, gin.H{"deleted_on": time.Now()})
+}
+
+// ListOrphanMedia lists media created before given time that no row references,
+// media waiting in the queue are left out
+func (m MediaComponent) ListOrphanMedia(before time.Time) ([]*models.Media, error) {
+	var media []*models.Media
+
+	sql, args, err := sqrl.Select("*").From("media_assets").
+		Where(sqrl.Eq{"deleted_on": nil}).
+		Where(sqrl.NotEq{"status": []models.MediaStatus{models.MediaPending, models.MediaProcessing}}).
+		Where(sqrl.Expr("created_on < ?", before)).
+		Where("NOT EXISTS (SELECT 1 FROM media_asset_references r WHERE r.asset_id = media_assets.id)").
+		OrderBy("created_on").
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pgxscan.Select(context.Background(), m, &media, sql, args[:]...); err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// ListMedia lists media recorded in our database that are not deleted
+func (m MediaComponent) ListMedia() ([]*models.Media, error) {
+	var media []*models.Media
+
+	sql, args, err := sqrl.Select("*").From("media_assets").Where(sqrl.Eq{"deleted_on": nil}).
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pgxscan.Select(context.Background(), m, &media, sql, args[:]...); err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// ListMediaFromIDs lists media of the ids that are not deleted
+func (m MediaComponent) ListMediaFromIDs(ids []uuid.UUID) ([]*models.Media, error) {
+	var media []*models.Media
+
+	sql, args, err := sqrl.Select("*").From("media_assets").
+		Where(sqrl.Eq{"id": ids, "deleted_on": nil}).
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pgxscan.Select(context.Background(), m, &media, sql, args[:]...); err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// SetReferences replaces references of the row in given fields with media recorded
+// under the keys, fields with empty key or key of no media are left without reference
+func (m MediaComponent) SetReferences(entity models.MediaEntity, entityID uuid.UUID, keys map[string]string) error {
+	ctx := context.Background()
+	tx, err := m.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for field, key := range keys {
+		sql, args, err := sqrl.Delete("media_asset_references").
+			Where(sqrl.Eq{"entity_type": entity, "entity_id": entityID, "field": field}).
+			PlaceholderFormat(sqrl.Dollar).ToSql()
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, sql, args[:]...); err != nil {
+			return err
+		}
+
+		if key == "" {
+			continue
+		}
+
+		sql = `INSERT INTO media_asset_references (asset_id, entity_type, entity_id, field, created_on)
+			SELECT id, $1, $2, $3, NOW() FROM media_assets WHERE key = $4 AND deleted_on IS NULL`
+		args = []interface{}{entity, entityID, field, key}
+
+		if _, err := tx.Exec(ctx, sql, args[:]...); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ClearReferences removes all references of the row, or those of fields starting with the prefixes
+func (m MediaComponent) ClearReferences(entity models.MediaEntity, entityID uuid.UUID, fieldPrefixes ...string) error {
+	query := sqrl.Delete("media_asset_references").
+		Where(sqrl.Eq{"entity_type": entity, "entity_id": entityID})
+	if len(fieldPrefixes) > 0 {
+		prefixes := sqrl.Or{}
+		for _, prefix := range fieldPrefixes {
+			prefixes = append(prefixes, sqrl.Expr("field LIKE ?", prefix+"%"))
+		}
+		query = query.Where(prefixes)
+	}
+
+	sql, args, err := query.PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = m.Exec(context.Background(), sql, args[:]...)
+	return err
+}
+
+// ListReferences lists rows referencing the media
+func (m MediaComponent) ListReferences(assetID uuid.UUID) ([]*models.MediaReference, error) {
+	var references []*models.MediaReference
+
+	sql, args, err := sqrl.Select("*").From("media_asset_references").
+		Where(sqrl.Eq{"asset_id": assetID}).
+		OrderBy("created_on").
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pgxscan.Select(context.Background(), m, &references, sql, args[:]...); err != nil {
+		return nil, err
+	}
+
+	return references, nil
+}
@@ -1,172 +1,124 @@
 package component
 
 import (
-	"context"
-	"errors"
 	"magazine_api/infrastructure"
 	"magazine_api/lib"
+	"magazine_api/models"
 	"magazine_api/models/magazine"
 	"time"
 
 	"github.com/elgris/sqrl"
-	"github.com/georgysavva/scany/pgxscan"
-	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type IPhotographMgmtComp struct {
-	infrastructure.Database
+	Repository[magazine.Photograph]
 }
 
 //New Photo Management Component creates a new Photo component
 func NewPhotographComp(db infrastructure.Database, logger lib.Logger) IPhotographMgmtComp {
-	return IPhotographMgmtComp{db}
+	return IPhotographMgmtComp{NewRepository[magazine.Photograph](db, Table{Name: "photographs", Creator: "created_by"})}
 }
 
 //Creates Photo in our database
 func (i IPhotographMgmtComp) CreatePhoto(photo magazine.Photograph) error {
-	sql, args, err := sqrl.Insert("photographs").
-		Columns("id", "photo_id", "photo_code", "creator_id", "photo_title", "photo_type", "url", "remarks").
-		Values(photo.ID, photo.PhotographID, photo.PhotographCode, photo.CreatedBy, photo.PhotographTitle, photo.PhotographType, photo.DocumentURL, photo.Remarks).
-		PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return err
-	}
-
-	exec, err := i.Exec(context.Background(), sql, args[:]...)
-	if err != nil {
-		return err
-	}
-
-	if exec.RowsAffected() != 1 {
-		return errors.New("not inserted")
-	}
-
-	return nil
+	return i.Insert(map[string]interface{}{
+		"id": photo.ID, "photo_id": photo.PhotographID, "photo_code": photo.PhotographCode, "created_by": photo.CreatedBy,
+		"photo_title": photo.PhotographTitle, "photo_type": photo.PhotographType, "url": photo.DocumentURL,
+		"photographer_id": photo.PhotographerId, "licence_source": photo.LicenceSource, "licence_type": photo.LicenceType,
+		"usage_scope": photo.UsageScope, "territories": photo.Territories, "licence_expires_on": photo.LicenceExpiresOn,
+		"remarks": photo.Remarks,
+	})
 }
 
 // Lists all the Photographs from our database
-func (s IPhotographMgmtComp) ListPhotos() ([]*magazine.Photograph, error) {
-	var stores []*magazine.Photograph
-	sql, args, err := sqrl.Select("*").From("photographs").Where(sqrl.Eq{"deleted_on": nil}).PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), s, &stores, sql, args[:]...); err != nil {
-		return nil, err
-	}
-
-	return stores, nil
+func (s IPhotographMgmtComp) ListPhotos(page models.Pagination, list models.ListQuery) ([]*magazine.Photograph, models.PageInfo, error) {
+	return s.Page(page, list, byCreatedOn)
 }
 
 //Get One Photo from our database based on id
 func (a IPhotographMgmtComp) GetPhotoFromID(id uuid.UUID) (*magazine.Photograph, error) {
-	var Photo magazine.Photograph
-
-	sql, args, err := sqrl.Select("*").From("photographs").Where(sqrl.Eq{"id": id}).PlaceholderFormat(sqrl.Dollar).ToSql()
-
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Get(context.Background(), a, &Photo, sql, args[:]...); err != nil {
-		return nil, err
-	}
-
-	return &Photo, nil
+	return a.GetFromID(id)
 }
 
 // Get Photos from our database based on profile id
-func (a IPhotographMgmtComp) GetPhotoFromCreatorId(id uuid.UUID) ([]*magazine.Photograph, error) {
-	var Photo []*magazine.Photograph
-	sql, args, err := sqrl.Select("*").From("photographs").Where(sqrl.Eq{"creator_id": id}).
-		PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), a, &Photo, sql, args[:]...); err != nil {
-		return nil, err
-	}
-
-	return Photo, nil
+func (a IPhotographMgmtComp) GetPhotoFromCreatorId(id uuid.UUID, page models.Pagination, list models.ListQuery) ([]*magazine.Photograph, models.PageInfo, error) {
+	return a.ListFromCreator(id, page, list)
 }
 
 // Get Photos from our database based on type
-func (a IPhotographMgmtComp) GetPhotoFromType(photo_type string) ([]*magazine.Photograph, error) {
-	var Photo []*magazine.Photograph
-	sql, args, err := sqrl.Select("*").From("photographs").Where(sqrl.Eq{"photo_type": photo_type}).
-		PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), a, &Photo, sql, args[:]...); err != nil {
-		return nil, err
-	}
-
-	return Photo, nil
+func (a IPhotographMgmtComp) GetPhotoFromType(photo_type string, page models.Pagination, list models.ListQuery) ([]*magazine.Photograph, models.PageInfo, error) {
+	return a.Page(page, list, byCreatedOn, sqrl.Eq{"photo_type": photo_type})
 }
 
 // Delete Photo soft deletes the Photo in our database
 func (a IPhotographMgmtComp) DeletePhoto(id uuid.UUID) error {
-	sql, arg, err := sqrl.Update("photographs").SetMap(gin.H{"deleted_on": time.Now()}).
-		Where(sqrl.Eq{"id": id}).PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return err
-	}
-
-	exec, err := a.Exec(context.Background(), sql, arg[:]...)
-	if err != nil {
-		return err
-	}
-
-	count := exec.RowsAffected()
-	if count != 1 {
-		return err
-	}
-
-	return nil
+	return a.Delete(id)
 }
 
 //Permanent Delete Photo permanently deletes the Photo in our database
 func (a IPhotographMgmtComp) PermanentDeletePhoto(id uuid.UUID) error {
-	sql, arg, err := sqrl.Delete("photographs").Where(sqrl.Eq{"id": id}).PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return err
-	}
+	return a.PermanentDelete(id)
+}
 
-	exec, err := a.Exec(context.Background(), sql, arg[:]...)
-	if err != nil {
-		return err
-	}
+//PatchPhoto updates the Photo in our database
+func (a IPhotographMgmtComp) PatchPhoto(id uuid.UUID, patch *map[string]interface{}) error {
+	return a.Patch(id, *patch)
+}
 
-	count := exec.RowsAffected()
-	if count != 1 {
-		return err
+// ListPhotosFromContentCode lists photographs placed by contents of the content code, both
+// photographs of the contents and photographs in galleries of their stories
+func (a IPhotographMgmtComp) ListPhotosFromContentCode(contentCode string) ([]*magazine.Photograph, error) {
+	return a.Select(sqrl.Select("p.*").From("photographs p").
+		Where(sqrl.Eq{"p.deleted_on": nil}).
+		Where(sqrl.Or{
+			sqrl.Expr("p.photo_code IN (SELECT photo_code FROM contents WHERE content_code = ? AND deleted_on IS NULL)", contentCode),
+			sqrl.Expr(`p.id IN (SELECT sp.photograph_id FROM story_photographs sp
+				JOIN contents c ON c.story_code = sp.story_id WHERE c.content_code = ? AND c.deleted_on IS NULL)`, contentCode),
+		}))
+}
+
+// ListPhotosFromCodes lists photographs of the photo codes
+func (a IPhotographMgmtComp) ListPhotosFromCodes(codes []string) ([]*magazine.Photograph, error) {
+	if len(codes) == 0 {
+		return []*magazine.Photograph{}, nil
 	}
 
-	return nil
+	return a.List(sqrl.Eq{"photo_code": codes})
 }
 
-//Patch Photo updates the Photo in our database
-func (a IPhotographMgmtComp) PatchPhoto(id uuid.UUID, patch *map[string]interface{}) error {
-	sql, args, err := sqrl.Update("photographs").SetMap(*patch).Where(sqrl.Eq{"id": id}).
-		PlaceholderFormat(sqrl.Dollar).ToSql()
-
-	if err != nil {
-		return nil
+// ListPhotosFromIDs lists photographs of the ids
+func (a IPhotographMgmtComp) ListPhotosFromIDs(ids []uuid.UUID) ([]*magazine.Photograph, error) {
+	if len(ids) == 0 {
+		return []*magazine.Photograph{}, nil
 	}
 
-	exec, err := a.Exec(context.Background(), sql, args[:]...)
-	if err != nil {
-		return err
-	}
+	return a.List(sqrl.Eq{"id": ids})
+}
 
-	if exec.RowsAffected() != 1 {
-		return errors.New("not updated")
+// ListPhotosLicenceExpiring lists photographs whose licence expires before the time ordered by
+// expiry, licences expired already are left out when from is set
+func (a IPhotographMgmtComp) ListPhotosLicenceExpiring(from *time.Time, before time.Time) ([]*magazine.Photograph, error) {
+	query := sqrl.Select("*").From("photographs").
+		Where(sqrl.Eq{"deleted_on": nil}).
+		Where(sqrl.NotEq{"licence_expires_on": nil}).
+		Where(sqrl.LtOrEq{"licence_expires_on": before})
+	if from != nil {
+		query = query.Where(sqrl.Gt{"licence_expires_on": *from})
 	}
 
-	return nil
+	return a.Select(query.OrderBy("licence_expires_on"))
+}
+
+// GetPublishedPhotoFromID gets the photograph when a published story has it in its gallery or
+// contents of a published issue place it
+func (a IPhotographMgmtComp) GetPublishedPhotoFromID(id uuid.UUID) (*magazine.Photograph, error) {
+	return a.GetOne(sqrl.Select("p.*").From("photographs p").
+		Where(sqrl.Eq{"p.id": id, "p.deleted_on": nil}).
+		Where(sqrl.Or{
+			sqrl.Expr(`EXISTS (SELECT 1 FROM story_photographs sp JOIN stories s ON s.id = sp.story_id
+				WHERE sp.photograph_id = p.id AND s.deleted_on IS NULL AND s.published_on IS NOT NULL)`),
+			sqrl.Expr(`EXISTS (SELECT 1 FROM contents c JOIN magazine_issues i ON i.content_code = c.content_code
+				WHERE c.photo_code = p.photo_code AND c.deleted_on IS NULL AND i.deleted_on IS NULL AND i.published_on IS NOT NULL)`),
+		}))
 }
@@ -0,0 +1,203 @@
+package component
+
+import (
+	"context"
+	"magazine_api/api/serializers/responses"
+	"magazine_api/apperrors"
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"time"
+
+	"github.com/elgris/sqrl"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// tables of royalties, royalties are created for the contributor earning them
+var (
+	agreementsTable = Table{Name: "royalty_agreements", Creator: "created_by"}
+	royaltiesTable  = Table{Name: "royalties", Creator: "contributor_id"}
+	payoutsTable    = Table{Name: "royalty_payouts", Creator: "created_by"}
+)
+
+// RoyaltyComponent database structure for royalty agreements, royalties and payouts
+type RoyaltyComponent struct {
+	Beginner
+	agreements Repository[models.RoyaltyAgreement]
+	royalties  Repository[models.Royalty]
+	payouts    Repository[models.RoyaltyPayout]
+}
+
+// NewRoyaltyComponent creates a new royalty component
+func NewRoyaltyComponent(db infrastructure.Database, logger lib.Logger) RoyaltyComponent {
+	return RoyaltyComponent{
+		Beginner:   db,
+		agreements: NewRepository[models.RoyaltyAgreement](db, agreementsTable),
+		royalties:  NewRepository[models.Royalty](db, royaltiesTable),
+		payouts:    NewRepository[models.RoyaltyPayout](db, payoutsTable),
+	}
+}
+
+// Creates royalty agreement in our database
+func (r RoyaltyComponent) CreateAgreement(agreement models.RoyaltyAgreement) error {
+	return r.agreements.Insert(map[string]interface{}{
+		"id": agreement.ID, "contributor_id": agreement.ContributorId, "per_word": agreement.PerWord,
+		"per_story": agreement.PerStory, "per_photo": agreement.PerPhoto, "effective_from": agreement.EffectiveFrom,
+		"effective_to": agreement.EffectiveTo, "remarks": agreement.Remarks, "created_on": agreement.CreatedOn,
+		"created_by": agreement.CreatedBy, "creator_name": agreement.CreatorName,
+	})
+}
+
+// Lists page of royalty agreements of contributor from our database newest first
+func (r RoyaltyComponent) ListAgreementsFromContributorID(contributorID uuid.UUID, page models.Pagination, list models.ListQuery) ([]*models.RoyaltyAgreement, models.PageInfo, error) {
+	return r.agreements.Page(page, list, byCreatedOnLatest, sqrl.Eq{"contributor_id": contributorID})
+}
+
+// Get One royalty agreement from our database based on id
+func (r RoyaltyComponent) GetAgreementFromID(id uuid.UUID) (*models.RoyaltyAgreement, error) {
+	return r.agreements.GetFromID(id)
+}
+
+// Get the latest royalty agreement of contributor in effect at given time
+func (r RoyaltyComponent) GetActiveAgreement(contributorID uuid.UUID, at time.Time) (*models.RoyaltyAgreement, error) {
+	return r.agreements.GetOne(r.agreements.query(sqrl.Select("*"), sqrl.Eq{"contributor_id": contributorID}).
+		Where(sqrl.Expr("effective_from <= ?", at)).
+		Where(sqrl.Or{sqrl.Eq{"effective_to": nil}, sqrl.Expr("effective_to >= ?", at)}).
+		OrderBy("effective_from DESC").
+		Limit(1))
+}
+
+// PatchAgreement updates the royalty agreement in our database
+func (r RoyaltyComponent) PatchAgreement(id uuid.UUID, patch *map[string]interface{}) error {
+	return r.agreements.Patch(id, *patch)
+}
+
+// DeleteAgreement soft deletes the royalty agreement in our database
+func (r RoyaltyComponent) DeleteAgreement(id uuid.UUID) error {
+	return r.agreements.Delete(id)
+}
+
+// CreateRoyalty creates royalty in our database, royalty already recorded for
+// the story or photograph in the issue is left as is and false is returned
+func (r RoyaltyComponent) CreateRoyalty(royalty models.Royalty) (bool, error) {
+	sql, args, err := sqrl.Insert("royalties").
+		Columns("id", "contributor_id", "agreement_id", "issue_id", "source_type", "source_id", "word_count",
+			"amount", "remarks", "created_on").
+		Values(royalty.ID, royalty.ContributorId, royalty.AgreementId, royalty.IssueId, royalty.SourceType,
+			royalty.SourceId, royalty.WordCount, royalty.Amount, royalty.Remarks, royalty.CreatedOn).
+		Suffix("ON CONFLICT (issue_id, source_type, source_id) DO NOTHING").
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	exec, err := r.Exec(context.Background(), sql, args[:]...)
+	if err != nil {
+		return false, apperrors.From(err)
+	}
+
+	return exec.RowsAffected() == 1, nil
+}
+
+// Lists page of royalties of contributor from our database
+func (r RoyaltyComponent) ListRoyaltiesFromContributorID(contributorID uuid.UUID, page models.Pagination, list models.ListQuery) ([]*models.Royalty, models.PageInfo, error) {
+	return r.royalties.ListFromCreator(contributorID, page, list)
+}
+
+// Lists page of royalties of issue from our database
+func (r RoyaltyComponent) ListRoyaltiesFromIssueID(issueID uuid.UUID, page models.Pagination, list models.ListQuery) ([]*models.Royalty, models.PageInfo, error) {
+	return r.royalties.Page(page, list, byCreatedOn, sqrl.Eq{"issue_id": issueID})
+}
+
+// Lists royalties not yet paid out from our database, of all contributors when none given
+func (r RoyaltyComponent) ListUnpaidRoyalties(contributorIDs []uuid.UUID) ([]*models.Royalty, error) {
+	where := sqrl.Eq{"payout_id": nil}
+	if len(contributorIDs) > 0 {
+		where["contributor_id"] = contributorIDs
+	}
+
+	return r.royalties.List(where)
+}
+
+// CreatePayout creates royalty payout, the transaction of each payment and marks the royalties of
+// the payment paid out by it in one transaction, nothing is written when any of it fails. Royalties
+// paid out meanwhile fail the payout with ErrNotFound
+func (r RoyaltyComponent) CreatePayout(payout models.RoyaltyPayout, payments []models.RoyaltyPayment) error {
+	ctx := context.Background()
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = NewRepository[models.RoyaltyPayout](tx, payoutsTable).Insert(map[string]interface{}{
+		"id": payout.ID, "total_amount": payout.TotalAmount, "contributor_count": payout.ContributorCount,
+		"paid_medium": payout.PaidMedium, "remarks": payout.Remarks, "created_on": payout.CreatedOn,
+		"created_by": payout.CreatedBy, "creator_name": payout.CreatorName,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, payment := range payments {
+		sql, args, err := insertTransaction(payment.Transaction)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, sql, args[:]...); err != nil {
+			return apperrors.From(err)
+		}
+
+		sql, args, err = sqrl.Update("royalties").
+			SetMap(gin.H{"payout_id": payout.ID, "transaction_id": payment.Transaction.ID, "updated_on": time.Now()}).
+			Where(sqrl.Eq{"id": payment.RoyaltyIds, "payout_id": nil}).
+			PlaceholderFormat(sqrl.Dollar).ToSql()
+		if err != nil {
+			return err
+		}
+
+		exec, err := tx.Exec(ctx, sql, args[:]...)
+		if err != nil {
+			return apperrors.From(err)
+		}
+
+		if exec.RowsAffected() != int64(len(payment.RoyaltyIds)) {
+			return ErrNotFound
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Lists page of royalty payouts from our database newest first
+func (r RoyaltyComponent) ListPayouts(page models.Pagination, list models.ListQuery) ([]*models.RoyaltyPayout, models.PageInfo, error) {
+	return r.payouts.Page(page, list, byCreatedOnLatest)
+}
+
+// Lists outstanding and paid out royalties per contributor from our database
+func (r RoyaltyComponent) ListBalances() ([]*responses.ContributorBalance, error) {
+	var balances []*responses.ContributorBalance
+
+	sql, args, err := sqrl.Select(
+		"contributor_id",
+		"COALESCE(SUM(amount) FILTER (WHERE payout_id IS NULL), 0) AS outstanding",
+		"COALESCE(SUM(amount) FILTER (WHERE payout_id IS NOT NULL), 0) AS paid",
+		"COUNT(*) FILTER (WHERE payout_id IS NULL) AS unpaid_count",
+	).From("royalties").
+		Where(sqrl.Eq{"deleted_on": nil}).
+		GroupBy("contributor_id").
+		OrderBy("outstanding DESC").
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pgxscan.Select(context.Background(), r, &balances, sql, args[:]...); err != nil {
+		return nil, apperrors.From(err)
+	}
+
+	return balances, nil
+}
@@ -0,0 +1,53 @@
+package component
+
+import (
+	"errors"
+	"magazine_api/models"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// newPayment payment of the royalties by a new transaction
+func newPayment(royalties ...uuid.UUID) models.RoyaltyPayment {
+	return models.RoyaltyPayment{Transaction: models.Transaction{Base: models.Base{ID: uuid.New()}}, RoyaltyIds: royalties}
+}
+
+func TestCreatePayoutInOneTransaction(t *testing.T) {
+	db := newFakeBeginner(1)
+	comp := RoyaltyComponent{Beginner: db}
+
+	payout := models.RoyaltyPayout{Base: models.Base{ID: uuid.New()}}
+	if err := comp.CreatePayout(payout, []models.RoyaltyPayment{newPayment(uuid.New()), newPayment(uuid.New())}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"INSERT INTO royalty_payouts", "INSERT INTO transactions", "UPDATE royalties", "INSERT INTO transactions", "UPDATE royalties"}
+	if len(db.statements) != len(want) {
+		t.Fatalf("got statements %v, want %v", db.statements, want)
+	}
+	for i, prefix := range want {
+		if !strings.HasPrefix(db.statements[i], prefix) {
+			t.Errorf("statement %d is %q, want %s", i, db.statements[i], prefix)
+		}
+	}
+	if !db.tx.committed {
+		t.Error("transaction not committed")
+	}
+}
+
+func TestCreatePayoutRollsBackRoyaltiesPaidMeanwhile(t *testing.T) {
+	db := newFakeBeginner(1)
+	comp := RoyaltyComponent{Beginner: db}
+
+	// one of the two royalties is paid out by another payout, only one is marked
+	payments := []models.RoyaltyPayment{newPayment(uuid.New()), newPayment(uuid.New(), uuid.New())}
+	if err := comp.CreatePayout(models.RoyaltyPayout{Base: models.Base{ID: uuid.New()}}, payments); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("got %v, want ErrNotFound", err)
+	}
+
+	if db.tx.committed || !db.tx.rolledBack {
+		t.Error("payout and transactions of contributors paid before the failure are kept")
+	}
+}
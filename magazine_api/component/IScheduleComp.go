@@ -0,0 +1,121 @@
+package component
+
+import (
+	"context"
+	"fmt"
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"strings"
+	"time"
+
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+// scheduleTables tables published by schedule with the column shown as title of their rows
+var scheduleTables = []struct {
+	entity models.ScheduleEntity
+	table  string
+	title  string
+}{
+	{models.ScheduleStory, "stories", "story_title"},
+	{models.ScheduleIssue, "magazine_issues", "issue_code"},
+	{models.ScheduleAdvert, "adverts", "advert_title"},
+}
+
+const (
+	// publishDue rows not published whose publish_at passed and unpublish_at did not
+	publishDue = "deleted_on IS NULL AND published_on IS NULL AND publish_at <= $1 AND (unpublish_at IS NULL OR unpublish_at > $1)"
+
+	// unpublishDue published rows whose unpublish_at passed
+	unpublishDue = "deleted_on IS NULL AND published_on IS NOT NULL AND unpublish_at <= $1"
+)
+
+// ScheduleComponent database structure for publishing stories, issues and adverts by schedule
+type ScheduleComponent struct {
+	infrastructure.Database
+}
+
+// NewScheduleComponent creates a new schedule component
+func NewScheduleComponent(db infrastructure.Database, logger lib.Logger) ScheduleComponent {
+	return ScheduleComponent{db}
+}
+
+// RunDue publishes and unpublishes rows whose scheduled time passed in one transaction holding
+// the advisory lock, false is returned without changing anything when another instance holds it.
+// In dry run nothing is locked or changed and actions that would be taken are returned
+func (s ScheduleComponent) RunDue(lockKey int64, now time.Time, dryRun bool) (bool, []*models.ScheduledAction, error) {
+	ctx := context.Background()
+	tx, err := s.Begin(ctx)
+	if err != nil {
+		return false, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if !dryRun {
+		var locked bool
+		if err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, lockKey).Scan(&locked); err != nil {
+			return false, nil, err
+		}
+		if !locked {
+			return false, nil, nil
+		}
+	}
+
+	actions := []*models.ScheduledAction{}
+	for _, t := range scheduleTables {
+		for _, due := range []struct {
+			action    models.ScheduleAction
+			condition string
+			set       string
+			at        string
+		}{
+			{models.SchedulePublish, publishDue, "published_on = publish_at", "publish_at"},
+			{models.ScheduleUnpublish, unpublishDue, "published_on = NULL", "unpublish_at"},
+		} {
+			returning := fmt.Sprintf("%d AS entity, id AS entity_id, %s AS title, %d AS action, %s AS at",
+				t.entity, t.title, due.action, due.at)
+
+			sql := fmt.Sprintf("UPDATE %s SET %s, updated_on = $1 WHERE %s RETURNING %s",
+				t.table, due.set, due.condition, returning)
+			if dryRun {
+				sql = fmt.Sprintf("SELECT %s FROM %s WHERE %s", returning, t.table, due.condition)
+			}
+
+			var rows []*models.ScheduledAction
+			if err := pgxscan.Select(ctx, tx, &rows, sql, now); err != nil {
+				return false, nil, err
+			}
+			actions = append(actions, rows...)
+		}
+	}
+
+	if dryRun {
+		return true, actions, nil
+	}
+
+	return true, actions, tx.Commit(ctx)
+}
+
+// ListUpcoming lists publishing and unpublishing scheduled after now up to until ordered by time
+func (s ScheduleComponent) ListUpcoming(now, until time.Time) ([]*models.ScheduledAction, error) {
+	var selects []string
+	for _, t := range scheduleTables {
+		selects = append(selects,
+			fmt.Sprintf(`SELECT %d AS entity, id AS entity_id, %s AS title, %d AS action, publish_at AS at FROM %s
+				WHERE deleted_on IS NULL AND published_on IS NULL AND publish_at > $1 AND publish_at <= $2`,
+				t.entity, t.title, models.SchedulePublish, t.table),
+			fmt.Sprintf(`SELECT %d AS entity, id AS entity_id, %s AS title, %d AS action, unpublish_at AS at FROM %s
+				WHERE deleted_on IS NULL AND unpublish_at > $1 AND unpublish_at <= $2`,
+				t.entity, t.title, models.ScheduleUnpublish, t.table),
+		)
+	}
+
+	var actions []*models.ScheduledAction
+	sql := strings.Join(selects, " UNION ALL ") + " ORDER BY at"
+	if err := pgxscan.Select(context.Background(), s, &actions, sql, now, until); err != nil {
+		return nil, err
+	}
+
+	return actions, nil
+}
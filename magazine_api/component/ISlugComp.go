@@ -0,0 +1,88 @@
+package component
+
+import (
+	"context"
+	"fmt"
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/google/uuid"
+)
+
+// SlugComponent database structure for slugs of rows and slugs they had before
+type SlugComponent struct {
+	Beginner
+}
+
+// NewSlugComponent creates a new slug component
+func NewSlugComponent(db infrastructure.Database, logger lib.Logger) SlugComponent {
+	return SlugComponent{db}
+}
+
+// SlugTaken checks if another row of the table has or had the slug
+func (s SlugComponent) SlugTaken(table, slug string, id uuid.UUID) (bool, error) {
+	var taken bool
+	sql := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE slug = $1 AND id <> $2)
+		OR EXISTS (SELECT 1 FROM slug_history WHERE table_name = $3 AND slug = $1 AND entity_id <> $2)`, table)
+	if err := s.QueryRow(context.Background(), sql, slug, id, table).Scan(&taken); err != nil {
+		return false, err
+	}
+
+	return taken, nil
+}
+
+// ChangeSlug sets slug of the row and keeps its current slug in history, a slug the row had
+// before is taken back from history
+func (s SlugComponent) ChangeSlug(table string, id uuid.UUID, slug string) error {
+	ctx := context.Background()
+	tx, err := s.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var current *string
+	sql := fmt.Sprintf("SELECT slug FROM %s WHERE id = $1 AND deleted_on IS NULL FOR UPDATE", table)
+	if err := tx.QueryRow(ctx, sql, id).Scan(&current); err != nil {
+		return err
+	}
+	if current != nil && *current == slug {
+		return nil
+	}
+
+	if current != nil {
+		if _, err := tx.Exec(ctx, `INSERT INTO slug_history (table_name, slug, entity_id, created_on)
+			VALUES ($1, $2, $3, NOW()) ON CONFLICT (table_name, slug) DO NOTHING`, table, *current, id); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM slug_history WHERE table_name = $1 AND slug = $2 AND entity_id = $3`,
+		table, slug, id); err != nil {
+		return err
+	}
+
+	sql = fmt.Sprintf("UPDATE %s SET slug = $1, updated_on = NOW() WHERE id = $2", table)
+	exec, err := tx.Exec(ctx, sql, slug, id)
+	if err != nil {
+		return err
+	}
+	if exec.RowsAffected() != 1 {
+		return ErrNotFound
+	}
+
+	return tx.Commit(ctx)
+}
+
+// FindMovedSlug gets current slug of the row that had the slug before
+func (s SlugComponent) FindMovedSlug(table, slug string) (string, error) {
+	var current string
+	sql := fmt.Sprintf(`SELECT t.slug FROM slug_history h JOIN %s t ON t.id = h.entity_id
+		WHERE h.table_name = $1 AND h.slug = $2 AND t.deleted_on IS NULL AND t.slug IS NOT NULL`, table)
+	if err := pgxscan.Get(context.Background(), s, &current, sql, table, slug); err != nil {
+		return "", err
+	}
+
+	return current, nil
+}
@@ -1,173 +1,99 @@
 package component
 
 import (
-	"context"
-	"errors"
 	"magazine_api/infrastructure"
 	"magazine_api/lib"
+	"magazine_api/models"
 	"magazine_api/models/magazine"
-	"time"
 
 	"github.com/elgris/sqrl"
-	"github.com/georgysavva/scany/pgxscan"
-	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 //Story management component structure
 type IStoryMgmtComp struct {
-	infrastructure.Database
+	Repository[magazine.Story]
 }
 
 //New Story Management Component creates a new Story component
 func NewStoryComp(db infrastructure.Database, logger lib.Logger) IStoryMgmtComp {
-	return IStoryMgmtComp{db}
+	return IStoryMgmtComp{NewRepository[magazine.Story](db, Table{Name: "stories", Creator: "creator_id"})}
 }
 
 //Creates Story in our database
 func (a IStoryMgmtComp) CreateStory(story magazine.Story) error {
-	sql, args, err := sqrl.Insert("stories").
-		Columns("id", "creator_id", "story_title", "story_type", "story_content", "remarks").
-		Values(story.ID, story.CreatorId, story.StoryTitle, story.StoryType, story.StoryContent, story.Remarks).
-		PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return err
-	}
-
-	exec, err := a.Exec(context.Background(), sql, args[:]...)
-	if err != nil {
-		return err
-	}
-
-	if exec.RowsAffected() != 1 {
-		return errors.New("not inserted")
-	}
-
-	return nil
+	return a.Insert(map[string]interface{}{
+		"id": story.ID, "creator_id": story.CreatorId, "story_title": story.StoryTitle, "story_type": story.StoryType,
+		"story_content": story.StoryContent, "story_body": story.StoryBody, "word_count": story.WordCount, "reading_time": story.ReadingTime,
+		"remarks": story.Remarks, "publish_at": story.PublishAt, "unpublish_at": story.UnpublishAt, "published_on": story.PublishedOn,
+		"slug": story.Slug, "meta_title": story.MetaTitle, "meta_description": story.MetaDescription,
+		"canonical_url": story.CanonicalURL, "og_image": story.OgImage,
+	})
 }
 
 // Lists all the Stories from our database
-func (s IStoryMgmtComp) ListStories() ([]*magazine.Story, error) {
-	var stores []*magazine.Story
-	sql, args, err := sqrl.Select("*").From("stories").Where(sqrl.Eq{"deleted_on": nil}).PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), s, &stores, sql, args[:]...); err != nil {
-		return nil, err
-	}
-
-	return stores, nil
+func (s IStoryMgmtComp) ListStories(page models.Pagination, list models.ListQuery) ([]*magazine.Story, models.PageInfo, error) {
+	return s.Page(page, list, byCreatedOn)
 }
 
 //Get One Story from our database based on id
 func (a IStoryMgmtComp) GetStoryFromID(id uuid.UUID) (*magazine.Story, error) {
-	var Story magazine.Story
-
-	sql, args, err := sqrl.Select("*").From("stories").Where(sqrl.Eq{"id": id}).PlaceholderFormat(sqrl.Dollar).ToSql()
-
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Get(context.Background(), a, &Story, sql, args[:]...); err != nil {
-		return nil, err
-	}
-
-	return &Story, nil
+	return a.GetFromID(id)
 }
 
 // Get Stories from our database based on profile id
-func (a IStoryMgmtComp) GetStoryFromCreatorId(id uuid.UUID) ([]*magazine.Story, error) {
-	var story []*magazine.Story
-	sql, args, err := sqrl.Select("*").From("stories").Where(sqrl.Eq{"creator_id": id}).
-		PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), a, &story, sql, args[:]...); err != nil {
-		return nil, err
-	}
-
-	return story, nil
+func (a IStoryMgmtComp) GetStoryFromCreatorId(id uuid.UUID, page models.Pagination, list models.ListQuery) ([]*magazine.Story, models.PageInfo, error) {
+	return a.ListFromCreator(id, page, list)
 }
 
 // Get Stories from our database based on type
-func (a IStoryMgmtComp) GetStoryFromType(story_type string) ([]*magazine.Story, error) {
-	var story []*magazine.Story
-	sql, args, err := sqrl.Select("*").From("stories").Where(sqrl.Eq{"story_type": story_type}).
-		PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), a, &story, sql, args[:]...); err != nil {
-		return nil, err
-	}
-
-	return story, nil
+func (a IStoryMgmtComp) GetStoryFromType(story_type string, page models.Pagination, list models.ListQuery) ([]*magazine.Story, models.PageInfo, error) {
+	return a.Page(page, list, byCreatedOn, sqrl.Eq{"story_type": story_type})
 }
 
 // Delete Story soft deletes the Story in our database
 func (a IStoryMgmtComp) DeleteStory(id uuid.UUID) error {
-	sql, arg, err := sqrl.Update("stories").SetMap(gin.H{"deleted_on": time.Now()}).
-		Where(sqrl.Eq{"id": id}).PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return err
-	}
-
-	exec, err := a.Exec(context.Background(), sql, arg[:]...)
-	if err != nil {
-		return err
-	}
-
-	count := exec.RowsAffected()
-	if count != 1 {
-		return err
-	}
-
-	return nil
+	return a.Delete(id)
 }
 
 //Permanent Delete Story permanently deletes the Story in our database
 func (a IStoryMgmtComp) PermanentDeleteStory(id uuid.UUID) error {
-	sql, arg, err := sqrl.Delete("stories").Where(sqrl.Eq{"id": id}).PlaceholderFormat(sqrl.Dollar).ToSql()
-	if err != nil {
-		return err
-	}
-
-	exec, err := a.Exec(context.Background(), sql, arg[:]...)
-	if err != nil {
-		return err
-	}
-
-	count := exec.RowsAffected()
-	if count != 1 {
-		return err
-	}
-
-	return nil
+	return a.PermanentDelete(id)
 }
 
 //PatchStory updates the Story in our database
 func (a IStoryMgmtComp) PatchStory(id uuid.UUID, patch *map[string]interface{}) error {
-	sql, args, err := sqrl.Update("stories").SetMap(*patch).Where(sqrl.Eq{"id": id}).
-		PlaceholderFormat(sqrl.Dollar).ToSql()
+	return a.Patch(id, *patch)
+}
 
-	if err != nil {
-		return nil
-	}
+// ListStoriesFromContentCode lists stories in contents of the content code in order they were added
+func (a IStoryMgmtComp) ListStoriesFromContentCode(contentCode string) ([]*magazine.Story, error) {
+	return a.Select(sqrl.Select("s.*").From("stories s").
+		Join("contents c ON c.story_code = s.id").
+		Where(sqrl.Eq{"c.content_code": contentCode, "c.deleted_on": nil, "s.deleted_on": nil}).
+		OrderBy("c.created_on"))
+}
 
-	exec, err := a.Exec(context.Background(), sql, args[:]...)
-	if err != nil {
-		return err
+// ListPublishedStories lists published stories newest first, of the story type when it is given
+func (a IStoryMgmtComp) ListPublishedStories(storyType string, page models.Pagination, list models.ListQuery) ([]*magazine.Story, models.PageInfo, error) {
+	where := []sqrl.Sqlizer{sqrl.NotEq{"published_on": nil}}
+	if storyType != "" {
+		where = append(where, sqrl.Eq{"story_type": storyType})
 	}
 
-	if exec.RowsAffected() != 1 {
-		return errors.New("not updated")
-	}
+	return a.Page(page, list, byPublishedOn, where...)
+}
+
+// GetPublishedStoryFromSlug gets the published story of the slug
+func (a IStoryMgmtComp) GetPublishedStoryFromSlug(slug string) (*magazine.Story, error) {
+	return a.Get(sqrl.Eq{"slug": slug}, sqrl.NotEq{"published_on": nil})
+}
 
-	return nil
+// ListPublishedStoriesFromContentCode lists published stories in contents of the content code in order they were added
+func (a IStoryMgmtComp) ListPublishedStoriesFromContentCode(contentCode string) ([]*magazine.Story, error) {
+	return a.Select(sqrl.Select("s.*").From("stories s").
+		Join("contents c ON c.story_code = s.id").
+		Where(sqrl.Eq{"c.content_code": contentCode, "c.deleted_on": nil, "s.deleted_on": nil}).
+		Where(sqrl.NotEq{"s.published_on": nil}).
+		OrderBy("c.created_on"))
 }
@@ -0,0 +1,134 @@
+package component
+
+import (
+	"context"
+	"magazine_api/apperrors"
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"time"
+
+	"github.com/elgris/sqrl"
+	"github.com/google/uuid"
+)
+
+// tables of subscriptions, subscriptions are created by the user subscribing
+var (
+	plansTable         = Table{Name: "subscription_plans", Creator: "created_by"}
+	subscriptionsTable = Table{Name: "subscriptions", Creator: "user_id"}
+)
+
+// SubscriptionComponent database structure for subscription plans and subscriptions
+type SubscriptionComponent struct {
+	Beginner
+	plans         Repository[models.SubscriptionPlan]
+	subscriptions Repository[models.Subscription]
+}
+
+// NewSubscriptionComponent creates a new subscription component
+func NewSubscriptionComponent(db infrastructure.Database, logger lib.Logger) SubscriptionComponent {
+	return SubscriptionComponent{
+		Beginner:      db,
+		plans:         NewRepository[models.SubscriptionPlan](db, plansTable),
+		subscriptions: NewRepository[models.Subscription](db, subscriptionsTable),
+	}
+}
+
+// Creates subscription plan in our database
+func (s SubscriptionComponent) CreatePlan(plan models.SubscriptionPlan) error {
+	return s.plans.Insert(map[string]interface{}{
+		"id": plan.ID, "plan_name": plan.PlanName, "interval": plan.Interval, "format": plan.Format,
+		"price": plan.Price, "remarks": plan.Remarks, "created_on": plan.CreatedOn, "created_by": plan.CreatedBy,
+		"creator_name": plan.CreatorName,
+	})
+}
+
+// Lists page of subscription plans from our database
+func (s SubscriptionComponent) ListPlans(page models.Pagination, list models.ListQuery) ([]*models.SubscriptionPlan, models.PageInfo, error) {
+	return s.plans.Page(page, list, byCreatedOn)
+}
+
+// Get One subscription plan from our database based on id
+func (s SubscriptionComponent) GetPlanFromID(id uuid.UUID) (*models.SubscriptionPlan, error) {
+	return s.plans.GetFromID(id)
+}
+
+// PatchPlan updates the subscription plan in our database
+func (s SubscriptionComponent) PatchPlan(id uuid.UUID, patch *map[string]interface{}) error {
+	return s.plans.Patch(id, *patch)
+}
+
+// DeletePlan soft deletes the subscription plan in our database
+func (s SubscriptionComponent) DeletePlan(id uuid.UUID) error {
+	return s.plans.Delete(id)
+}
+
+// CreateSubscription creates subscription with the transaction of its payment in one transaction,
+// neither is inserted when the other fails
+func (s SubscriptionComponent) CreateSubscription(subscription models.Subscription, transaction models.Transaction) error {
+	ctx := context.Background()
+	tx, err := s.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	sql, args, err := insertTransaction(transaction)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, sql, args[:]...); err != nil {
+		return apperrors.From(err)
+	}
+
+	err = NewRepository[models.Subscription](tx, subscriptionsTable).Insert(map[string]interface{}{
+		"id": subscription.ID, "user_id": subscription.UserId, "plan_id": subscription.PlanId,
+		"start_date": subscription.StartDate, "end_date": subscription.EndDate, "status": subscription.Status,
+		"transaction_id": subscription.TransactionId, "remarks": subscription.Remarks,
+		"created_on": subscription.CreatedOn,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Get One subscription from our database based on id
+func (s SubscriptionComponent) GetSubscriptionFromID(id uuid.UUID) (*models.Subscription, error) {
+	return s.subscriptions.GetFromID(id)
+}
+
+// Lists subscriptions of user from our database
+func (s SubscriptionComponent) ListSubscriptionsFromUserID(userID uuid.UUID) ([]*models.Subscription, error) {
+	return s.subscriptions.Select(s.subscriptions.query(sqrl.Select("*"), sqrl.Eq{"user_id": userID}).OrderBy("end_date DESC"))
+}
+
+// Lists page of subscriptions of user from our database newest first
+func (s SubscriptionComponent) PageSubscriptionsFromUserID(userID uuid.UUID, page models.Pagination, list models.ListQuery) ([]*models.Subscription, models.PageInfo, error) {
+	return s.subscriptions.Page(page, list, byCreatedOnLatest, sqrl.Eq{"user_id": userID})
+}
+
+// Lists subscriptions of user active at given time with plan of the format
+func (s SubscriptionComponent) ListActiveSubscriptions(
+	userID uuid.UUID,
+	format models.SubscriptionFormat,
+	at time.Time,
+) ([]*models.Subscription, error) {
+	return s.subscriptions.Select(sqrl.Select("s.*").From("subscriptions s").
+		Join("subscription_plans p ON p.id = s.plan_id").
+		Where(sqrl.Eq{
+			"s.user_id":    userID,
+			"s.status":     models.SubscriptionActive,
+			"s.deleted_on": nil,
+			"p.format":     format,
+		}).
+		Where(sqrl.Expr("s.start_date <= ?", at)).
+		Where(sqrl.Expr("s.end_date >= ?", at)))
+}
+
+// PatchSubscription updates the subscription in our database
+func (s SubscriptionComponent) PatchSubscription(id uuid.UUID, patch *map[string]interface{}) error {
+	return s.subscriptions.Patch(id, *patch)
+}
@@ -0,0 +1,54 @@
+package component
+
+import (
+	"errors"
+	"magazine_api/models"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func newSubscription() (models.Subscription, models.Transaction) {
+	transaction := models.Transaction{Base: models.Base{ID: uuid.New()}}
+	subscription := models.Subscription{
+		Base:             models.Base{ID: uuid.New()},
+		SubscriptionBase: models.SubscriptionBase{TransactionId: &transaction.ID},
+	}
+	return subscription, transaction
+}
+
+func TestCreateSubscriptionWithItsTransaction(t *testing.T) {
+	db := newFakeBeginner(1)
+	comp := SubscriptionComponent{Beginner: db}
+
+	subscription, transaction := newSubscription()
+	if err := comp.CreateSubscription(subscription, transaction); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(db.statements) != 2 {
+		t.Fatalf("got statements %v, want insert of transaction and subscription", db.statements)
+	}
+	if !strings.HasPrefix(db.statements[0], "INSERT INTO transactions") ||
+		!strings.HasPrefix(db.statements[1], "INSERT INTO subscriptions") {
+		t.Errorf("got statements %v, want transaction inserted before its subscription", db.statements)
+	}
+	if !db.tx.committed {
+		t.Error("transaction not committed")
+	}
+}
+
+func TestCreateSubscriptionRollsBackTransaction(t *testing.T) {
+	db := newFakeBeginner(0)
+	comp := SubscriptionComponent{Beginner: db}
+
+	subscription, transaction := newSubscription()
+	if err := comp.CreateSubscription(subscription, transaction); !errors.Is(err, ErrNotInserted) {
+		t.Fatalf("got %v, want ErrNotInserted", err)
+	}
+
+	if db.tx.committed || !db.tx.rolledBack {
+		t.Error("payment kept for subscription which was not created")
+	}
+}
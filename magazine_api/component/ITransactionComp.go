@@ -2,7 +2,6 @@ package component
 
 import (
 	"context"
-	"errors"
 	"magazine_api/infrastructure"
 	"magazine_api/models"
 	"time"
@@ -24,15 +23,7 @@ func NewTransactionComponent(db infrastructure.Database) TransactionComponent {
 
 // Creates the Transaction in our Database
 func (t TransactionComponent) CreateTransaction(transaction models.Transaction) error {
-	sql, args, err := sqrl.Insert("transactions").
-		Columns("id", "title", "transaction_cost", "debit_amount", "credit_amount", "payment_to", "payment_from", "payment_date", "payment_month", "paid_type", "paid_medium", "bank_payment_from", "bank_payment_to", "bank_payment_transaction_id",
-			"online_payment_name",
-			"online_payment_from",
-			"online_payment_to",
-			"online_payment_transaction_id", "remarks",
-			"created_on").
-		Values(transaction.ID, transaction.Title, transaction.TransactionCost, transaction.DebitAmount, transaction.CreditAmount, transaction.PaymentTo, transaction.PaymentFrom, time.Now(), transaction.PaymentMonth, transaction.PaidType, transaction.PaidMedium, transaction.BankPaymentFrom, transaction.BankPaymentTo, transaction.BankPaymentTransactionId, transaction.OnlinePaymentName, transaction.OnlinePaymentFrom, transaction.OnlinePaymentTo, transaction.OnlinePaymentTransactionId, transaction.Remarks, transaction.CreatedOn).
-		PlaceholderFormat(sqrl.Dollar).ToSql()
+	sql, args, err := insertTransaction(transaction)
 	if err != nil {
 		return err
 	}
@@ -42,53 +33,20 @@ func (t TransactionComponent) CreateTransaction(transaction models.Transaction)
 	}
 
 	if exec.RowsAffected() != 1 {
-		return errors.New("not inserted")
+		return ErrNotInserted
 	}
 
 	return nil
 }
 
 //List deleted tailor assignments
-func (t TransactionComponent) ListDeletedTransactions(limit int64, offset int64) ([]*models.Transaction, error) {
-	var tailors []*models.Transaction
-	sql, args, err := sqrl.Select("*").
-		From("transactions"). // ?
-		Where(sqrl.NotEq{"deleted_on": nil}).
-		Limit(uint64(limit)).
-		Offset(uint64(offset)).
-		PlaceholderFormat(sqrl.Dollar).
-		ToSql()
-
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), t, &tailors, sql, args...); err != nil {
-		return nil, err
-	}
-
-	return tailors, nil
+func (t TransactionComponent) ListDeletedTransactions(page models.Pagination, list models.ListQuery) ([]*models.Transaction, models.PageInfo, error) {
+	return t.pageTransactions(page, list, sqrl.NotEq{"deleted_on": nil})
 }
 
 //Lists Transaction from database
-func (t TransactionComponent) ListTransactions(limit int64, offset int64) ([]*models.Transaction, error) {
-	var tailors []*models.Transaction
-	sql, args, err := sqrl.Select("*").
-		From("transactions").
-		Limit(uint64(limit)).
-		Offset(uint64(offset)).
-		PlaceholderFormat(sqrl.Dollar).
-		ToSql()
-
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), t, &tailors, sql, args...); err != nil {
-		return nil, err
-	}
-
-	return tailors, nil
+func (t TransactionComponent) ListTransactions(page models.Pagination, list models.ListQuery) ([]*models.Transaction, models.PageInfo, error) {
+	return t.pageTransactions(page, list)
 }
 
 // Gets single transaction from Database using ID
@@ -128,201 +86,61 @@ func (t TransactionComponent) GetTransactionFromID(id uuid.UUID) (*models.Transa
 // }
 
 //Get transaction from mediom
-func (t TransactionComponent) GetTransactionBeforeDate(date time.Time, limit int64, offset int64) ([]*models.Transaction, error) {
-	var transactions []*models.Transaction
-
-	sql, args, err := sqrl.Select("*").
-		From("transactions").
-		Where(sqrl.Eq{"deleted_on": nil}).
-		Where(sqrl.Expr("payment_date <= ?", date)).
-		Limit(uint64(limit)).
-		Offset(uint64(offset)).
-		PlaceholderFormat(sqrl.Dollar).
-		ToSql()
-
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), t, &transactions, sql, args...); err != nil {
-		return nil, err
-	}
-
-	return transactions, nil
+func (t TransactionComponent) GetTransactionBeforeDate(date time.Time, page models.Pagination, list models.ListQuery) ([]*models.Transaction, models.PageInfo, error) {
+	return t.pageTransactions(page, list, sqrl.Eq{"deleted_on": nil}, sqrl.Expr("payment_date <= ?", date))
 }
 
-func (t TransactionComponent) GetTransactionAfterDate(date time.Time, limit int64, offset int64) ([]*models.Transaction, error) {
-	var transactions []*models.Transaction
-
-	sql, args, err := sqrl.Select("*").
-		From("transactions").
-		Where(sqrl.Eq{"deleted_on": nil}).
-		Where(sqrl.Expr("payment_date >= ?", date)).
-		Limit(uint64(limit)).
-		Offset(uint64(offset)).
-		PlaceholderFormat(sqrl.Dollar).
-		ToSql()
-
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), t, &transactions, sql, args...); err != nil {
-		return nil, err
-	}
-
-	return transactions, nil
+func (t TransactionComponent) GetTransactionAfterDate(date time.Time, page models.Pagination, list models.ListQuery) ([]*models.Transaction, models.PageInfo, error) {
+	return t.pageTransactions(page, list, sqrl.Eq{"deleted_on": nil}, sqrl.Expr("payment_date >= ?", date))
 }
 
 //Get transaction from mediom
-func (t TransactionComponent) GetTransactionFromDate(date time.Time, limit int64, offset int64) ([]*models.Transaction, error) {
-	var transactions []*models.Transaction
-
-	sql, args, err := sqrl.Select("*").
-		From("transactions").
-		Where(sqrl.Eq{"deleted_on": nil}).
-		Where(sqrl.Eq{"payment_date": date}).
-		Limit(uint64(limit)).
-		Offset(uint64(offset)).
-		PlaceholderFormat(sqrl.Dollar).
-		ToSql()
-
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), t, &transactions, sql, args...); err != nil {
-		return nil, err
-	}
-
-	return transactions, nil
+func (t TransactionComponent) GetTransactionFromDate(date time.Time, page models.Pagination, list models.ListQuery) ([]*models.Transaction, models.PageInfo, error) {
+	return t.pageTransactions(page, list, sqrl.Eq{"deleted_on": nil}, sqrl.Eq{"payment_date": date})
 }
 
 //Get transaction from mediom
-func (t TransactionComponent) GetTransactionFromMonth(month string, limit int64, offset int64) ([]*models.Transaction, error) {
-	var transactions []*models.Transaction
-
-	sql, args, err := sqrl.Select("*").
-		From("transactions").
-		Where(sqrl.Eq{"deleted_on": nil}).
-		Where(sqrl.Eq{"payment_month": month}).
-		Limit(uint64(limit)).
-		Offset(uint64(offset)).
-		PlaceholderFormat(sqrl.Dollar).
-		ToSql()
-
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), t, &transactions, sql, args...); err != nil {
-		return nil, err
-	}
-
-	return transactions, nil
+func (t TransactionComponent) GetTransactionFromMonth(month string, page models.Pagination, list models.ListQuery) ([]*models.Transaction, models.PageInfo, error) {
+	return t.pageTransactions(page, list, sqrl.Eq{"deleted_on": nil}, sqrl.Eq{"payment_month": month})
 }
 
 //Get all transaction to account
-func (t TransactionComponent) GetTransactionToAccount(account uuid.UUID, limit int64, offset int64) ([]*models.Transaction, error) {
-	var transactions []*models.Transaction
-
-	sql, args, err := sqrl.Select("*").
-		From("transactions").
-		Where(sqrl.Eq{"deleted_on": nil}).
-		Where(sqrl.Eq{"payment_to": account}).
-		Limit(uint64(limit)).
-		Offset(uint64(offset)).
-		PlaceholderFormat(sqrl.Dollar).
-		ToSql()
-
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), t, &transactions, sql, args...); err != nil {
-		return nil, err
-	}
-
-	return transactions, nil
+func (t TransactionComponent) GetTransactionToAccount(account uuid.UUID, page models.Pagination, list models.ListQuery) ([]*models.Transaction, models.PageInfo, error) {
+	return t.pageTransactions(page, list, sqrl.Eq{"deleted_on": nil}, sqrl.Eq{"payment_to": account})
 }
 
 //GET all transaction from account
-func (t TransactionComponent) GetTransactionFromAccount(account uuid.UUID, limit int64, offset int64) ([]*models.Transaction, error) {
-	var transactions []*models.Transaction
-
-	sql, args, err := sqrl.Select("*").
-		From("transactions").
-		Where(sqrl.Eq{"deleted_on": nil}).
-		Where(sqrl.Eq{"payment_from": account}).
-		Limit(uint64(limit)).
-		Offset(uint64(offset)).
-		PlaceholderFormat(sqrl.Dollar).
-		ToSql()
-
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), t, &transactions, sql, args...); err != nil {
-		return nil, err
-	}
-
-	return transactions, nil
+func (t TransactionComponent) GetTransactionFromAccount(account uuid.UUID, page models.Pagination, list models.ListQuery) ([]*models.Transaction, models.PageInfo, error) {
+	return t.pageTransactions(page, list, sqrl.Eq{"deleted_on": nil}, sqrl.Eq{"payment_from": account})
 }
 
 //Get transaction from mediom
-func (t TransactionComponent) GetTransactionFromMedium(paid_medium string, limit int64, offset int64) ([]*models.Transaction, error) {
-	var transactions []*models.Transaction
-
-	sql, args, err := sqrl.Select("*").
-		From("transactions").
-		Where(sqrl.Eq{"deleted_on": nil}).
-		Where(sqrl.Eq{"paid_medium": paid_medium}).
-		Limit(uint64(limit)).
-		Offset(uint64(offset)).
-		PlaceholderFormat(sqrl.Dollar).
-		ToSql()
-
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), t, &transactions, sql, args...); err != nil {
-		return nil, err
-	}
-
-	return transactions, nil
+func (t TransactionComponent) GetTransactionFromMedium(paid_medium string, page models.Pagination, list models.ListQuery) ([]*models.Transaction, models.PageInfo, error) {
+	return t.pageTransactions(page, list, sqrl.Eq{"deleted_on": nil}, sqrl.Eq{"paid_medium": paid_medium})
 }
 
 //Get transaction from type
-func (t TransactionComponent) GetTransactionFromType(paid_type string, limit int64, offset int64) ([]*models.Transaction, error) {
-	var transactions []*models.Transaction
-
-	sql, args, err := sqrl.Select("*").
-		From("transactions").
-		Where(sqrl.Eq{"deleted_on": nil}).
-		Where(sqrl.Eq{"paid_type": paid_type}).
-		Limit(uint64(limit)).
-		Offset(uint64(offset)).
-		PlaceholderFormat(sqrl.Dollar).
-		ToSql()
-
-	if err != nil {
-		return nil, err
-	}
+func (t TransactionComponent) GetTransactionFromType(paid_type string, page models.Pagination, list models.ListQuery) ([]*models.Transaction, models.PageInfo, error) {
+	return t.pageTransactions(page, list, sqrl.Eq{"deleted_on": nil}, sqrl.Eq{"paid_type": paid_type})
+}
 
-	if err := pgxscan.Select(context.Background(), t, &transactions, sql, args...); err != nil {
-		return nil, err
+// pageTransactions lists page of transactions matching the conditions
+func (t TransactionComponent) pageTransactions(page models.Pagination, list models.ListQuery, where ...sqrl.Sqlizer) ([]*models.Transaction, models.PageInfo, error) {
+	var transactions []*models.Transaction
+	query := sqrl.Select().From("transactions")
+	for _, condition := range where {
+		query = query.Where(condition)
 	}
 
-	return transactions, nil
+	info, err := selectPage(t.Database, &transactions, query, page, list, byCreatedOn)
+	return transactions, info, err
 }
 
 // Updates transaction in our database
 func (t TransactionComponent) PatchTransaction(id uuid.UUID, patch *map[string]interface{}) error {
 	sql, args, err := sqrl.Update("transactions").SetMap(*patch).Where(sqrl.Eq{"id": id}).PlaceholderFormat(sqrl.Dollar).ToSql()
 	if err != nil {
-		return nil
+		return err
 	}
 
 	exec, err := t.Exec(context.Background(), sql, args[:]...)
@@ -331,7 +149,7 @@ func (t TransactionComponent) PatchTransaction(id uuid.UUID, patch *map[string]i
 	}
 
 	if exec.RowsAffected() != 1 {
-		return errors.New("not updated")
+		return ErrNotFound
 	}
 
 	return nil
@@ -353,7 +171,7 @@ func (t TransactionComponent) DeleteTransaction(id uuid.UUID) error {
 
 	count := exec.RowsAffected()
 	if count != 1 {
-		return err
+		return ErrNotFound
 	}
 
 	return nil
@@ -373,8 +191,22 @@ func (t TransactionComponent) PermanentDeleteTransaction(id uuid.UUID) error {
 
 	count := exec.RowsAffected()
 	if count != 1 {
-		return err
+		return ErrNotFound
 	}
 
 	return nil
 }
+
+// insertTransaction builds insert of the transaction, it is shared with inserts of transactions
+// posted together with other rows
+func insertTransaction(transaction models.Transaction) (string, []interface{}, error) {
+	return sqrl.Insert("transactions").
+		Columns("id", "title", "transaction_cost", "debit_amount", "credit_amount", "payment_to", "payment_from", "payment_date", "payment_month", "paid_type", "paid_medium", "bank_payment_from", "bank_payment_to", "bank_payment_transaction_id",
+			"online_payment_name",
+			"online_payment_from",
+			"online_payment_to",
+			"online_payment_transaction_id", "remarks",
+			"created_on").
+		Values(transaction.ID, transaction.Title, transaction.TransactionCost, transaction.DebitAmount, transaction.CreditAmount, transaction.PaymentTo, transaction.PaymentFrom, time.Now(), transaction.PaymentMonth, transaction.PaidType, transaction.PaidMedium, transaction.BankPaymentFrom, transaction.BankPaymentTo, transaction.BankPaymentTransactionId, transaction.OnlinePaymentName, transaction.OnlinePaymentFrom, transaction.OnlinePaymentTo, transaction.OnlinePaymentTransactionId, transaction.Remarks, transaction.CreatedOn).
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+}
@@ -0,0 +1,116 @@
+package component
+
+import (
+	"context"
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"time"
+
+	"github.com/elgris/sqrl"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// UploadComponent database structure for files uploaded straight to storage
+type UploadComponent struct {
+	infrastructure.Database
+}
+
+// NewUploadComponent creates a new upload component
+func NewUploadComponent(db infrastructure.Database, logger lib.Logger) UploadComponent {
+	return UploadComponent{db}
+}
+
+// CreateUpload records upload the client is about to make
+func (u UploadComponent) CreateUpload(upload models.DirectUpload) error {
+	sql, args, err := sqrl.Insert("direct_uploads").
+		Columns("id", "key", "file_name", "content_type", "size", "upload_id", "part_size", "status",
+			"uploaded_by", "expires_on", "created_on").
+		Values(upload.ID, upload.Key, upload.FileName, upload.ContentType, upload.Size, upload.UploadId,
+			upload.PartSize, upload.Status, upload.UploadedBy, upload.ExpiresOn, upload.CreatedOn).
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = u.Exec(context.Background(), sql, args[:]...)
+	return err
+}
+
+// Get One upload from our database based on id
+func (u UploadComponent) GetUploadFromID(id uuid.UUID) (*models.DirectUpload, error) {
+	var upload models.DirectUpload
+
+	sql, args, err := sqrl.Select("*").From("direct_uploads").
+		Where(sqrl.Eq{"id": id, "deleted_on": nil}).
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pgxscan.Get(context.Background(), u, &upload, sql, args[:]...); err != nil {
+		return nil, err
+	}
+
+	return &upload, nil
+}
+
+// CompleteUpload records media the pending upload became
+func (u UploadComponent) CompleteUpload(id uuid.UUID, mediaID uuid.UUID) error {
+	return u.finish(id, gin.H{
+		"status":     models.UploadCompleted,
+		"media_id":   mediaID,
+		"updated_on": time.Now(),
+	})
+}
+
+// AbortUpload marks the pending upload as aborted
+func (u UploadComponent) AbortUpload(id uuid.UUID) error {
+	return u.finish(id, gin.H{
+		"status":     models.UploadAborted,
+		"updated_on": time.Now(),
+	})
+}
+
+// finish updates the upload only while it is pending so that it is finished once
+func (u UploadComponent) finish(id uuid.UUID, patch gin.H) error {
+	sql, args, err := sqrl.Update("direct_uploads").SetMap(patch).
+		Where(sqrl.Eq{"id": id, "status": models.UploadPending}).
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	exec, err := u.Exec(context.Background(), sql, args[:]...)
+	if err != nil {
+		return err
+	}
+
+	if exec.RowsAffected() != 1 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ListExpiredUploads lists uploads still pending after they expired before given time
+func (u UploadComponent) ListExpiredUploads(before time.Time) ([]*models.DirectUpload, error) {
+	var uploads []*models.DirectUpload
+
+	sql, args, err := sqrl.Select("*").From("direct_uploads").
+		Where(sqrl.Eq{"status": models.UploadPending, "deleted_on": nil}).
+		Where(sqrl.Expr("expires_on < ?", before)).
+		OrderBy("expires_on").
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pgxscan.Select(context.Background(), u, &uploads, sql, args[:]...); err != nil {
+		return nil, err
+	}
+
+	return uploads, nil
+}
@@ -2,7 +2,6 @@ package component
 
 import (
 	"context"
-	"errors"
 	"magazine_api/api/serializers/responses"
 	"magazine_api/infrastructure"
 	"magazine_api/lib"
@@ -17,7 +16,7 @@ import (
 
 // UserComponent database structure
 type UserComponent struct {
-	infrastructure.Database
+	Querier
 }
 
 // NewUserComponent creates a new user component
@@ -41,7 +40,7 @@ func (u UserComponent) CreateUser(user models.User) error {
 	}
 
 	if exec.RowsAffected() != 1 {
-		return errors.New("not inserted")
+		return ErrNotInserted
 	}
 
 	return nil
@@ -64,68 +63,32 @@ func (u UserComponent) ListDeletedUsers() ([]*models.User, error) {
 	return users, nil
 }
 
-// Lists users from our database
-func (u UserComponent) ListEmployees(limit int64, offset int64) (map[string]interface{}, error) {
-	var users []*responses.EmployeeSmall
-	var count int64
-
-	sql, args, err := sqrl.
-		Select("employee_profile_id", "user_id", "name", "email", "role", "contact_number", "company_id").
-		From("employee_view").
-		Where(sqrl.Eq{"deleted_on": nil}).
-		Limit(uint64(limit)).
-		Offset(uint64(offset)).
-		PlaceholderFormat(sqrl.Dollar).
-		ToSql()
-
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), u, &users, sql, args...); err != nil {
-		return nil, err
-	}
+// employeeColumns columns of employees in lists
+var employeeColumns = []string{"employee_profile_id", "user_id", "name", "email", "role", "contact_number", "company_id"}
 
-	sql, args, err = sqrl.
-		Select("COUNT(*) AS total").
-		From("employee_view").
-		Where(sqrl.Eq{"deleted_on": nil}).
-		PlaceholderFormat(sqrl.Dollar).
-		ToSql()
-
-	if err != nil {
-		return nil, err
-	}
+// byEmployeeCreatedOn oldest employees first, employees of the view are told apart by their profile
+var byEmployeeCreatedOn = keyset{column: "created_on", field: "CreatedOn", idColumn: "employee_profile_id", idField: "EmployeeProfileId"}
 
-	if err := pgxscan.Get(context.Background(), u, &count, sql, args...); err != nil {
-		return nil, err
-	}
+// Lists page of employees from our database
+func (u UserComponent) ListEmployees(page models.Pagination, list models.ListQuery) ([]*responses.EmployeeSmall, models.PageInfo, error) {
+	return u.pageEmployees(page, list)
+}
 
-	return gin.H{"data": users, "count": count}, nil
+// Lists page of employees of the role from our database
+func (u UserComponent) ListEmployeesByType(userType string, page models.Pagination, list models.ListQuery) ([]*responses.EmployeeSmall, models.PageInfo, error) {
+	return u.pageEmployees(page, list, sqrl.Expr("? = ANY (role)", userType))
 }
 
-// Lists users by type from our database
-func (u UserComponent) ListEmployeesByType(limit int64, offset int64, userType string) ([]*responses.EmployeeSmall, error) {
+func (u UserComponent) pageEmployees(page models.Pagination, list models.ListQuery, where ...sqrl.Sqlizer) ([]*responses.EmployeeSmall, models.PageInfo, error) {
 	var users []*responses.EmployeeSmall
-	sql, args, err := sqrl.
-		Select("employee_profile_id", "user_id", "name", "email", "role", "contact_number", "company_id").
-		From("employee_view").
-		Where(sqrl.Eq{"deleted_on": nil}).
-		Where(sqrl.Expr("? = ANY (role)", userType)).
-		Limit(uint64(limit)).
-		Offset(uint64(offset)).
-		PlaceholderFormat(sqrl.Dollar).
-		ToSql()
-
-	if err != nil {
-		return nil, err
-	}
-
-	if err := pgxscan.Select(context.Background(), u, &users, sql, args...); err != nil {
-		return nil, err
+	query := sqrl.Select().From("employee_view").Where(sqrl.Eq{"deleted_on": nil})
+	for _, condition := range where {
+		query = query.Where(condition)
 	}
 
-	return users, nil
+	list.Columns = employeeColumns
+	info, err := selectPage(u.Querier, &users, query, page, list, byEmployeeCreatedOn)
+	return users, info, err
 }
 
 // Get One user from our database based on id
@@ -200,12 +163,29 @@ func (u UserComponent) GetUserFromID(id uuid.UUID) (*models.User, error) {
 	return &user, nil
 }
 
+// Get users from our database based on list of ids
+func (u UserComponent) GetUsersFromIDs(ids []uuid.UUID) ([]*models.User, error) {
+	var users []*models.User
+
+	sql, args, err := sqrl.Select("*").From("users").Where(sqrl.Eq{"id": ids}).
+		PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pgxscan.Select(context.Background(), u, &users, sql, args[:]...); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
 // Patch Users updates the user in our database
 func (u UserComponent) PatchUser(id uuid.UUID, patch *map[string]interface{}) error {
 	sql, args, err := sqrl.Update("users").SetMap(*patch).Where(sqrl.Eq{"id": id}).
 		PlaceholderFormat(sqrl.Dollar).ToSql()
 	if err != nil {
-		return nil
+		return err
 	}
 
 	exec, err := u.Exec(context.Background(), sql, args[:]...)
@@ -214,7 +194,7 @@ func (u UserComponent) PatchUser(id uuid.UUID, patch *map[string]interface{}) er
 	}
 
 	if exec.RowsAffected() != 1 {
-		return errors.New("not updated")
+		return ErrNotFound
 	}
 
 	return nil
@@ -235,7 +215,7 @@ func (u UserComponent) DeleteUser(id uuid.UUID) error {
 
 	count := exec.RowsAffected()
 	if count != 1 {
-		return err
+		return ErrNotFound
 	}
 
 	return nil
@@ -255,7 +235,7 @@ func (u UserComponent) PermanentDeleteUser(id uuid.UUID) error {
 
 	count := exec.RowsAffected()
 	if count != 1 {
-		return err
+		return ErrNotFound
 	}
 
 	return nil
@@ -2,7 +2,6 @@ package component
 
 import (
 	"context"
-	"errors"
 	"magazine_api/infrastructure"
 	"magazine_api/lib"
 	"magazine_api/models"
@@ -39,7 +38,7 @@ func (u UserProfileComponent) CreateUserProfile(profile models.UserProfile) erro
 	}
 
 	if exec.RowsAffected() != 1 {
-		return errors.New("not inserted")
+		return ErrNotInserted
 	}
 
 	return nil
@@ -72,7 +71,7 @@ func (u UserProfileComponent) PatchUserProfile(
 	sql, args, err := sqrl.Update("user_profiles").SetMap(*patch).Where(sqrl.Eq{"id": id}).
 		PlaceholderFormat(sqrl.Dollar).ToSql()
 	if err != nil {
-		return nil
+		return err
 	}
 
 	exec, err := u.Exec(context.Background(), sql, args[:]...)
@@ -81,7 +80,7 @@ func (u UserProfileComponent) PatchUserProfile(
 	}
 
 	if exec.RowsAffected() != 1 {
-		return errors.New("not updated")
+		return ErrNotFound
 	}
 
 	return nil
@@ -102,7 +101,7 @@ func (u UserProfileComponent) DeleteUserProfile(id uuid.UUID) error {
 
 	count := exec.RowsAffected()
 	if count != 1 {
-		return err
+		return ErrNotFound
 	}
 
 	return nil
@@ -125,7 +124,7 @@ func (u UserProfileComponent) PermanentDeleteUserProfile(id uuid.UUID) error {
 
 	count := exec.RowsAffected()
 	if count != 1 {
-		return err
+		return ErrNotFound
 	}
 
 	return nil
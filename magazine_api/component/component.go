@@ -13,4 +13,12 @@ var Module = fx.Options(
 	fx.Provide(NewIssueComp),
 	fx.Provide(NewMagazineComp),
 	fx.Provide(NewPhotographComp),
+	fx.Provide(NewSubscriptionComponent),
+	fx.Provide(NewAdBookingComponent),
+	fx.Provide(NewRoyaltyComponent),
+	fx.Provide(NewMediaComponent),
+	fx.Provide(NewUploadComponent),
+	fx.Provide(NewGalleryComponent),
+	fx.Provide(NewScheduleComponent),
+	fx.Provide(NewSlugComponent),
 )
@@ -0,0 +1,190 @@
+package component
+
+import (
+	"context"
+	"fmt"
+	"magazine_api/models"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/elgris/sqrl"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/google/uuid"
+)
+
+// keyset order of rows for cursor pages, column with the time of rows and field of the row struct it is scanned into.
+// Rows are told apart by idColumn scanned into idField, ID when it is not given
+type keyset struct {
+	column     string
+	field      string
+	idColumn   string
+	idField    string
+	descending bool
+}
+
+var (
+	// byCreatedOn oldest rows first
+	byCreatedOn = keyset{column: "created_on", field: "CreatedOn", idColumn: "id"}
+	// byCreatedOnLatest latest created rows first
+	byCreatedOnLatest = keyset{column: "created_on", field: "CreatedOn", idColumn: "id", descending: true}
+	// byPublishedOn latest published rows first
+	byPublishedOn = keyset{column: "published_on", field: "PublishedOn", idColumn: "id", descending: true}
+)
+
+// keysetOf keyset of time column sorted by
+func keysetOf(sort models.Sort) keyset {
+	field := ""
+	for _, word := range strings.Split(sort.Column, "_") {
+		field += strings.Title(word)
+	}
+	return keyset{column: sort.Column, field: field, idColumn: "id", descending: sort.Descending}
+}
+
+// applyFilters adds filters of the list to the query
+func applyFilters(query *sqrl.SelectBuilder, list models.ListQuery) *sqrl.SelectBuilder {
+	for _, filter := range list.Filters {
+		switch filter.Op {
+		case models.FilterEq, models.FilterIn:
+			query = query.Where(sqrl.Eq{filter.Column: filter.Value})
+		case models.FilterNe:
+			query = query.Where(sqrl.NotEq{filter.Column: filter.Value})
+		case models.FilterGt:
+			query = query.Where(sqrl.Gt{filter.Column: filter.Value})
+		case models.FilterGte:
+			query = query.Where(sqrl.GtOrEq{filter.Column: filter.Value})
+		case models.FilterLt:
+			query = query.Where(sqrl.Lt{filter.Column: filter.Value})
+		case models.FilterLte:
+			query = query.Where(sqrl.LtOrEq{filter.Column: filter.Value})
+		case models.FilterLike:
+			pattern := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(filter.Value.(string))
+			query = query.Where(filter.Column+" ILIKE ?", "%"+pattern+"%")
+		case models.FilterNull:
+			if filter.Value.(bool) {
+				query = query.Where(sqrl.Eq{filter.Column: nil})
+			} else {
+				query = query.Where(sqrl.NotEq{filter.Column: nil})
+			}
+		}
+	}
+
+	return query
+}
+
+// selectPage selects page of the query, built without columns, into dest, pointer to slice of row pointers, and
+// counts rows of the query. Rows are filtered and sorted by the list and are in order of the keyset when not sorted,
+// lists sorted by columns other than one time are paged by offset. Rows without time are ordered with the oldest rows
+func selectPage(db Querier, dest interface{}, query *sqrl.SelectBuilder, page models.Pagination, list models.ListQuery, order keyset) (models.PageInfo, error) {
+	var info models.PageInfo
+
+	if len(list.Sort) == 1 && list.Keyset() {
+		order = keysetOf(list.Sort[0])
+	}
+
+	query = applyFilters(query, list)
+	if len(list.Columns) > 0 {
+		query = query.Columns(withColumns(list.Columns, order.idColumn, order.column)...)
+	} else {
+		query = query.Columns("*")
+	}
+
+	countSQL, countArgs, err := sqrl.Select("COUNT(*)").FromSelect(query, "q").PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return info, err
+	}
+	if err := db.QueryRow(context.Background(), countSQL, countArgs...).Scan(&info.Total); err != nil {
+		return info, err
+	}
+
+	if list.Keyset() {
+		at := fmt.Sprintf("COALESCE(%s, 'epoch'::timestamp)", order.column)
+		direction, compare := "ASC", ">"
+		if order.descending {
+			direction, compare = "DESC", "<"
+		}
+
+		if page.Cursor != nil {
+			query = query.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", at, order.idColumn, compare), page.Cursor.At, page.Cursor.ID)
+		}
+		query = query.OrderBy(at+" "+direction, order.idColumn+" "+direction)
+	} else {
+		for _, sort := range list.Sort {
+			if sort.Descending {
+				query = query.OrderBy(sort.Column + " DESC NULLS LAST")
+			} else {
+				query = query.OrderBy(sort.Column + " ASC NULLS FIRST")
+			}
+		}
+		query = query.OrderBy(order.idColumn)
+	}
+	if page.Limit > 0 {
+		query = query.Limit(page.Limit + 1)
+	}
+	if page.Offset > 0 {
+		query = query.Offset(page.Offset)
+	}
+
+	sql, args, err := query.PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return info, err
+	}
+	if err := pgxscan.Select(context.Background(), db, dest, sql, args...); err != nil {
+		return info, err
+	}
+
+	rows := reflect.ValueOf(dest).Elem()
+	if page.Limit == 0 || uint64(rows.Len()) <= page.Limit {
+		return info, nil
+	}
+
+	rows.Set(rows.Slice(0, int(page.Limit)))
+	if !page.OffsetMode && list.Keyset() {
+		info.NextCursor = cursorOf(rows.Index(rows.Len()-1), order).Encode()
+	}
+
+	return info, nil
+}
+
+// withColumns columns with the columns needed to page rows added
+func withColumns(columns []string, needed ...string) []string {
+	selected := append([]string{}, columns...)
+	for _, column := range needed {
+		found := false
+		for _, c := range selected {
+			found = found || c == column
+		}
+		if !found {
+			selected = append(selected, column)
+		}
+	}
+
+	return selected
+}
+
+// cursorOf cursor at the row
+func cursorOf(row reflect.Value, order keyset) models.Cursor {
+	row = reflect.Indirect(row)
+
+	idField := order.idField
+	if idField == "" {
+		idField = "ID"
+	}
+
+	cursor := models.Cursor{At: time.Unix(0, 0).UTC()}
+	switch id := row.FieldByName(idField).Interface().(type) {
+	case uuid.UUID:
+		cursor.ID = id
+	case *uuid.UUID:
+		if id != nil {
+			cursor.ID = *id
+		}
+	}
+	if value := row.FieldByName(order.field); value.IsValid() {
+		if at, ok := value.Interface().(*time.Time); ok && at != nil {
+			cursor.At = *at
+		}
+	}
+
+	return cursor
+}
@@ -0,0 +1,302 @@
+package component
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"magazine_api/models"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elgris/sqrl"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgproto3/v2"
+	"github.com/jackc/pgx/v4"
+)
+
+// timedRow row of a list ordered by time, rows of some lists have no time
+type timedRow struct {
+	ID        uuid.UUID
+	CreatedOn *time.Time
+}
+
+// pageQuerier returns its rows for every query whatever its limit, like a table of more rows than
+// the page. Statements and arguments of the queries are recorded
+type pageQuerier struct {
+	rows       []timedRow
+	statements []string
+	args       [][]interface{}
+}
+
+func (p *pageQuerier) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return nil, errors.New("no statements in pages")
+}
+
+func (p *pageQuerier) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	p.statements = append(p.statements, sql)
+	p.args = append(p.args, args)
+	return &timedRows{rows: p.rows}, nil
+}
+
+func (p *pageQuerier) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return countRow{}
+}
+
+// timedRows rows scanned by scany, methods not needed for that are left to the nil interface
+type timedRows struct {
+	pgx.Rows
+	rows []timedRow
+	next int
+}
+
+func (r *timedRows) Next() bool {
+	r.next++
+	return r.next <= len(r.rows)
+}
+
+func (r *timedRows) Scan(dest ...interface{}) error {
+	*dest[0].(*uuid.UUID) = r.rows[r.next-1].ID
+	*dest[1].(**time.Time) = r.rows[r.next-1].CreatedOn
+	return nil
+}
+
+func (r *timedRows) FieldDescriptions() []pgproto3.FieldDescription {
+	return []pgproto3.FieldDescription{{Name: []byte("id")}, {Name: []byte("created_on")}}
+}
+
+func (r *timedRows) Err() error { return nil }
+func (r *timedRows) Close()     {}
+
+// newTimedRows rows created an hour apart from now, the rows of the indexes have no time
+func newTimedRows(n int, withoutTime ...int) []timedRow {
+	rows := make([]timedRow, n)
+	start := time.Now()
+	for i := range rows {
+		at := start.Add(time.Duration(i) * time.Hour)
+		rows[i] = timedRow{ID: uuid.New(), CreatedOn: &at}
+	}
+	for _, i := range withoutTime {
+		rows[i].CreatedOn = nil
+	}
+	return rows
+}
+
+func TestCursorOfRowRoundTrips(t *testing.T) {
+	at := time.Date(2026, 3, 14, 15, 9, 26, 535897932, time.FixedZone("NPT", 5*3600+45*60))
+	id := uuid.New()
+
+	tests := []struct {
+		name   string
+		row    interface{}
+		order  keyset
+		wantAt time.Time
+	}{
+		{"time with nanoseconds in other zone", &timedRow{ID: id, CreatedOn: &at}, byCreatedOn, at},
+		{"row without time", &timedRow{ID: id}, byCreatedOn, time.Unix(0, 0)},
+		{"id in pointer field", &struct {
+			ProfileId   *uuid.UUID
+			PublishedOn *time.Time
+		}{&id, &at}, keyset{column: "published_on", field: "PublishedOn", idColumn: "profile_id", idField: "ProfileId"}, at},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := models.DecodeCursor(cursorOf(reflect.ValueOf(tt.row), tt.order).Encode())
+			if err != nil {
+				t.Fatalf("cursor given out is refused: %v", err)
+			}
+			if !decoded.At.Equal(tt.wantAt) || decoded.ID != id {
+				t.Errorf("got cursor at %s of %s, want at %s of %s", decoded.At, decoded.ID, tt.wantAt, id)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorRefusesCursorsNotGivenOut(t *testing.T) {
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{"not base64", "not a cursor!"},
+		{"without id", encode("2026-03-14T15:09:26Z")},
+		{"time not RFC 3339", encode("14/03/2026," + uuid.NewString())},
+		{"id not uuid", encode("2026-03-14T15:09:26Z,42")},
+		{"empty", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := models.DecodeCursor(tt.encoded); !errors.Is(err, models.ErrInvalidCursor) {
+				t.Errorf("got %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestSelectPageOrdersRowsWithoutTimeAsOldest(t *testing.T) {
+	epoch := "COALESCE(created_on, 'epoch'::timestamp)"
+	published := "COALESCE(published_on, 'epoch'::timestamp)"
+	cursor := &models.Cursor{At: time.Unix(0, 0).UTC(), ID: uuid.New()}
+
+	tests := []struct {
+		name    string
+		order   keyset
+		list    models.ListQuery
+		orderBy string
+		after   string
+	}{
+		{"oldest first", byCreatedOn, models.ListQuery{},
+			"ORDER BY " + epoch + " ASC, id ASC", "(" + epoch + ", id) > ($1, $2)"},
+		{"latest first", byCreatedOnLatest, models.ListQuery{},
+			"ORDER BY " + epoch + " DESC, id DESC", "(" + epoch + ", id) < ($1, $2)"},
+		{"sorted by time column", byCreatedOn, models.ListQuery{Sort: []models.Sort{{Column: "published_on", Kind: models.ColumnTime, Descending: true}}},
+			"ORDER BY " + published + " DESC, id DESC", "(" + published + ", id) < ($1, $2)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &pageQuerier{}
+			var rows []*timedRow
+			if _, err := selectPage(db, &rows, sqrl.Select().From("rows"), models.Pagination{Limit: 10, Cursor: cursor}, tt.list, tt.order); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			sql := db.statements[0]
+			if !strings.Contains(sql, tt.orderBy) {
+				t.Errorf("got %q, want rows ordered by %s", sql, tt.orderBy)
+			}
+			if !strings.Contains(sql, tt.after) {
+				t.Errorf("got %q, want rows after the cursor by %s", sql, tt.after)
+			}
+			if at, ok := db.args[0][0].(time.Time); !ok || !at.Equal(cursor.At) {
+				t.Errorf("got cursor arguments %v, want the cursor at epoch", db.args[0])
+			}
+		})
+	}
+}
+
+func TestSelectPageNextCursor(t *testing.T) {
+	text := models.ListQuery{Sort: []models.Sort{{Column: "title", Kind: models.ColumnText}}}
+
+	tests := []struct {
+		name       string
+		rows       []timedRow
+		page       models.Pagination
+		list       models.ListQuery
+		wantRows   int
+		wantCursor bool
+	}{
+		{"row after the page", newTimedRows(4), models.Pagination{Limit: 3}, models.ListQuery{}, 3, true},
+		{"last row of the page without time", newTimedRows(4, 2), models.Pagination{Limit: 3}, models.ListQuery{}, 3, true},
+		{"last page", newTimedRows(3), models.Pagination{Limit: 3}, models.ListQuery{}, 3, false},
+		{"short last page", newTimedRows(2), models.Pagination{Limit: 3}, models.ListQuery{}, 2, false},
+		{"offset page", newTimedRows(4), models.Pagination{Limit: 3, Offset: 3, OffsetMode: true}, models.ListQuery{}, 3, false},
+		{"sorted by text", newTimedRows(4), models.Pagination{Limit: 3}, text, 3, false},
+		{"not paged", newTimedRows(4), models.Pagination{}, models.ListQuery{}, 4, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &pageQuerier{rows: tt.rows}
+			var rows []*timedRow
+			info, err := selectPage(db, &rows, sqrl.Select().From("rows"), tt.page, tt.list, byCreatedOn)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(rows) != tt.wantRows {
+				t.Fatalf("got %d rows, want %d", len(rows), tt.wantRows)
+			}
+			if tt.page.Limit > 0 && !strings.HasSuffix(strings.SplitN(db.statements[0], " OFFSET", 2)[0], "LIMIT 4") {
+				t.Errorf("got %q, want one row more than the page asked for", db.statements[0])
+			}
+
+			if !tt.wantCursor {
+				if info.NextCursor != "" {
+					t.Errorf("got next cursor %q, want none", info.NextCursor)
+				}
+				return
+			}
+
+			cursor, err := models.DecodeCursor(info.NextCursor)
+			if err != nil {
+				t.Fatalf("next cursor %q is refused: %v", info.NextCursor, err)
+			}
+			last := rows[len(rows)-1]
+			if cursor.ID != last.ID {
+				t.Errorf("next cursor at %s, want the last row of the page %s", cursor.ID, last.ID)
+			}
+			if last.CreatedOn == nil && !cursor.At.Equal(time.Unix(0, 0)) {
+				t.Errorf("next cursor of row without time at %s, want epoch", cursor.At)
+			}
+		})
+	}
+}
+
+func TestApplyFiltersEscapesLike(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"spring", `%spring%`},
+		{"50%", `%50\%%`},
+		{"snake_case", `%snake\_case%`},
+		{`back\slash`, `%back\\slash%`},
+		{`\%_`, `%\\\%\_%`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			list := models.ListQuery{Filters: []models.Filter{{Column: "story_title", Op: models.FilterLike, Value: tt.value}}}
+			sql, args, err := applyFilters(sqrl.Select("*").From("stories"), list).ToSql()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !strings.HasSuffix(sql, "WHERE story_title ILIKE ?") {
+				t.Errorf("got %q, want value matched by ILIKE as argument", sql)
+			}
+			if len(args) != 1 || args[0] != tt.want {
+				t.Errorf("got arguments %v, want pattern %s", args, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyFiltersOperators(t *testing.T) {
+	tests := []struct {
+		op    models.FilterOp
+		value interface{}
+		where string
+	}{
+		{models.FilterEq, "a", "c = ?"},
+		{models.FilterNe, "a", "c <> ?"},
+		{models.FilterGt, 1.0, "c > ?"},
+		{models.FilterGte, 1.0, "c >= ?"},
+		{models.FilterLt, 1.0, "c < ?"},
+		{models.FilterLte, 1.0, "c <= ?"},
+		{models.FilterIn, []interface{}{1.0, 2.0}, "c IN (?,?)"},
+		{models.FilterNull, true, "c IS NULL"},
+		{models.FilterNull, false, "c IS NOT NULL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.op), func(t *testing.T) {
+			list := models.ListQuery{Filters: []models.Filter{{Column: "c", Op: tt.op, Value: tt.value}}}
+			sql, _, err := applyFilters(sqrl.Select("*").From("t"), list).ToSql()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want := "SELECT * FROM t WHERE " + tt.where; sql != want {
+				t.Errorf("got %q, want %q", sql, want)
+			}
+		})
+	}
+}
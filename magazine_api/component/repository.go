@@ -0,0 +1,201 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"magazine_api/apperrors"
+	"magazine_api/models"
+	"time"
+
+	"github.com/elgris/sqrl"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+var (
+	// ErrNotInserted is returned when insert did not add the row
+	ErrNotInserted = errors.New("not inserted")
+	// ErrNotFound is returned when update or delete did not find the row, it wraps pgx.ErrNoRows
+	ErrNotFound = apperrors.Wrap(apperrors.NotFound, "resource not found", pgx.ErrNoRows)
+	// ErrEmptyPatch is returned for patches without any column
+	ErrEmptyPatch = apperrors.NewValidation("nothing to update")
+)
+
+// Querier runs statements of repositories, infrastructure.Database is one
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// Beginner begins transactions of components writing rows of more than one table together,
+// infrastructure.Database is one
+type Beginner interface {
+	Querier
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// Table of a resource, creator is the column of the user who created rows
+type Table struct {
+	Name    string
+	Creator string
+}
+
+// Repository rows of the table scanned into T. Rows soft deleted are left out of everything but
+// ListDeleted and PermanentDelete. Errors are translated by apperrors, rows missing or deleted are
+// not found wrapping pgx.ErrNoRows
+type Repository[T any] struct {
+	Querier
+	table Table
+}
+
+// NewRepository creates repository of rows of the table
+func NewRepository[T any](db Querier, table Table) Repository[T] {
+	return Repository[T]{Querier: db, table: table}
+}
+
+// Insert inserts row of the values by column
+func (r Repository[T]) Insert(values map[string]interface{}) error {
+	sql, args, err := sqrl.Insert(r.table.Name).SetMap(values).PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	exec, err := r.Exec(context.Background(), sql, args...)
+	if err != nil {
+		return apperrors.From(err)
+	}
+
+	if exec.RowsAffected() != 1 {
+		return ErrNotInserted
+	}
+
+	return nil
+}
+
+// Page lists page of rows matching the conditions, see selectPage
+func (r Repository[T]) Page(page models.Pagination, list models.ListQuery, order keyset, where ...sqrl.Sqlizer) ([]*T, models.PageInfo, error) {
+	var rows []*T
+	query := r.query(sqrl.Select(), where...)
+
+	info, err := selectPage(r.Querier, &rows, query, page, list, order)
+	if err != nil {
+		return nil, info, apperrors.From(err)
+	}
+
+	return rows, info, nil
+}
+
+// List lists rows matching the conditions oldest first
+func (r Repository[T]) List(where ...sqrl.Sqlizer) ([]*T, error) {
+	return r.Select(r.query(sqrl.Select("*"), where...).OrderBy("created_on", "id"))
+}
+
+// ListDeleted lists rows soft deleted
+func (r Repository[T]) ListDeleted() ([]*T, error) {
+	return r.Select(sqrl.Select("*").From(r.table.Name).Where(sqrl.NotEq{"deleted_on": nil}).OrderBy("deleted_on", "id"))
+}
+
+// ListFromCreator lists page of rows created by the user, see selectPage
+func (r Repository[T]) ListFromCreator(id uuid.UUID, page models.Pagination, list models.ListQuery) ([]*T, models.PageInfo, error) {
+	return r.Page(page, list, byCreatedOn, sqrl.Eq{r.table.Creator: id})
+}
+
+// Get gets the row matching the conditions
+func (r Repository[T]) Get(where ...sqrl.Sqlizer) (*T, error) {
+	return r.GetOne(r.query(sqrl.Select("*"), where...))
+}
+
+// GetFromID gets the row of the id
+func (r Repository[T]) GetFromID(id uuid.UUID) (*T, error) {
+	return r.Get(sqrl.Eq{"id": id})
+}
+
+// Select lists rows of the query
+func (r Repository[T]) Select(query *sqrl.SelectBuilder) ([]*T, error) {
+	var rows []*T
+	sql, args, err := query.PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pgxscan.Select(context.Background(), r, &rows, sql, args...); err != nil {
+		return nil, apperrors.From(err)
+	}
+
+	return rows, nil
+}
+
+// GetOne gets the row of the query
+func (r Repository[T]) GetOne(query *sqrl.SelectBuilder) (*T, error) {
+	var row T
+	sql, args, err := query.PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pgxscan.Get(context.Background(), r, &row, sql, args...); err != nil {
+		return nil, apperrors.From(err)
+	}
+
+	return &row, nil
+}
+
+// Patch updates columns of the row of the id
+func (r Repository[T]) Patch(id uuid.UUID, patch map[string]interface{}) error {
+	if len(patch) == 0 {
+		return ErrEmptyPatch
+	}
+
+	return r.update(sqrl.Update(r.table.Name).SetMap(patch).Where(sqrl.Eq{"id": id, "deleted_on": nil}))
+}
+
+// Delete soft deletes the row of the id
+func (r Repository[T]) Delete(id uuid.UUID) error {
+	return r.update(sqrl.Update(r.table.Name).Set("deleted_on", time.Now()).Where(sqrl.Eq{"id": id, "deleted_on": nil}))
+}
+
+// PermanentDelete deletes the row of the id, soft deleted or not
+func (r Repository[T]) PermanentDelete(id uuid.UUID) error {
+	sql, args, err := sqrl.Delete(r.table.Name).Where(sqrl.Eq{"id": id}).PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	return r.exec(sql, args)
+}
+
+// query selects from the table rows not deleted matching the conditions
+func (r Repository[T]) query(query *sqrl.SelectBuilder, where ...sqrl.Sqlizer) *sqrl.SelectBuilder {
+	query = query.From(r.table.Name).Where(sqrl.Eq{"deleted_on": nil})
+	for _, condition := range where {
+		query = query.Where(condition)
+	}
+
+	return query
+}
+
+func (r Repository[T]) update(query *sqrl.UpdateBuilder) error {
+	sql, args, err := query.PlaceholderFormat(sqrl.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	return r.exec(sql, args)
+}
+
+// exec runs the statement, rows not found are ErrNotFound
+func (r Repository[T]) exec(sql string, args []interface{}) error {
+	exec, err := r.Exec(context.Background(), sql, args...)
+	if err != nil {
+		return apperrors.From(err)
+	}
+
+	if exec.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,358 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"magazine_api/apperrors"
+	"magazine_api/infrastructure"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// fakeQuerier records statements, queries return no rows and statements affect affected rows
+type fakeQuerier struct {
+	statements []string
+	affected   int64
+}
+
+func (f *fakeQuerier) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	f.statements = append(f.statements, sql)
+	return pgconn.CommandTag(fmt.Sprintf("UPDATE %d", f.affected)), nil
+}
+
+func (f *fakeQuerier) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	f.statements = append(f.statements, sql)
+	return emptyRows{}, nil
+}
+
+func (f *fakeQuerier) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	f.statements = append(f.statements, sql)
+	return countRow{}
+}
+
+// last statement run
+func (f *fakeQuerier) last() string {
+	if len(f.statements) == 0 {
+		return ""
+	}
+	return f.statements[len(f.statements)-1]
+}
+
+// fakeTx transaction running its statements on db, methods not needed are left to the nil interface
+type fakeTx struct {
+	pgx.Tx
+	db         *fakeQuerier
+	committed  bool
+	rolledBack bool
+}
+
+func (f *fakeTx) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return f.db.Exec(ctx, sql, args...)
+}
+
+func (f *fakeTx) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return f.db.Query(ctx, sql, args...)
+}
+
+func (f *fakeTx) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return f.db.QueryRow(ctx, sql, args...)
+}
+
+func (f *fakeTx) Commit(ctx context.Context) error {
+	f.committed = true
+	return nil
+}
+
+// Rollback after commit does nothing like rollback of pgx
+func (f *fakeTx) Rollback(ctx context.Context) error {
+	if !f.committed {
+		f.rolledBack = true
+	}
+	return nil
+}
+
+// fakeBeginner database of fakeQuerier beginning the one transaction tx
+type fakeBeginner struct {
+	*fakeQuerier
+	tx *fakeTx
+}
+
+func newFakeBeginner(affected int64) *fakeBeginner {
+	db := &fakeQuerier{affected: affected}
+	return &fakeBeginner{fakeQuerier: db, tx: &fakeTx{db: db}}
+}
+
+func (f *fakeBeginner) Begin(ctx context.Context) (pgx.Tx, error) {
+	return f.tx, nil
+}
+
+// emptyRows rows of a query without result, methods not needed for that are left to the nil interface
+type emptyRows struct {
+	pgx.Rows
+}
+
+func (emptyRows) Next() bool                     { return false }
+func (emptyRows) Err() error                     { return nil }
+func (emptyRows) Close()                         {}
+func (emptyRows) Values() ([]interface{}, error) { return nil, nil }
+
+// countRow row of COUNT(*) with no rows counted
+type countRow struct{}
+
+func (countRow) Scan(dest ...interface{}) error {
+	reflect.ValueOf(dest[0]).Elem().SetInt(0)
+	return nil
+}
+
+type row struct {
+	ID uuid.UUID
+}
+
+func newFakeRepository() (Repository[row], *fakeQuerier) {
+	db := &fakeQuerier{}
+	return NewRepository[row](db, Table{Name: "rows", Creator: "created_by"}), db
+}
+
+func TestRepositoryLeavesOutSoftDeletedRows(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name string
+		run  func(r Repository[row]) error
+	}{
+		{"List", func(r Repository[row]) error { _, err := r.List(); return err }},
+		{"Page", func(r Repository[row]) error {
+			_, _, err := r.Page(models.Pagination{Limit: 10}, models.ListQuery{}, byCreatedOn)
+			return err
+		}},
+		{"ListFromCreator", func(r Repository[row]) error {
+			_, _, err := r.ListFromCreator(id, models.Pagination{Limit: 10}, models.ListQuery{})
+			return err
+		}},
+		{"Get", func(r Repository[row]) error { _, _ = r.GetFromID(id); return nil }},
+		{"Patch", func(r Repository[row]) error { return r.Patch(id, map[string]interface{}{"remarks": "x"}) }},
+		{"Delete", func(r Repository[row]) error { return r.Delete(id) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, db := newFakeRepository()
+			db.affected = 1
+			if err := tt.run(r); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(db.statements) == 0 {
+				t.Fatal("no statement run")
+			}
+			for _, sql := range db.statements {
+				if !strings.Contains(sql, "deleted_on IS NULL") {
+					t.Errorf("statement does not leave out deleted rows: %s", sql)
+				}
+			}
+		})
+	}
+}
+
+func TestRepositoryListDeletedListsOnlySoftDeletedRows(t *testing.T) {
+	r, db := newFakeRepository()
+	if _, err := r.ListDeleted(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(db.last(), "deleted_on IS NOT NULL") {
+		t.Errorf("statement lists rows not deleted: %s", db.last())
+	}
+}
+
+func TestRepositoryPermanentDeleteIncludesSoftDeletedRows(t *testing.T) {
+	r, db := newFakeRepository()
+	db.affected = 1
+	if err := r.PermanentDelete(uuid.New()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(db.last(), "deleted_on") {
+		t.Errorf("statement leaves out deleted rows: %s", db.last())
+	}
+}
+
+func TestRepositoryNotFoundWhenNoRowAffected(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name string
+		run  func(r Repository[row]) error
+	}{
+		{"Patch", func(r Repository[row]) error { return r.Patch(id, map[string]interface{}{"remarks": "x"}) }},
+		{"Delete", func(r Repository[row]) error { return r.Delete(id) }},
+		{"PermanentDelete", func(r Repository[row]) error { return r.PermanentDelete(id) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := newFakeRepository()
+			err := tt.run(r)
+			if !errors.Is(err, ErrNotFound) {
+				t.Errorf("got %v, want ErrNotFound", err)
+			}
+			if !errors.Is(err, pgx.ErrNoRows) {
+				t.Errorf("got %v, want it to wrap pgx.ErrNoRows", err)
+			}
+		})
+	}
+}
+
+func TestRepositoryGetNotFoundWithoutRow(t *testing.T) {
+	r, _ := newFakeRepository()
+	if _, err := r.GetFromID(uuid.New()); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("got %v, want it to wrap pgx.ErrNoRows", err)
+	}
+}
+
+func TestRepositoryPatchWithoutColumns(t *testing.T) {
+	r, db := newFakeRepository()
+	db.affected = 1
+
+	if err := r.Patch(uuid.New(), map[string]interface{}{}); !errors.Is(err, ErrEmptyPatch) {
+		t.Errorf("got %v, want ErrEmptyPatch", err)
+	}
+	if len(db.statements) != 0 {
+		t.Errorf("empty patch ran statements: %v", db.statements)
+	}
+}
+
+func TestListFromCreatorColumnOfTables(t *testing.T) {
+	id := uuid.New()
+	page, list := models.Pagination{Limit: 10}, models.ListQuery{}
+	tests := []struct {
+		table  string
+		column string
+		run    func(db Querier) error
+	}{
+		{"stories", "creator_id", func(db Querier) error {
+			comp := NewStoryComp(infrastructure.Database{}, lib.Logger{})
+			comp.Querier = db
+			_, _, err := comp.GetStoryFromCreatorId(id, page, list)
+			return err
+		}},
+		{"magazine_issues", "created_by", func(db Querier) error {
+			comp := NewIssueComp(infrastructure.Database{}, lib.Logger{})
+			comp.Querier = db
+			_, _, err := comp.GetIssueFromCreatorId(id, page, list)
+			return err
+		}},
+		{"contents", "created_by", func(db Querier) error {
+			comp := NewContentComp(infrastructure.Database{}, lib.Logger{})
+			comp.Querier = db
+			_, _, err := comp.GetContentFromCreatorId(id, page, list)
+			return err
+		}},
+		{"magazines", "created_by", func(db Querier) error {
+			comp := NewMagazineComp(infrastructure.Database{}, lib.Logger{})
+			comp.Querier = db
+			_, _, err := comp.GetMagazineFromCreatorId(id, page, list)
+			return err
+		}},
+		{"photographs", "created_by", func(db Querier) error {
+			comp := NewPhotographComp(infrastructure.Database{}, lib.Logger{})
+			comp.Querier = db
+			_, _, err := comp.GetPhotoFromCreatorId(id, page, list)
+			return err
+		}},
+		{"adverts", "created_by", func(db Querier) error {
+			comp := NewAdMgmtComp(infrastructure.Database{}, lib.Logger{})
+			comp.Querier = db
+			_, _, err := comp.GetAdFromCreatorId(id, page, list)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.table, func(t *testing.T) {
+			db := &fakeQuerier{}
+			if err := tt.run(db); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(db.statements) == 0 {
+				t.Fatal("no statement run")
+			}
+			for _, statement := range db.statements {
+				if !strings.Contains(statement, "FROM "+tt.table) {
+					t.Errorf("statement does not select from %s: %s", tt.table, statement)
+				}
+				if !strings.Contains(statement, tt.column+" = $") {
+					t.Errorf("statement does not filter by %s: %s", tt.column, statement)
+				}
+			}
+		})
+	}
+}
+
+func TestPatchesOfComponentsLeaveOutDeletedRows(t *testing.T) {
+	id := uuid.New()
+	patch := &map[string]interface{}{"remarks": "x"}
+	tests := []struct {
+		name string
+		run  func(db Querier) error
+	}{
+		{"PatchPlan", func(db Querier) error {
+			comp := NewSubscriptionComponent(infrastructure.Database{}, lib.Logger{})
+			comp.plans.Querier = db
+			return comp.PatchPlan(id, patch)
+		}},
+		{"PatchSubscription", func(db Querier) error {
+			comp := NewSubscriptionComponent(infrastructure.Database{}, lib.Logger{})
+			comp.subscriptions.Querier = db
+			return comp.PatchSubscription(id, patch)
+		}},
+		{"PatchAdvertiser", func(db Querier) error {
+			comp := NewAdBookingComponent(infrastructure.Database{}, lib.Logger{})
+			comp.advertisers.Querier = db
+			return comp.PatchAdvertiser(id, patch)
+		}},
+		{"PatchRateCard", func(db Querier) error {
+			comp := NewAdBookingComponent(infrastructure.Database{}, lib.Logger{})
+			comp.rateCards.Querier = db
+			return comp.PatchRateCard(id, patch)
+		}},
+		{"PatchBooking", func(db Querier) error {
+			comp := NewAdBookingComponent(infrastructure.Database{}, lib.Logger{})
+			comp.bookings.Querier = db
+			return comp.PatchBooking(id, patch)
+		}},
+		{"PatchInvoice", func(db Querier) error {
+			comp := NewAdBookingComponent(infrastructure.Database{}, lib.Logger{})
+			comp.invoices.Querier = db
+			return comp.PatchInvoice(id, patch)
+		}},
+		{"PatchAgreement", func(db Querier) error {
+			comp := NewRoyaltyComponent(infrastructure.Database{}, lib.Logger{})
+			comp.agreements.Querier = db
+			return comp.PatchAgreement(id, patch)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &fakeQuerier{affected: 1}
+			if err := tt.run(db); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(db.last(), "deleted_on IS NULL") {
+				t.Errorf("patch updates deleted rows: %s", db.last())
+			}
+
+			if err := tt.run(&fakeQuerier{}); !errors.Is(err, ErrNotFound) || !apperrors.Is(err, apperrors.NotFound) {
+				t.Errorf("patch of missing row got %v, want not found", err)
+			}
+		})
+	}
+}
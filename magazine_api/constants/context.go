@@ -21,4 +21,19 @@ const (
 
 	// Offset
 	Offset = "Offset"
+
+	// ResolvedURLs urls of stored objects resolved in the request
+	ResolvedURLs = "@resolved_urls"
+
+	// Entitled whether the request was found entitled to full content
+	Entitled = "@entitled"
+
+	// Pagination page of the list asked for by the request
+	Pagination = "@pagination"
+
+	// ListQuery filters, sort and fields of the list asked for by the request
+	ListQuery = "@list_query"
+
+	// RequestID id of the request, sent back in X-Request-ID header
+	RequestID = "@request_id"
 )
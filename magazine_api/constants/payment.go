@@ -54,6 +54,9 @@ var PaidType = map[string]string{
 
 	"Advance Salary Payment Return": "AdvanceSalaryPaymentReturn",
 	"Store Payment Recieve":         "StorePaymentRecieve",
+	"Subscription Payment":          "SubscriptionPayment",
+	"Advert Payment":                "AdvertPayment",
+	"Royalty Payment":               "RoyaltyPayment",
 }
 
 var ItemType = map[string]string{
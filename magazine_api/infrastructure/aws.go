@@ -33,7 +33,8 @@ func NewS3Client(cfg aws.Config) *s3.Client {
 	return s3.NewFromConfig(cfg)
 }
 
-func NewCognitoClient(cfg aws.Config) *cognitoidentityprovider.Client {
+// NewCognitoClient new cognito user pool client
+func NewCognitoClient(cfg aws.Config) lib.CognitoClient {
 	return cognitoidentityprovider.NewFromConfig(cfg)
 }
 
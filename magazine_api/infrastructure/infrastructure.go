@@ -12,4 +12,6 @@ var Module = fx.Options(
 	fx.Provide(NewCognitoClient),
 	fx.Provide(NewPresignClient),
 	fx.Provide(NewS3Uploader),
+	fx.Provide(NewStorage),
+	fx.Provide(NewScanner),
 )
@@ -29,6 +29,7 @@ func NewRouter(env lib.Env, logger lib.Logger) Router {
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"PUT", "PATCH", "GET", "POST", "OPTIONS", "DELETE"},
 		AllowHeaders:     []string{"*"},
+		ExposeHeaders:    []string{"X-Request-ID"},
 		AllowCredentials: true,
 	}))
 
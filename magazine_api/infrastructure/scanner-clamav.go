@@ -0,0 +1,98 @@
+package infrastructure
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"magazine_api/lib"
+	"net"
+	"strings"
+	"time"
+)
+
+// clamAVChunkSize size of chunks file is streamed to clamd in
+const clamAVChunkSize = 64 << 10
+
+// ClamAVScanner scans files with clamd over TCP using its INSTREAM command
+type ClamAVScanner struct {
+	address string
+	timeout time.Duration
+}
+
+// NewClamAVScanner creates scanner of clamd listening at CLAMAV_ADDRESS
+func NewClamAVScanner(env lib.Env) ClamAVScanner {
+	return ClamAVScanner{
+		address: env.ClamAVAddress,
+		timeout: env.ClamAVTimeout,
+	}
+}
+
+// Scan streams the file to clamd, signature clamd found is in the returned error
+func (s ClamAVScanner) Scan(ctx context.Context, file io.Reader) error {
+	dialer := net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return fmt.Errorf("connecting clamd: %w", err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(s.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return err
+	}
+
+	chunk := make([]byte, clamAVChunkSize)
+	size := make([]byte, 4)
+	for {
+		n, err := file.Read(chunk)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, err := conn.Write(size); err != nil {
+				return err
+			}
+			if _, err := conn.Write(chunk[:n]); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// zero length chunk ends the stream
+	binary.BigEndian.PutUint32(size, 0)
+	if _, err := conn.Write(size); err != nil {
+		return err
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("reading clamd reply: %w", err)
+	}
+
+	return clamAVResult(strings.TrimRight(reply, "\x00\n"))
+}
+
+// clamAVResult parses reply of clamd like `stream: OK` or `stream: Eicar-Signature FOUND`
+func clamAVResult(reply string) error {
+	result := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+	switch {
+	case result == "OK":
+		return nil
+	case strings.HasSuffix(result, " FOUND"):
+		return fmt.Errorf("%w: %s", lib.ErrMalwareFound, strings.TrimSuffix(result, " FOUND"))
+	}
+	return fmt.Errorf("clamd: %s", reply)
+}
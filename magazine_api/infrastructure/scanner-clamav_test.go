@@ -0,0 +1,38 @@
+package infrastructure
+
+import (
+	"errors"
+	"magazine_api/lib"
+	"strings"
+	"testing"
+)
+
+func TestClamAVResult(t *testing.T) {
+	tests := []struct {
+		reply       string
+		wantErr     bool
+		wantMalware bool
+		wantName    string
+	}{
+		{reply: "stream: OK"},
+		{reply: "stream: Eicar-Signature FOUND", wantErr: true, wantMalware: true, wantName: "Eicar-Signature"},
+		{reply: "stream: Win.Test.EICAR_HDB-1 FOUND", wantErr: true, wantMalware: true, wantName: "Win.Test.EICAR_HDB-1"},
+		{reply: "INSTREAM size limit exceeded. ERROR", wantErr: true},
+		{reply: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reply, func(t *testing.T) {
+			err := clamAVResult(tt.reply)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if errors.Is(err, lib.ErrMalwareFound) != tt.wantMalware {
+				t.Errorf("got %v, want malware found %v", err, tt.wantMalware)
+			}
+			if tt.wantName != "" && !strings.Contains(err.Error(), tt.wantName) {
+				t.Errorf("got %v, want it to name %s", err, tt.wantName)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package infrastructure
+
+import "magazine_api/lib"
+
+// NewScanner creates malware scanner, files are scanned with clamd when CLAMAV_ADDRESS is set
+func NewScanner(env lib.Env, logger lib.Logger) lib.Scanner {
+	if env.ClamAVAddress == "" {
+		logger.Info("malware scanning of uploads is disabled")
+		return lib.StubScanner{}
+	}
+
+	logger.Info("scanning uploads with clamd at ", env.ClamAVAddress)
+	return NewClamAVScanner(env)
+}
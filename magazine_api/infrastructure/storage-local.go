@@ -0,0 +1,383 @@
+package infrastructure
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"magazine_api/lib"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidKey is returned when object key escapes the storage directory
+var ErrInvalidKey = errors.New("invalid object key")
+
+const (
+	// LocalStoragePath route where the API serves objects of local storage
+	LocalStoragePath = "/api/v1/storage/"
+
+	// localUploadsPrefix keys of parts of multipart uploads in progress
+	localUploadsPrefix = ".uploads/"
+)
+
+// LocalStorage object storage in local filesystem, objects are downloaded from
+// the API through HMAC signed urls
+type LocalStorage struct {
+	root    string
+	secret  string
+	baseURL string
+}
+
+// NewLocalStorage creates storage in directory of STORAGE_LOCAL_PATH, it fails when
+// STORAGE_SIGNING_KEY is too short to sign its urls
+func NewLocalStorage(env lib.Env) (LocalStorage, error) {
+	if len(env.StorageSigningKey) < lib.MinSigningKeySize {
+		return LocalStorage{}, lib.ErrWeakSigningKey
+	}
+
+	return LocalStorage{
+		root:    env.StorageLocalPath,
+		secret:  env.StorageSigningKey,
+		baseURL: strings.TrimSuffix(env.StorageBaseURL, "/"),
+	}, nil
+}
+
+// Put writes the object to file
+func (l LocalStorage) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	file, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+
+	out, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, body); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+// Get opens file of the object
+func (l LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	file, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	in, err := os.Open(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, lib.ErrObjectNotFound
+	}
+
+	return in, err
+}
+
+// Delete removes file of the object
+func (l LocalStorage) Delete(ctx context.Context, key string) error {
+	file, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// Presign creates url of the API serving the object signed with STORAGE_SIGNING_KEY
+func (l LocalStorage) Presign(ctx context.Context, key string, expires time.Duration) (string, error) {
+	if _, err := l.path(key); err != nil {
+		return "", err
+	}
+
+	expiry := time.Now().Add(expires).Unix()
+	query := url.Values{}
+	query.Set("expires", fmt.Sprint(expiry))
+	query.Set("signature", lib.SignObjectKey(l.secret, key, expiry))
+
+	return l.baseURL + LocalStoragePath + key + "?" + query.Encode(), nil
+}
+
+// List lists keys of files starting with prefix
+func (l LocalStorage) List(ctx context.Context, prefix string) ([]string, error) {
+	keys := []string{}
+	err := filepath.WalkDir(l.root, func(file string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(l.root, file)
+		if err != nil {
+			return err
+		}
+
+		key := filepath.ToSlash(rel)
+		if strings.HasPrefix(key, prefix) && !strings.HasPrefix(key, localUploadsPrefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// Stat gets size of the file, content type is guessed from extension of the key
+func (l LocalStorage) Stat(ctx context.Context, key string) (*lib.ObjectInfo, error) {
+	file, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, lib.ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &lib.ObjectInfo{
+		Size:        info.Size(),
+		ContentType: mime.TypeByExtension(path.Ext(key)),
+	}, nil
+}
+
+// PresignPut creates url of the API receiving the object signed with STORAGE_SIGNING_KEY,
+// body larger than the declared size is refused
+func (l LocalStorage) PresignPut(
+	ctx context.Context,
+	key, contentType string,
+	size int64,
+	expires time.Duration,
+) (string, error) {
+	return l.presignPut(key, size, expires)
+}
+
+// CreateMultipartUpload creates directory keeping parts of the upload
+func (l LocalStorage) CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error) {
+	if _, err := l.path(key); err != nil {
+		return "", err
+	}
+
+	uploadID := uuid.NewString()
+	if err := l.Put(ctx, l.uploadKey(uploadID, "key"), strings.NewReader(key), ""); err != nil {
+		return "", err
+	}
+
+	return uploadID, nil
+}
+
+// PresignUploadPart creates url of the API receiving the part, parts are refused
+// when larger than S3 allows
+func (l LocalStorage) PresignUploadPart(
+	ctx context.Context,
+	key, uploadID string,
+	partNumber int32,
+	expires time.Duration,
+) (string, error) {
+	if err := l.checkUpload(key, uploadID); err != nil {
+		return "", err
+	}
+
+	return l.presignPut(l.uploadKey(uploadID, strconv.Itoa(int(partNumber))), maxLocalPartSize, expires)
+}
+
+// ListUploadParts lists part files of the upload, etag of the part is md5 of its content
+func (l LocalStorage) ListUploadParts(ctx context.Context, key, uploadID string) ([]lib.UploadPart, error) {
+	if err := l.checkUpload(key, uploadID); err != nil {
+		return nil, err
+	}
+
+	dir, err := l.path(l.uploadKey(uploadID, ""))
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := []lib.UploadPart{}
+	for _, entry := range entries {
+		number, err := strconv.Atoi(entry.Name())
+		if err != nil || number < 1 {
+			continue
+		}
+
+		part, err := l.partOf(uploadID, int32(number))
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, *part)
+	}
+
+	sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+	return parts, nil
+}
+
+// CompleteMultipartUpload concatenates part files into file of the object
+func (l LocalStorage) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []lib.UploadPart) error {
+	if err := l.checkUpload(key, uploadID); err != nil {
+		return err
+	}
+	if len(parts) == 0 {
+		return errors.New("upload has no parts")
+	}
+
+	files := make([]io.Reader, len(parts))
+	for i, part := range parts {
+		if i > 0 && part.PartNumber <= parts[i-1].PartNumber {
+			return errors.New("parts are not in ascending order")
+		}
+
+		uploaded, err := l.partOf(uploadID, part.PartNumber)
+		if err != nil {
+			return err
+		}
+		if part.ETag != "" && strings.Trim(part.ETag, `"`) != strings.Trim(uploaded.ETag, `"`) {
+			return fmt.Errorf("etag of part %d does not match", part.PartNumber)
+		}
+
+		file, err := l.Get(ctx, l.uploadKey(uploadID, strconv.Itoa(int(part.PartNumber))))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		files[i] = file
+	}
+
+	if err := l.Put(ctx, key, io.MultiReader(files...), ""); err != nil {
+		return err
+	}
+
+	return l.AbortMultipartUpload(ctx, key, uploadID)
+}
+
+// AbortMultipartUpload removes directory keeping parts of the upload
+func (l LocalStorage) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	if err := l.checkUpload(key, uploadID); err != nil {
+		return err
+	}
+
+	dir, err := l.path(l.uploadKey(uploadID, ""))
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(dir)
+}
+
+// maxLocalPartSize largest part S3 accepts
+const maxLocalPartSize = 5 << 30
+
+func (l LocalStorage) presignPut(key string, maxSize int64, expires time.Duration) (string, error) {
+	if _, err := l.path(key); err != nil {
+		return "", err
+	}
+
+	expiry := time.Now().Add(expires).Unix()
+	query := url.Values{}
+	query.Set("expires", fmt.Sprint(expiry))
+	query.Set("max_size", fmt.Sprint(maxSize))
+	query.Set("signature", lib.SignObjectKey(l.secret, lib.SignedPutKey(key, maxSize), expiry))
+
+	return l.baseURL + LocalStoragePath + key + "?" + query.Encode(), nil
+}
+
+// uploadKey key of file of the upload or of its directory when name is empty
+func (l LocalStorage) uploadKey(uploadID, name string) string {
+	if name == "" {
+		return localUploadsPrefix + uploadID
+	}
+	return localUploadsPrefix + uploadID + "/" + name
+}
+
+// checkUpload checks that upload is in progress for the key
+func (l LocalStorage) checkUpload(key, uploadID string) error {
+	if _, err := uuid.Parse(uploadID); err != nil {
+		return lib.ErrUploadNotFound
+	}
+
+	file, err := l.Get(context.Background(), l.uploadKey(uploadID, "key"))
+	if errors.Is(err, lib.ErrObjectNotFound) {
+		return lib.ErrUploadNotFound
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	uploadKey, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+	if string(uploadKey) != key {
+		return lib.ErrUploadNotFound
+	}
+
+	return nil
+}
+
+func (l LocalStorage) partOf(uploadID string, number int32) (*lib.UploadPart, error) {
+	file, err := l.Get(context.Background(), l.uploadKey(uploadID, strconv.Itoa(int(number))))
+	if errors.Is(err, lib.ErrObjectNotFound) {
+		return nil, fmt.Errorf("part %d is not uploaded", number)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	hash := md5.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lib.UploadPart{
+		PartNumber: number,
+		ETag:       `"` + hex.EncodeToString(hash.Sum(nil)) + `"`,
+		Size:       size,
+	}, nil
+}
+
+// path resolves file of the key inside storage directory
+func (l LocalStorage) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if key == "" || clean == "/" || clean[1:] != key {
+		return "", ErrInvalidKey
+	}
+
+	return filepath.Join(l.root, filepath.FromSlash(clean)), nil
+}
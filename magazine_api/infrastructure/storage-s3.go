@@ -0,0 +1,255 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"io"
+	"magazine_api/lib"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3Storage object storage in AWS S3 bucket
+type S3Storage struct {
+	bucket   string
+	client   *s3.Client
+	presign  *s3.PresignClient
+	uploader *manager.Uploader
+}
+
+// NewS3Storage creates storage in bucket of AWS_S3_BUCKET_NAME
+func NewS3Storage(
+	env lib.Env,
+	client *s3.Client,
+	presign *s3.PresignClient,
+	uploader *manager.Uploader,
+) S3Storage {
+	return S3Storage{
+		bucket:   env.S3BucketName,
+		client:   client,
+		presign:  presign,
+		uploader: uploader,
+	}
+}
+
+// Put uploads the object to bucket
+func (s S3Storage) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	input := &s3.PutObjectInput{
+		Bucket: &s.bucket,
+		Key:    &key,
+		Body:   body,
+	}
+	if contentType != "" {
+		input.ContentType = &contentType
+	}
+
+	_, err := s.uploader.Upload(ctx, input)
+	return err
+}
+
+// Get downloads the object from bucket
+func (s S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	output, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &s.bucket,
+		Key:    &key,
+	})
+	if err != nil {
+		var notFound *types.NoSuchKey
+		if errors.As(err, &notFound) {
+			return nil, lib.ErrObjectNotFound
+		}
+		return nil, err
+	}
+
+	return output.Body, nil
+}
+
+// Delete deletes the object from bucket
+func (s S3Storage) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: &s.bucket,
+		Key:    &key,
+	})
+	return err
+}
+
+// Presign creates presigned get url of the object
+func (s S3Storage) Presign(ctx context.Context, key string, expires time.Duration) (string, error) {
+	resp, err := s.presign.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: &s.bucket,
+		Key:    &key,
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", err
+	}
+
+	return resp.URL, nil
+}
+
+// List lists keys in bucket starting with prefix
+func (s S3Storage) List(ctx context.Context, prefix string) ([]string, error) {
+	keys := []string{}
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: &s.bucket,
+		Prefix: &prefix,
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range page.Contents {
+			if object.Key != nil {
+				keys = append(keys, *object.Key)
+			}
+		}
+	}
+
+	return keys, nil
+}
+
+// Stat gets size and content type of the object from its head
+func (s S3Storage) Stat(ctx context.Context, key string) (*lib.ObjectInfo, error) {
+	output, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: &s.bucket,
+		Key:    &key,
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return nil, lib.ErrObjectNotFound
+		}
+		return nil, err
+	}
+
+	info := &lib.ObjectInfo{Size: output.ContentLength}
+	if output.ContentType != nil {
+		info.ContentType = *output.ContentType
+	}
+
+	return info, nil
+}
+
+// PresignPut creates presigned put url, content type and length are signed so
+// the upload is refused by S3 when the file differs from the declared one
+func (s S3Storage) PresignPut(
+	ctx context.Context,
+	key, contentType string,
+	size int64,
+	expires time.Duration,
+) (string, error) {
+	resp, err := s.presign.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:        &s.bucket,
+		Key:           &key,
+		ContentType:   &contentType,
+		ContentLength: size,
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", err
+	}
+
+	return resp.URL, nil
+}
+
+// CreateMultipartUpload starts multipart upload in bucket
+func (s S3Storage) CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error) {
+	output, err := s.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      &s.bucket,
+		Key:         &key,
+		ContentType: &contentType,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return *output.UploadId, nil
+}
+
+// PresignUploadPart creates presigned url of the part of multipart upload
+func (s S3Storage) PresignUploadPart(
+	ctx context.Context,
+	key, uploadID string,
+	partNumber int32,
+	expires time.Duration,
+) (string, error) {
+	resp, err := s.presign.PresignUploadPart(ctx, &s3.UploadPartInput{
+		Bucket:     &s.bucket,
+		Key:        &key,
+		UploadId:   &uploadID,
+		PartNumber: partNumber,
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", err
+	}
+
+	return resp.URL, nil
+}
+
+// ListUploadParts lists parts of multipart upload stored in bucket
+func (s S3Storage) ListUploadParts(ctx context.Context, key, uploadID string) ([]lib.UploadPart, error) {
+	parts := []lib.UploadPart{}
+	paginator := s3.NewListPartsPaginator(s.client, &s3.ListPartsInput{
+		Bucket:   &s.bucket,
+		Key:      &key,
+		UploadId: &uploadID,
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, s3UploadError(err)
+		}
+
+		for _, part := range page.Parts {
+			uploaded := lib.UploadPart{PartNumber: part.PartNumber, Size: part.Size}
+			if part.ETag != nil {
+				uploaded.ETag = *part.ETag
+			}
+			parts = append(parts, uploaded)
+		}
+	}
+
+	return parts, nil
+}
+
+// CompleteMultipartUpload assembles parts of multipart upload into the object
+func (s S3Storage) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []lib.UploadPart) error {
+	completed := make([]types.CompletedPart, len(parts))
+	for i := range parts {
+		completed[i] = types.CompletedPart{
+			ETag:       &parts[i].ETag,
+			PartNumber: parts[i].PartNumber,
+		}
+	}
+
+	_, err := s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          &s.bucket,
+		Key:             &key,
+		UploadId:        &uploadID,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: completed},
+	})
+	return s3UploadError(err)
+}
+
+// AbortMultipartUpload aborts multipart upload so that bucket drops its parts
+func (s S3Storage) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	_, err := s.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   &s.bucket,
+		Key:      &key,
+		UploadId: &uploadID,
+	})
+	return s3UploadError(err)
+}
+
+func s3UploadError(err error) error {
+	var notFound *types.NoSuchUpload
+	if errors.As(err, &notFound) {
+		return lib.ErrUploadNotFound
+	}
+	return err
+}
@@ -0,0 +1,26 @@
+package infrastructure
+
+import (
+	"magazine_api/lib"
+
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// NewStorage creates object storage selected by STORAGE_BACKEND, the application does not
+// start when local storage has no signing key
+func NewStorage(
+	env lib.Env,
+	logger lib.Logger,
+	client *s3.Client,
+	presign *s3.PresignClient,
+	uploader *manager.Uploader,
+) (lib.Storage, error) {
+	if env.StorageBackend == lib.StorageLocal {
+		logger.Info("using local object storage at ", env.StorageLocalPath)
+		return NewLocalStorage(env)
+	}
+
+	logger.Info("using s3 object storage bucket ", env.S3BucketName)
+	return NewS3Storage(env, client, presign, uploader), nil
+}
@@ -0,0 +1,52 @@
+package lib
+
+import (
+	"bytes"
+	"strings"
+)
+
+// SniffLength bytes of the head of file needed to detect its content type
+const SniffLength = 64
+
+// ContentTypeExtensions extensions keys of uploaded files get by their content type
+var ContentTypeExtensions = map[string]string{
+	"image/jpeg":           ".jpg",
+	"image/png":            ".png",
+	"image/gif":            ".gif",
+	"image/webp":           ".webp",
+	"application/pdf":      ".pdf",
+	"application/epub+zip": ".epub",
+}
+
+// SniffContentType detects content type of file from magic bytes at its head,
+// empty string is returned for content types that are not uploaded
+func SniffContentType(head []byte) string {
+	switch {
+	case bytes.HasPrefix(head, []byte{0xFF, 0xD8, 0xFF}):
+		return "image/jpeg"
+	case bytes.HasPrefix(head, []byte("\x89PNG\r\n\x1a\n")):
+		return "image/png"
+	case bytes.HasPrefix(head, []byte("GIF87a")), bytes.HasPrefix(head, []byte("GIF89a")):
+		return "image/gif"
+	case len(head) >= 12 && string(head[:4]) == "RIFF" && string(head[8:12]) == "WEBP":
+		return "image/webp"
+	case bytes.HasPrefix(head, []byte("%PDF-")):
+		return "application/pdf"
+	case isEPUB(head):
+		return "application/epub+zip"
+	}
+	return ""
+}
+
+// isEPUB checks for zip whose first entry is the uncompressed mimetype file epub requires
+func isEPUB(head []byte) bool {
+	const mimetype = "mimetypeapplication/epub+zip"
+	return len(head) >= 30+len(mimetype) &&
+		bytes.HasPrefix(head, []byte("PK\x03\x04")) &&
+		string(head[30:30+len(mimetype)]) == mimetype
+}
+
+// IsImageContentType checks if content type is of image
+func IsImageContentType(contentType string) bool {
+	return strings.HasPrefix(contentType, "image/")
+}
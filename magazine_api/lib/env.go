@@ -2,6 +2,7 @@ package lib
 
 import (
 	"log"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -32,12 +33,79 @@ type Env struct {
 	S3BucketName string `mapstructure:"AWS_S3_BUCKET_NAME"`
 	AWSPINPOINT  string `mapstructure:"AWS_PINPOINT"`
 
+	StorageBackend    string `mapstructure:"STORAGE_BACKEND"`
+	StorageLocalPath  string `mapstructure:"STORAGE_LOCAL_PATH"`
+	StorageSigningKey string `mapstructure:"STORAGE_SIGNING_KEY"`
+	StorageBaseURL    string `mapstructure:"STORAGE_BASE_URL"`
+
+	StorageURLMode   string        `mapstructure:"STORAGE_URL_MODE"`
+	StorageURLExpiry time.Duration `mapstructure:"STORAGE_URL_EXPIRY"`
+	StorageCDNURL    string        `mapstructure:"STORAGE_CDN_URL"`
+
+	ImageVariants    string  `mapstructure:"IMAGE_VARIANTS"`
+	ImageJPEGQuality int     `mapstructure:"IMAGE_JPEG_QUALITY"`
+	ImageWebPQuality float32 `mapstructure:"IMAGE_WEBP_QUALITY"`
+	ImageMaxPixels   int64   `mapstructure:"IMAGE_MAX_PIXELS"`
+
+	MediaWorkerConcurrency  int           `mapstructure:"MEDIA_WORKER_CONCURRENCY"`
+	MediaWorkerPollInterval time.Duration `mapstructure:"MEDIA_WORKER_POLL_INTERVAL"`
+	MediaWorkerMaxAttempts  int           `mapstructure:"MEDIA_WORKER_MAX_ATTEMPTS"`
+
+	SchedulerInterval time.Duration `mapstructure:"SCHEDULER_INTERVAL"`
+
+	PaginationDefaultLimit uint64 `mapstructure:"PAGINATION_DEFAULT_LIMIT"`
+	PaginationMaxLimit     uint64 `mapstructure:"PAGINATION_MAX_LIMIT"`
+
+	SiteURL   string `mapstructure:"SITE_URL"`
+	FeedTitle string `mapstructure:"FEED_TITLE"`
+	FeedLimit int    `mapstructure:"FEED_LIMIT"`
+
+	UploadFileMaxSize        int64         `mapstructure:"UPLOAD_FILE_MAX_SIZE"`
+	UploadMaxSize            int64         `mapstructure:"UPLOAD_MAX_SIZE"`
+	UploadMultipartThreshold int64         `mapstructure:"UPLOAD_MULTIPART_THRESHOLD"`
+	UploadPartSize           int64         `mapstructure:"UPLOAD_PART_SIZE"`
+	UploadURLExpiry          time.Duration `mapstructure:"UPLOAD_URL_EXPIRY"`
+	UploadExpiry             time.Duration `mapstructure:"UPLOAD_EXPIRY"`
+
+	ClamAVAddress string        `mapstructure:"CLAMAV_ADDRESS"`
+	ClamAVTimeout time.Duration `mapstructure:"CLAMAV_TIMEOUT"`
+
 	AdminEmail    string `mapstructure:"ADMIN_EMAIL"`
 	AdminPassword string `mapstructure:"ADMIN_PASSWORD"`
 }
 
 var globalEnv = Env{
 	MaxMultipartMemory: 10 << 20, // 10 MB
+	StorageBackend:     StorageS3,
+	StorageLocalPath:   "storage",
+	StorageURLMode:     URLModePresigned,
+	StorageURLExpiry:   SignedURLExpiry,
+
+	ImageVariants:    DefaultImageVariants,
+	ImageJPEGQuality: 82,
+	ImageWebPQuality: 75,
+	ImageMaxPixels:   50_000_000,
+
+	MediaWorkerConcurrency:  2,
+	MediaWorkerPollInterval: 5 * time.Second,
+	MediaWorkerMaxAttempts:  5,
+
+	SchedulerInterval: 30 * time.Second,
+
+	PaginationDefaultLimit: 20,
+	PaginationMaxLimit:     100,
+
+	FeedTitle: "Magazine",
+	FeedLimit: 50,
+
+	UploadFileMaxSize:        20 << 20, // 20 MB
+	UploadMaxSize:            1 << 30,  // 1 GB
+	UploadMultipartThreshold: 64 << 20, // 64 MB
+	UploadPartSize:           16 << 20, // 16 MB
+	UploadURLExpiry:          time.Hour,
+	UploadExpiry:             7 * 24 * time.Hour,
+
+	ClamAVTimeout: time.Minute,
 }
 
 func GetEnv() Env {
@@ -1,15 +1,16 @@
 package lib
 
-import "github.com/aws/aws-sdk-go-v2/feature/s3/manager"
-
 // UploadMetadata metadata received after uploading file
 type UploadMetadata struct {
-	FieldName      string
-	URL            string
-	FileName       string
-	FileUID        string
-	Size           int64
-	UploadResponse *manager.UploadOutput
+	FieldName string
+	URL       string
+	FileName  string
+	FileUID   string
+	Size      int64
+	MimeType  string
+
+	// MediaID id of media the file is recorded as in the media library
+	MediaID string
 }
 
 type UploadedFiles []UploadMetadata
@@ -22,3 +23,14 @@ func (f UploadedFiles) GetFile(fieldName string) UploadMetadata {
 	}
 	return UploadMetadata{}
 }
+
+// GetFiles gets all files uploaded in the field
+func (f UploadedFiles) GetFiles(fieldName string) []UploadMetadata {
+	files := []UploadMetadata{}
+	for _, file := range f {
+		if file.FieldName == fieldName {
+			files = append(files, file)
+		}
+	}
+	return files
+}
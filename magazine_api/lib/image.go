@@ -0,0 +1,247 @@
+package lib
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// DefaultImageVariants named widths of image variants generated after upload
+const DefaultImageVariants = "thumb:320,medium:768,large:1600"
+
+// VariantContentTypes content types of images variants are generated for
+var VariantContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// ImageVariant named width an uploaded image is resized to
+type ImageVariant struct {
+	Name  string
+	Width uint
+}
+
+// ParseImageVariants parses variants given as `name:width` separated by comma
+func ParseImageVariants(spec string) ([]ImageVariant, error) {
+	var variants []ImageVariant
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		pair := strings.SplitN(part, ":", 2)
+		if len(pair) != 2 || !validVariantName(pair[0]) {
+			return nil, fmt.Errorf("invalid image variant %q", part)
+		}
+
+		width, err := strconv.ParseUint(strings.TrimSpace(pair[1]), 10, 32)
+		if err != nil || width == 0 {
+			return nil, fmt.Errorf("invalid width of image variant %q", part)
+		}
+
+		variants = append(variants, ImageVariant{Name: pair[0], Width: uint(width)})
+	}
+
+	return variants, nil
+}
+
+func validVariantName(name string) bool {
+	if name == "" || name == VariantOriginal || name == VariantWebp {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+const (
+	exifOrientationTag = 0x0112
+	exifGPSInfoTag     = 0x8825
+)
+
+// ExifOrientation gets orientation recorded in EXIF of jpeg image, 1 when there is none
+func ExifOrientation(data []byte) int {
+	tiff, order, ok := exifTIFF(data)
+	if !ok {
+		return 1
+	}
+
+	orientation := 1
+	walkIFD(tiff, order, order.Uint32(tiff[4:8]), func(entry []byte) {
+		if order.Uint16(entry[0:2]) == exifOrientationTag {
+			if o := int(order.Uint16(entry[8:10])); o >= 1 && o <= 8 {
+				orientation = o
+			}
+		}
+	})
+
+	return orientation
+}
+
+// StripExifLocation removes GPS metadata from EXIF of jpeg image in place,
+// the rest of the file including orientation is left untouched
+func StripExifLocation(data []byte) {
+	tiff, order, ok := exifTIFF(data)
+	if !ok {
+		return
+	}
+
+	var gps uint32
+	walkIFD(tiff, order, order.Uint32(tiff[4:8]), func(entry []byte) {
+		if order.Uint16(entry[0:2]) == exifGPSInfoTag {
+			gps = order.Uint32(entry[8:12])
+		}
+	})
+	if gps == 0 || int(gps)+2 > len(tiff) {
+		return
+	}
+
+	walkIFD(tiff, order, gps, func(entry []byte) {
+		size := exifTypeSize(order.Uint16(entry[2:4])) * int(order.Uint32(entry[4:8]))
+		if size <= 4 {
+			return
+		}
+		offset := int(order.Uint32(entry[8:12]))
+		if offset >= 0 && offset+size <= len(tiff) {
+			zero(tiff[offset : offset+size])
+		}
+	})
+
+	count := int(order.Uint16(tiff[gps:]))
+	end := int(gps) + 2 + count*12 + 4
+	if end > len(tiff) {
+		end = len(tiff)
+	}
+	zero(tiff[gps:end])
+}
+
+// exifTIFF finds TIFF structure of EXIF in APP1 segment of jpeg image
+func exifTIFF(data []byte) ([]byte, binary.ByteOrder, bool) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, nil, false
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return nil, nil, false
+		}
+
+		marker := data[i+1]
+		switch {
+		case marker == 0xFF:
+			i++
+			continue
+		case marker == 0x01 || marker >= 0xD0 && marker <= 0xD8:
+			i += 2
+			continue
+		case marker == 0xDA || marker == 0xD9:
+			return nil, nil, false
+		}
+
+		size := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		if size < 2 || i+2+size > len(data) {
+			return nil, nil, false
+		}
+
+		segment := data[i+4 : i+2+size]
+		if marker == 0xE1 && len(segment) >= 14 && string(segment[:6]) == "Exif\x00\x00" {
+			tiff := segment[6:]
+			switch string(tiff[:2]) {
+			case "II":
+				return tiff, binary.LittleEndian, true
+			case "MM":
+				return tiff, binary.BigEndian, true
+			}
+			return nil, nil, false
+		}
+
+		i += 2 + size
+	}
+
+	return nil, nil, false
+}
+
+// walkIFD calls fn with every 12 byte entry of the IFD at offset
+func walkIFD(tiff []byte, order binary.ByteOrder, offset uint32, fn func(entry []byte)) {
+	start := int(offset)
+	if start <= 0 || start+2 > len(tiff) {
+		return
+	}
+
+	count := int(order.Uint16(tiff[start:]))
+	for k := 0; k < count; k++ {
+		at := start + 2 + k*12
+		if at+12 > len(tiff) {
+			return
+		}
+		fn(tiff[at : at+12])
+	}
+}
+
+func exifTypeSize(kind uint16) int {
+	switch kind {
+	case 1, 2, 6, 7:
+		return 1
+	case 3, 8:
+		return 2
+	case 4, 9, 11:
+		return 4
+	case 5, 10, 12:
+		return 8
+	}
+	return 0
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// Orient rotates and flips image so that it is displayed upright for the EXIF orientation
+func Orient(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			}
+			dst.Set(x, y, color.NRGBAModel.Convert(img.At(b.Min.X+sx, b.Min.Y+sy)))
+		}
+	}
+
+	return dst
+}
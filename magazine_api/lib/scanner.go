@@ -0,0 +1,30 @@
+package lib
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrMalwareFound is returned when scanner finds malware in the file
+var ErrMalwareFound = errors.New("file is infected")
+
+// Scanner scans uploaded files for malware
+type Scanner interface {
+	// Scan reads the file and returns error wrapping ErrMalwareFound when it is infected
+	Scan(ctx context.Context, file io.Reader) error
+}
+
+// StubScanner scanner used when no malware scanner is configured and in tests,
+// it reads the file and returns Result
+type StubScanner struct {
+	Result error
+}
+
+// Scan drains the file and returns Result
+func (s StubScanner) Scan(ctx context.Context, file io.Reader) error {
+	if _, err := io.Copy(io.Discard, file); err != nil {
+		return err
+	}
+	return s.Result
+}
@@ -0,0 +1,96 @@
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// SignedURLExpiry how long urls of stored objects sent in responses are valid
+const SignedURLExpiry = 15 * time.Minute
+
+const (
+	// URLModePresigned resolves keys to presigned urls of the storage
+	URLModePresigned = "presigned"
+	// URLModePublic resolves keys to urls of public S3 bucket
+	URLModePublic = "public"
+	// URLModeCDN resolves keys to urls under CDN base url
+	URLModeCDN = "cdn"
+)
+
+const (
+	// VariantOriginal uploaded file as is
+	VariantOriginal = "original"
+	// VariantThumb smallest image variant generated after upload
+	VariantThumb = "thumb"
+	// VariantWebp full size webp image generated after upload
+	VariantWebp = "webp"
+)
+
+// SignedURL key of the stored object, it is resolved to downloadable url
+// by the url resolver before the response is sent
+type SignedURL string
+
+// UnmarshalJSON -> convert from json string
+func (s *SignedURL) UnmarshalJSON(by []byte) error {
+	str := ""
+	_ = json.Unmarshal(by, &str)
+	*s = SignedURL(str)
+	return nil
+}
+
+// MarshalJSON -> convert to json string
+func (s SignedURL) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(s))
+}
+
+// Key gets the key of stored object
+func (s SignedURL) Key() string {
+	return string(s)
+}
+
+// IsResolved checks if key is already resolved to url
+func (s SignedURL) IsResolved() bool {
+	return strings.HasPrefix(string(s), "http://") || strings.HasPrefix(string(s), "https://")
+}
+
+// Variant gets key of variant of image generated after upload, variant is either
+// `webp` or name of image variant with optional format like `medium` or `medium.webp`,
+// keys of files that are not images are returned as is
+func (s SignedURL) Variant(variant string) SignedURL {
+	key := string(s)
+	ext := path.Ext(key)
+	switch strings.ToLower(ext) {
+	case ".jpg", ".jpeg", ".png", ".gif":
+	default:
+		return s
+	}
+
+	base := strings.TrimSuffix(key, ext)
+	switch variant {
+	case "", VariantOriginal:
+		return s
+	case VariantWebp:
+		return SignedURL(base + ".webp")
+	}
+
+	name, format := variant, "jpg"
+	if i := strings.LastIndex(variant, "."); i >= 0 {
+		name, format = variant[:i], variant[i+1:]
+	}
+	if format == "jpeg" {
+		format = "jpg"
+	}
+	if !validVariantName(name) || format != "jpg" && format != "webp" {
+		return s
+	}
+
+	return SignedURL(base + "_" + name + "." + format)
+}
+
+// PublicBucketURL public bucket url generate from key
+func PublicBucketURL(bucketName, awsRegion, key string) string {
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", bucketName, awsRegion, key)
+}
@@ -0,0 +1,39 @@
+package lib
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MaxSlugLength characters slugs are cut to at a hyphen
+const MaxSlugLength = 80
+
+// Slugify writes Nepali of the text in latin letters, lowercases letters and digits and
+// joins runs of them with hyphens
+func Slugify(text string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(Transliterate(text)) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+			continue
+		}
+		hyphen = true
+	}
+
+	slug := b.String()
+	if len(slug) > MaxSlugLength {
+		// words ending at the cut are kept whole
+		cut := slug[:MaxSlugLength]
+		if i := strings.LastIndexByte(cut, '-'); i > 0 && slug[MaxSlugLength] != '-' {
+			cut = cut[:i]
+		}
+		slug = cut
+	}
+
+	return slug
+}
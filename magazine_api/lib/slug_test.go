@@ -0,0 +1,39 @@
+package lib
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	// 80 characters end with the ninth magazine and with the hyphen after the eighth magazines
+	magazine, magazines := strings.Repeat("magazine ", 12), strings.Repeat("magazines ", 12)
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"lower case words joined by hyphens", "Hello, World!", "hello-world"},
+		{"hyphens not repeated", "spring -- summer", "spring-summer"},
+		{"no hyphens at ends", "  ...issue 42...  ", "issue-42"},
+		{"devanagari transliterated", "नेपाल २०८१", "nepal-2081"},
+		{"mixed scripts", "Hello नेपाल!", "hello-nepal"},
+		{"without letters or digits", "!!! ???", ""},
+		{"cut at end of word", magazine, strings.TrimSuffix(strings.Repeat("magazine-", 9), "-")},
+		{"cut at hyphen", magazines, strings.TrimSuffix(strings.Repeat("magazines-", 8), "-")},
+		{"word at cut dropped", "a" + magazine, "amagazine-" + strings.TrimSuffix(strings.Repeat("magazine-", 7), "-")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Slugify(tt.text)
+			if got != tt.want {
+				t.Errorf("Slugify(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+			if len(got) > MaxSlugLength {
+				t.Errorf("slug of %d characters, want at most %d", len(got), MaxSlugLength)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package lib
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	// StorageS3 stores objects in AWS S3 bucket
+	StorageS3 = "s3"
+	// StorageLocal stores objects in local filesystem and serves them from the API
+	StorageLocal = "local"
+)
+
+var (
+	// ErrObjectNotFound is returned when object of the key is not stored
+	ErrObjectNotFound = errors.New("object not found")
+	// ErrInvalidSignature is returned when signed url is tampered or expired
+	ErrInvalidSignature = errors.New("invalid or expired signature")
+	// ErrUploadNotFound is returned when multipart upload is completed, aborted or never started
+	ErrUploadNotFound = errors.New("upload not found")
+	// ErrWeakSigningKey is returned when STORAGE_SIGNING_KEY is shorter than MinSigningKeySize
+	ErrWeakSigningKey = errors.New("STORAGE_SIGNING_KEY must be at least 32 bytes with local storage")
+)
+
+const (
+	// MinUploadPartSize smallest part of multipart upload other than the last one
+	MinUploadPartSize = 5 << 20
+	// MaxUploadParts most parts multipart upload can have
+	MaxUploadParts = 10000
+	// MinSigningKeySize shortest secret urls served by the API are signed with
+	MinSigningKeySize = 32
+)
+
+// ObjectInfo metadata of stored object
+type ObjectInfo struct {
+	Size        int64
+	ContentType string
+}
+
+// UploadPart part of multipart upload
+type UploadPart struct {
+	PartNumber int32  `json:"part_number"`
+	ETag       string `json:"etag"`
+	Size       int64  `json:"size,omitempty"`
+}
+
+// Storage object storage where uploaded files are kept
+type Storage interface {
+	// Put stores the object under key
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+
+	// Get opens the object of key, caller closes the reader
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the object of key
+	Delete(ctx context.Context, key string) error
+
+	// Presign creates url to download the object valid for the duration
+	Presign(ctx context.Context, key string, expires time.Duration) (string, error)
+
+	// List lists keys of objects starting with prefix
+	List(ctx context.Context, prefix string) ([]string, error)
+
+	// Stat gets size and content type of the object
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+
+	// PresignPut creates url to upload the object of content type and size with PUT
+	PresignPut(ctx context.Context, key, contentType string, size int64, expires time.Duration) (string, error)
+
+	// CreateMultipartUpload starts upload of the object in parts and returns id of the upload
+	CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error)
+
+	// PresignUploadPart creates url to upload part of multipart upload with PUT
+	PresignUploadPart(ctx context.Context, key, uploadID string, partNumber int32, expires time.Duration) (string, error)
+
+	// ListUploadParts lists parts uploaded so far, upload is resumed by uploading the missing parts
+	ListUploadParts(ctx context.Context, key, uploadID string) ([]UploadPart, error)
+
+	// CompleteMultipartUpload assembles uploaded parts into the object
+	CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []UploadPart) error
+
+	// AbortMultipartUpload discards multipart upload and its uploaded parts
+	AbortMultipartUpload(ctx context.Context, key, uploadID string) error
+}
+
+// SignedPutKey what is signed in urls uploading the object to the API, so that
+// download urls do not authorize uploads and body larger than maxSize is refused
+func SignedPutKey(key string, maxSize int64) string {
+	return "PUT\x00" + key + "\x00" + strconv.FormatInt(maxSize, 10)
+}
+
+// SignObjectKey signs key with expiry for urls served by the API
+func SignObjectKey(secret, key string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(key))
+	mac.Write([]byte{0})
+	mac.Write([]byte(strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyObjectKey verifies signature of key and that it is not expired, nothing
+// verifies when the secret is too short to trust its signatures
+func VerifyObjectKey(secret, key string, expires int64, signature string) error {
+	if len(secret) < MinSigningKeySize || time.Now().Unix() > expires {
+		return ErrInvalidSignature
+	}
+
+	expected := SignObjectKey(secret, key, expires)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package lib
+
+import "strings"
+
+// devanagariConsonants latin letters of Devanagari consonants without their inherent vowel
+var devanagariConsonants = map[rune]string{
+	'क': "k", 'ख': "kh", 'ग': "g", 'घ': "gh", 'ङ': "ng",
+	'च': "ch", 'छ': "chh", 'ज': "j", 'झ': "jh", 'ञ': "n",
+	'ट': "t", 'ठ': "th", 'ड': "d", 'ढ': "dh", 'ण': "n",
+	'त': "t", 'थ': "th", 'द': "d", 'ध': "dh", 'न': "n",
+	'प': "p", 'फ': "ph", 'ब': "b", 'भ': "bh", 'म': "m",
+	'य': "y", 'र': "r", 'ल': "l", 'व': "w",
+	'श': "sh", 'ष': "sh", 'स': "s", 'ह': "h",
+	'\u0958': "q", '\u0959': "kh", '\u095A': "g", '\u095B': "z",
+	'\u095C': "r", '\u095D': "rh", '\u095E': "f", '\u095F': "y",
+}
+
+// devanagariNuktaForms latin letters of consonants followed by nukta for sounds of loan words
+var devanagariNuktaForms = map[string]string{
+	"k": "q", "j": "z", "ph": "f", "d": "r", "dh": "rh",
+}
+
+// devanagariVowels latin letters of independent vowels and vowel signs following consonants
+var devanagariVowels = map[rune]string{
+	'अ': "a", 'आ': "a", 'इ': "i", 'ई': "i", 'उ': "u", 'ऊ': "u", 'ऋ': "ri",
+	'ए': "e", 'ऐ': "ai", 'ओ': "o", 'औ': "au", 'ऑ': "o",
+	'ा': "a", 'ि': "i", 'ी': "i", 'ु': "u", 'ू': "u", 'ृ': "ri",
+	'े': "e", 'ै': "ai", 'ो': "o", 'ौ': "au", 'ॅ': "e", 'ॉ': "o",
+}
+
+// devanagariSigns latin letters of nasal signs, visarga, digits and om
+var devanagariSigns = map[rune]string{
+	'ं': "n", 'ँ': "n", 'ः': "h", 'ॐ': "om",
+	'०': "0", '१': "1", '२': "2", '३': "3", '४': "4",
+	'५': "5", '६': "6", '७': "7", '८': "8", '९': "9",
+}
+
+const (
+	devanagariVirama = '\u094D'
+	devanagariNukta  = '\u093C'
+)
+
+// Transliterate writes Devanagari of Nepali text in latin letters the way Nepali is commonly
+// romanised, inherent vowel of the last consonant of words is dropped as it is not spoken.
+// Text in other scripts is kept as is
+func Transliterate(text string) string {
+	var b strings.Builder
+	pending := ""
+	word := 0
+
+	flush := func(vowel bool) {
+		if pending == "" {
+			return
+		}
+		b.WriteString(pending)
+		word += len(pending)
+		if vowel {
+			b.WriteByte('a')
+			word++
+		}
+		pending = ""
+	}
+
+	for _, r := range text {
+		if consonant, ok := devanagariConsonants[r]; ok {
+			flush(true)
+			pending = consonant
+			continue
+		}
+		if vowel, ok := devanagariVowels[r]; ok {
+			flush(false)
+			b.WriteString(vowel)
+			word += len(vowel)
+			continue
+		}
+		if sign, ok := devanagariSigns[r]; ok {
+			flush(true)
+			b.WriteString(sign)
+			word += len(sign)
+			continue
+		}
+
+		switch r {
+		case devanagariVirama:
+			flush(false)
+		case devanagariNukta:
+			if form, ok := devanagariNuktaForms[pending]; ok {
+				pending = form
+			}
+		default:
+			// last consonant of words of one letter keeps its vowel, like र and म
+			flush(word == 0)
+			b.WriteRune(r)
+			word = 0
+		}
+	}
+	flush(word == 0)
+
+	return b.String()
+}
@@ -0,0 +1,32 @@
+package lib
+
+import "testing"
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"vowel of last consonant dropped", "नेपाल", "nepal"},
+		{"vowel sign after consonant", "राम", "ram"},
+		{"word of one letter keeps its vowel", "र", "ra"},
+		{"conjunct by virama", "पत्रिका", "patrika"},
+		{"nukta of loan words", "ज़िन्दगी", "zindagi"},
+		{"nukta after ph", "फ़िल्म", "film"},
+		{"anusvara", "संविधान", "sanwidhan"},
+		{"digits", "२०८१", "2081"},
+		{"om", "ॐ", "om"},
+		{"words of a sentence", "कमल र गुलाफ", "kamal ra gulaph"},
+		{"other scripts kept", "Hello नेपाल!", "Hello nepal!"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Transliterate(tt.text); got != tt.want {
+				t.Errorf("Transliterate(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
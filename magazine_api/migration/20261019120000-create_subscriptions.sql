@@ -0,0 +1,64 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS payment_types (
+    id UUID PRIMARY KEY,
+    payment_name TEXT NOT NULL,
+    created_on TIMESTAMP DEFAULT NOW(),
+    updated_on TIMESTAMP,
+    deleted_on TIMESTAMP,
+    created_by UUID,
+    creator_name TEXT,
+    updated_by UUID,
+    updator_name TEXT,
+    deleted_by UUID,
+    deletor_name TEXT
+);
+
+INSERT INTO payment_types (id, payment_name)
+VALUES ('5b0f4c1e-7d0a-4b8e-9a43-2f1c6e0d8a01', 'SubscriptionPayment')
+ON CONFLICT (id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS subscription_plans (
+    id UUID PRIMARY KEY,
+    plan_name TEXT NOT NULL,
+    interval INT NOT NULL,
+    format INT NOT NULL,
+    price REAL NOT NULL DEFAULT 0,
+    remarks TEXT,
+    created_on TIMESTAMP DEFAULT NOW(),
+    updated_on TIMESTAMP,
+    deleted_on TIMESTAMP,
+    created_by UUID,
+    creator_name TEXT,
+    updated_by UUID,
+    updator_name TEXT,
+    deleted_by UUID,
+    deletor_name TEXT
+);
+
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id),
+    plan_id UUID NOT NULL REFERENCES subscription_plans (id),
+    start_date TIMESTAMP NOT NULL,
+    end_date TIMESTAMP NOT NULL,
+    status INT NOT NULL,
+    transaction_id UUID REFERENCES transactions (id),
+    remarks TEXT,
+    created_on TIMESTAMP DEFAULT NOW(),
+    updated_on TIMESTAMP,
+    deleted_on TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS subscriptions_user_id_idx ON subscriptions (user_id);
+
+ALTER TABLE magazine_issues ADD COLUMN IF NOT EXISTS pdf_url TEXT;
+ALTER TABLE magazine_issues ADD COLUMN IF NOT EXISTS epub_url TEXT;
+
+-- +migrate Down
+ALTER TABLE magazine_issues DROP COLUMN IF EXISTS epub_url;
+ALTER TABLE magazine_issues DROP COLUMN IF EXISTS pdf_url;
+
+DROP TABLE IF EXISTS subscriptions;
+DROP TABLE IF EXISTS subscription_plans;
+
+DELETE FROM payment_types WHERE id = '5b0f4c1e-7d0a-4b8e-9a43-2f1c6e0d8a01';
@@ -0,0 +1,107 @@
+-- +migrate Up
+INSERT INTO payment_types (id, payment_name)
+VALUES ('9c2e7a14-3b6f-4d1a-8e25-6a0f3d9b7c42', 'AdvertPayment')
+ON CONFLICT (id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS advertisers (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id),
+    company_name TEXT NOT NULL,
+    contact_name TEXT,
+    email TEXT,
+    contact_number TEXT,
+    billing_address TEXT,
+    pan_number TEXT,
+    remarks TEXT,
+    created_on TIMESTAMP DEFAULT NOW(),
+    updated_on TIMESTAMP,
+    deleted_on TIMESTAMP,
+    created_by UUID,
+    creator_name TEXT,
+    updated_by UUID,
+    updator_name TEXT,
+    deleted_by UUID,
+    deletor_name TEXT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS advertisers_user_id_idx ON advertisers (user_id) WHERE deleted_on IS NULL;
+
+CREATE TABLE IF NOT EXISTS rate_cards (
+    id UUID PRIMARY KEY,
+    size INT NOT NULL,
+    placement INT NOT NULL,
+    price REAL NOT NULL,
+    remarks TEXT,
+    created_on TIMESTAMP DEFAULT NOW(),
+    updated_on TIMESTAMP,
+    deleted_on TIMESTAMP,
+    created_by UUID,
+    creator_name TEXT,
+    updated_by UUID,
+    updator_name TEXT,
+    deleted_by UUID,
+    deletor_name TEXT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS rate_cards_size_placement_idx ON rate_cards (size, placement) WHERE deleted_on IS NULL;
+
+CREATE TABLE IF NOT EXISTS ad_invoices (
+    id UUID PRIMARY KEY,
+    invoice_number TEXT NOT NULL UNIQUE,
+    advertiser_id UUID NOT NULL REFERENCES advertisers (id),
+    amount REAL NOT NULL,
+    due_date TIMESTAMP,
+    paid_status INT NOT NULL,
+    paid_on TIMESTAMP,
+    transaction_id UUID REFERENCES transactions (id),
+    remarks TEXT,
+    created_on TIMESTAMP DEFAULT NOW(),
+    updated_on TIMESTAMP,
+    deleted_on TIMESTAMP,
+    created_by UUID,
+    creator_name TEXT,
+    updated_by UUID,
+    updator_name TEXT,
+    deleted_by UUID,
+    deletor_name TEXT
+);
+
+CREATE TABLE IF NOT EXISTS ad_bookings (
+    id UUID PRIMARY KEY,
+    advertiser_id UUID NOT NULL REFERENCES advertisers (id),
+    advert_id UUID REFERENCES adverts (id),
+    issue_id UUID NOT NULL REFERENCES magazine_issues (id),
+    placement INT NOT NULL,
+    size INT NOT NULL,
+    page INT NOT NULL,
+    price REAL NOT NULL,
+    status INT NOT NULL,
+    invoice_id UUID REFERENCES ad_invoices (id),
+    remarks TEXT,
+    created_on TIMESTAMP DEFAULT NOW(),
+    updated_on TIMESTAMP,
+    deleted_on TIMESTAMP,
+    created_by UUID,
+    creator_name TEXT,
+    updated_by UUID,
+    updator_name TEXT,
+    deleted_by UUID,
+    deletor_name TEXT
+);
+
+CREATE INDEX IF NOT EXISTS ad_bookings_slot_idx ON ad_bookings (issue_id, placement, page);
+CREATE INDEX IF NOT EXISTS ad_bookings_advertiser_id_idx ON ad_bookings (advertiser_id);
+
+ALTER TABLE adverts ADD COLUMN IF NOT EXISTS advertiser_id UUID REFERENCES advertisers (id);
+ALTER TABLE adverts ADD COLUMN IF NOT EXISTS size INT;
+
+-- +migrate Down
+ALTER TABLE adverts DROP COLUMN IF EXISTS size;
+ALTER TABLE adverts DROP COLUMN IF EXISTS advertiser_id;
+
+DROP TABLE IF EXISTS ad_bookings;
+DROP TABLE IF EXISTS ad_invoices;
+DROP TABLE IF EXISTS rate_cards;
+DROP TABLE IF EXISTS advertisers;
+
+DELETE FROM payment_types WHERE id = '9c2e7a14-3b6f-4d1a-8e25-6a0f3d9b7c42';
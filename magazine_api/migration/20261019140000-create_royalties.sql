@@ -0,0 +1,70 @@
+-- +migrate Up
+INSERT INTO payment_types (id, payment_name)
+VALUES ('3e8d5b20-6c1f-4a97-b4d8-0f7a2c9e1b63', 'RoyaltyPayment')
+ON CONFLICT (id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS royalty_agreements (
+    id UUID PRIMARY KEY,
+    contributor_id UUID NOT NULL REFERENCES users (id),
+    per_word REAL,
+    per_story REAL,
+    per_photo REAL,
+    effective_from TIMESTAMP NOT NULL,
+    effective_to TIMESTAMP,
+    remarks TEXT,
+    created_on TIMESTAMP DEFAULT NOW(),
+    updated_on TIMESTAMP,
+    deleted_on TIMESTAMP,
+    created_by UUID,
+    creator_name TEXT,
+    updated_by UUID,
+    updator_name TEXT,
+    deleted_by UUID,
+    deletor_name TEXT
+);
+
+CREATE INDEX IF NOT EXISTS royalty_agreements_contributor_id_idx ON royalty_agreements (contributor_id);
+
+CREATE TABLE IF NOT EXISTS royalty_payouts (
+    id UUID PRIMARY KEY,
+    total_amount REAL NOT NULL,
+    contributor_count INT NOT NULL,
+    paid_medium INT,
+    remarks TEXT,
+    created_on TIMESTAMP DEFAULT NOW(),
+    updated_on TIMESTAMP,
+    deleted_on TIMESTAMP,
+    created_by UUID,
+    creator_name TEXT,
+    updated_by UUID,
+    updator_name TEXT,
+    deleted_by UUID,
+    deletor_name TEXT
+);
+
+CREATE TABLE IF NOT EXISTS royalties (
+    id UUID PRIMARY KEY,
+    contributor_id UUID NOT NULL REFERENCES users (id),
+    agreement_id UUID NOT NULL REFERENCES royalty_agreements (id),
+    issue_id UUID NOT NULL REFERENCES magazine_issues (id),
+    source_type INT NOT NULL,
+    source_id UUID NOT NULL,
+    word_count INT,
+    amount REAL NOT NULL,
+    payout_id UUID REFERENCES royalty_payouts (id),
+    transaction_id UUID REFERENCES transactions (id),
+    remarks TEXT,
+    created_on TIMESTAMP DEFAULT NOW(),
+    updated_on TIMESTAMP,
+    deleted_on TIMESTAMP,
+    UNIQUE (issue_id, source_type, source_id)
+);
+
+CREATE INDEX IF NOT EXISTS royalties_contributor_id_idx ON royalties (contributor_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS royalties;
+DROP TABLE IF EXISTS royalty_payouts;
+DROP TABLE IF EXISTS royalty_agreements;
+
+DELETE FROM payment_types WHERE id = '3e8d5b20-6c1f-4a97-b4d8-0f7a2c9e1b63';
@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS media (
+    id UUID PRIMARY KEY,
+    key TEXT NOT NULL,
+    mime_type TEXT,
+    width INT,
+    height INT,
+    status INT NOT NULL,
+    variants JSONB,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    run_after TIMESTAMP NOT NULL DEFAULT NOW(),
+    locked_on TIMESTAMP,
+    created_on TIMESTAMP DEFAULT NOW(),
+    updated_on TIMESTAMP,
+    deleted_on TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS media_key_idx ON media (key) WHERE deleted_on IS NULL;
+CREATE INDEX IF NOT EXISTS media_queue_idx ON media (status, run_after) WHERE deleted_on IS NULL;
+
+-- +migrate Down
+DROP TABLE IF EXISTS media;
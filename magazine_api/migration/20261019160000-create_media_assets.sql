@@ -0,0 +1,66 @@
+-- +migrate Up
+ALTER TABLE media RENAME TO media_assets;
+ALTER INDEX IF EXISTS media_key_idx RENAME TO media_assets_key_idx;
+ALTER INDEX IF EXISTS media_queue_idx RENAME TO media_assets_queue_idx;
+
+ALTER TABLE media_assets
+    ADD COLUMN IF NOT EXISTS size BIGINT,
+    ADD COLUMN IF NOT EXISTS sha256 TEXT,
+    ADD COLUMN IF NOT EXISTS uploaded_by UUID;
+
+CREATE UNIQUE INDEX IF NOT EXISTS media_assets_sha256_idx ON media_assets (sha256)
+    WHERE deleted_on IS NULL AND sha256 IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS media_asset_references (
+    asset_id UUID NOT NULL REFERENCES media_assets (id),
+    entity_type INT NOT NULL,
+    entity_id UUID NOT NULL,
+    field TEXT NOT NULL,
+    created_on TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (entity_type, entity_id, field)
+);
+
+CREATE INDEX IF NOT EXISTS media_asset_references_asset_id_idx ON media_asset_references (asset_id);
+
+-- files uploaded before the media library are recorded as ready assets referenced by their rows
+CREATE TEMPORARY TABLE legacy_media_references AS
+    SELECT 1 AS entity_type, id AS entity_id, 'url' AS field, url AS key FROM photographs WHERE url IS NOT NULL AND url <> ''
+    UNION ALL
+    SELECT 2, id, 'url', url FROM adverts WHERE url IS NOT NULL AND url <> ''
+    UNION ALL
+    SELECT 3, id, 'picture', picture FROM user_profiles WHERE picture IS NOT NULL AND picture <> ''
+    UNION ALL
+    SELECT 5, id, 'pdf_url', pdf_url FROM magazine_issues WHERE pdf_url IS NOT NULL AND pdf_url <> ''
+    UNION ALL
+    SELECT 5, id, 'epub_url', epub_url FROM magazine_issues WHERE epub_url IS NOT NULL AND epub_url <> ''
+    UNION ALL
+    SELECT 7, user_id, 'picture', picture FROM employee_view WHERE picture IS NOT NULL AND picture <> ''
+    UNION ALL
+    SELECT 4, e.user_id, 'documents.' || (d.position - 1), d.document->>'url'
+    FROM employee_view e, jsonb_array_elements(COALESCE(to_jsonb(e.document), '[]'::jsonb)) WITH ORDINALITY d(document, position)
+    WHERE d.document->>'url' IS NOT NULL AND d.document->>'url' <> '';
+
+INSERT INTO media_assets (id, key, status, created_on)
+SELECT DISTINCT md5(key)::uuid, key, 3, NOW() FROM legacy_media_references
+ON CONFLICT DO NOTHING;
+
+INSERT INTO media_asset_references (asset_id, entity_type, entity_id, field)
+SELECT a.id, r.entity_type, r.entity_id, r.field
+FROM legacy_media_references r
+JOIN media_assets a ON a.key = r.key AND a.deleted_on IS NULL
+ON CONFLICT DO NOTHING;
+
+DROP TABLE legacy_media_references;
+
+-- +migrate Down
+DROP TABLE IF EXISTS media_asset_references;
+
+DROP INDEX IF EXISTS media_assets_sha256_idx;
+ALTER TABLE media_assets
+    DROP COLUMN IF EXISTS size,
+    DROP COLUMN IF EXISTS sha256,
+    DROP COLUMN IF EXISTS uploaded_by;
+
+ALTER INDEX IF EXISTS media_assets_queue_idx RENAME TO media_queue_idx;
+ALTER INDEX IF EXISTS media_assets_key_idx RENAME TO media_key_idx;
+ALTER TABLE media_assets RENAME TO media;
@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS direct_uploads (
+    id UUID PRIMARY KEY,
+    key TEXT NOT NULL,
+    file_name TEXT,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    upload_id TEXT,
+    part_size BIGINT,
+    status INT NOT NULL,
+    media_id UUID REFERENCES media_assets (id),
+    uploaded_by UUID,
+    expires_on TIMESTAMP NOT NULL,
+    created_on TIMESTAMP DEFAULT NOW(),
+    updated_on TIMESTAMP,
+    deleted_on TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS direct_uploads_pending_idx ON direct_uploads (expires_on) WHERE status = 1;
+
+-- +migrate Down
+DROP TABLE IF EXISTS direct_uploads;
@@ -0,0 +1,21 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS story_photographs (
+    story_id UUID NOT NULL REFERENCES stories (id) ON DELETE CASCADE,
+    photograph_id UUID NOT NULL REFERENCES photographs (id),
+    position INT NOT NULL,
+    caption TEXT,
+    credit_user_id UUID REFERENCES users (id),
+    credit_text TEXT,
+    crop_hints JSONB,
+    is_lead BOOLEAN NOT NULL DEFAULT FALSE,
+    created_on TIMESTAMP DEFAULT NOW(),
+    updated_on TIMESTAMP,
+    PRIMARY KEY (story_id, photograph_id),
+    CHECK (credit_user_id IS NULL OR credit_text IS NULL)
+);
+
+CREATE INDEX IF NOT EXISTS story_photographs_position_idx ON story_photographs (story_id, position);
+CREATE UNIQUE INDEX IF NOT EXISTS story_photographs_lead_idx ON story_photographs (story_id) WHERE is_lead;
+
+-- +migrate Down
+DROP TABLE IF EXISTS story_photographs;
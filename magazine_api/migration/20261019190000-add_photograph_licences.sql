@@ -0,0 +1,26 @@
+-- +migrate Up
+ALTER TABLE photographs ADD COLUMN IF NOT EXISTS photographer_id UUID REFERENCES users (id);
+ALTER TABLE photographs ADD COLUMN IF NOT EXISTS licence_source TEXT;
+ALTER TABLE photographs ADD COLUMN IF NOT EXISTS licence_type INT;
+ALTER TABLE photographs ADD COLUMN IF NOT EXISTS usage_scope INT;
+ALTER TABLE photographs ADD COLUMN IF NOT EXISTS territories TEXT[];
+ALTER TABLE photographs ADD COLUMN IF NOT EXISTS licence_expires_on TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS photographs_licence_expires_on_idx ON photographs (licence_expires_on)
+    WHERE licence_expires_on IS NOT NULL AND deleted_on IS NULL;
+
+ALTER TABLE magazine_issues ADD COLUMN IF NOT EXISTS usage_scope INT;
+ALTER TABLE magazine_issues ADD COLUMN IF NOT EXISTS territory TEXT;
+
+-- +migrate Down
+ALTER TABLE magazine_issues DROP COLUMN IF EXISTS territory;
+ALTER TABLE magazine_issues DROP COLUMN IF EXISTS usage_scope;
+
+DROP INDEX IF EXISTS photographs_licence_expires_on_idx;
+
+ALTER TABLE photographs DROP COLUMN IF EXISTS licence_expires_on;
+ALTER TABLE photographs DROP COLUMN IF EXISTS territories;
+ALTER TABLE photographs DROP COLUMN IF EXISTS usage_scope;
+ALTER TABLE photographs DROP COLUMN IF EXISTS licence_type;
+ALTER TABLE photographs DROP COLUMN IF EXISTS licence_source;
+ALTER TABLE photographs DROP COLUMN IF EXISTS photographer_id;
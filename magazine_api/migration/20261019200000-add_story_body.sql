@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS story_body JSONB;
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS word_count INT;
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS reading_time INT;
+
+-- +migrate Down
+ALTER TABLE stories DROP COLUMN IF EXISTS reading_time;
+ALTER TABLE stories DROP COLUMN IF EXISTS word_count;
+ALTER TABLE stories DROP COLUMN IF EXISTS story_body;
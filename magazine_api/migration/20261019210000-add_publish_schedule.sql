@@ -0,0 +1,44 @@
+-- +migrate Up
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP;
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS unpublish_at TIMESTAMP;
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS published_on TIMESTAMP;
+
+ALTER TABLE magazine_issues ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP;
+ALTER TABLE magazine_issues ADD COLUMN IF NOT EXISTS unpublish_at TIMESTAMP;
+ALTER TABLE magazine_issues ADD COLUMN IF NOT EXISTS published_on TIMESTAMP;
+
+ALTER TABLE adverts ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP;
+ALTER TABLE adverts ADD COLUMN IF NOT EXISTS unpublish_at TIMESTAMP;
+ALTER TABLE adverts ADD COLUMN IF NOT EXISTS published_on TIMESTAMP;
+
+-- rows created before scheduling were visible, they stay published
+UPDATE stories SET published_on = COALESCE(created_on, NOW()) WHERE published_on IS NULL AND deleted_on IS NULL;
+UPDATE magazine_issues SET published_on = COALESCE(created_on, NOW()) WHERE published_on IS NULL AND deleted_on IS NULL;
+UPDATE adverts SET published_on = COALESCE(created_on, NOW()) WHERE published_on IS NULL AND deleted_on IS NULL;
+
+CREATE INDEX IF NOT EXISTS stories_publish_at_idx ON stories (publish_at) WHERE published_on IS NULL;
+CREATE INDEX IF NOT EXISTS stories_unpublish_at_idx ON stories (unpublish_at) WHERE published_on IS NOT NULL;
+CREATE INDEX IF NOT EXISTS magazine_issues_publish_at_idx ON magazine_issues (publish_at) WHERE published_on IS NULL;
+CREATE INDEX IF NOT EXISTS magazine_issues_unpublish_at_idx ON magazine_issues (unpublish_at) WHERE published_on IS NOT NULL;
+CREATE INDEX IF NOT EXISTS adverts_publish_at_idx ON adverts (publish_at) WHERE published_on IS NULL;
+CREATE INDEX IF NOT EXISTS adverts_unpublish_at_idx ON adverts (unpublish_at) WHERE published_on IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS adverts_unpublish_at_idx;
+DROP INDEX IF EXISTS adverts_publish_at_idx;
+DROP INDEX IF EXISTS magazine_issues_unpublish_at_idx;
+DROP INDEX IF EXISTS magazine_issues_publish_at_idx;
+DROP INDEX IF EXISTS stories_unpublish_at_idx;
+DROP INDEX IF EXISTS stories_publish_at_idx;
+
+ALTER TABLE adverts DROP COLUMN IF EXISTS published_on;
+ALTER TABLE adverts DROP COLUMN IF EXISTS unpublish_at;
+ALTER TABLE adverts DROP COLUMN IF EXISTS publish_at;
+
+ALTER TABLE magazine_issues DROP COLUMN IF EXISTS published_on;
+ALTER TABLE magazine_issues DROP COLUMN IF EXISTS unpublish_at;
+ALTER TABLE magazine_issues DROP COLUMN IF EXISTS publish_at;
+
+ALTER TABLE stories DROP COLUMN IF EXISTS published_on;
+ALTER TABLE stories DROP COLUMN IF EXISTS unpublish_at;
+ALTER TABLE stories DROP COLUMN IF EXISTS publish_at;
@@ -0,0 +1,23 @@
+-- +migrate Up
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS slug TEXT;
+ALTER TABLE magazine_issues ADD COLUMN IF NOT EXISTS slug TEXT;
+
+UPDATE stories SET slug = CONCAT_WS('-',
+    NULLIF(TRIM(BOTH '-' FROM REGEXP_REPLACE(LOWER(COALESCE(story_title, '')), '[^a-z0-9]+', '-', 'g')), ''),
+    LEFT(id::TEXT, 8))
+WHERE slug IS NULL;
+
+UPDATE magazine_issues SET slug = CONCAT_WS('-',
+    NULLIF(TRIM(BOTH '-' FROM REGEXP_REPLACE(LOWER(COALESCE(issue_code, '')), '[^a-z0-9]+', '-', 'g')), ''),
+    LEFT(id::TEXT, 8))
+WHERE slug IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS stories_slug_idx ON stories (slug);
+CREATE UNIQUE INDEX IF NOT EXISTS magazine_issues_slug_idx ON magazine_issues (slug);
+
+-- +migrate Down
+DROP INDEX IF EXISTS magazine_issues_slug_idx;
+DROP INDEX IF EXISTS stories_slug_idx;
+
+ALTER TABLE magazine_issues DROP COLUMN IF EXISTS slug;
+ALTER TABLE stories DROP COLUMN IF EXISTS slug;
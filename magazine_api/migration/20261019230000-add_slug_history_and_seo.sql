@@ -0,0 +1,31 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS slug_history (
+    table_name TEXT NOT NULL,
+    slug TEXT NOT NULL,
+    entity_id UUID NOT NULL,
+    created_on TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (table_name, slug)
+);
+
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS meta_title TEXT;
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS meta_description TEXT;
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS canonical_url TEXT;
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS og_image TEXT;
+
+ALTER TABLE magazine_issues ADD COLUMN IF NOT EXISTS meta_title TEXT;
+ALTER TABLE magazine_issues ADD COLUMN IF NOT EXISTS meta_description TEXT;
+ALTER TABLE magazine_issues ADD COLUMN IF NOT EXISTS canonical_url TEXT;
+ALTER TABLE magazine_issues ADD COLUMN IF NOT EXISTS og_image TEXT;
+
+-- +migrate Down
+ALTER TABLE magazine_issues DROP COLUMN IF EXISTS og_image;
+ALTER TABLE magazine_issues DROP COLUMN IF EXISTS canonical_url;
+ALTER TABLE magazine_issues DROP COLUMN IF EXISTS meta_description;
+ALTER TABLE magazine_issues DROP COLUMN IF EXISTS meta_title;
+
+ALTER TABLE stories DROP COLUMN IF EXISTS og_image;
+ALTER TABLE stories DROP COLUMN IF EXISTS canonical_url;
+ALTER TABLE stories DROP COLUMN IF EXISTS meta_description;
+ALTER TABLE stories DROP COLUMN IF EXISTS meta_title;
+
+DROP TABLE IF EXISTS slug_history;
@@ -0,0 +1,16 @@
+-- +migrate Up
+-- list pages are ordered by time rows were created and id, rows without time come first
+CREATE INDEX IF NOT EXISTS stories_created_on_id_idx ON stories ((COALESCE(created_on, 'epoch'::timestamp)), id) WHERE deleted_on IS NULL;
+CREATE INDEX IF NOT EXISTS photographs_created_on_id_idx ON photographs ((COALESCE(created_on, 'epoch'::timestamp)), id) WHERE deleted_on IS NULL;
+CREATE INDEX IF NOT EXISTS contents_created_on_id_idx ON contents ((COALESCE(created_on, 'epoch'::timestamp)), id) WHERE deleted_on IS NULL;
+CREATE INDEX IF NOT EXISTS magazines_created_on_id_idx ON magazines ((COALESCE(created_on, 'epoch'::timestamp)), id) WHERE deleted_on IS NULL;
+CREATE INDEX IF NOT EXISTS adverts_created_on_id_idx ON adverts ((COALESCE(created_on, 'epoch'::timestamp)), id) WHERE deleted_on IS NULL;
+CREATE INDEX IF NOT EXISTS magazine_issues_created_on_id_idx ON magazine_issues ((COALESCE(created_on, 'epoch'::timestamp)), id) WHERE deleted_on IS NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS magazine_issues_created_on_id_idx;
+DROP INDEX IF EXISTS adverts_created_on_id_idx;
+DROP INDEX IF EXISTS magazines_created_on_id_idx;
+DROP INDEX IF EXISTS contents_created_on_id_idx;
+DROP INDEX IF EXISTS photographs_created_on_id_idx;
+DROP INDEX IF EXISTS stories_created_on_id_idx;
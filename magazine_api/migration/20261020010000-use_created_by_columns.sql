@@ -0,0 +1,24 @@
+-- +migrate Up
+-- rows of every management table are listed by the user who created them from created_by, magazines and
+-- photographs were written to creator_id which their models do not have
+-- +migrate StatementBegin
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'magazines' AND column_name = 'creator_id')
+        AND NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'magazines' AND column_name = 'created_by') THEN
+        ALTER TABLE magazines RENAME COLUMN creator_id TO created_by;
+    END IF;
+
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'photographs' AND column_name = 'creator_id')
+        AND NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'photographs' AND column_name = 'created_by') THEN
+        ALTER TABLE photographs RENAME COLUMN creator_id TO created_by;
+    END IF;
+END $$;
+-- +migrate StatementEnd
+
+ALTER TABLE magazines ADD COLUMN IF NOT EXISTS created_by UUID;
+ALTER TABLE photographs ADD COLUMN IF NOT EXISTS created_by UUID;
+ALTER TABLE magazine_issues ADD COLUMN IF NOT EXISTS created_by UUID;
+
+-- +migrate Down
+-- created_by is kept, rows are read and written with it
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AdSize int
+
+const (
+	AdSizeQuarterPage AdSize = iota + 1
+	AdSizeHalfPage
+	AdSizeFullPage
+)
+
+// Quarters number of quarter pages the ad size takes up
+func (a AdSize) Quarters() int {
+	switch a {
+	case AdSizeQuarterPage:
+		return 1
+	case AdSizeHalfPage:
+		return 2
+	case AdSizeFullPage:
+		return 4
+	}
+	return 0
+}
+
+type AdPlacement int
+
+const (
+	PlacementInside AdPlacement = iota + 1
+	PlacementInsideFrontCover
+	PlacementInsideBackCover
+	PlacementBackCover
+)
+
+type BookingStatus int
+
+const (
+	BookingBooked BookingStatus = iota + 1
+	BookingCancelled
+)
+
+// AdvertPaymentType payment type of receivable transactions posted for advert invoices
+var AdvertPaymentType = uuid.MustParse("9c2e7a14-3b6f-4d1a-8e25-6a0f3d9b7c42")
+
+type AdvertiserBase struct {
+	UserId *uuid.UUID `json:"user_id"`
+
+	CompanyName    *string `json:"company_name"`
+	ContactName    *string `json:"contact_name"`
+	Email          *string `json:"email"`
+	ContactNumber  *string `json:"contact_number"`
+	BillingAddress *string `json:"billing_address"`
+	PanNumber      *string `json:"pan_number"`
+
+	Remarks *string `json:"remarks"`
+}
+
+type Advertiser struct {
+	Base
+	BaseDate
+	BaseCreatedBy
+	AdvertiserBase
+}
+
+type RateCardBase struct {
+	Size      *AdSize      `json:"size"`
+	Placement *AdPlacement `json:"placement"`
+	Price     *float32     `json:"price"`
+
+	Remarks *string `json:"remarks"`
+}
+
+type RateCard struct {
+	Base
+	BaseDate
+	BaseCreatedBy
+	RateCardBase
+}
+
+type AdBookingBase struct {
+	AdvertiserId *uuid.UUID `json:"advertiser_id"`
+	AdvertId     *uuid.UUID `json:"advert_id"`
+	IssueId      *uuid.UUID `json:"issue_id"`
+
+	Placement *AdPlacement `json:"placement"`
+	Size      *AdSize      `json:"size"`
+	Page      *int         `json:"page"`
+	Price     *float32     `json:"price"`
+
+	Status    *BookingStatus `json:"status"`
+	InvoiceId *uuid.UUID     `json:"invoice_id"`
+
+	Remarks *string `json:"remarks"`
+}
+
+type AdBooking struct {
+	Base
+	BaseDate
+	BaseCreatedBy
+	AdBookingBase
+}
+
+type AdInvoiceBase struct {
+	InvoiceNumber *string    `json:"invoice_number"`
+	AdvertiserId  *uuid.UUID `json:"advertiser_id"`
+
+	Amount     *float32    `json:"amount"`
+	DueDate    *time.Time  `json:"due_date" form:"due_date" time_format:"2006-01-02"`
+	PaidStatus *PaidStatus `json:"paid_status"`
+	PaidOn     *time.Time  `json:"paid_on"`
+
+	TransactionId *uuid.UUID `json:"transaction_id"`
+
+	Remarks *string `json:"remarks"`
+}
+
+type AdInvoice struct {
+	Base
+	BaseDate
+	BaseCreatedBy
+	AdInvoiceBase
+}
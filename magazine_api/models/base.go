@@ -24,3 +24,8 @@ type BaseCreatedBy struct {
 	DeletedBy   *uuid.UUID `json:"deleted_by"`
 	DeletedName *string    `json:"deletor_name"`
 }
+
+// BasePublished is set while the row is published, the scheduler sets it at publish_at and clears it at unpublish_at
+type BasePublished struct {
+	PublishedOn *time.Time `json:"published_on"`
+}
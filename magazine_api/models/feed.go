@@ -0,0 +1,36 @@
+package models
+
+import (
+	"magazine_api/lib"
+	"time"
+)
+
+// Feed published stories or issues syndicated to partners, rendered as RSS, Atom or JSON Feed
+type Feed struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Link        string     `json:"link"`
+	FeedURL     string     `json:"feed_url"`
+	Updated     time.Time  `json:"updated"`
+	Items       []FeedItem `json:"items"`
+}
+
+// FeedItem story or issue of the feed, updated is when it was last changed
+type FeedItem struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Link      string    `json:"link"`
+	Summary   string    `json:"summary"`
+	Category  string    `json:"category"`
+	Published time.Time `json:"published"`
+	Updated   time.Time `json:"updated"`
+
+	// Image lead image, presigned urls stay valid for a week as readers fetch feeds rarely
+	Image *lib.SignedURL `json:"image" url_expiry:"168h"`
+}
+
+// SitemapURL page of published story or issue listed in the sitemap
+type SitemapURL struct {
+	Loc     string    `json:"loc"`
+	LastMod time.Time `json:"lastmod"`
+}
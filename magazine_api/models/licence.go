@@ -0,0 +1,24 @@
+package models
+
+type LicenceType int
+
+const (
+	LicenceStaff LicenceType = iota + 1
+	LicenceRoyaltyFree
+	LicenceRightsManaged
+	LicenceEditorial
+	LicenceCreativeCommons
+)
+
+type UsageScope int
+
+const (
+	UsagePrint UsageScope = iota + 1
+	UsageDigital
+	UsagePrintDigital
+)
+
+// Covers reports whether usage allowed by the scope includes all of the other scope
+func (u UsageScope) Covers(other UsageScope) bool {
+	return u == other || u == UsagePrintDigital
+}
@@ -3,6 +3,9 @@ package magazine
 import (
 	"magazine_api/lib"
 	"magazine_api/models"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type AdvertBase struct {
@@ -12,12 +15,45 @@ type AdvertBase struct {
 	AdvertType    *string        `json:"advert_type"`
 	AdvertURL     *lib.SignedURL `json:"url"`
 
+	AdvertiserId *uuid.UUID     `json:"advertiser_id"`
+	Size         *models.AdSize `json:"size"`
+
+	// PublishAt and UnpublishAt schedule publishing, rows without publish_at are published when created
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+
 	Remarks *string `json:"remarks"`
 }
 
 type Advert struct {
 	models.Base
 	models.BaseDate
+	models.BasePublished
 	models.BaseCreatedBy
 	AdvertBase
 }
+
+// AdvertColumns columns adverts can be filtered, sorted and selected by
+var AdvertColumns = models.QueryColumns{
+	"id":             {Name: "id", Kind: models.ColumnUUID},
+	"advert_code":    {Name: "advert_code", Kind: models.ColumnText},
+	"advert_title":   {Name: "advert_title", Kind: models.ColumnText},
+	"advert_content": {Name: "advert_content", Kind: models.ColumnText},
+	"advert_type":    {Name: "advert_type", Kind: models.ColumnText},
+	"url":            {Name: "url", Kind: models.ColumnData},
+	"advertiser_id":  {Name: "advertiser_id", Kind: models.ColumnUUID},
+	"created_by":     {Name: "created_by", Kind: models.ColumnUUID},
+	"remarks":        {Name: "remarks", Kind: models.ColumnText},
+	"publish_at":     {Name: "publish_at", Kind: models.ColumnTime},
+	"unpublish_at":   {Name: "unpublish_at", Kind: models.ColumnTime},
+	"published_on":   {Name: "published_on", Kind: models.ColumnTime},
+	"created_on":     {Name: "created_on", Kind: models.ColumnTime},
+	"updated_on":     {Name: "updated_on", Kind: models.ColumnTime},
+}
+
+// PublicAdvertColumns columns readers can filter and sort published adverts by
+var PublicAdvertColumns = models.QueryColumns{
+	"advert_title": AdvertColumns["advert_title"],
+	"advert_type":  AdvertColumns["advert_type"],
+	"published_on": AdvertColumns["published_on"],
+}
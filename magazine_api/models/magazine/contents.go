@@ -19,3 +19,15 @@ type Content struct {
 	models.BaseDate
 	models.BaseCreatedBy
 }
+
+// ContentColumns columns contents can be filtered, sorted and selected by
+var ContentColumns = models.QueryColumns{
+	"id":           {Name: "id", Kind: models.ColumnUUID},
+	"content_code": {Name: "content_code", Kind: models.ColumnText},
+	"story_code":   {Name: "story_code", Kind: models.ColumnUUID},
+	"photo_code":   {Name: "photo_code", Kind: models.ColumnText},
+	"created_by":   {Name: "created_by", Kind: models.ColumnUUID},
+	"remarks":      {Name: "remarks", Kind: models.ColumnText},
+	"created_on":   {Name: "created_on", Kind: models.ColumnTime},
+	"updated_on":   {Name: "updated_on", Kind: models.ColumnTime},
+}
@@ -0,0 +1,44 @@
+package magazine
+
+import (
+	"magazine_api/lib"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CropHint region of the photograph to keep when cropped to the aspect ratio,
+// given as fractions of width and height of the photograph
+type CropHint struct {
+	Aspect string  `json:"aspect"`
+	X      float32 `json:"x"`
+	Y      float32 `json:"y"`
+	Width  float32 `json:"width"`
+	Height float32 `json:"height"`
+}
+
+// StoryPhotoBase photograph in the gallery of a story
+type StoryPhotoBase struct {
+	StoryId      *uuid.UUID `json:"story_id"`
+	PhotographId *uuid.UUID `json:"photograph_id"`
+	Position     *int       `json:"position"`
+	Caption      *string    `json:"caption"`
+
+	// CreditUserId photographer credited when the photograph is by a user, CreditText otherwise
+	CreditUserId *uuid.UUID `json:"credit_user_id"`
+	CreditText   *string    `json:"credit_text"`
+
+	CropHints []CropHint `json:"crop_hints"`
+	IsLead    *bool      `json:"is_lead"`
+}
+
+type StoryPhoto struct {
+	StoryPhotoBase
+	CreatedOn *time.Time `json:"created_on"`
+	UpdatedOn *time.Time `json:"updated_on"`
+
+	// PhotoTitle, URL and CreditName are joined from the photograph and credited user
+	PhotoTitle *string        `json:"photo_title"`
+	URL        *lib.SignedURL `json:"url"`
+	CreditName *string        `json:"credit_name"`
+}
@@ -15,3 +15,15 @@ type Magazine struct {
 	models.BaseDate
 	models.BaseCreatedBy
 }
+
+// MagazineColumns columns magazines can be filtered, sorted and selected by
+var MagazineColumns = models.QueryColumns{
+	"id":            {Name: "id", Kind: models.ColumnUUID},
+	"magazine_code": {Name: "magazine_code", Kind: models.ColumnText},
+	"issue_code":    {Name: "issue_code", Kind: models.ColumnText},
+	"placement":     {Name: "placement", Kind: models.ColumnText},
+	"created_by":    {Name: "created_by", Kind: models.ColumnUUID},
+	"remarks":       {Name: "remarks", Kind: models.ColumnText},
+	"created_on":    {Name: "created_on", Kind: models.ColumnTime},
+	"updated_on":    {Name: "updated_on", Kind: models.ColumnTime},
+}
@@ -1,17 +1,78 @@
 package magazine
 
-import "magazine_api/models"
+import (
+	"magazine_api/lib"
+	"magazine_api/models"
+	"time"
+)
 
 type IssuseBase struct {
-	IssueCode   *string `json:"issue_code"`
-	ContentCode *string `json:"content_code"`
-	AdvertCode  *string `json:"advert_code"`
-	Remarks     *string `json:"remarks"`
+	IssueCode   *string        `json:"issue_code"`
+	ContentCode *string        `json:"content_code"`
+	AdvertCode  *string        `json:"advert_code"`
+	PdfURL      *lib.SignedURL `json:"pdf_url" url_expiry:"1h"`
+	EpubURL     *lib.SignedURL `json:"epub_url" url_expiry:"1h"`
+
+	// Editions and country the issue is published in, photographs placed in it are licensed for them
+	UsageScope *models.UsageScope `json:"usage_scope"`
+	Territory  *string            `json:"territory"`
+
+	// PublishAt and UnpublishAt schedule publishing, rows without publish_at are published when created
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+
+	// SEO of pages of the issue, issue code, titles of its stories and lead image of the first story are used for fields not set
+	MetaTitle       *string        `json:"meta_title"`
+	MetaDescription *string        `json:"meta_description"`
+	CanonicalURL    *string        `json:"canonical_url"`
+	OgImage         *lib.SignedURL `json:"og_image" url_expiry:"168h"`
+
+	Remarks *string `json:"remarks"`
 }
 
 type MagazineIssue struct {
 	IssuseBase
 	models.Base
 	models.BaseDate
+	models.BasePublished
 	models.BaseCreatedBy
+
+	// Slug identifies the issue in urls of readers, it is made from the issue code when created and
+	// only changes when it is changed on its own. Slugs it had before redirect to it
+	Slug *string `json:"slug"`
+
+	// Preview is set when downloads are hidden for non-subscribers
+	Preview bool `json:"preview" db:"-"`
+
+	// Stories assembled from contents of the issue with their galleries
+	Stories []*Story `json:"stories,omitempty" db:"-"`
+}
+
+// IssueColumns columns issues can be filtered, sorted and selected by
+var IssueColumns = models.QueryColumns{
+	"id":               {Name: "id", Kind: models.ColumnUUID},
+	"issue_code":       {Name: "issue_code", Kind: models.ColumnText},
+	"content_code":     {Name: "content_code", Kind: models.ColumnText},
+	"advert_code":      {Name: "advert_code", Kind: models.ColumnText},
+	"pdf_url":          {Name: "pdf_url", Kind: models.ColumnData},
+	"epub_url":         {Name: "epub_url", Kind: models.ColumnData},
+	"territory":        {Name: "territory", Kind: models.ColumnText},
+	"slug":             {Name: "slug", Kind: models.ColumnText},
+	"meta_title":       {Name: "meta_title", Kind: models.ColumnText},
+	"meta_description": {Name: "meta_description", Kind: models.ColumnText},
+	"canonical_url":    {Name: "canonical_url", Kind: models.ColumnText},
+	"og_image":         {Name: "og_image", Kind: models.ColumnData},
+	"remarks":          {Name: "remarks", Kind: models.ColumnText},
+	"publish_at":       {Name: "publish_at", Kind: models.ColumnTime},
+	"unpublish_at":     {Name: "unpublish_at", Kind: models.ColumnTime},
+	"published_on":     {Name: "published_on", Kind: models.ColumnTime},
+	"created_on":       {Name: "created_on", Kind: models.ColumnTime},
+	"updated_on":       {Name: "updated_on", Kind: models.ColumnTime},
+}
+
+// PublicIssueColumns columns readers can filter and sort published issues by
+var PublicIssueColumns = models.QueryColumns{
+	"issue_code":   IssueColumns["issue_code"],
+	"territory":    IssueColumns["territory"],
+	"published_on": IssueColumns["published_on"],
 }
@@ -3,6 +3,7 @@ package magazine
 import (
 	"magazine_api/lib"
 	"magazine_api/models"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,6 +16,14 @@ type PhotographBase struct {
 	PhotographType  *string        `json:"photo_type"`
 	DocumentURL     *lib.SignedURL `json:"url"`
 
+	// Rights of the photograph, photographs without licence type are owned by the magazine
+	PhotographerId   *uuid.UUID          `json:"photographer_id"`
+	LicenceSource    *string             `json:"licence_source"`
+	LicenceType      *models.LicenceType `json:"licence_type"`
+	UsageScope       *models.UsageScope  `json:"usage_scope"`
+	Territories      []string            `json:"territories"`
+	LicenceExpiresOn *time.Time          `json:"licence_expires_on"`
+
 	Remarks *string `json:"remarks"`
 }
 
@@ -24,3 +33,21 @@ type Photograph struct {
 	models.BaseDate
 	models.BaseCreatedBy
 }
+
+// PhotographColumns columns photographs can be filtered, sorted and selected by
+var PhotographColumns = models.QueryColumns{
+	"id":                 {Name: "id", Kind: models.ColumnUUID},
+	"photo_id":           {Name: "photo_id", Kind: models.ColumnUUID},
+	"photo_code":         {Name: "photo_code", Kind: models.ColumnText},
+	"created_by":         {Name: "created_by", Kind: models.ColumnUUID},
+	"photo_title":        {Name: "photo_title", Kind: models.ColumnText},
+	"photo_type":         {Name: "photo_type", Kind: models.ColumnText},
+	"url":                {Name: "url", Kind: models.ColumnData},
+	"photographer_id":    {Name: "photographer_id", Kind: models.ColumnUUID},
+	"licence_source":     {Name: "licence_source", Kind: models.ColumnText},
+	"territories":        {Name: "territories", Kind: models.ColumnData},
+	"licence_expires_on": {Name: "licence_expires_on", Kind: models.ColumnTime},
+	"remarks":            {Name: "remarks", Kind: models.ColumnText},
+	"created_on":         {Name: "created_on", Kind: models.ColumnTime},
+	"updated_on":         {Name: "updated_on", Kind: models.ColumnTime},
+}
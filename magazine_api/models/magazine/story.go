@@ -1,7 +1,9 @@
 package magazine
 
 import (
+	"magazine_api/lib"
 	"magazine_api/models"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,6 +17,19 @@ type StoryBase struct {
 	StoryType    *string `json:"story_type"`
 	StoryContent *string `json:"story_content"`
 
+	// StoryBody block document of the story, story content is its plain text when it is set
+	StoryBody *StoryBody `json:"story_body"`
+
+	// PublishAt and UnpublishAt schedule publishing, rows without publish_at are published when created
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+
+	// SEO of pages of the story, title, summary and lead image are used for fields not set
+	MetaTitle       *string        `json:"meta_title"`
+	MetaDescription *string        `json:"meta_description"`
+	CanonicalURL    *string        `json:"canonical_url"`
+	OgImage         *lib.SignedURL `json:"og_image" url_expiry:"168h"`
+
 	Remarks *string `json:"remarks"`
 }
 
@@ -22,4 +37,67 @@ type Story struct {
 	StoryBase
 	models.Base
 	models.BaseDate
+	models.BasePublished
+
+	// Slug identifies the story in urls of readers, it is made from the title when created and
+	// only changes when it is changed on its own. Slugs it had before redirect to it
+	Slug *string `json:"slug"`
+
+	// WordCount and ReadingTime in minutes are computed from the content when it is saved
+	WordCount   *int `json:"word_count"`
+	ReadingTime *int `json:"reading_time"`
+
+	// Preview is set when only part of the content is sent to non-subscribers
+	Preview bool `json:"preview" db:"-"`
+
+	// Gallery photographs of the story ordered by position
+	Gallery []*StoryPhoto `json:"gallery,omitempty" db:"-"`
+}
+
+// LeadPhoto photograph of the gallery marked as lead, the first one when none is marked
+func (s *Story) LeadPhoto() *StoryPhoto {
+	for _, photo := range s.Gallery {
+		if photo.IsLead != nil && *photo.IsLead {
+			return photo
+		}
+	}
+	if len(s.Gallery) > 0 {
+		return s.Gallery[0]
+	}
+
+	return nil
+}
+
+// StoryColumns columns stories can be filtered, sorted and selected by
+var StoryColumns = models.QueryColumns{
+	"id":               {Name: "id", Kind: models.ColumnUUID},
+	"story_id":         {Name: "story_id", Kind: models.ColumnUUID},
+	"story_code":       {Name: "story_code", Kind: models.ColumnText},
+	"creator_id":       {Name: "creator_id", Kind: models.ColumnUUID},
+	"story_title":      {Name: "story_title", Kind: models.ColumnText},
+	"story_type":       {Name: "story_type", Kind: models.ColumnText},
+	"story_content":    {Name: "story_content", Kind: models.ColumnText},
+	"story_body":       {Name: "story_body", Kind: models.ColumnData},
+	"slug":             {Name: "slug", Kind: models.ColumnText},
+	"word_count":       {Name: "word_count", Kind: models.ColumnNumber},
+	"reading_time":     {Name: "reading_time", Kind: models.ColumnNumber},
+	"meta_title":       {Name: "meta_title", Kind: models.ColumnText},
+	"meta_description": {Name: "meta_description", Kind: models.ColumnText},
+	"canonical_url":    {Name: "canonical_url", Kind: models.ColumnText},
+	"og_image":         {Name: "og_image", Kind: models.ColumnData},
+	"remarks":          {Name: "remarks", Kind: models.ColumnText},
+	"publish_at":       {Name: "publish_at", Kind: models.ColumnTime},
+	"unpublish_at":     {Name: "unpublish_at", Kind: models.ColumnTime},
+	"published_on":     {Name: "published_on", Kind: models.ColumnTime},
+	"created_on":       {Name: "created_on", Kind: models.ColumnTime},
+	"updated_on":       {Name: "updated_on", Kind: models.ColumnTime},
+}
+
+// PublicStoryColumns columns readers can filter and sort published stories by
+var PublicStoryColumns = models.QueryColumns{
+	"story_title":  StoryColumns["story_title"],
+	"story_type":   StoryColumns["story_type"],
+	"word_count":   StoryColumns["word_count"],
+	"reading_time": StoryColumns["reading_time"],
+	"published_on": StoryColumns["published_on"],
 }
@@ -0,0 +1,50 @@
+package magazine
+
+import (
+	"magazine_api/lib"
+
+	"github.com/google/uuid"
+)
+
+// StoryBodyVersion version of the block format written by the API
+const StoryBodyVersion = 1
+
+type BlockType int
+
+const (
+	BlockParagraph BlockType = iota + 1
+	BlockHeading
+	BlockQuote
+	BlockImage
+	BlockEmbed
+	BlockList
+)
+
+// StoryBlock block of the story body, fields used depend on type of the block.
+// Paragraph, heading and quote have text, heading has level and quote may have attribution
+// and be a pull quote. Image references media asset with caption and credit, embed has url
+// of the embedded page and list has its items
+type StoryBlock struct {
+	Type BlockType `json:"type"`
+
+	Text        *string `json:"text,omitempty"`
+	Level       *int    `json:"level,omitempty"`
+	Attribution *string `json:"attribution,omitempty"`
+	Pull        bool    `json:"pull,omitempty"`
+
+	MediaId *uuid.UUID     `json:"media_id,omitempty"`
+	URL     *lib.SignedURL `json:"url,omitempty"`
+	Caption *string        `json:"caption,omitempty"`
+	Credit  *string        `json:"credit,omitempty"`
+
+	EmbedURL *string `json:"embed_url,omitempty"`
+
+	Items   []string `json:"items,omitempty"`
+	Ordered bool     `json:"ordered,omitempty"`
+}
+
+// StoryBody block based document of the story stored as JSONB
+type StoryBody struct {
+	Version int          `json:"version"`
+	Blocks  []StoryBlock `json:"blocks"`
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"magazine_api/lib"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MediaStatus int
+
+const (
+	MediaPending MediaStatus = iota + 1
+	MediaProcessing
+	MediaReady
+	MediaFailed
+)
+
+// MediaEntity kind of rows referencing media assets
+type MediaEntity int
+
+const (
+	MediaEntityPhotograph MediaEntity = iota + 1
+	MediaEntityAdvert
+	MediaEntityUserProfile
+	MediaEntityDocument
+	MediaEntityIssue
+	MediaEntityStory
+	MediaEntityEmployeeProfile
+)
+
+// MediaVariant resized or re-encoded copy of uploaded image
+type MediaVariant struct {
+	Name   string        `json:"name"`
+	Format string        `json:"format"`
+	Width  int           `json:"width"`
+	Height int           `json:"height"`
+	Size   int64         `json:"size"`
+	Key    lib.SignedURL `json:"url"`
+}
+
+type MediaBase struct {
+	Key        *lib.SignedURL `json:"url"`
+	MimeType   *string        `json:"mime_type"`
+	Size       *int64         `json:"size"`
+	Width      *int           `json:"width"`
+	Height     *int           `json:"height"`
+	Sha256     *string        `json:"sha256"`
+	UploadedBy *uuid.UUID     `json:"uploaded_by"`
+
+	Status   *MediaStatus   `json:"status"`
+	Variants []MediaVariant `json:"variants"`
+}
+
+// MediaJob state of background processing of the media
+type MediaJob struct {
+	Attempts  int        `json:"-"`
+	LastError *string    `json:"last_error"`
+	RunAfter  *time.Time `json:"-"`
+	LockedOn  *time.Time `json:"-"`
+}
+
+type Media struct {
+	Base
+	BaseDate
+	MediaBase
+	MediaJob
+}
+
+// MediaReference row using the media asset in one of its fields
+type MediaReference struct {
+	AssetId    uuid.UUID   `json:"asset_id"`
+	EntityType MediaEntity `json:"entity_type"`
+	EntityId   uuid.UUID   `json:"entity_id"`
+	Field      string      `json:"field"`
+	CreatedOn  *time.Time  `json:"created_on"`
+}
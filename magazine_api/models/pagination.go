@@ -0,0 +1,64 @@
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCursor is returned for cursors not given out by the API
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Pagination page of a list asked for. Pages start after the cursor in order of time and id of
+// rows, offset pages skip rows instead and are used when offset or page is asked for. Lists
+// without limit are not paged, only lists for the API itself are
+type Pagination struct {
+	Limit      uint64
+	Offset     uint64
+	Cursor     *Cursor
+	OffsetMode bool
+}
+
+// Cursor position in a list ordered by time and id of rows, it is sent to clients encoded
+type Cursor struct {
+	At time.Time
+	ID uuid.UUID
+}
+
+// PageInfo total rows of the list and cursor of the page after, empty on the last page
+type PageInfo struct {
+	NextCursor string
+	Total      int
+}
+
+// Encode cursor for clients
+func (c Cursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.At.UTC().Format(time.RFC3339Nano) + "," + c.ID.String()))
+}
+
+// DecodeCursor decodes cursor sent by client
+func DecodeCursor(encoded string) (*Cursor, error) {
+	by, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	parts := strings.SplitN(string(by), ",", 2)
+	if len(parts) != 2 {
+		return nil, ErrInvalidCursor
+	}
+
+	at, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{At: at, ID: id}, nil
+}
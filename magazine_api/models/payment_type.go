@@ -8,6 +8,11 @@ const (
 	Online
 )
 
+// Valid checks if paid medium is one of the paid mediums
+func (p PaidMedium) Valid() bool {
+	return p >= Cash && p <= Online
+}
+
 type PaidStatus int
 
 const (
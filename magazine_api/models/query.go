@@ -0,0 +1,67 @@
+package models
+
+// ColumnKind type of values a column is filtered by
+type ColumnKind int
+
+const (
+	ColumnText ColumnKind = iota + 1
+	ColumnUUID
+	ColumnTime
+	ColumnNumber
+	ColumnBool
+	// ColumnData columns of documents and lists, they can only be selected
+	ColumnData
+)
+
+// FilterOp comparison of a filter
+type FilterOp string
+
+const (
+	FilterEq   FilterOp = "eq"
+	FilterNe   FilterOp = "ne"
+	FilterGt   FilterOp = "gt"
+	FilterGte  FilterOp = "gte"
+	FilterLt   FilterOp = "lt"
+	FilterLte  FilterOp = "lte"
+	FilterLike FilterOp = "like"
+	FilterIn   FilterOp = "in"
+	FilterNull FilterOp = "null"
+)
+
+// Column of a resource clients can filter, sort and select by its name in json
+type Column struct {
+	Name string
+	Kind ColumnKind
+}
+
+// QueryColumns whitelist of columns of a resource by name in json, other names are refused
+type QueryColumns map[string]Column
+
+// Filter of rows, value is parsed for kind of the column, a slice for in and bool for null
+type Filter struct {
+	Column string
+	Op     FilterOp
+	Value  interface{}
+}
+
+// Sort of rows by column
+type Sort struct {
+	Column     string
+	Kind       ColumnKind
+	Descending bool
+}
+
+// ListQuery filters, sort and fields of a list asked for. Fields are json names of fields to send
+// and Columns their columns, rows are sent whole when none are asked for
+type ListQuery struct {
+	Filters []Filter
+	Sort    []Sort
+	Fields  []string
+	Columns []string
+}
+
+// Keyset whether rows are sorted by one time column, only these lists can be paged with cursors.
+// Lists not sorted are paged in their default order
+func (q ListQuery) Keyset() bool {
+	return len(q.Sort) == 0 || (len(q.Sort) == 1 && q.Sort[0].Kind == ColumnTime)
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RoyaltySource int
+
+const (
+	SourceStory RoyaltySource = iota + 1
+	SourcePhotograph
+)
+
+// ContributorPaymentType payment type of transactions posted for royalty payouts
+var ContributorPaymentType = uuid.MustParse("3e8d5b20-6c1f-4a97-b4d8-0f7a2c9e1b63")
+
+type RoyaltyAgreementBase struct {
+	ContributorId *uuid.UUID `json:"contributor_id"`
+
+	PerWord  *float32 `json:"per_word"`
+	PerStory *float32 `json:"per_story"`
+	PerPhoto *float32 `json:"per_photo"`
+
+	EffectiveFrom *time.Time `json:"effective_from" form:"effective_from" time_format:"2006-01-02"`
+	EffectiveTo   *time.Time `json:"effective_to" form:"effective_to" time_format:"2006-01-02"`
+
+	Remarks *string `json:"remarks"`
+}
+
+type RoyaltyAgreement struct {
+	Base
+	BaseDate
+	BaseCreatedBy
+	RoyaltyAgreementBase
+}
+
+// StoryRoyalty computes royalty of story with words under the agreement
+func (r RoyaltyAgreement) StoryRoyalty(words int) float32 {
+	var amount float32
+	if r.PerStory != nil {
+		amount += *r.PerStory
+	}
+	if r.PerWord != nil {
+		amount += *r.PerWord * float32(words)
+	}
+	return amount
+}
+
+// PhotoRoyalty computes royalty of a photograph under the agreement
+func (r RoyaltyAgreement) PhotoRoyalty() float32 {
+	if r.PerPhoto == nil {
+		return 0
+	}
+	return *r.PerPhoto
+}
+
+type RoyaltyBase struct {
+	ContributorId *uuid.UUID `json:"contributor_id"`
+	AgreementId   *uuid.UUID `json:"agreement_id"`
+	IssueId       *uuid.UUID `json:"issue_id"`
+
+	SourceType *RoyaltySource `json:"source_type"`
+	SourceId   *uuid.UUID     `json:"source_id"`
+	WordCount  *int           `json:"word_count"`
+	Amount     *float32       `json:"amount"`
+
+	PayoutId      *uuid.UUID `json:"payout_id"`
+	TransactionId *uuid.UUID `json:"transaction_id"`
+
+	Remarks *string `json:"remarks"`
+}
+
+type Royalty struct {
+	Base
+	BaseDate
+	RoyaltyBase
+}
+
+// RoyaltyPayment transaction of a payout paying the contributor the royalties
+type RoyaltyPayment struct {
+	Transaction Transaction
+	RoyaltyIds  []uuid.UUID
+}
+
+type RoyaltyPayoutBase struct {
+	TotalAmount      *float32    `json:"total_amount"`
+	ContributorCount *int        `json:"contributor_count"`
+	PaidMedium       *PaidMedium `json:"paid_medium"`
+
+	Remarks *string `json:"remarks"`
+}
+
+type RoyaltyPayout struct {
+	Base
+	BaseDate
+	BaseCreatedBy
+	RoyaltyPayoutBase
+}
@@ -14,6 +14,11 @@ const (
 	Yearly
 )
 
+// Valid checks if salary format is one of the salary formats
+func (s SalaryFormat) Valid() bool {
+	return s >= PerPiece && s <= Yearly
+}
+
 type TailorRate struct {
 	ItemId *uuid.UUID `json:"product_id"`
 	Rate   *float32   `json:"rate"`
@@ -19,6 +19,11 @@ const (
 	Ashad
 )
 
+// Valid checks if month is one of the months
+func (m Month) Valid() bool {
+	return m >= Shrawan && m <= Ashad
+}
+
 type SalarySheetBase struct {
 	EmployeeId   *uuid.UUID
 	EmployeeName *string
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScheduleEntity kind of rows published by schedule
+type ScheduleEntity int
+
+const (
+	ScheduleStory ScheduleEntity = iota + 1
+	ScheduleIssue
+	ScheduleAdvert
+)
+
+type ScheduleAction int
+
+const (
+	SchedulePublish ScheduleAction = iota + 1
+	ScheduleUnpublish
+)
+
+// ScheduledAction publishing or unpublishing of a row at its scheduled time
+type ScheduledAction struct {
+	Entity   ScheduleEntity `json:"entity"`
+	EntityId uuid.UUID      `json:"entity_id"`
+	Title    *string        `json:"title"`
+	Action   ScheduleAction `json:"action"`
+	At       time.Time      `json:"at"`
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SubscriptionInterval int
+
+const (
+	IntervalMonthly SubscriptionInterval = iota + 1
+	IntervalAnnual
+)
+
+type SubscriptionFormat int
+
+const (
+	FormatPrint SubscriptionFormat = iota + 1
+	FormatDigital
+)
+
+type SubscriptionStatus int
+
+const (
+	SubscriptionActive SubscriptionStatus = iota + 1
+	SubscriptionExpired
+	SubscriptionCancelled
+)
+
+// SubscriptionPaymentType payment type of transactions posted for subscriptions
+var SubscriptionPaymentType = uuid.MustParse("5b0f4c1e-7d0a-4b8e-9a43-2f1c6e0d8a01")
+
+type SubscriptionPlanBase struct {
+	PlanName *string               `json:"plan_name"`
+	Interval *SubscriptionInterval `json:"interval"`
+	Format   *SubscriptionFormat   `json:"format"`
+	Price    *float32              `json:"price"`
+
+	Remarks *string `json:"remarks"`
+}
+
+type SubscriptionPlan struct {
+	Base
+	BaseDate
+	BaseCreatedBy
+	SubscriptionPlanBase
+}
+
+type SubscriptionBase struct {
+	UserId *uuid.UUID `json:"user_id"`
+	PlanId *uuid.UUID `json:"plan_id"`
+
+	StartDate *time.Time          `json:"start_date"`
+	EndDate   *time.Time          `json:"end_date"`
+	Status    *SubscriptionStatus `json:"status"`
+
+	TransactionId *uuid.UUID `json:"transaction_id"`
+
+	Remarks *string `json:"remarks"`
+}
+
+type Subscription struct {
+	Base
+	BaseDate
+	SubscriptionBase
+}
+
+// IsActive checks if subscription is active at given time
+func (s Subscription) IsActive(at time.Time) bool {
+	if s.Status == nil || *s.Status != SubscriptionActive {
+		return false
+	}
+	if s.StartDate != nil && at.Before(*s.StartDate) {
+		return false
+	}
+	if s.EndDate != nil && at.After(*s.EndDate) {
+		return false
+	}
+	return true
+}
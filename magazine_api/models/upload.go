@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UploadStatus int
+
+const (
+	UploadPending UploadStatus = iota + 1
+	UploadCompleted
+	UploadAborted
+)
+
+// DirectUploadBase file the client uploads straight to storage through presigned urls
+type DirectUploadBase struct {
+	Key         *string       `json:"key"`
+	FileName    *string       `json:"file_name"`
+	ContentType *string       `json:"content_type"`
+	Size        *int64        `json:"size"`
+	UploadId    *string       `json:"-"`
+	PartSize    *int64        `json:"part_size"`
+	Status      *UploadStatus `json:"status"`
+	MediaId     *uuid.UUID    `json:"media_id"`
+	UploadedBy  *uuid.UUID    `json:"uploaded_by"`
+	ExpiresOn   *time.Time    `json:"expires_on"`
+}
+
+type DirectUpload struct {
+	Base
+	BaseDate
+	DirectUploadBase
+}
+
+// IsMultipart checks if the file is uploaded in parts
+func (d DirectUpload) IsMultipart() bool {
+	return d.UploadId != nil && *d.UploadId != ""
+}
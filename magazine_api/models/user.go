@@ -9,6 +9,24 @@ import (
 
 type UserRole []string
 
+// Roles lists values of user_role enum in database
+var Roles = UserRole{
+	"user",
+	"magazine_manager",
+	"employee",
+	"accountant",
+	"contributor",
+	"advertiser",
+	"marketing",
+	"admin",
+}
+
+// StaffRoles roles of Roles whose users read all content without subscription, admins and editors
+var StaffRoles = UserRole{
+	"admin",
+	"magazine_manager",
+}
+
 type UserBase struct {
 	Name          *string  `json:"name"`
 	Email         *string  `json:"email"`
@@ -54,3 +72,26 @@ func (u UserRole) EncodeText(ci *pgtype.ConnInfo, buf []byte) (newBuf []byte, er
 	}
 	return enum.EncodeText(ci, buf)
 }
+
+// Contains checks if role is present in user roles
+func (u UserRole) Contains(role string) bool {
+	for _, val := range u {
+		if val == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Valid checks if all user roles are part of user_role enum
+func (u UserRole) Valid() bool {
+	if len(u) == 0 {
+		return false
+	}
+	for _, val := range u {
+		if !Roles.Contains(val) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,203 @@
+package orchestrators
+
+import (
+	"errors"
+	"magazine_api/api/serializers/responses"
+	"magazine_api/apperrors"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"magazine_api/services"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+var (
+	ErrInvalidRole        = apperrors.NewValidation("role is not valid")
+	ErrAdminNotConfigured = errors.New("ADMIN_EMAIL and ADMIN_PASSWORD must be set")
+)
+
+type AdminUserOrchestrator struct {
+	logger          lib.Logger
+	userService     services.UserService
+	cognito_service services.CognitoAuthService
+}
+
+func NewAdminUserOrchestrator(
+	logger lib.Logger,
+	userService services.UserService,
+	cognito_service services.CognitoAuthService,
+) AdminUserOrchestrator {
+	return AdminUserOrchestrator{
+		logger:          logger,
+		userService:     userService,
+		cognito_service: cognito_service,
+	}
+}
+
+// ListUsers lists users from identity provider merged with users in our database
+func (a AdminUserOrchestrator) ListUsers(limit *int32, paginationToken *string) ([]*responses.AdminUser, *string, error) {
+	output, err := a.cognito_service.ListUsers(limit, paginationToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []uuid.UUID
+	for _, cognitoUser := range output.Users {
+		if cognitoUser.Username == nil {
+			continue
+		}
+		if id, err := uuid.Parse(*cognitoUser.Username); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	users := map[string]*models.User{}
+	if len(ids) > 0 {
+		dbUsers, err := a.userService.ListUsersByIDs(ids)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, user := range dbUsers {
+			users[user.ID.String()] = user
+		}
+	}
+
+	result := []*responses.AdminUser{}
+	for _, cognitoUser := range output.Users {
+		adminUser := &responses.AdminUser{
+			Username:  cognitoUser.Username,
+			Enabled:   cognitoUser.Enabled,
+			Status:    string(cognitoUser.UserStatus),
+			CreatedOn: cognitoUser.UserCreateDate,
+		}
+
+		for _, attribute := range cognitoUser.Attributes {
+			if attribute.Name != nil && *attribute.Name == "email" {
+				adminUser.Email = attribute.Value
+			}
+		}
+
+		if cognitoUser.Username != nil {
+			if user, ok := users[*cognitoUser.Username]; ok {
+				adminUser.Name = user.Name
+				adminUser.Email = user.Email
+				adminUser.Role = user.Role
+				adminUser.ContactNumber = user.ContactNumber
+				adminUser.CreatedOn = user.CreatedOn
+			}
+		}
+
+		result = append(result, adminUser)
+	}
+
+	return result, output.PaginationToken, nil
+}
+
+// ChangeRole changes role of user in our database and the role claim of identity provider
+func (a AdminUserOrchestrator) ChangeRole(id uuid.UUID, role models.UserRole) (*models.User, error) {
+	if !role.Valid() {
+		return nil, ErrInvalidRole
+	}
+
+	user, err := a.userService.GetUserByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	oldRole := user.Role
+	err = a.userService.UpdateUser(id, &map[string]interface{}{
+		"role":       role,
+		"updated_on": time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	username := id.String()
+	if err := a.cognito_service.SetRoleToUser(&username, role); err != nil {
+		// Revert role in our database so both stay consistent
+		if revertErr := a.userService.UpdateUser(id, &map[string]interface{}{"role": oldRole}); revertErr != nil {
+			a.logger.Error("error-reverting-user-role: ", revertErr.Error())
+		}
+		return nil, err
+	}
+
+	user.Role = role
+	return user, nil
+}
+
+// DisableUser disables the user in identity provider
+func (a AdminUserOrchestrator) DisableUser(id uuid.UUID) error {
+	return a.cognito_service.DisableUser(id.String())
+}
+
+// EnableUser enables the user in identity provider
+func (a AdminUserOrchestrator) EnableUser(id uuid.UUID) error {
+	return a.cognito_service.EnableUser(id.String())
+}
+
+// ResetPassword forces password reset of user on next sign in
+func (a AdminUserOrchestrator) ResetPassword(id uuid.UUID) error {
+	return a.cognito_service.PasswordReset(id.String())
+}
+
+// BootstrapAdmin creates the admin user in our database and identity provider
+// it is idempotent, existing user only gets the admin role added
+func (a AdminUserOrchestrator) BootstrapAdmin(email, password string) (*models.User, error) {
+	if email == "" || password == "" {
+		return nil, ErrAdminNotConfigured
+	}
+
+	user, err := a.userService.GetUserByEmail(email)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+
+		name := "Admin"
+		user, err = a.userService.CreateUser(&models.User{
+			UserBase: models.UserBase{
+				Name:  &name,
+				Email: &email,
+				Role:  models.UserRole{"admin"},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		a.logger.Info("admin user created in database")
+	}
+
+	if !user.Role.Contains("admin") {
+		role := append(user.Role, "admin")
+		err := a.userService.UpdateUser(user.ID, &map[string]interface{}{
+			"role":       role,
+			"updated_on": time.Now(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		user.Role = role
+	}
+
+	username := user.ID.String()
+	exists, err := a.cognito_service.UserExists(username)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		if err := a.cognito_service.CreateAdminUser(username, email, password); err != nil {
+			return nil, err
+		}
+		a.logger.Info("admin user created in identity provider")
+	}
+
+	if err := a.cognito_service.SetRoleToUser(&username, user.Role); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,120 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"magazine_api/component"
+	"magazine_api/lib"
+	"magazine_api/services"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// fakeCognito user pool without users recording the calls made to it, calls not needed are
+// left to the nil interface
+type fakeCognito struct {
+	lib.CognitoClient
+	created     []*cognitoidentityprovider.AdminCreateUserInput
+	passwords   []*cognitoidentityprovider.AdminSetUserPasswordInput
+	deleted     []string
+	passwordErr error
+}
+
+func (f *fakeCognito) AdminGetUser(ctx context.Context, params *cognitoidentityprovider.AdminGetUserInput, optFns ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.AdminGetUserOutput, error) {
+	return nil, &types.UserNotFoundException{}
+}
+
+func (f *fakeCognito) AdminCreateUser(ctx context.Context, params *cognitoidentityprovider.AdminCreateUserInput, optFns ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.AdminCreateUserOutput, error) {
+	f.created = append(f.created, params)
+	return &cognitoidentityprovider.AdminCreateUserOutput{User: &types.UserType{Username: params.Username}}, nil
+}
+
+func (f *fakeCognito) AdminSetUserPassword(ctx context.Context, params *cognitoidentityprovider.AdminSetUserPasswordInput, optFns ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.AdminSetUserPasswordOutput, error) {
+	f.passwords = append(f.passwords, params)
+	return &cognitoidentityprovider.AdminSetUserPasswordOutput{}, f.passwordErr
+}
+
+func (f *fakeCognito) AdminUpdateUserAttributes(ctx context.Context, params *cognitoidentityprovider.AdminUpdateUserAttributesInput, optFns ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.AdminUpdateUserAttributesOutput, error) {
+	return &cognitoidentityprovider.AdminUpdateUserAttributesOutput{}, nil
+}
+
+func (f *fakeCognito) AddCustomAttributes(ctx context.Context, params *cognitoidentityprovider.AddCustomAttributesInput, optFns ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.AddCustomAttributesOutput, error) {
+	return &cognitoidentityprovider.AddCustomAttributesOutput{}, nil
+}
+
+func (f *fakeCognito) AdminDeleteUser(ctx context.Context, params *cognitoidentityprovider.AdminDeleteUserInput, optFns ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.AdminDeleteUserOutput, error) {
+	f.deleted = append(f.deleted, *params.Username)
+	return &cognitoidentityprovider.AdminDeleteUserOutput{}, nil
+}
+
+// emptyUsers users table without rows, inserts add one row
+type emptyUsers struct{}
+
+func (emptyUsers) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag("INSERT 0 1"), nil
+}
+
+func (emptyUsers) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return noRows{}, nil
+}
+
+func (emptyUsers) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return nil
+}
+
+type noRows struct {
+	pgx.Rows
+}
+
+func (noRows) Next() bool                     { return false }
+func (noRows) Err() error                     { return nil }
+func (noRows) Close()                         {}
+func (noRows) Values() ([]interface{}, error) { return nil, nil }
+
+func newBootstrapOrchestrator(cognito *fakeCognito) AdminUserOrchestrator {
+	logger := lib.GetLogger()
+	users := services.NewUserService(logger, component.UserComponent{Querier: emptyUsers{}}, services.MediaService{})
+	return NewAdminUserOrchestrator(logger, users, services.NewCognitoAuthService(cognito, lib.Env{PoolID: "pool"}, logger))
+}
+
+func TestBootstrapAdminSetsPasswordPermanently(t *testing.T) {
+	cognito := &fakeCognito{}
+	user, err := newBootstrapOrchestrator(cognito).BootstrapAdmin("admin@example.com", "Secret-password-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(cognito.created) != 1 {
+		t.Fatalf("admin created %d times in identity provider, want once", len(cognito.created))
+	}
+	if len(cognito.passwords) != 1 {
+		t.Fatalf("password set %d times, want once", len(cognito.passwords))
+	}
+
+	set := cognito.passwords[0]
+	if !set.Permanent {
+		t.Error("password is temporary, admin would have to change it before signing in")
+	}
+	if *set.Password != "Secret-password-1" {
+		t.Errorf("got password %q, want the bootstrap password", *set.Password)
+	}
+	if *set.Username != user.ID.String() {
+		t.Errorf("password set for %q, want the admin %s", *set.Username, user.ID)
+	}
+}
+
+func TestBootstrapAdminRemovesUserWithoutPassword(t *testing.T) {
+	failed := errors.New("password does not conform to policy")
+	cognito := &fakeCognito{passwordErr: failed}
+
+	if _, err := newBootstrapOrchestrator(cognito).BootstrapAdmin("admin@example.com", "weak"); !errors.Is(err, failed) {
+		t.Fatalf("got %v, want %v", err, failed)
+	}
+	if len(cognito.deleted) != 1 || cognito.deleted[0] != *cognito.created[0].Username {
+		t.Errorf("admin left in identity provider without permanent password, deleted %v", cognito.deleted)
+	}
+}
@@ -0,0 +1,151 @@
+package orchestrators
+
+import (
+	"magazine_api/api/serializers/requests"
+	"magazine_api/apperrors"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"magazine_api/services"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrNotAdvertiser is returned when advertiser account is linked to user without advertiser role
+	ErrNotAdvertiser = apperrors.NewForbidden("user does not have advertiser role")
+	// ErrInvalidInvoice is returned when invoiced bookings are empty or not of the advertiser
+	ErrInvalidInvoice = services.ErrInvalidInvoice
+	// ErrInvoicePaid is returned when paid invoice is paid again
+	ErrInvoicePaid = apperrors.NewConflict("invoice already paid")
+)
+
+type AdvertisingOrchestrator struct {
+	logger             lib.Logger
+	bookingService     services.AdBookingService
+	userService        services.UserService
+	transactionService services.TransactionService
+}
+
+func NewAdvertisingOrchestrator(
+	logger lib.Logger,
+	bookingService services.AdBookingService,
+	userService services.UserService,
+	transactionService services.TransactionService,
+) AdvertisingOrchestrator {
+	return AdvertisingOrchestrator{
+		logger:             logger,
+		bookingService:     bookingService,
+		userService:        userService,
+		transactionService: transactionService,
+	}
+}
+
+// CreateAdvertiser creates advertiser account linked to user with advertiser role
+func (a AdvertisingOrchestrator) CreateAdvertiser(advertiser *models.Advertiser) (*models.Advertiser, error) {
+	if advertiser.UserId == nil {
+		return nil, ErrNotAdvertiser
+	}
+
+	user, err := a.userService.GetUserByID(*advertiser.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.Role.Contains("advertiser") {
+		return nil, ErrNotAdvertiser
+	}
+
+	if advertiser.Email == nil {
+		advertiser.Email = user.Email
+	}
+	if advertiser.ContactNumber == nil {
+		advertiser.ContactNumber = user.ContactNumber
+	}
+
+	return a.bookingService.CreateAdvertiser(advertiser)
+}
+
+// Invoice invoices the bookings of advertiser and posts the receivable as transaction
+func (a AdvertisingOrchestrator) Invoice(advertiserID uuid.UUID, request requests.CreateInvoice) (*models.AdInvoice, error) {
+	if len(request.BookingIds) == 0 {
+		return nil, ErrInvalidInvoice
+	}
+
+	advertiser, err := a.bookingService.GetAdvertiserByID(advertiserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	invoiceID := uuid.New()
+	number := a.bookingService.InvoiceNumber(invoiceID, now)
+	title := "Advert Invoice " + number
+	if advertiser.CompanyName != nil {
+		title = title + ": " + *advertiser.CompanyName
+	}
+	paidType := models.AdvertPaymentType
+
+	transaction := a.transactionService.BeforeCreate(&models.Transaction{
+		TransactionBase: models.TransactionBase{
+			Title:       &title,
+			PaymentFrom: advertiser.UserId,
+			PaidType:    &paidType,
+			Remarks:     request.Remarks,
+		},
+	})
+
+	return a.bookingService.CreateInvoice(&models.AdInvoice{
+		Base: models.Base{ID: invoiceID},
+		AdInvoiceBase: models.AdInvoiceBase{
+			InvoiceNumber: &number,
+			AdvertiserId:  &advertiser.ID,
+			DueDate:       request.DueDate,
+			Remarks:       request.Remarks,
+		},
+	}, transaction, request.BookingIds)
+}
+
+// PayInvoice marks invoice as paid and records the payment on its transaction
+func (a AdvertisingOrchestrator) PayInvoice(id uuid.UUID, request requests.PayInvoice) (*models.AdInvoice, error) {
+	invoice, err := a.bookingService.GetInvoiceByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if invoice.PaidStatus != nil && *invoice.PaidStatus == models.Complete {
+		return nil, ErrInvoicePaid
+	}
+
+	if invoice.TransactionId != nil {
+		patch := map[string]interface{}{
+			"payment_date": time.Now(),
+			"updated_on":   time.Now(),
+		}
+		if request.PaidMedium != nil {
+			patch["paid_medium"] = *request.PaidMedium
+		}
+		if request.BankPaymentTransactionId != nil {
+			patch["bank_payment_transaction_id"] = *request.BankPaymentTransactionId
+		}
+		if request.OnlinePaymentName != nil {
+			patch["online_payment_name"] = *request.OnlinePaymentName
+		}
+		if request.OnlinePaymentFrom != nil {
+			patch["online_payment_from"] = *request.OnlinePaymentFrom
+		}
+		if request.OnlinePaymentTransactionId != nil {
+			patch["online_payment_transaction_id"] = *request.OnlinePaymentTransactionId
+		}
+
+		if err := a.transactionService.UpdateTransaction(*invoice.TransactionId, &patch); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := a.bookingService.MarkInvoicePaid(id); err != nil {
+		return nil, err
+	}
+
+	return a.bookingService.GetInvoiceByID(id)
+}
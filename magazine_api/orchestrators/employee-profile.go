@@ -41,5 +41,9 @@ func (e EmployeeProfileOrchestrator) CreateEmployee(user requests.CreateUser, id
 		},
 	}
 
+	if err := e.userService.TrackEmployeeReferences(id, user.Picture, user.Documents); err != nil {
+		return nil, err
+	}
+
 	return profile, nil
 }
@@ -0,0 +1,181 @@
+package orchestrators
+
+import (
+	"errors"
+	"magazine_api/lib"
+	"magazine_api/models/magazine"
+	"magazine_api/services"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IssueOrchestrator struct {
+	logger         lib.Logger
+	issueService   services.MagazineIssueService
+	storyService   services.StoryService
+	galleryService services.GalleryService
+	photoService   services.PhotoService
+}
+
+func NewIssueOrchestrator(
+	logger lib.Logger,
+	issueService services.MagazineIssueService,
+	storyService services.StoryService,
+	galleryService services.GalleryService,
+	photoService services.PhotoService,
+) IssueOrchestrator {
+	return IssueOrchestrator{
+		logger:         logger,
+		issueService:   issueService,
+		storyService:   storyService,
+		galleryService: galleryService,
+		photoService:   photoService,
+	}
+}
+
+// AssembleIssue gets the issue with stories of its contents, each with its ordered gallery
+func (i IssueOrchestrator) AssembleIssue(id uuid.UUID) (*magazine.MagazineIssue, error) {
+	issue, err := i.issueService.GetMagazineIssueById(id)
+	if err != nil {
+		return nil, err
+	}
+
+	issue.Stories = []*magazine.Story{}
+	if issue.ContentCode == nil {
+		return issue, nil
+	}
+
+	stories, err := i.storyService.ListStoriesByContentCode(*issue.ContentCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.galleryService.AttachGalleries(stories...); err != nil {
+		return nil, err
+	}
+	issue.Stories = stories
+
+	return issue, nil
+}
+
+// CheckIssueLicences checks licences of photographs the contents of the issue place in it
+func (i IssueOrchestrator) CheckIssueLicences(issue *magazine.MagazineIssue) error {
+	if issue.ContentCode == nil {
+		return nil
+	}
+
+	photos, err := i.photoService.ListPhotosByContentCode(*issue.ContentCode)
+	if err != nil {
+		return err
+	}
+
+	return i.photoService.CheckLicences(photos, issue, time.Now())
+}
+
+// CheckContentLicences checks licences of the photograph of the content and of the gallery of
+// its story against issues already having the content code
+func (i IssueOrchestrator) CheckContentLicences(content magazine.ContentBase) error {
+	if content.ContentCode == nil {
+		return nil
+	}
+
+	issues, err := i.issueService.ListIssuesByContentCode(*content.ContentCode)
+	if err != nil || len(issues) == 0 {
+		return err
+	}
+
+	var photos []*magazine.Photograph
+	if content.PhotographCode != nil {
+		photos, err = i.photoService.ListPhotosByCodes([]string{*content.PhotographCode})
+		if err != nil {
+			return err
+		}
+	}
+
+	if content.StoryCode != nil {
+		gallery, err := i.galleryService.ListGallery(*content.StoryCode)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]uuid.UUID, 0, len(gallery))
+		for _, photo := range gallery {
+			ids = append(ids, *photo.PhotographId)
+		}
+
+		galleryPhotos, err := i.photoService.ListPhotosByIDs(ids)
+		if err != nil {
+			return err
+		}
+		photos = append(photos, galleryPhotos...)
+	}
+
+	return i.checkIssues(issues, photos)
+}
+
+// CheckGalleryLicences checks licence of the photograph added to gallery of the story against
+// issues already having the story
+func (i IssueOrchestrator) CheckGalleryLicences(storyID uuid.UUID, photographID uuid.UUID) error {
+	issues, err := i.issueService.ListIssuesByStoryId(storyID)
+	if err != nil || len(issues) == 0 {
+		return err
+	}
+
+	photos, err := i.photoService.ListPhotosByIDs([]uuid.UUID{photographID})
+	if err != nil {
+		return err
+	}
+
+	return i.checkIssues(issues, photos)
+}
+
+func (i IssueOrchestrator) checkIssues(issues []*magazine.MagazineIssue, photos []*magazine.Photograph) error {
+	if len(photos) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	blocked := &services.LicenceError{}
+	for _, issue := range issues {
+		err := i.photoService.CheckLicences(photos, issue, now)
+
+		var licence *services.LicenceError
+		if errors.As(err, &licence) {
+			blocked.Violations = append(blocked.Violations, licence.Violations...)
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if len(blocked.Violations) > 0 {
+		return blocked
+	}
+	return nil
+}
+
+// AssemblePublishedIssue gets the published issue of the slug with published stories of its contents,
+// each with its ordered gallery
+func (i IssueOrchestrator) AssemblePublishedIssue(slug string) (*magazine.MagazineIssue, error) {
+	issue, err := i.issueService.GetPublishedIssueBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	issue.Stories = []*magazine.Story{}
+	if issue.ContentCode == nil {
+		return issue, nil
+	}
+
+	stories, err := i.storyService.ListPublishedStoriesByContentCode(*issue.ContentCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.galleryService.AttachGalleries(stories...); err != nil {
+		return nil, err
+	}
+	issue.Stories = stories
+
+	return issue, nil
+}
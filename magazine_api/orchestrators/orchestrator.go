@@ -7,4 +7,10 @@ var Module = fx.Options(
 	fx.Provide(NewUserOrchestrator),
 	fx.Provide(NewUserProfileOrchestrator),
 	fx.Provide(NewEmployeeProfileOrchestrator),
+	fx.Provide(NewAdminUserOrchestrator),
+	fx.Provide(NewSubscriptionOrchestrator),
+	fx.Provide(NewAdvertisingOrchestrator),
+	fx.Provide(NewRoyaltyOrchestrator),
+	fx.Provide(NewIssueOrchestrator),
+	fx.Invoke(RoyaltyOrchestrator.PublishOnSchedule),
 )
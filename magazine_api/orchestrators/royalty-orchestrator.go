@@ -0,0 +1,215 @@
+package orchestrators
+
+import (
+	"magazine_api/api/serializers/requests"
+	"magazine_api/apperrors"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"magazine_api/services"
+	"time"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrNothingToPay is returned when payout finds no outstanding royalties
+	ErrNothingToPay = apperrors.NewValidation("no outstanding royalties to pay")
+	// ErrIssueNotPublished is returned when royalties are recorded for issue not published
+	ErrIssueNotPublished = apperrors.NewValidation("issue is not published")
+)
+
+type RoyaltyOrchestrator struct {
+	logger             lib.Logger
+	royaltyService     services.RoyaltyService
+	issueService       services.MagazineIssueService
+	storyService       services.StoryService
+	photoService       services.PhotoService
+	transactionService services.TransactionService
+}
+
+func NewRoyaltyOrchestrator(
+	logger lib.Logger,
+	royaltyService services.RoyaltyService,
+	issueService services.MagazineIssueService,
+	storyService services.StoryService,
+	photoService services.PhotoService,
+	transactionService services.TransactionService,
+) RoyaltyOrchestrator {
+	return RoyaltyOrchestrator{
+		logger:             logger,
+		royaltyService:     royaltyService,
+		issueService:       issueService,
+		storyService:       storyService,
+		photoService:       photoService,
+		transactionService: transactionService,
+	}
+}
+
+// Publish records royalties of stories and photographs the contents of the published issue place
+// in it under agreements of their writers and photographers, sources without contributor or
+// agreement in effect and sources already recorded for the issue are returned as skipped
+func (r RoyaltyOrchestrator) Publish(issueID uuid.UUID) ([]*models.Royalty, []uuid.UUID, error) {
+	issue, err := r.issueService.GetMagazineIssueById(issueID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if issue.PublishedOn == nil {
+		return nil, nil, ErrIssueNotPublished
+	}
+
+	royalties := []*models.Royalty{}
+	skipped := []uuid.UUID{}
+	if issue.ContentCode == nil {
+		return royalties, skipped, nil
+	}
+
+	stories, err := r.storyService.ListStoriesByContentCode(*issue.ContentCode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, story := range stories {
+		words := story.WordCount
+		if words == nil && story.StoryContent != nil {
+			count := services.WordCount(*story.StoryContent)
+			words = &count
+		}
+
+		royalty, err := r.record(issueID, story.CreatorId, models.SourceStory, story.ID, words)
+		if err != nil {
+			return nil, nil, err
+		}
+		if royalty == nil {
+			skipped = append(skipped, story.ID)
+			continue
+		}
+		royalties = append(royalties, royalty)
+	}
+
+	photos, err := r.photoService.ListPhotosByContentCode(*issue.ContentCode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, photo := range photos {
+		royalty, err := r.record(issueID, photo.PhotographerId, models.SourcePhotograph, photo.ID, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		if royalty == nil {
+			skipped = append(skipped, photo.ID)
+			continue
+		}
+		royalties = append(royalties, royalty)
+	}
+
+	return royalties, skipped, nil
+}
+
+// IssuePublished records royalties of the issue published by a request or by the schedule, the
+// issue stays published when recording fails so errors are only logged
+func (r RoyaltyOrchestrator) IssuePublished(issueID uuid.UUID) {
+	if _, _, err := r.Publish(issueID); err != nil {
+		r.logger.Error("royalty-publish-error: ", issueID, " ", err)
+	}
+}
+
+// PublishOnSchedule records royalties of issues when the scheduler publishes them
+func (r RoyaltyOrchestrator) PublishOnSchedule(scheduler services.Scheduler) {
+	scheduler.OnPublish(r.publishScheduled)
+}
+
+func (r RoyaltyOrchestrator) publishScheduled(action *models.ScheduledAction) {
+	if action.Entity == models.ScheduleIssue {
+		r.IssuePublished(action.EntityId)
+	}
+}
+
+func (r RoyaltyOrchestrator) record(
+	issueID uuid.UUID,
+	contributorID *uuid.UUID,
+	source models.RoyaltySource,
+	sourceID uuid.UUID,
+	words *int,
+) (*models.Royalty, error) {
+	if contributorID == nil {
+		r.logger.Info("royalty-skipped-no-contributor: ", sourceID)
+		return nil, nil
+	}
+
+	agreement, err := r.royaltyService.GetActiveAgreement(*contributorID)
+	if pgxscan.NotFound(err) {
+		r.logger.Info("royalty-skipped-no-agreement: ", sourceID)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.royaltyService.RecordRoyalty(agreement, issueID, source, sourceID, words)
+}
+
+// Payout pays outstanding royalties with one transaction per contributor in a payout batch, the
+// payout is written in one database transaction so nothing is posted if any contributor fails
+func (r RoyaltyOrchestrator) Payout(request requests.RoyaltyPayout) (*models.RoyaltyPayout, error) {
+	royalties, err := r.royaltyService.ListUnpaidRoyalties(request.ContributorIds)
+	if err != nil {
+		return nil, err
+	}
+
+	var order []uuid.UUID
+	amounts := map[uuid.UUID]float32{}
+	royaltyIDs := map[uuid.UUID][]uuid.UUID{}
+	var total float32
+	for _, royalty := range royalties {
+		if royalty.ContributorId == nil || royalty.Amount == nil {
+			continue
+		}
+		contributor := *royalty.ContributorId
+		if _, ok := amounts[contributor]; !ok {
+			order = append(order, contributor)
+		}
+		amounts[contributor] += *royalty.Amount
+		royaltyIDs[contributor] = append(royaltyIDs[contributor], royalty.ID)
+		total += *royalty.Amount
+	}
+
+	if len(order) == 0 {
+		return nil, ErrNothingToPay
+	}
+
+	title := "Royalty Payment"
+	paidType := models.ContributorPaymentType
+	paymentDate := time.Now()
+	payments := make([]models.RoyaltyPayment, 0, len(order))
+	for _, contributor := range order {
+		contributorID := contributor
+		amount := amounts[contributor]
+
+		transaction := r.transactionService.BeforeCreate(&models.Transaction{
+			TransactionBase: models.TransactionBase{
+				Title:           &title,
+				TransactionCost: &amount,
+				DebitAmount:     &amount,
+				PaymentTo:       &contributorID,
+				PaymentDate:     &paymentDate,
+				PaidType:        &paidType,
+				PaidMedium:      request.PaidMedium,
+				Remarks:         request.Remarks,
+			},
+		})
+		payments = append(payments, models.RoyaltyPayment{Transaction: *transaction, RoyaltyIds: royaltyIDs[contributor]})
+	}
+
+	count := len(order)
+	return r.royaltyService.CreatePayout(&models.RoyaltyPayout{
+		RoyaltyPayoutBase: models.RoyaltyPayoutBase{
+			TotalAmount:      &total,
+			ContributorCount: &count,
+			PaidMedium:       request.PaidMedium,
+			Remarks:          request.Remarks,
+		},
+	}, payments)
+}
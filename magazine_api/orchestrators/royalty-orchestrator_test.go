@@ -0,0 +1,149 @@
+package orchestrators
+
+import (
+	"context"
+	"magazine_api/component"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"magazine_api/models/magazine"
+	"magazine_api/services"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgproto3/v2"
+	"github.com/jackc/pgx/v4"
+)
+
+// contentsDB database of one published issue whose contents place a story without writer,
+// queries of other tables return no rows. Ids are arguments of queries as their strings
+type contentsDB struct {
+	issueID     uuid.UUID
+	contentCode string
+	queries     []string
+}
+
+func (d *contentsDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	d.queries = append(d.queries, sql)
+	return pgconn.CommandTag("UPDATE 1"), nil
+}
+
+func (d *contentsDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	d.queries = append(d.queries, sql)
+
+	published := time.Now()
+	switch {
+	case strings.Contains(sql, "FROM magazine_issues") && args[0] == d.issueID.String():
+		return &fakeRows{columns: []string{"id", "published_on", "content_code"},
+			values: [][]interface{}{{d.issueID, &published, &d.contentCode}}}, nil
+	case strings.Contains(sql, "FROM stories") && args[0] == d.contentCode:
+		return &fakeRows{columns: []string{"id"}, values: [][]interface{}{{uuid.New()}}}, nil
+	}
+	return noRows{}, nil
+}
+
+func (d *contentsDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return nil
+}
+
+// fakeRows rows of values of the columns, values have the types of the fields they are scanned to
+type fakeRows struct {
+	pgx.Rows
+	columns []string
+	values  [][]interface{}
+	next    int
+}
+
+func (r *fakeRows) Next() bool {
+	r.next++
+	return r.next <= len(r.values)
+}
+
+func (r *fakeRows) Scan(dest ...interface{}) error {
+	for i, value := range r.values[r.next-1] {
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(value))
+	}
+	return nil
+}
+
+func (r *fakeRows) FieldDescriptions() []pgproto3.FieldDescription {
+	fields := make([]pgproto3.FieldDescription, len(r.columns))
+	for i, column := range r.columns {
+		fields[i] = pgproto3.FieldDescription{Name: []byte(column)}
+	}
+	return fields
+}
+
+func (r *fakeRows) Err() error { return nil }
+func (r *fakeRows) Close()     {}
+
+func newPublishOrchestrator(db *contentsDB) RoyaltyOrchestrator {
+	logger := lib.GetLogger()
+	issues := services.NewMagazineIssueService(logger, component.IIssueMgmtComp{
+		Repository: component.NewRepository[magazine.MagazineIssue](db, component.Table{Name: "magazine_issues"}),
+	}, services.MediaService{}, services.SlugService{})
+	stories := services.NewStoryService(logger, component.IStoryMgmtComp{
+		Repository: component.NewRepository[magazine.Story](db, component.Table{Name: "stories"}),
+	}, services.MediaService{}, services.SlugService{})
+	photos := services.NewPhotoService(logger, component.IPhotographMgmtComp{
+		Repository: component.NewRepository[magazine.Photograph](db, component.Table{Name: "photographs"}),
+	}, services.MediaService{})
+
+	return NewRoyaltyOrchestrator(logger, services.RoyaltyService{}, issues, stories, photos, services.TransactionService{})
+}
+
+// recordsContents reports whether royalties of the contents of the issue were recorded, the
+// issue is looked up and its stories and photographs are listed
+func (d *contentsDB) recordsContents() bool {
+	var issue, stories, photos bool
+	for _, query := range d.queries {
+		issue = issue || strings.Contains(query, "FROM magazine_issues")
+		stories = stories || strings.Contains(query, "FROM stories")
+		photos = photos || strings.Contains(query, "FROM photographs")
+	}
+	return issue && stories && photos
+}
+
+func TestRoyaltiesOfIssuePublished(t *testing.T) {
+	tests := []struct {
+		name    string
+		publish func(r RoyaltyOrchestrator, issueID uuid.UUID)
+		want    bool
+	}{
+		{"by request", func(r RoyaltyOrchestrator, issueID uuid.UUID) {
+			r.IssuePublished(issueID)
+		}, true},
+		{"by schedule", func(r RoyaltyOrchestrator, issueID uuid.UUID) {
+			r.publishScheduled(&models.ScheduledAction{Entity: models.ScheduleIssue, EntityId: issueID, Action: models.SchedulePublish})
+		}, true},
+		{"story by schedule", func(r RoyaltyOrchestrator, issueID uuid.UUID) {
+			r.publishScheduled(&models.ScheduledAction{Entity: models.ScheduleStory, EntityId: issueID, Action: models.SchedulePublish})
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &contentsDB{issueID: uuid.New(), contentCode: "spring"}
+			tt.publish(newPublishOrchestrator(db), db.issueID)
+
+			if got := db.recordsContents(); got != tt.want {
+				t.Errorf("royalties of contents recorded %v, want %v: %v", got, tt.want, db.queries)
+			}
+		})
+	}
+}
+
+func TestPublishSkipsStoryWithoutWriter(t *testing.T) {
+	db := &contentsDB{issueID: uuid.New(), contentCode: "spring"}
+
+	royalties, skipped, err := newPublishOrchestrator(db).Publish(db.issueID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(royalties) != 0 || len(skipped) != 1 {
+		t.Errorf("got %d royalties and %d skipped, want the story skipped", len(royalties), len(skipped))
+	}
+}
@@ -0,0 +1,80 @@
+package orchestrators
+
+import (
+	"magazine_api/api/serializers/requests"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"magazine_api/services"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SubscriptionOrchestrator struct {
+	logger              lib.Logger
+	subscriptionService services.SubscriptionService
+	transactionService  services.TransactionService
+}
+
+func NewSubscriptionOrchestrator(
+	logger lib.Logger,
+	subscriptionService services.SubscriptionService,
+	transactionService services.TransactionService,
+) SubscriptionOrchestrator {
+	return SubscriptionOrchestrator{
+		logger:              logger,
+		subscriptionService: subscriptionService,
+		transactionService:  transactionService,
+	}
+}
+
+// Subscribe posts the payment as transaction and subscribes user to the plan in one database
+// transaction, renewal of an active subscription starts when the current one ends
+func (s SubscriptionOrchestrator) Subscribe(userID uuid.UUID, request requests.Subscribe) (*models.Subscription, error) {
+	plan, err := s.subscriptionService.GetPlanByID(request.PlanId)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	existing, err := s.subscriptionService.ListSubscriptionsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, subscription := range existing {
+		if subscription.PlanId != nil && *subscription.PlanId == plan.ID &&
+			subscription.IsActive(start) && subscription.EndDate.After(start) {
+			start = *subscription.EndDate
+		}
+	}
+
+	title := "Subscription Payment"
+	if plan.PlanName != nil {
+		title = title + ": " + *plan.PlanName
+	}
+	paymentDate := time.Now()
+	paidType := models.SubscriptionPaymentType
+
+	transaction := s.transactionService.BeforeCreate(&models.Transaction{
+		TransactionBase: models.TransactionBase{
+			Title:                      &title,
+			TransactionCost:            plan.Price,
+			CreditAmount:               plan.Price,
+			PaymentFrom:                &userID,
+			PaymentDate:                &paymentDate,
+			PaidType:                   &paidType,
+			PaidMedium:                 request.PaidMedium,
+			BankPaymentTransactionId:   request.BankPaymentTransactionId,
+			OnlinePaymentName:          request.OnlinePaymentName,
+			OnlinePaymentFrom:          request.OnlinePaymentFrom,
+			OnlinePaymentTransactionId: request.OnlinePaymentTransactionId,
+		},
+	})
+
+	subscription, err := s.subscriptionService.CreateSubscription(userID, plan, start, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
@@ -28,20 +28,10 @@ func NewUserOrchestrator(
 	}
 }
 
-func (e UserOrchestrator) CreateUser(user_request requests.CreateUser) (*requests.CreateUser, error) {
-
-	user := &models.User{
-		UserBase: models.UserBase{
-			Name:          user_request.Name,
-			Password:      user_request.Password,
-			Role:          user_request.Role,
-			Email:         user_request.Email,
-			ContactNumber: user_request.ContactNumber,
-		},
-	}
+func (e UserOrchestrator) CreateUser(user_request requests.CreateUser) (*models.User, error) {
 
 	// Create User in our Database
-	user, err := e.userService.CreateUser(user)
+	user, err := e.userService.CreateUser(user_request.User())
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +70,7 @@ func (e UserOrchestrator) CreateUser(user_request requests.CreateUser) (*request
 
 	e.cognito_service.SetRoleToUser(&id, user.Role)
 
-	return &user_request, nil
+	return user, nil
 }
 
 func (e UserOrchestrator) contains(value string, checkIn []string) bool {
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"fmt"
+	"magazine_api/apperrors"
+	"magazine_api/component"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidBooking is returned when booking misses issue, size or placement
+	ErrInvalidBooking = apperrors.NewValidation("booking needs issue, size, placement and page for inside placement")
+	// ErrBookingInvoiced is returned when invoiced booking is cancelled or invoiced again
+	ErrBookingInvoiced = component.ErrBookingInvoiced
+	// ErrInvalidInvoice is returned when invoiced bookings are empty or not booked by the advertiser
+	ErrInvalidInvoice = component.ErrInvalidInvoice
+	// ErrSlotConflict is returned when booking does not fit in the page of the issue
+	ErrSlotConflict = component.ErrSlotConflict
+)
+
+// AdBookingService service layer for advertisers, rate cards, bookings and invoices
+type AdBookingService struct {
+	logger lib.Logger
+	comp   component.AdBookingComponent
+}
+
+// NewAdBookingService creates new instance of AdBookingService
+func NewAdBookingService(logger lib.Logger, comp component.AdBookingComponent) AdBookingService {
+	return AdBookingService{logger: logger, comp: comp}
+}
+
+// CreateAdvertiser creates the advertiser account in database
+func (a AdBookingService) CreateAdvertiser(advertiser *models.Advertiser) (*models.Advertiser, error) {
+	advertiser.ID = uuid.New()
+	create := time.Now()
+	advertiser.CreatedOn = &create
+	advertiser.UpdatedOn = &create
+
+	if err := a.comp.CreateAdvertiser(*advertiser); err != nil {
+		return nil, err
+	}
+
+	return advertiser, nil
+}
+
+// ListAdvertisers lists page of advertiser accounts from database
+func (a AdBookingService) ListAdvertisers(page models.Pagination, list models.ListQuery) ([]*models.Advertiser, models.PageInfo, error) {
+	return a.comp.ListAdvertisers(page, list)
+}
+
+// GetAdvertiserByID gets advertiser account by id from database
+func (a AdBookingService) GetAdvertiserByID(id uuid.UUID) (*models.Advertiser, error) {
+	return a.comp.GetAdvertiserFromID(id)
+}
+
+// GetAdvertiserByUserID gets advertiser account linked to the user from database
+func (a AdBookingService) GetAdvertiserByUserID(userID uuid.UUID) (*models.Advertiser, error) {
+	return a.comp.GetAdvertiserFromUserID(userID)
+}
+
+// UpdateAdvertiser updates advertiser account by id in database
+func (a AdBookingService) UpdateAdvertiser(id uuid.UUID, patch *map[string]interface{}) error {
+	return a.comp.PatchAdvertiser(id, patch)
+}
+
+// DeleteAdvertiser soft deletes advertiser account by id in database
+func (a AdBookingService) DeleteAdvertiser(id uuid.UUID) error {
+	return a.comp.DeleteAdvertiser(id)
+}
+
+// CreateRateCard creates the rate card in database
+func (a AdBookingService) CreateRateCard(card *models.RateCard) (*models.RateCard, error) {
+	card.ID = uuid.New()
+	create := time.Now()
+	card.CreatedOn = &create
+	card.UpdatedOn = &create
+
+	if err := a.comp.CreateRateCard(*card); err != nil {
+		return nil, err
+	}
+
+	return card, nil
+}
+
+// ListRateCards lists page of rate cards from database
+func (a AdBookingService) ListRateCards(page models.Pagination, list models.ListQuery) ([]*models.RateCard, models.PageInfo, error) {
+	return a.comp.ListRateCards(page, list)
+}
+
+// UpdateRateCard updates rate card by id in database
+func (a AdBookingService) UpdateRateCard(id uuid.UUID, patch *map[string]interface{}) error {
+	return a.comp.PatchRateCard(id, patch)
+}
+
+// DeleteRateCard soft deletes rate card by id in database
+func (a AdBookingService) DeleteRateCard(id uuid.UUID) error {
+	return a.comp.DeleteRateCard(id)
+}
+
+// BookAdvert books the advert into the issue, price is taken from rate card when not given
+func (a AdBookingService) BookAdvert(booking *models.AdBooking) (*models.AdBooking, error) {
+	if booking.IssueId == nil || booking.Size == nil || booking.Placement == nil {
+		return nil, ErrInvalidBooking
+	}
+
+	// Cover placements are a single page
+	if *booking.Placement != models.PlacementInside {
+		page := 1
+		booking.Page = &page
+	}
+	if booking.Page == nil || *booking.Page < 1 {
+		return nil, ErrInvalidBooking
+	}
+
+	if booking.Price == nil {
+		card, err := a.comp.GetRateCard(*booking.Size, *booking.Placement)
+		if err != nil {
+			return nil, err
+		}
+		booking.Price = card.Price
+	}
+
+	booking.ID = uuid.New()
+	create := time.Now()
+	booking.CreatedOn = &create
+	booking.UpdatedOn = &create
+	status := models.BookingBooked
+	booking.Status = &status
+	booking.InvoiceId = nil
+
+	if err := a.comp.CreateBooking(*booking); err != nil {
+		return nil, err
+	}
+
+	return booking, nil
+}
+
+// GetBookingByID gets booking by id from database
+func (a AdBookingService) GetBookingByID(id uuid.UUID) (*models.AdBooking, error) {
+	return a.comp.GetBookingFromID(id)
+}
+
+// ListBookingsByIssueID lists page of bookings into the issue
+func (a AdBookingService) ListBookingsByIssueID(issueID uuid.UUID, page models.Pagination, list models.ListQuery) ([]*models.AdBooking, models.PageInfo, error) {
+	return a.comp.ListBookingsFromIssueID(issueID, page, list)
+}
+
+// ListBookingsByAdvertiserID lists page of bookings of the advertiser
+func (a AdBookingService) ListBookingsByAdvertiserID(advertiserID uuid.UUID, page models.Pagination, list models.ListQuery) ([]*models.AdBooking, models.PageInfo, error) {
+	return a.comp.ListBookingsFromAdvertiserID(advertiserID, page, list)
+}
+
+// CancelBooking cancels booking by id, invoiced bookings can't be cancelled
+func (a AdBookingService) CancelBooking(id uuid.UUID) error {
+	booking, err := a.comp.GetBookingFromID(id)
+	if err != nil {
+		return err
+	}
+
+	if booking.InvoiceId != nil {
+		return ErrBookingInvoiced
+	}
+
+	return a.comp.PatchBooking(id, &map[string]interface{}{
+		"status":     models.BookingCancelled,
+		"updated_on": time.Now(),
+	})
+}
+
+// CreateInvoice creates pending invoice of the bookings with its receivable transaction in database,
+// the amounts are the total of the bookings
+func (a AdBookingService) CreateInvoice(
+	invoice *models.AdInvoice,
+	transaction *models.Transaction,
+	bookingIDs []uuid.UUID,
+) (*models.AdInvoice, error) {
+	create := time.Now()
+	invoice.CreatedOn = &create
+	invoice.UpdatedOn = &create
+	status := models.Pending
+	invoice.PaidStatus = &status
+	invoice.TransactionId = &transaction.ID
+
+	if err := a.comp.CreateInvoice(invoice, transaction, bookingIDs); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// GetInvoiceByID gets invoice by id from database
+func (a AdBookingService) GetInvoiceByID(id uuid.UUID) (*models.AdInvoice, error) {
+	return a.comp.GetInvoiceFromID(id)
+}
+
+// ListInvoices lists page of invoices, filtered by paid status when given
+func (a AdBookingService) ListInvoices(status *models.PaidStatus, page models.Pagination, list models.ListQuery) ([]*models.AdInvoice, models.PageInfo, error) {
+	return a.comp.ListInvoices(status, page, list)
+}
+
+// ListInvoicesByAdvertiserID lists page of invoices of the advertiser
+func (a AdBookingService) ListInvoicesByAdvertiserID(advertiserID uuid.UUID, page models.Pagination, list models.ListQuery) ([]*models.AdInvoice, models.PageInfo, error) {
+	return a.comp.ListInvoicesFromAdvertiserID(advertiserID, page, list)
+}
+
+// MarkInvoicePaid marks invoice as paid
+func (a AdBookingService) MarkInvoicePaid(id uuid.UUID) error {
+	now := time.Now()
+	return a.comp.PatchInvoice(id, &map[string]interface{}{
+		"paid_status": models.Complete,
+		"paid_on":     now,
+		"updated_on":  now,
+	})
+}
+
+// InvoiceNumber generates human readable invoice number for invoice id
+func (a AdBookingService) InvoiceNumber(id uuid.UUID, at time.Time) string {
+	return fmt.Sprintf("INV-%s-%s", at.Format("20060102"), strings.ToUpper(id.String()[:8]))
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"magazine_api/component"
 	"magazine_api/lib"
+	"magazine_api/models"
 	"magazine_api/models/magazine"
 	"time"
 
@@ -14,32 +15,46 @@ import (
 type AdvertService struct {
 	logger lib.Logger
 	repo   component.IAdMgmtComp
+	media  MediaService
 }
 
 // NewAdvertService creates new instance of AdvertService
-func NewAdvertService(logger lib.Logger, repo component.IAdMgmtComp) AdvertService {
-	return AdvertService{logger: logger, repo: repo}
+func NewAdvertService(logger lib.Logger, repo component.IAdMgmtComp, media MediaService) AdvertService {
+	return AdvertService{logger: logger, repo: repo, media: media}
 }
 
 // Creates the Advert in database
 func (u AdvertService) CreateAdvert(ad *magazine.Advert) (*magazine.Advert, error) {
+	publishedOn, err := PublishedOn(ad.PublishAt, ad.UnpublishAt)
+	if err != nil {
+		return nil, err
+	}
+	ad.PublishedOn = publishedOn
+
 	ad = u.BeforeCreate(ad)
 
-	err := u.repo.CreateAd(*ad)
+	err = u.repo.CreateAd(*ad)
 	if err != nil {
 		return nil, err
 	}
 
+	refs := map[string]*lib.SignedURL{
+		"url": ad.AdvertURL,
+	}
+	if err := u.media.TrackReferences(models.MediaEntityAdvert, ad.ID, refs); err != nil {
+		return nil, err
+	}
+
 	return ad, nil
 }
 
 // Lists stories form database
-func (p AdvertService) ListAds(c *gin.Context) ([]*magazine.Advert, error) {
-	stories, err := p.repo.ListAds()
+func (p AdvertService) ListAds(c *gin.Context) ([]*magazine.Advert, models.PageInfo, error) {
+	stories, page, err := p.repo.ListAds(PaginationFrom(c), ListQueryFrom(c))
 	if err != nil {
-		return nil, err
+		return nil, page, err
 	}
-	return stories, nil
+	return stories, page, nil
 }
 
 // Gets Advert by id from database
@@ -53,13 +68,8 @@ func (u AdvertService) GetAdvertById(id uuid.UUID) (*magazine.Advert, error) {
 }
 
 // Lists Adverts by profile id
-func (u AdvertService) ListAdsByProfileId(id uuid.UUID) ([]*magazine.Advert, error) {
-	Advert, err := u.repo.GetAdFromCreatorId(id)
-	if err != nil {
-		return nil, err
-	}
-
-	return Advert, nil
+func (u AdvertService) ListAdsByProfileId(id uuid.UUID, page models.Pagination, list models.ListQuery) ([]*magazine.Advert, models.PageInfo, error) {
+	return u.repo.GetAdFromCreatorId(id, page, list)
 }
 
 // Update Advert by id in our database
@@ -69,7 +79,7 @@ func (u AdvertService) UpdateAdvert(id uuid.UUID, patch *map[string]interface{})
 		return err
 	}
 
-	return nil
+	return u.media.TrackPatchedReferences(models.MediaEntityAdvert, id, patch, "url")
 }
 
 // Delete Advert by in our database
@@ -89,7 +99,7 @@ func (u AdvertService) PermanentDeleteAdvert(id uuid.UUID) error {
 		return err
 	}
 
-	return nil
+	return u.media.ClearReferences(models.MediaEntityAdvert, id)
 }
 
 func (u AdvertService) BeforeCreate(Advert *magazine.Advert) *magazine.Advert {
@@ -100,3 +110,8 @@ func (u AdvertService) BeforeCreate(Advert *magazine.Advert) *magazine.Advert {
 
 	return Advert
 }
+
+// Lists page of published Adverts for readers
+func (u AdvertService) ListPublishedAds(page models.Pagination, list models.ListQuery) ([]*magazine.Advert, models.PageInfo, error) {
+	return u.repo.ListPublishedAds(page, list)
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"magazine_api/lib"
 	"magazine_api/models"
 	"magazine_api/utils"
@@ -20,13 +21,13 @@ var issuer = ""
 var keySet jwk.Set = jwk.NewSet()
 
 type CognitoAuthService struct {
-	client *cognitoidentityprovider.Client
+	client lib.CognitoClient
 	env    lib.Env
 	logger lib.Logger
 }
 
 func NewCognitoAuthService(
-	client *cognitoidentityprovider.Client,
+	client lib.CognitoClient,
 	env lib.Env,
 	logger lib.Logger,
 ) CognitoAuthService {
@@ -82,7 +83,8 @@ func (cg *CognitoAuthService) ConfirmSignUp(email string) error {
 	return nil
 }
 
-// AdminCreateUser creates normal user by admin
+// AdminCreateUser creates normal user by admin, password given as temporary one is set permanent
+// and the user is deleted again when it can not be
 func (cg *CognitoAuthService) AdminCreateUser(id, email, contact_number, password *string) (
 	*cognitoidentityprovider.AdminCreateUserOutput, error,
 ) {
@@ -182,27 +184,16 @@ func (cg *CognitoAuthService) CreateStoreEmployee() {
 
 }
 
-func (cg *CognitoAuthService) CreateAdminUser(email, password string) error {
-	_, err := cg.client.SignUp(
-		context.Background(), &cognitoidentityprovider.SignUpInput{
-			ClientId: &cg.env.ClientID,
-			Password: &password,
-			Username: &email,
-			UserAttributes: []types.AttributeType{
-				{
-					Name:  aws.String("email"),
-					Value: aws.String(email),
-				},
-			},
-			ValidationData: []types.AttributeType{},
-		},
-	)
+// CreateAdminUser creates confirmed user with admin role claim, id is used as username. The password
+// is set permanent after creation so the admin signs in with it without FORCE_CHANGE_PASSWORD challenge
+func (cg *CognitoAuthService) CreateAdminUser(id, email, password string) error {
+	_, err := cg.AdminCreateUser(&id, &email, nil, &password)
 	if err != nil {
 		return err
 	}
 
 	err = cg.SetCustomClaimToOneUser(
-		email, map[string]string{
+		id, map[string]string{
 			"role": "admin",
 		},
 	)
@@ -210,12 +201,21 @@ func (cg *CognitoAuthService) CreateAdminUser(email, password string) error {
 		return err
 	}
 
-	err = cg.ConfirmSignUp(email)
+	return nil
+}
+
+// UserExists checks whether user with username exists in the pool
+func (cg *CognitoAuthService) UserExists(user string) (bool, error) {
+	_, err := cg.GetUserByEmail(user)
 	if err != nil {
-		return err
+		var notFound *types.UserNotFoundException
+		if errors.As(err, &notFound) {
+			return false, nil
+		}
+		return false, err
 	}
 
-	return nil
+	return true, nil
 }
 
 func (cg *CognitoAuthService) UpdateUserAttribute(user string, c map[string]string) error {
@@ -321,10 +321,13 @@ func (cg *CognitoAuthService) EnableUser(user string) error {
 	return nil
 }
 
-func (cg *CognitoAuthService) ListUsers() (*cognitoidentityprovider.ListUsersOutput, error) {
+// ListUsers lists users of the pool, limit and paginationToken are optional
+func (cg *CognitoAuthService) ListUsers(limit *int32, paginationToken *string) (*cognitoidentityprovider.ListUsersOutput, error) {
 	users, err := cg.client.ListUsers(
 		context.Background(), &cognitoidentityprovider.ListUsersInput{
-			UserPoolId: &cg.env.PoolID,
+			UserPoolId:      &cg.env.PoolID,
+			Limit:           limit,
+			PaginationToken: paginationToken,
 		},
 	)
 	if err != nil {
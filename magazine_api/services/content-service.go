@@ -3,6 +3,7 @@ package services
 import (
 	"magazine_api/component"
 	"magazine_api/lib"
+	"magazine_api/models"
 	"magazine_api/models/magazine"
 	"time"
 
@@ -34,12 +35,12 @@ func (u ContentService) CreateContent(story *magazine.Content) (*magazine.Conten
 }
 
 // Lists stories form database
-func (p ContentService) ListContents(c *gin.Context) ([]*magazine.Content, error) {
-	stories, err := p.repo.ListContents()
+func (p ContentService) ListContents(c *gin.Context) ([]*magazine.Content, models.PageInfo, error) {
+	stories, page, err := p.repo.ListContents(PaginationFrom(c), ListQueryFrom(c))
 	if err != nil {
-		return nil, err
+		return nil, page, err
 	}
-	return stories, nil
+	return stories, page, nil
 }
 
 // Gets Content by id from database
@@ -53,23 +54,13 @@ func (u ContentService) GetContentById(id uuid.UUID) (*magazine.Content, error)
 }
 
 // Lists Contents by profile id
-func (u ContentService) ListContentsByProfileId(id uuid.UUID) ([]*magazine.Content, error) {
-	Content, err := u.repo.GetContentFromCreatorId(id)
-	if err != nil {
-		return nil, err
-	}
-
-	return Content, nil
+func (u ContentService) ListContentsByProfileId(id uuid.UUID, page models.Pagination, list models.ListQuery) ([]*magazine.Content, models.PageInfo, error) {
+	return u.repo.GetContentFromCreatorId(id, page, list)
 }
 
 // Lists Contents by type
-func (u ContentService) ListContentsByType(story_type string) ([]*magazine.Content, error) {
-	Content, err := u.repo.GetContentFromType(story_type)
-	if err != nil {
-		return nil, err
-	}
-
-	return Content, nil
+func (u ContentService) ListContentsByType(story_type string, page models.Pagination, list models.ListQuery) ([]*magazine.Content, models.PageInfo, error) {
+	return u.repo.GetContentFromType(story_type, page, list)
 }
 
 // Update Content by id in our database
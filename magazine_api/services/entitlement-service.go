@@ -0,0 +1,144 @@
+package services
+
+import (
+	"magazine_api/constants"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"magazine_api/models/magazine"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PreviewWords number of words of story content shown to non-subscribers
+const PreviewWords = 60
+
+// SummaryWords number of words of story content in summaries of listed stories and feeds
+const SummaryWords = 40
+
+// EntitlementService decides access to full content for readers
+type EntitlementService struct {
+	logger        lib.Logger
+	subscriptions SubscriptionService
+}
+
+// NewEntitlementService creates new instance of EntitlementService
+func NewEntitlementService(logger lib.Logger, subscriptions SubscriptionService) EntitlementService {
+	return EntitlementService{logger: logger, subscriptions: subscriptions}
+}
+
+// IsEntitled checks if the authenticated user of request can access full content,
+// staff roles of models.StaffRoles are always entitled while everyone else needs an active
+// digital subscription. The answer is memoised for the request
+func (e EntitlementService) IsEntitled(c *gin.Context) bool {
+	if entitled, ok := c.Get(constants.Entitled); ok {
+		return entitled.(bool)
+	}
+
+	entitled := e.isEntitled(c)
+	c.Set(constants.Entitled, entitled)
+	return entitled
+}
+
+func (e EntitlementService) isEntitled(c *gin.Context) bool {
+	claims, ok := c.Get(constants.Claims)
+	if !ok {
+		return false
+	}
+
+	if claimMap, ok := claims.(map[string]interface{}); ok {
+		roles, _ := claimMap["custom:role"].(string)
+		for _, role := range strings.Split(roles, ",") {
+			if models.StaffRoles.Contains(strings.TrimSpace(role)) {
+				return true
+			}
+		}
+	}
+
+	uid, _ := c.Get(constants.UID)
+	username, _ := uid.(string)
+	userID, err := uuid.Parse(username)
+	if err != nil {
+		return false
+	}
+
+	entitled, err := e.subscriptions.HasActiveSubscription(userID, models.FormatDigital)
+	if err != nil {
+		e.logger.Error("error-checking-entitlement: ", err.Error())
+		return false
+	}
+
+	return entitled
+}
+
+// GateStory replaces story content with a preview if request is not entitled
+func (e EntitlementService) GateStory(c *gin.Context, story *magazine.Story) *magazine.Story {
+	if story == nil || e.IsEntitled(c) {
+		return story
+	}
+
+	if story.StoryContent != nil {
+		preview := Preview(*story.StoryContent, PreviewWords)
+		story.StoryContent = &preview
+	}
+	if story.StoryBody != nil {
+		story.StoryBody = PreviewBody(story.StoryBody, PreviewWords)
+	}
+	story.Preview = true
+
+	return story
+}
+
+// GateIssue hides issue downloads and previews its stories if request is not entitled
+func (e EntitlementService) GateIssue(c *gin.Context, issue *magazine.MagazineIssue) *magazine.MagazineIssue {
+	if issue == nil || e.IsEntitled(c) {
+		return issue
+	}
+
+	issue.PdfURL = nil
+	issue.EpubURL = nil
+	issue.Preview = true
+	for _, story := range issue.Stories {
+		e.GateStory(c, story)
+	}
+
+	return issue
+}
+
+// GateStories gates every story of the list like GateStory
+func (e EntitlementService) GateStories(c *gin.Context, stories []*magazine.Story) []*magazine.Story {
+	for _, story := range stories {
+		e.GateStory(c, story)
+	}
+
+	return stories
+}
+
+// GateIssues gates every issue of the list like GateIssue
+func (e EntitlementService) GateIssues(c *gin.Context, issues []*magazine.MagazineIssue) []*magazine.MagazineIssue {
+	for _, issue := range issues {
+		e.GateIssue(c, issue)
+	}
+
+	return issues
+}
+
+// Preview returns first words of the content
+func Preview(content string, words int) string {
+	fields := strings.Fields(content)
+	if len(fields) <= words {
+		return content
+	}
+
+	return strings.Join(fields[:words], " ") + "…"
+}
+
+// Summary returns first words of content of the story, empty for stories without content
+func Summary(story *magazine.Story) string {
+	if story.StoryContent == nil {
+		return ""
+	}
+
+	return Preview(*story.StoryContent, SummaryWords)
+}
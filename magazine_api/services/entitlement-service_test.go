@@ -0,0 +1,39 @@
+package services
+
+import (
+	"magazine_api/constants"
+	"magazine_api/lib"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestIsEntitledOnlyForStaffRoles(t *testing.T) {
+	tests := []struct {
+		roles string
+		want  bool
+	}{
+		{"admin", true},
+		{"magazine_manager", true},
+		{"user, magazine_manager", true},
+		{"user", false},
+		{"contributor", false},
+		{"advertiser,accountant", false},
+		{"superuser", false},
+		{"", false},
+	}
+
+	entitlement := NewEntitlementService(lib.GetLogger(), SubscriptionService{})
+	for _, tt := range tests {
+		t.Run(tt.roles, func(t *testing.T) {
+			// requests without user are not looked up for subscriptions
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Set(constants.Claims, map[string]interface{}{"custom:role": tt.roles})
+
+			if got := entitlement.IsEntitled(c); got != tt.want {
+				t.Errorf("IsEntitled with roles %q = %v, want %v", tt.roles, got, tt.want)
+			}
+		})
+	}
+}
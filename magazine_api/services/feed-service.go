@@ -0,0 +1,209 @@
+package services
+
+import (
+	"magazine_api/lib"
+	"magazine_api/models"
+	"magazine_api/models/magazine"
+	"strings"
+	"time"
+)
+
+// FeedService builds feeds of published stories and issues
+type FeedService struct {
+	logger  lib.Logger
+	env     lib.Env
+	stories StoryService
+	issues  MagazineIssueService
+	gallery GalleryService
+}
+
+// NewFeedService creates new instance of FeedService
+func NewFeedService(
+	logger lib.Logger,
+	env lib.Env,
+	stories StoryService,
+	issues MagazineIssueService,
+	gallery GalleryService,
+) FeedService {
+	return FeedService{
+		logger:  logger,
+		env:     env,
+		stories: stories,
+		issues:  issues,
+		gallery: gallery,
+	}
+}
+
+// StoryFeed feed of newest published stories, of the story type when it is given. Links point
+// to stories on the site at siteURL and feedURL is where the feed itself is served
+func (f FeedService) StoryFeed(siteURL, feedURL, storyType string) (*models.Feed, error) {
+	stories, _, err := f.stories.ListPublishedStories(storyType, models.Pagination{Limit: f.limit()}, models.ListQuery{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.gallery.AttachGalleries(stories...); err != nil {
+		return nil, err
+	}
+
+	feed := &models.Feed{
+		Title:       f.env.FeedTitle,
+		Description: "Stories published by " + f.env.FeedTitle,
+		Link:        siteURL,
+		FeedURL:     feedURL,
+		Items:       []models.FeedItem{},
+	}
+	if storyType != "" {
+		feed.Title += " - " + storyType
+		feed.Description = storyType + " stories published by " + f.env.FeedTitle
+	}
+
+	for _, story := range stories {
+		item := models.FeedItem{
+			ID:        story.ID.String(),
+			Title:     deref(story.StoryTitle),
+			Link:      siteURL + "/stories/" + deref(story.Slug),
+			Summary:   Summary(story),
+			Category:  deref(story.StoryType),
+			Published: firstSet(story.PublishedOn, story.CreatedOn),
+			Updated:   firstSet(story.UpdatedOn, story.PublishedOn, story.CreatedOn),
+		}
+		if lead := story.LeadPhoto(); lead != nil {
+			item.Image = lead.URL
+		}
+
+		feed.Items = append(feed.Items, item)
+		if item.Updated.After(feed.Updated) {
+			feed.Updated = item.Updated
+		}
+	}
+
+	return feed, nil
+}
+
+// IssueFeed feed of newest published issues summarised by titles of their published stories,
+// lead image of the first story with one is the image of the issue
+func (f FeedService) IssueFeed(siteURL, feedURL string) (*models.Feed, error) {
+	issues, _, err := f.issues.ListPublishedIssues(models.Pagination{Limit: f.limit()}, models.ListQuery{})
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &models.Feed{
+		Title:       f.env.FeedTitle + " - Issues",
+		Description: "Issues published by " + f.env.FeedTitle,
+		Link:        siteURL,
+		FeedURL:     feedURL,
+		Items:       []models.FeedItem{},
+	}
+
+	for _, issue := range issues {
+		item := models.FeedItem{
+			ID:        issue.ID.String(),
+			Title:     deref(issue.IssueCode),
+			Link:      siteURL + "/issues/" + deref(issue.Slug),
+			Published: firstSet(issue.PublishedOn, issue.CreatedOn),
+			Updated:   firstSet(issue.UpdatedOn, issue.PublishedOn, issue.CreatedOn),
+		}
+
+		if issue.ContentCode != nil {
+			stories, err := f.stories.ListPublishedStoriesByContentCode(*issue.ContentCode)
+			if err != nil {
+				return nil, err
+			}
+			if err := f.gallery.AttachGalleries(stories...); err != nil {
+				return nil, err
+			}
+
+			item.Summary, item.Image = f.issueSummary(stories)
+		}
+
+		feed.Items = append(feed.Items, item)
+		if item.Updated.After(feed.Updated) {
+			feed.Updated = item.Updated
+		}
+	}
+
+	return feed, nil
+}
+
+func (f FeedService) issueSummary(stories []*magazine.Story) (string, *lib.SignedURL) {
+	var titles []string
+	var image *lib.SignedURL
+	for _, story := range stories {
+		if story.StoryTitle != nil {
+			titles = append(titles, *story.StoryTitle)
+		}
+		if lead := story.LeadPhoto(); image == nil && lead != nil {
+			image = lead.URL
+		}
+	}
+
+	return strings.Join(titles, ", "), image
+}
+
+func (f FeedService) limit() uint64 {
+	if f.env.FeedLimit <= 0 {
+		return 50
+	}
+	return uint64(f.env.FeedLimit)
+}
+
+// firstSet returns the first of the times set
+func firstSet(times ...*time.Time) time.Time {
+	for _, t := range times {
+		if t != nil {
+			return *t
+		}
+	}
+	return time.Time{}
+}
+
+func deref(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// Sitemap lists pages of every published story and issue on the site at siteURL, pages with
+// canonical url elsewhere are listed by it
+func (f FeedService) Sitemap(siteURL string) ([]models.SitemapURL, error) {
+	stories, _, err := f.stories.ListPublishedStories("", models.Pagination{}, models.ListQuery{})
+	if err != nil {
+		return nil, err
+	}
+
+	issues, _, err := f.issues.ListPublishedIssues(models.Pagination{}, models.ListQuery{})
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]models.SitemapURL, 0, len(stories)+len(issues))
+	for _, issue := range issues {
+		urls = append(urls, models.SitemapURL{
+			Loc:     CanonicalURL(siteURL, "/issues/", issue.Slug, issue.CanonicalURL),
+			LastMod: firstSet(issue.UpdatedOn, issue.PublishedOn, issue.CreatedOn),
+		})
+	}
+	for _, story := range stories {
+		urls = append(urls, models.SitemapURL{
+			Loc:     CanonicalURL(siteURL, "/stories/", story.Slug, story.CanonicalURL),
+			LastMod: firstSet(story.UpdatedOn, story.PublishedOn, story.CreatedOn),
+		})
+	}
+
+	return urls, nil
+}
+
+// CanonicalURL canonical url set for the page or url of the page of the slug on the site
+func CanonicalURL(siteURL, path string, slug, canonical *string) string {
+	if canonical != nil && *canonical != "" {
+		return *canonical
+	}
+	if slug == nil {
+		return ""
+	}
+
+	return siteURL + path + *slug
+}
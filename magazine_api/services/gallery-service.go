@@ -0,0 +1,216 @@
+package services
+
+import (
+	"magazine_api/api/serializers/requests"
+	"magazine_api/apperrors"
+	"magazine_api/component"
+	"magazine_api/lib"
+	"magazine_api/models/magazine"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrGalleryCredit     = apperrors.NewValidation("photograph is credited to either a user or a text, not both")
+	ErrGalleryCropHint   = apperrors.NewValidation("crop hint must lie within the photograph")
+	ErrGalleryPosition   = apperrors.NewValidation("position starts at 1")
+	ErrGalleryOrder      = apperrors.NewValidation("order must list every photograph of the gallery once")
+	ErrGalleryPhotograph = apperrors.NewValidation("photograph not found")
+	ErrGalleryCreditUser = apperrors.NewValidation("credited user not found")
+)
+
+// GalleryService galleries of photographs of stories
+type GalleryService struct {
+	logger lib.Logger
+	comp   component.GalleryComponent
+}
+
+// NewGalleryService creates new instance of GalleryService
+func NewGalleryService(logger lib.Logger, comp component.GalleryComponent) GalleryService {
+	return GalleryService{logger: logger, comp: comp}
+}
+
+// ListGallery lists photographs in gallery of the story ordered by position
+func (g GalleryService) ListGallery(storyID uuid.UUID) ([]*magazine.StoryPhoto, error) {
+	return g.comp.ListGalleries([]uuid.UUID{storyID})
+}
+
+// AttachGalleries sets galleries of the stories with one query
+func (g GalleryService) AttachGalleries(stories ...*magazine.Story) error {
+	ids := make([]uuid.UUID, 0, len(stories))
+	for _, story := range stories {
+		ids = append(ids, story.ID)
+	}
+
+	photos, err := g.comp.ListGalleries(ids)
+	if err != nil {
+		return err
+	}
+
+	galleries := map[uuid.UUID][]*magazine.StoryPhoto{}
+	for _, photo := range photos {
+		galleries[*photo.StoryId] = append(galleries[*photo.StoryId], photo)
+	}
+
+	for _, story := range stories {
+		story.Gallery = galleries[story.ID]
+		if story.Gallery == nil {
+			story.Gallery = []*magazine.StoryPhoto{}
+		}
+	}
+
+	return nil
+}
+
+// AddPhoto adds photograph to gallery of the story
+func (g GalleryService) AddPhoto(storyID uuid.UUID, request requests.GalleryPhoto) ([]*magazine.StoryPhoto, error) {
+	if request.CreditUserId != nil && request.CreditText != nil {
+		return nil, ErrGalleryCredit
+	}
+	if request.Position != nil && *request.Position < 1 {
+		return nil, ErrGalleryPosition
+	}
+	if err := validateCropHints(request.CropHints); err != nil {
+		return nil, err
+	}
+	if err := g.checkReferences(&request.PhotographId, request.CreditUserId); err != nil {
+		return nil, err
+	}
+
+	create := time.Now()
+	photo := magazine.StoryPhoto{
+		StoryPhotoBase: magazine.StoryPhotoBase{
+			StoryId:      &storyID,
+			PhotographId: &request.PhotographId,
+			Position:     request.Position,
+			Caption:      request.Caption,
+			CreditUserId: request.CreditUserId,
+			CreditText:   request.CreditText,
+			CropHints:    request.CropHints,
+			IsLead:       &request.IsLead,
+		},
+		CreatedOn: &create,
+		UpdatedOn: &create,
+	}
+
+	if err := g.comp.AddPhoto(photo); err != nil {
+		return nil, err
+	}
+
+	return g.ListGallery(storyID)
+}
+
+// PatchPhoto updates caption, credit, crop hints or lead flag of photograph in gallery of the story,
+// crediting a user drops credit text and the other way round
+func (g GalleryService) PatchPhoto(
+	storyID, photographID uuid.UUID,
+	request requests.PatchGalleryPhoto,
+) ([]*magazine.StoryPhoto, error) {
+	if request.CreditUserId != nil && request.CreditText != nil {
+		return nil, ErrGalleryCredit
+	}
+	if err := g.checkReferences(nil, request.CreditUserId); err != nil {
+		return nil, err
+	}
+
+	patch := gin.H{}
+	if request.Caption != nil {
+		patch["caption"] = *request.Caption
+	}
+	if request.CreditUserId != nil {
+		patch["credit_user_id"] = *request.CreditUserId
+		patch["credit_text"] = nil
+	}
+	if request.CreditText != nil {
+		patch["credit_text"] = *request.CreditText
+		patch["credit_user_id"] = nil
+	}
+	if request.CropHints != nil {
+		if err := validateCropHints(*request.CropHints); err != nil {
+			return nil, err
+		}
+		patch["crop_hints"] = *request.CropHints
+	}
+	if request.IsLead != nil {
+		patch["is_lead"] = *request.IsLead
+	}
+
+	if err := g.comp.PatchPhoto(storyID, photographID, patch); err != nil {
+		return nil, err
+	}
+
+	return g.ListGallery(storyID)
+}
+
+// RemovePhoto removes photograph from gallery of the story
+func (g GalleryService) RemovePhoto(storyID, photographID uuid.UUID) ([]*magazine.StoryPhoto, error) {
+	if err := g.comp.RemovePhoto(storyID, photographID); err != nil {
+		return nil, err
+	}
+
+	return g.ListGallery(storyID)
+}
+
+// Reorder orders gallery of the story as listed, every photograph of the gallery is listed once
+func (g GalleryService) Reorder(storyID uuid.UUID, photographIDs []uuid.UUID) ([]*magazine.StoryPhoto, error) {
+	gallery, err := g.ListGallery(storyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(photographIDs) != len(gallery) {
+		return nil, ErrGalleryOrder
+	}
+
+	listed := map[uuid.UUID]bool{}
+	for _, id := range photographIDs {
+		listed[id] = true
+	}
+	for _, photo := range gallery {
+		if !listed[*photo.PhotographId] {
+			return nil, ErrGalleryOrder
+		}
+	}
+
+	if err := g.comp.ReorderGallery(storyID, photographIDs); err != nil {
+		return nil, err
+	}
+
+	return g.ListGallery(storyID)
+}
+
+func (g GalleryService) checkReferences(photographID, creditUserID *uuid.UUID) error {
+	if photographID != nil {
+		exists, err := g.comp.PhotographExists(*photographID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrGalleryPhotograph
+		}
+	}
+
+	if creditUserID != nil {
+		exists, err := g.comp.UserExists(*creditUserID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrGalleryCreditUser
+		}
+	}
+
+	return nil
+}
+
+func validateCropHints(hints []magazine.CropHint) error {
+	for _, hint := range hints {
+		if hint.X < 0 || hint.Y < 0 || hint.Width <= 0 || hint.Height <= 0 ||
+			hint.X+hint.Width > 1 || hint.Y+hint.Height > 1 {
+			return ErrGalleryCropHint
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"magazine_api/component"
 	"magazine_api/lib"
+	"magazine_api/models"
 	"magazine_api/models/magazine"
 	"time"
 
@@ -14,32 +15,55 @@ import (
 type MagazineIssueService struct {
 	logger lib.Logger
 	comp   component.IIssueMgmtComp
+	media  MediaService
+	slugs  SlugService
 }
 
 // NewMagazineIssueService creates new instance of MagazineIssueService
-func NewMagazineIssueService(logger lib.Logger, comp component.IIssueMgmtComp) MagazineIssueService {
-	return MagazineIssueService{logger: logger, comp: comp}
+func NewMagazineIssueService(logger lib.Logger, comp component.IIssueMgmtComp, media MediaService, slugs SlugService) MagazineIssueService {
+	return MagazineIssueService{logger: logger, comp: comp, media: media, slugs: slugs}
 }
 
 // Creates the MagazineIssue in database
 func (u MagazineIssueService) CreateMagazineIssue(issue *magazine.MagazineIssue) (*magazine.MagazineIssue, error) {
+	publishedOn, err := PublishedOn(issue.PublishAt, issue.UnpublishAt)
+	if err != nil {
+		return nil, err
+	}
+	issue.PublishedOn = publishedOn
+
 	issue = u.BeforeCreate(issue)
 
-	err := u.comp.CreateIssue(*issue)
+	slug, err := u.slugs.UniqueSlug(SlugIssues, issue.IssueCode, issue.ID)
 	if err != nil {
 		return nil, err
 	}
+	issue.Slug = &slug
+
+	err = u.comp.CreateIssue(*issue)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := map[string]*lib.SignedURL{
+		"pdf_url":  issue.PdfURL,
+		"epub_url": issue.EpubURL,
+		"og_image": issue.OgImage,
+	}
+	if err := u.media.TrackReferences(models.MediaEntityIssue, issue.ID, refs); err != nil {
+		return nil, err
+	}
 
 	return issue, nil
 }
 
 // Lists stories form database
-func (p MagazineIssueService) ListIssues(c *gin.Context) ([]*magazine.MagazineIssue, error) {
-	stories, err := p.comp.ListIssues()
+func (p MagazineIssueService) ListIssues(c *gin.Context) ([]*magazine.MagazineIssue, models.PageInfo, error) {
+	stories, page, err := p.comp.ListIssues(PaginationFrom(c), ListQueryFrom(c))
 	if err != nil {
-		return nil, err
+		return nil, page, err
 	}
-	return stories, nil
+	return stories, page, nil
 }
 
 // Gets MagazineIssue by id from database
@@ -53,23 +77,13 @@ func (u MagazineIssueService) GetMagazineIssueById(id uuid.UUID) (*magazine.Maga
 }
 
 // Lists MagazineIssues by profile id
-func (u MagazineIssueService) ListIssuesByProfileId(id uuid.UUID) ([]*magazine.MagazineIssue, error) {
-	MagazineIssue, err := u.comp.GetIssueFromCreatorId(id)
-	if err != nil {
-		return nil, err
-	}
-
-	return MagazineIssue, nil
+func (u MagazineIssueService) ListIssuesByProfileId(id uuid.UUID, page models.Pagination, list models.ListQuery) ([]*magazine.MagazineIssue, models.PageInfo, error) {
+	return u.comp.GetIssueFromCreatorId(id, page, list)
 }
 
 // Lists Issues by type
-func (u MagazineIssueService) ListIssuesByType(issue_type string) ([]*magazine.MagazineIssue, error) {
-	MagazineIssue, err := u.comp.GetIssueFromType(issue_type)
-	if err != nil {
-		return nil, err
-	}
-
-	return MagazineIssue, nil
+func (u MagazineIssueService) ListIssuesByType(issue_type string, page models.Pagination, list models.ListQuery) ([]*magazine.MagazineIssue, models.PageInfo, error) {
+	return u.comp.GetIssueFromType(issue_type, page, list)
 }
 
 // Update MagazineIssue by id in our database
@@ -79,7 +93,7 @@ func (u MagazineIssueService) UpdateMagazineIssue(id uuid.UUID, patch *map[strin
 		return err
 	}
 
-	return nil
+	return u.media.TrackPatchedReferences(models.MediaEntityIssue, id, patch, "pdf_url", "epub_url", "og_image")
 }
 
 // Delete MagazineIssue by in our database
@@ -99,7 +113,7 @@ func (u MagazineIssueService) PermanentDeleteMagazineIssue(id uuid.UUID) error {
 		return err
 	}
 
-	return nil
+	return u.media.ClearReferences(models.MediaEntityIssue, id)
 }
 
 func (u MagazineIssueService) BeforeCreate(MagazineIssue *magazine.MagazineIssue) *magazine.MagazineIssue {
@@ -110,3 +124,29 @@ func (u MagazineIssueService) BeforeCreate(MagazineIssue *magazine.MagazineIssue
 
 	return MagazineIssue
 }
+
+// Lists MagazineIssues of the content code
+func (u MagazineIssueService) ListIssuesByContentCode(contentCode string) ([]*magazine.MagazineIssue, error) {
+	return u.comp.ListIssuesFromContentCode(contentCode)
+}
+
+// Lists MagazineIssues whose contents have the story
+func (u MagazineIssueService) ListIssuesByStoryId(storyID uuid.UUID) ([]*magazine.MagazineIssue, error) {
+	return u.comp.ListIssuesFromStoryID(storyID)
+}
+
+// Lists page of published MagazineIssues for readers
+func (u MagazineIssueService) ListPublishedIssues(page models.Pagination, list models.ListQuery) ([]*magazine.MagazineIssue, models.PageInfo, error) {
+	return u.comp.ListPublishedIssues(page, list)
+}
+
+// Gets published MagazineIssue by its slug
+func (u MagazineIssueService) GetPublishedIssueBySlug(slug string) (*magazine.MagazineIssue, error) {
+	return u.comp.GetPublishedIssueFromSlug(slug)
+}
+
+// ChangeSlug sets slug of the issue to the slug asked for or a new one from its issue code,
+// the old slug redirects to the issue
+func (u MagazineIssueService) ChangeSlug(issue *magazine.MagazineIssue, slug string) (string, error) {
+	return u.slugs.ChangeSlug(SlugIssues, issue.ID, slug, issue.IssueCode)
+}
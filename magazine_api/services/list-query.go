@@ -0,0 +1,16 @@
+package services
+
+import (
+	"magazine_api/constants"
+	"magazine_api/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListQueryFrom filters, sort and fields of the list asked for by the request, set by the query middleware
+func ListQueryFrom(c *gin.Context) models.ListQuery {
+	if query, ok := c.Get(constants.ListQuery); ok {
+		return query.(models.ListQuery)
+	}
+	return models.ListQuery{}
+}
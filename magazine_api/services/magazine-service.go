@@ -3,6 +3,7 @@ package services
 import (
 	"magazine_api/component"
 	"magazine_api/lib"
+	"magazine_api/models"
 	"magazine_api/models/magazine"
 	"time"
 
@@ -34,12 +35,12 @@ func (u MagazineService) CreateMagazine(story *magazine.Magazine) (*magazine.Mag
 }
 
 // Lists stories form database
-func (p MagazineService) ListMagazines(c *gin.Context) ([]*magazine.Magazine, error) {
-	stories, err := p.repo.ListMagazines()
+func (p MagazineService) ListMagazines(c *gin.Context) ([]*magazine.Magazine, models.PageInfo, error) {
+	stories, page, err := p.repo.ListMagazines(PaginationFrom(c), ListQueryFrom(c))
 	if err != nil {
-		return nil, err
+		return nil, page, err
 	}
-	return stories, nil
+	return stories, page, nil
 }
 
 // Gets Magazine by id from database
@@ -53,23 +54,13 @@ func (u MagazineService) GetMagazineById(id uuid.UUID) (*magazine.Magazine, erro
 }
 
 // Lists Magazines by profile id
-func (u MagazineService) ListMagazinesByProfileId(id uuid.UUID) ([]*magazine.Magazine, error) {
-	Magazine, err := u.repo.GetMagazineFromCreatorId(id)
-	if err != nil {
-		return nil, err
-	}
-
-	return Magazine, nil
+func (u MagazineService) ListMagazinesByProfileId(id uuid.UUID, page models.Pagination, list models.ListQuery) ([]*magazine.Magazine, models.PageInfo, error) {
+	return u.repo.GetMagazineFromCreatorId(id, page, list)
 }
 
 // Lists Magazines by type
-func (u MagazineService) ListMagazinesByType(magazine_code string) ([]*magazine.Magazine, error) {
-	Magazine, err := u.repo.GetMagazineFromType(magazine_code)
-	if err != nil {
-		return nil, err
-	}
-
-	return Magazine, nil
+func (u MagazineService) ListMagazinesByType(magazine_code string, page models.Pagination, list models.ListQuery) ([]*magazine.Magazine, models.PageInfo, error) {
+	return u.repo.GetMagazineFromType(magazine_code, page, list)
 }
 
 // Update Magazine by id in our database
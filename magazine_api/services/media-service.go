@@ -0,0 +1,382 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"magazine_api/component"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"time"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/google/uuid"
+)
+
+// MediaService service layer of the media library
+type MediaService struct {
+	logger  lib.Logger
+	comp    component.MediaComponent
+	storage StorageService
+}
+
+// NewMediaService creates new instance of MediaService
+func NewMediaService(logger lib.Logger, comp component.MediaComponent, storage StorageService) MediaService {
+	return MediaService{logger: logger, comp: comp, storage: storage}
+}
+
+// MediaUpload file being uploaded to the media library
+type MediaUpload struct {
+	Data       []byte
+	Key        string
+	MimeType   string
+	UploadedBy *uuid.UUID
+
+	// Process queues image for generating its variants
+	Process bool
+}
+
+// Upload stores the file and records it as media, file with the same content as
+// recorded media is not stored again and the recorded media is returned instead
+func (m MediaService) Upload(ctx context.Context, upload MediaUpload) (*models.Media, error) {
+	sum := sha256.Sum256(upload.Data)
+	hash := hex.EncodeToString(sum[:])
+
+	existing, err := m.comp.GetMediaFromHash(hash)
+	if err == nil {
+		return existing, m.ensureProcessed(existing, upload.Process)
+	}
+	if !pgxscan.NotFound(err) {
+		return nil, err
+	}
+
+	if err := m.storage.UploadFile(ctx, bytes.NewReader(upload.Data), upload.Key); err != nil {
+		return nil, err
+	}
+
+	return m.record(ctx, upload.Key, upload.MimeType, int64(len(upload.Data)), hash, upload.UploadedBy, upload.Process)
+}
+
+// MediaObject file uploaded straight to storage by the client
+type MediaObject struct {
+	Key        string
+	MimeType   string
+	UploadedBy *uuid.UUID
+
+	// Process queues image for generating its variants
+	Process bool
+}
+
+// Register records file uploaded straight to storage as media, location is stripped from jpeg
+// images first. File with the same content as recorded media is deleted and the recorded media
+// is returned instead
+func (m MediaService) Register(ctx context.Context, object MediaObject) (*models.Media, error) {
+	hash, size, err := m.hashObject(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := m.comp.GetMediaFromHash(hash)
+	if err == nil {
+		if existing.Key == nil || existing.Key.Key() != object.Key {
+			if err := m.deleteObject(ctx, object.Key); err != nil {
+				m.logger.Error("media-duplicate-delete-error: ", err)
+			}
+		}
+		return existing, m.ensureProcessed(existing, object.Process)
+	}
+	if !pgxscan.NotFound(err) {
+		return nil, err
+	}
+
+	return m.record(ctx, object.Key, object.MimeType, size, hash, object.UploadedBy, object.Process)
+}
+
+// hashObject computes hash and size of the stored file, jpeg images are read whole
+// and stored again when their location is stripped
+func (m MediaService) hashObject(ctx context.Context, object MediaObject) (string, int64, error) {
+	file, err := m.storage.DownloadFile(ctx, object.Key)
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+
+	if object.MimeType != "image/jpeg" {
+		hash := sha256.New()
+		size, err := io.Copy(hash, file)
+		if err != nil {
+			return "", 0, err
+		}
+		return hex.EncodeToString(hash.Sum(nil)), size, nil
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return "", 0, err
+	}
+
+	stripped := append([]byte(nil), data...)
+	lib.StripExifLocation(stripped)
+	if !bytes.Equal(stripped, data) {
+		if err := m.storage.UploadFile(ctx, bytes.NewReader(stripped), object.Key); err != nil {
+			return "", 0, err
+		}
+	}
+
+	sum := sha256.Sum256(stripped)
+	return hex.EncodeToString(sum[:]), int64(len(stripped)), nil
+}
+
+// record records stored file as media, when the same content was recorded by concurrent
+// upload the file is deleted and the recorded media is returned
+func (m MediaService) record(
+	ctx context.Context,
+	key, mimeType string,
+	size int64,
+	hash string,
+	uploadedBy *uuid.UUID,
+	process bool,
+) (*models.Media, error) {
+	create := time.Now()
+	signed := lib.SignedURL(key)
+	status := models.MediaReady
+	if process {
+		status = models.MediaPending
+	}
+
+	media := models.Media{
+		Base:     models.Base{ID: uuid.New()},
+		BaseDate: models.BaseDate{CreatedOn: &create, UpdatedOn: &create},
+		MediaBase: models.MediaBase{
+			Key:        &signed,
+			MimeType:   &mimeType,
+			Size:       &size,
+			Sha256:     &hash,
+			UploadedBy: uploadedBy,
+			Status:     &status,
+		},
+		MediaJob: models.MediaJob{RunAfter: &create},
+	}
+
+	created, err := m.comp.CreateMedia(media)
+	if err != nil {
+		return nil, err
+	}
+	if created {
+		return &media, nil
+	}
+
+	// same content was recorded by concurrent upload
+	if err := m.storage.DeleteFile(ctx, key); err != nil {
+		m.logger.Error("media-duplicate-delete-error: ", err)
+	}
+
+	existing, err := m.comp.GetMediaFromHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return existing, m.ensureProcessed(existing, process)
+}
+
+// ensureProcessed queues media uploaded earlier without variants when variants are wanted now
+func (m MediaService) ensureProcessed(media *models.Media, process bool) error {
+	if !process || len(media.Variants) > 0 || media.Status == nil || *media.Status != models.MediaReady {
+		return nil
+	}
+
+	return m.comp.RequeueMedia(media.ID)
+}
+
+// GetMediaByID gets media by id from database
+func (m MediaService) GetMediaByID(id uuid.UUID) (*models.Media, error) {
+	return m.comp.GetMediaFromID(id)
+}
+
+// GetMediaByKey gets media by key of the uploaded file from database
+func (m MediaService) GetMediaByKey(key string) (*models.Media, error) {
+	return m.comp.GetMediaFromKey(key)
+}
+
+// ListMediaByIDs lists media of the ids from database
+func (m MediaService) ListMediaByIDs(ids []uuid.UUID) ([]*models.Media, error) {
+	return m.comp.ListMediaFromIDs(ids)
+}
+
+// ClaimMedia claims next media to be processed, media processing for longer than
+// staleAfter are considered abandoned and claimed again
+func (m MediaService) ClaimMedia(staleAfter time.Duration) (*models.Media, error) {
+	return m.comp.ClaimMedia(time.Now().Add(-staleAfter))
+}
+
+// CompleteMedia records result of processing the media
+func (m MediaService) CompleteMedia(id uuid.UUID, width, height int, variants []models.MediaVariant) error {
+	return m.comp.CompleteMedia(id, width, height, variants)
+}
+
+// RetryMedia puts media back in the queue after failed attempt
+func (m MediaService) RetryMedia(id uuid.UUID, err error, runAfter time.Time) error {
+	return m.comp.RetryMedia(id, err.Error(), runAfter)
+}
+
+// FailMedia marks media as failed after the last attempt
+func (m MediaService) FailMedia(id uuid.UUID, err error) error {
+	return m.comp.FailMedia(id, err.Error())
+}
+
+// TrackReferences records media used by the row in its fields, keyed by field name
+func (m MediaService) TrackReferences(entity models.MediaEntity, entityID uuid.UUID, fields map[string]*lib.SignedURL) error {
+	keys := map[string]string{}
+	for field, key := range fields {
+		keys[field] = ""
+		if key != nil {
+			keys[field] = key.Key()
+		}
+	}
+
+	return m.comp.SetReferences(entity, entityID, keys)
+}
+
+// TrackPatchedReferences records media used by the row in the fields present in the patch
+func (m MediaService) TrackPatchedReferences(
+	entity models.MediaEntity,
+	entityID uuid.UUID,
+	patch *map[string]interface{},
+	fields ...string,
+) error {
+	keys := map[string]*lib.SignedURL{}
+	for _, field := range fields {
+		value, ok := (*patch)[field]
+		if !ok {
+			continue
+		}
+
+		switch key := value.(type) {
+		case *lib.SignedURL:
+			keys[field] = key
+		case lib.SignedURL:
+			keys[field] = &key
+		case string:
+			signed := lib.SignedURL(key)
+			keys[field] = &signed
+		default:
+			keys[field] = nil
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return m.TrackReferences(entity, entityID, keys)
+}
+
+// ClearReferences forgets media used by the row, it is called when the row is deleted permanently.
+// Only references of fields starting with one of the prefixes are forgotten when they are given
+func (m MediaService) ClearReferences(entity models.MediaEntity, entityID uuid.UUID, fieldPrefixes ...string) error {
+	return m.comp.ClearReferences(entity, entityID, fieldPrefixes...)
+}
+
+// ListReferences lists rows using the media
+func (m MediaService) ListReferences(id uuid.UUID) ([]*models.MediaReference, error) {
+	return m.comp.ListReferences(id)
+}
+
+// MediaGarbage result of collecting garbage of the media library
+type MediaGarbage struct {
+	OrphanMedia      []uuid.UUID
+	UntrackedObjects []string
+	DeletedObjects   int
+}
+
+// CollectGarbage deletes stored objects of media no row references that were uploaded before
+// olderThan. Objects recorded as no media are only deleted when untracked is set, as they
+// may belong to upload in progress. Nothing is deleted in dry run
+func (m MediaService) CollectGarbage(ctx context.Context, olderThan time.Time, untracked, dryRun bool) (*MediaGarbage, error) {
+	garbage := &MediaGarbage{}
+
+	orphans, err := m.comp.ListOrphanMedia(olderThan)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, media := range orphans {
+		garbage.OrphanMedia = append(garbage.OrphanMedia, media.ID)
+		if dryRun {
+			continue
+		}
+
+		for _, key := range mediaKeys(media) {
+			if err := m.deleteObject(ctx, key); err != nil {
+				return garbage, err
+			}
+			garbage.DeletedObjects++
+		}
+
+		if err := m.comp.DeleteMedia(media.ID); err != nil {
+			return garbage, err
+		}
+	}
+
+	if !untracked {
+		return garbage, nil
+	}
+
+	recorded, err := m.comp.ListMedia()
+	if err != nil {
+		return garbage, err
+	}
+
+	known := map[string]bool{}
+	for _, media := range recorded {
+		for _, key := range mediaKeys(media) {
+			known[key] = true
+		}
+	}
+
+	keys, err := m.storage.ListFiles(ctx, "")
+	if err != nil {
+		return garbage, err
+	}
+
+	for _, key := range keys {
+		if known[key] {
+			continue
+		}
+
+		garbage.UntrackedObjects = append(garbage.UntrackedObjects, key)
+		if dryRun {
+			continue
+		}
+
+		if err := m.deleteObject(ctx, key); err != nil {
+			return garbage, err
+		}
+		garbage.DeletedObjects++
+	}
+
+	return garbage, nil
+}
+
+func (m MediaService) deleteObject(ctx context.Context, key string) error {
+	err := m.storage.DeleteFile(ctx, key)
+	if errors.Is(err, lib.ErrObjectNotFound) {
+		return nil
+	}
+	return err
+}
+
+// mediaKeys keys of stored objects of the media and its variants
+func mediaKeys(media *models.Media) []string {
+	var keys []string
+	if media.Key != nil {
+		keys = append(keys, media.Key.Key())
+	}
+	for _, variant := range media.Variants {
+		keys = append(keys, variant.Key.Key())
+	}
+	return keys
+}
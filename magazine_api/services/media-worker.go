@@ -0,0 +1,252 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chai2010/webp"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/nfnt/resize"
+)
+
+// mediaStaleAfter how long media stays claimed by a worker before another worker may claim it again
+const mediaStaleAfter = 10 * time.Minute
+
+// MediaWorker background workers generating variants of uploaded images from the media queue
+type MediaWorker struct {
+	logger   lib.Logger
+	env      lib.Env
+	media    MediaService
+	storage  StorageService
+	variants []lib.ImageVariant
+	state    *mediaWorkerState
+}
+
+type mediaWorkerState struct {
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewMediaWorker creates new instance of MediaWorker
+func NewMediaWorker(
+	logger lib.Logger,
+	env lib.Env,
+	media MediaService,
+	storage StorageService,
+) (MediaWorker, error) {
+	variants, err := lib.ParseImageVariants(env.ImageVariants)
+	if err != nil {
+		return MediaWorker{}, err
+	}
+
+	return MediaWorker{
+		logger:   logger,
+		env:      env,
+		media:    media,
+		storage:  storage,
+		variants: variants,
+		state:    &mediaWorkerState{},
+	}, nil
+}
+
+// Start starts the workers polling the media queue
+func (w MediaWorker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.state.cancel = cancel
+
+	concurrency := w.env.MediaWorkerConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	w.logger.Infof("starting %d media workers", concurrency)
+	for i := 0; i < concurrency; i++ {
+		w.state.wg.Add(1)
+		go w.run(ctx)
+	}
+}
+
+// Stop stops the workers and waits for media being processed to finish or ctx to be done
+func (w MediaWorker) Stop(ctx context.Context) error {
+	if w.state.cancel == nil {
+		return nil
+	}
+	w.state.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		w.state.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w MediaWorker) run(ctx context.Context) {
+	defer w.state.wg.Done()
+
+	interval := w.env.MediaWorkerPollInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil && w.next(ctx) {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// next processes one media from the queue, false is returned when the queue is empty
+func (w MediaWorker) next(ctx context.Context) bool {
+	media, err := w.media.ClaimMedia(mediaStaleAfter)
+	if pgxscan.NotFound(err) {
+		return false
+	}
+	if err != nil {
+		w.logger.Error("media-claim-error: ", err)
+		return false
+	}
+
+	width, height, variants, err := w.Process(ctx, media)
+	if err == nil {
+		err = w.media.CompleteMedia(media.ID, width, height, variants)
+	}
+	if err == nil {
+		return true
+	}
+
+	w.logger.Error("media-process-error: ", media.ID, " ", err)
+	if media.Attempts >= w.env.MediaWorkerMaxAttempts {
+		err = w.media.FailMedia(media.ID, err)
+	} else {
+		backoff := time.Duration(1<<uint(media.Attempts)) * 30 * time.Second
+		err = w.media.RetryMedia(media.ID, err, time.Now().Add(backoff))
+	}
+	if err != nil {
+		w.logger.Error("media-requeue-error: ", media.ID, " ", err)
+	}
+
+	return true
+}
+
+// Process generates named variants of the image in jpeg and webp and full size webp,
+// the image is rotated upright by its EXIF orientation and variants carry no metadata
+func (w MediaWorker) Process(ctx context.Context, media *models.Media) (int, int, []models.MediaVariant, error) {
+	if media.Key == nil {
+		return 0, 0, nil, fmt.Errorf("media %s has no key", media.ID)
+	}
+	key := media.Key.Key()
+
+	file, err := w.storage.DownloadFile(ctx, key)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	data, err := io.ReadAll(file)
+	file.Close()
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	if format == "jpeg" {
+		img = lib.Orient(img, lib.ExifOrientation(data))
+	}
+
+	bounds := img.Bounds()
+	base := strings.TrimSuffix(key, path.Ext(key))
+	variants := []models.MediaVariant{}
+
+	for _, variant := range w.variants {
+		resized := img
+		if uint(bounds.Dx()) > variant.Width {
+			resized = resize.Resize(variant.Width, 0, img, resize.Lanczos3)
+		}
+
+		jpegVariant, err := w.upload(ctx, resized, variant.Name, "jpeg", base+"_"+variant.Name+".jpg")
+		if err != nil {
+			return 0, 0, nil, err
+		}
+
+		webpVariant, err := w.upload(ctx, resized, variant.Name, "webp", base+"_"+variant.Name+".webp")
+		if err != nil {
+			return 0, 0, nil, err
+		}
+
+		variants = append(variants, *jpegVariant, *webpVariant)
+	}
+
+	webpVariant, err := w.upload(ctx, img, lib.VariantWebp, "webp", base+".webp")
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	variants = append(variants, *webpVariant)
+
+	return bounds.Dx(), bounds.Dy(), variants, nil
+}
+
+func (w MediaWorker) upload(ctx context.Context, img image.Image, name, format, key string) (*models.MediaVariant, error) {
+	var buf bytes.Buffer
+	switch format {
+	case "jpeg":
+		if err := jpeg.Encode(&buf, flatten(img), &jpeg.Options{Quality: w.env.ImageJPEGQuality}); err != nil {
+			return nil, err
+		}
+	case "webp":
+		if err := webp.Encode(&buf, img, &webp.Options{Quality: w.env.ImageWebPQuality}); err != nil {
+			return nil, err
+		}
+	}
+
+	size := int64(buf.Len())
+	if err := w.storage.UploadFile(ctx, &buf, key); err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	return &models.MediaVariant{
+		Name:   name,
+		Format: format,
+		Width:  bounds.Dx(),
+		Height: bounds.Dy(),
+		Size:   size,
+		Key:    lib.SignedURL(key),
+	}, nil
+}
+
+// flatten draws image on white background as jpeg has no transparency
+func flatten(img image.Image) image.Image {
+	bounds := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Over)
+	return dst
+}
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"magazine_api/constants"
+	"magazine_api/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultPageLimit rows of pages of requests not read by the pagination middleware
+const defaultPageLimit = 20
+
+// PaginationFrom page of the list asked for by the request, set by the pagination middleware
+func PaginationFrom(c *gin.Context) models.Pagination {
+	if page, ok := c.Get(constants.Pagination); ok {
+		return page.(models.Pagination)
+	}
+	return models.Pagination{Limit: defaultPageLimit}
+}
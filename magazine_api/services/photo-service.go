@@ -3,7 +3,10 @@ package services
 import (
 	"magazine_api/component"
 	"magazine_api/lib"
+	"magazine_api/models"
 	"magazine_api/models/magazine"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -14,11 +17,12 @@ import (
 type PhotoService struct {
 	logger lib.Logger
 	repo   component.IPhotographMgmtComp
+	media  MediaService
 }
 
 // NewPhotoService creates new instance of PhotoService
-func NewPhotoService(logger lib.Logger, repo component.IPhotographMgmtComp) PhotoService {
-	return PhotoService{logger: logger, repo: repo}
+func NewPhotoService(logger lib.Logger, repo component.IPhotographMgmtComp, media MediaService) PhotoService {
+	return PhotoService{logger: logger, repo: repo, media: media}
 }
 
 // Creates the Photo in database
@@ -30,16 +34,23 @@ func (u PhotoService) CreatePhoto(story *magazine.Photograph) (*magazine.Photogr
 		return nil, err
 	}
 
+	refs := map[string]*lib.SignedURL{
+		"url": story.DocumentURL,
+	}
+	if err := u.media.TrackReferences(models.MediaEntityPhotograph, story.ID, refs); err != nil {
+		return nil, err
+	}
+
 	return story, nil
 }
 
 // Lists photos form database
-func (p PhotoService) ListPhotos(c *gin.Context) ([]*magazine.Photograph, error) {
-	photos, err := p.repo.ListPhotos()
+func (p PhotoService) ListPhotos(c *gin.Context) ([]*magazine.Photograph, models.PageInfo, error) {
+	photos, page, err := p.repo.ListPhotos(PaginationFrom(c), ListQueryFrom(c))
 	if err != nil {
-		return nil, err
+		return nil, page, err
 	}
-	return photos, nil
+	return photos, page, nil
 }
 
 // Gets Photo by id from database
@@ -53,23 +64,13 @@ func (u PhotoService) GetPhotoById(id uuid.UUID) (*magazine.Photograph, error) {
 }
 
 // Lists Photos by profile id
-func (u PhotoService) ListPhotosByProfileId(id uuid.UUID) ([]*magazine.Photograph, error) {
-	Photo, err := u.repo.GetPhotoFromCreatorId(id)
-	if err != nil {
-		return nil, err
-	}
-
-	return Photo, nil
+func (u PhotoService) ListPhotosByProfileId(id uuid.UUID, page models.Pagination, list models.ListQuery) ([]*magazine.Photograph, models.PageInfo, error) {
+	return u.repo.GetPhotoFromCreatorId(id, page, list)
 }
 
 // Lists Photos by type
-func (u PhotoService) ListPhotosByType(story_type string) ([]*magazine.Photograph, error) {
-	Photo, err := u.repo.GetPhotoFromType(story_type)
-	if err != nil {
-		return nil, err
-	}
-
-	return Photo, nil
+func (u PhotoService) ListPhotosByType(story_type string, page models.Pagination, list models.ListQuery) ([]*magazine.Photograph, models.PageInfo, error) {
+	return u.repo.GetPhotoFromType(story_type, page, list)
 }
 
 // Update Photo by id in our database
@@ -79,7 +80,7 @@ func (u PhotoService) UpdatePhoto(id uuid.UUID, patch *map[string]interface{}) e
 		return err
 	}
 
-	return nil
+	return u.media.TrackPatchedReferences(models.MediaEntityPhotograph, id, patch, "url")
 }
 
 // Delete Photo by in our database
@@ -99,7 +100,7 @@ func (u PhotoService) PermanentDeletePhoto(id uuid.UUID) error {
 		return err
 	}
 
-	return nil
+	return u.media.ClearReferences(models.MediaEntityPhotograph, id)
 }
 
 func (u PhotoService) BeforeCreate(Photo *magazine.Photograph) *magazine.Photograph {
@@ -110,3 +111,107 @@ func (u PhotoService) BeforeCreate(Photo *magazine.Photograph) *magazine.Photogr
 
 	return Photo
 }
+
+// Lists Photos placed by contents of the content code, including galleries of their stories
+func (u PhotoService) ListPhotosByContentCode(contentCode string) ([]*magazine.Photograph, error) {
+	return u.repo.ListPhotosFromContentCode(contentCode)
+}
+
+// Lists Photos of the photo codes
+func (u PhotoService) ListPhotosByCodes(codes []string) ([]*magazine.Photograph, error) {
+	return u.repo.ListPhotosFromCodes(codes)
+}
+
+// Lists Photos of the ids
+func (u PhotoService) ListPhotosByIDs(ids []uuid.UUID) ([]*magazine.Photograph, error) {
+	return u.repo.ListPhotosFromIDs(ids)
+}
+
+// ListExpiringLicences lists Photos whose licence expires within the duration, soonest first.
+// Photos with licence expired already are listed too when asked
+func (u PhotoService) ListExpiringLicences(within time.Duration, expired bool) ([]*magazine.Photograph, error) {
+	now := time.Now()
+	from := &now
+	if expired {
+		from = nil
+	}
+
+	return u.repo.ListPhotosLicenceExpiring(from, now.Add(within))
+}
+
+// LicenceViolation photograph whose licence does not allow its placement in the issue
+type LicenceViolation struct {
+	PhotographId uuid.UUID `json:"photo_id"`
+	PhotoTitle   *string   `json:"photo_title"`
+	IssueCode    *string   `json:"issue_code"`
+	Reason       string    `json:"reason"`
+}
+
+// LicenceError is returned when photographs are blocked from placement in an issue
+type LicenceError struct {
+	Violations []LicenceViolation
+}
+
+func (e *LicenceError) Error() string {
+	if len(e.Violations) == 1 {
+		return "photograph " + e.Violations[0].PhotographId.String() + " can not be placed: " + e.Violations[0].Reason
+	}
+	return strconv.Itoa(len(e.Violations)) + " photographs can not be placed for their licences"
+}
+
+// CheckLicences checks licences of the photos allow their placement in the issue at the time.
+// Expired licences, licences not covering editions of the issue and licences limited to
+// territories other than the issue's are returned in LicenceError
+func (u PhotoService) CheckLicences(photos []*magazine.Photograph, issue *magazine.MagazineIssue, at time.Time) error {
+	scope := models.UsagePrintDigital
+	if issue.UsageScope != nil {
+		scope = *issue.UsageScope
+	}
+
+	var violations []LicenceViolation
+	for _, photo := range photos {
+		reason := licenceViolation(photo, scope, issue.Territory, at)
+		if reason == "" {
+			continue
+		}
+		violations = append(violations, LicenceViolation{
+			PhotographId: photo.ID,
+			PhotoTitle:   photo.PhotographTitle,
+			IssueCode:    issue.IssueCode,
+			Reason:       reason,
+		})
+	}
+
+	if len(violations) > 0 {
+		return &LicenceError{Violations: violations}
+	}
+	return nil
+}
+
+func licenceViolation(photo *magazine.Photograph, scope models.UsageScope, territory *string, at time.Time) string {
+	if photo.LicenceExpiresOn != nil && !photo.LicenceExpiresOn.After(at) {
+		return "licence expired on " + photo.LicenceExpiresOn.Format("2006-01-02")
+	}
+
+	if photo.UsageScope != nil && !photo.UsageScope.Covers(scope) {
+		return "licence covers " + photo.UsageScope.String() + " usage only, issue is published in " + scope.String()
+	}
+
+	if len(photo.Territories) == 0 {
+		return ""
+	}
+	if territory == nil {
+		return "licence is limited to " + strings.Join(photo.Territories, ", ") + ", issue has no territory"
+	}
+	for _, t := range photo.Territories {
+		if strings.EqualFold(t, *territory) {
+			return ""
+		}
+	}
+	return "licence does not cover territory " + *territory
+}
+
+// Gets Photograph by id when published stories or issues place it
+func (u PhotoService) GetPublishedPhotoById(id uuid.UUID) (*magazine.Photograph, error) {
+	return u.repo.GetPublishedPhotoFromID(id)
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"magazine_api/api/serializers/responses"
+	"magazine_api/apperrors"
+	"magazine_api/component"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrAgreementWindow is returned when effective_to is not after effective_from
+var ErrAgreementWindow = apperrors.NewValidation("effective_to must be after effective_from")
+
+// RoyaltyService service layer
+type RoyaltyService struct {
+	logger lib.Logger
+	comp   component.RoyaltyComponent
+}
+
+// NewRoyaltyService creates new instance of RoyaltyService
+func NewRoyaltyService(logger lib.Logger, comp component.RoyaltyComponent) RoyaltyService {
+	return RoyaltyService{logger: logger, comp: comp}
+}
+
+// CreateAgreement creates the royalty agreement in database, effective from now when not given
+func (r RoyaltyService) CreateAgreement(agreement *models.RoyaltyAgreement) (*models.RoyaltyAgreement, error) {
+	agreement.ID = uuid.New()
+	create := time.Now()
+	agreement.CreatedOn = &create
+	agreement.UpdatedOn = &create
+	if agreement.EffectiveFrom == nil {
+		agreement.EffectiveFrom = &create
+	}
+	if err := agreementWindow(agreement.EffectiveFrom, agreement.EffectiveTo); err != nil {
+		return nil, err
+	}
+
+	if err := r.comp.CreateAgreement(*agreement); err != nil {
+		return nil, err
+	}
+
+	return agreement, nil
+}
+
+// ListAgreementsByContributorID lists page of royalty agreements of contributor
+func (r RoyaltyService) ListAgreementsByContributorID(contributorID uuid.UUID, page models.Pagination, list models.ListQuery) ([]*models.RoyaltyAgreement, models.PageInfo, error) {
+	return r.comp.ListAgreementsFromContributorID(contributorID, page, list)
+}
+
+// GetAgreementByID gets royalty agreement by id from database
+func (r RoyaltyService) GetAgreementByID(id uuid.UUID) (*models.RoyaltyAgreement, error) {
+	return r.comp.GetAgreementFromID(id)
+}
+
+// GetActiveAgreement gets royalty agreement of contributor in effect now
+func (r RoyaltyService) GetActiveAgreement(contributorID uuid.UUID) (*models.RoyaltyAgreement, error) {
+	return r.comp.GetActiveAgreement(contributorID, time.Now())
+}
+
+// UpdateAgreement updates royalty agreement by id in database
+func (r RoyaltyService) UpdateAgreement(id uuid.UUID, patch *map[string]interface{}) error {
+	return r.comp.PatchAgreement(id, patch)
+}
+
+// AgreementPatch checks effective dates in the patch, dates left out of it are the current ones
+// of the agreement
+func AgreementPatch(patch *map[string]interface{}, effectiveFrom, effectiveTo *time.Time) error {
+	if value, ok := (*patch)["effective_from"]; ok {
+		effectiveFrom, _ = value.(*time.Time)
+	}
+	if value, ok := (*patch)["effective_to"]; ok {
+		effectiveTo, _ = value.(*time.Time)
+	}
+
+	return agreementWindow(effectiveFrom, effectiveTo)
+}
+
+func agreementWindow(effectiveFrom, effectiveTo *time.Time) error {
+	if effectiveFrom != nil && effectiveTo != nil && !effectiveTo.After(*effectiveFrom) {
+		return ErrAgreementWindow
+	}
+	return nil
+}
+
+// DeleteAgreement soft deletes royalty agreement by id in database
+func (r RoyaltyService) DeleteAgreement(id uuid.UUID) error {
+	return r.comp.DeleteAgreement(id)
+}
+
+// RecordRoyalty records royalty of the source published in the issue under the agreement,
+// nil is returned when royalty for the source in the issue is already recorded
+func (r RoyaltyService) RecordRoyalty(
+	agreement *models.RoyaltyAgreement,
+	issueID uuid.UUID,
+	source models.RoyaltySource,
+	sourceID uuid.UUID,
+	words *int,
+) (*models.Royalty, error) {
+	amount := agreement.PhotoRoyalty()
+	if source == models.SourceStory {
+		count := 0
+		if words != nil {
+			count = *words
+		}
+		amount = agreement.StoryRoyalty(count)
+	}
+
+	create := time.Now()
+	royalty := &models.Royalty{
+		Base: models.Base{ID: uuid.New()},
+		BaseDate: models.BaseDate{
+			CreatedOn: &create,
+			UpdatedOn: &create,
+		},
+		RoyaltyBase: models.RoyaltyBase{
+			ContributorId: agreement.ContributorId,
+			AgreementId:   &agreement.ID,
+			IssueId:       &issueID,
+			SourceType:    &source,
+			SourceId:      &sourceID,
+			WordCount:     words,
+			Amount:        &amount,
+		},
+	}
+
+	inserted, err := r.comp.CreateRoyalty(*royalty)
+	if err != nil || !inserted {
+		return nil, err
+	}
+
+	return royalty, nil
+}
+
+// ListRoyaltiesByContributorID lists page of royalties of contributor
+func (r RoyaltyService) ListRoyaltiesByContributorID(contributorID uuid.UUID, page models.Pagination, list models.ListQuery) ([]*models.Royalty, models.PageInfo, error) {
+	return r.comp.ListRoyaltiesFromContributorID(contributorID, page, list)
+}
+
+// ListRoyaltiesByIssueID lists page of royalties of issue
+func (r RoyaltyService) ListRoyaltiesByIssueID(issueID uuid.UUID, page models.Pagination, list models.ListQuery) ([]*models.Royalty, models.PageInfo, error) {
+	return r.comp.ListRoyaltiesFromIssueID(issueID, page, list)
+}
+
+// ListUnpaidRoyalties lists royalties not yet paid out of the contributors, all when none given
+func (r RoyaltyService) ListUnpaidRoyalties(contributorIDs []uuid.UUID) ([]*models.Royalty, error) {
+	return r.comp.ListUnpaidRoyalties(contributorIDs)
+}
+
+// CreatePayout creates the royalty payout in database with the transactions of its payments paying
+// out their royalties
+func (r RoyaltyService) CreatePayout(payout *models.RoyaltyPayout, payments []models.RoyaltyPayment) (*models.RoyaltyPayout, error) {
+	payout.ID = uuid.New()
+	create := time.Now()
+	payout.CreatedOn = &create
+	payout.UpdatedOn = &create
+
+	if err := r.comp.CreatePayout(*payout, payments); err != nil {
+		return nil, err
+	}
+
+	return payout, nil
+}
+
+// ListPayouts lists page of royalty payouts from database
+func (r RoyaltyService) ListPayouts(page models.Pagination, list models.ListQuery) ([]*models.RoyaltyPayout, models.PageInfo, error) {
+	return r.comp.ListPayouts(page, list)
+}
+
+// ListBalances lists outstanding and paid out royalties per contributor
+func (r RoyaltyService) ListBalances() ([]*responses.ContributorBalance, error) {
+	return r.comp.ListBalances()
+}
+
+// WordCount counts words of the content
+func WordCount(content string) int {
+	return len(strings.Fields(content))
+}
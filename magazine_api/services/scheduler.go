@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"magazine_api/apperrors"
+	"magazine_api/component"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"time"
+)
+
+// schedulerLockKey key of the postgres advisory lock held by the instance running the schedule
+const schedulerLockKey int64 = 0x6d61677363686564
+
+// ErrScheduleWindow is returned when unpublish_at is not after publish_at
+var ErrScheduleWindow = apperrors.NewValidation("unpublish_at must be after publish_at")
+
+// Scheduler publishes and unpublishes stories, issues and adverts at their publish_at and
+// unpublish_at, only one instance of a multi-instance deployment acts at a time
+type Scheduler struct {
+	logger lib.Logger
+	env    lib.Env
+	comp   component.ScheduleComponent
+	state  *schedulerState
+}
+
+type schedulerState struct {
+	cancel    context.CancelFunc
+	done      chan struct{}
+	published []func(action *models.ScheduledAction)
+}
+
+// NewScheduler creates new instance of Scheduler
+func NewScheduler(logger lib.Logger, env lib.Env, comp component.ScheduleComponent) Scheduler {
+	return Scheduler{
+		logger: logger,
+		env:    env,
+		comp:   comp,
+		state:  &schedulerState{},
+	}
+}
+
+// OnPublish calls fn with every row the schedule publishes, after publishing is committed
+func (s Scheduler) OnPublish(fn func(action *models.ScheduledAction)) {
+	s.state.published = append(s.state.published, fn)
+}
+
+// Start starts running the schedule every interval
+func (s Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.state.cancel = cancel
+	s.state.done = make(chan struct{})
+
+	interval := s.env.SchedulerInterval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	s.logger.Infof("starting scheduler every %s", interval)
+	go s.run(ctx, interval)
+}
+
+// Stop stops the scheduler and waits for the running pass to finish or ctx to be done
+func (s Scheduler) Stop(ctx context.Context) error {
+	if s.state.cancel == nil {
+		return nil
+	}
+	s.state.cancel()
+
+	select {
+	case <-s.state.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s Scheduler) run(ctx context.Context, interval time.Duration) {
+	defer close(s.state.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RunDue(false); err != nil {
+			s.logger.Error("scheduler-run-error: ", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue publishes and unpublishes everything due now and returns what was done, nothing is
+// done when another instance is running the schedule. In dry run actions due are only listed
+func (s Scheduler) RunDue(dryRun bool) ([]*models.ScheduledAction, error) {
+	locked, actions, err := s.comp.RunDue(schedulerLockKey, time.Now(), dryRun)
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return actions, nil
+	}
+
+	if !dryRun {
+		for _, action := range actions {
+			s.logger.Info("scheduled-", action.Action.String(), ": ", action.Entity.String(), " ", action.EntityId)
+			if action.Action != models.SchedulePublish {
+				continue
+			}
+			for _, fn := range s.state.published {
+				fn(action)
+			}
+		}
+	}
+
+	return actions, nil
+}
+
+// ListUpcoming lists publishing and unpublishing scheduled within the duration from now
+func (s Scheduler) ListUpcoming(within time.Duration) ([]*models.ScheduledAction, error) {
+	now := time.Now()
+	return s.comp.ListUpcoming(now, now.Add(within))
+}
+
+// PublishedOn time a row with the schedule is published on when it is created, nil when it waits
+// for the scheduler or is already past unpublish_at
+func PublishedOn(publishAt, unpublishAt *time.Time) (*time.Time, error) {
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		return nil, ErrScheduleWindow
+	}
+
+	now := time.Now()
+	if unpublishAt != nil && !unpublishAt.After(now) {
+		return nil, nil
+	}
+	if publishAt == nil {
+		return &now, nil
+	}
+	if publishAt.After(now) {
+		return nil, nil
+	}
+
+	return publishAt, nil
+}
+
+// SchedulePatch checks the schedule in the patch and unpublishes the row when publish_at is moved
+// to the future, the scheduler publishes it then. publishAt and unpublishAt are the current schedule
+func SchedulePatch(patch *map[string]interface{}, publishAt, unpublishAt *time.Time) error {
+	value, publishPatched := (*patch)["publish_at"]
+	if publishPatched {
+		publishAt, _ = value.(*time.Time)
+	}
+	if value, ok := (*patch)["unpublish_at"]; ok {
+		unpublishAt, _ = value.(*time.Time)
+	}
+
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		return ErrScheduleWindow
+	}
+
+	if publishPatched && publishAt != nil && publishAt.After(time.Now()) {
+		(*patch)["published_on"] = nil
+	}
+
+	return nil
+}
+
+// PublishPatch publishes the row waiting for the schedule when the patch moves publish_at to now or
+// before, as rows created with such schedule are, instead of leaving it to the next run of the
+// scheduler. It runs after SchedulePatch, true is returned when the patch publishes the row
+func PublishPatch(patch *map[string]interface{}, publishedOn, unpublishAt *time.Time) bool {
+	value, ok := (*patch)["publish_at"]
+	if !ok || publishedOn != nil {
+		return false
+	}
+	publishAt, _ := value.(*time.Time)
+	if publishAt == nil {
+		return false
+	}
+	if value, ok := (*patch)["unpublish_at"]; ok {
+		unpublishAt, _ = value.(*time.Time)
+	}
+
+	published, err := PublishedOn(publishAt, unpublishAt)
+	if err != nil || published == nil {
+		return false
+	}
+
+	(*patch)["published_on"] = published
+	return true
+}
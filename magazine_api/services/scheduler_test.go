@@ -0,0 +1,109 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSchedulePatchOnlyUnpublishAt(t *testing.T) {
+	now := time.Now()
+	before, after := now.Add(-time.Hour), now.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		publishAt *time.Time
+		unpublish *time.Time
+		wantErr   error
+	}{
+		{name: "after stored publish_at", publishAt: &before, unpublish: &now},
+		{name: "without stored publish_at", unpublish: &now},
+		{name: "cleared", publishAt: &before, unpublish: nil},
+		{name: "before stored publish_at", publishAt: &after, unpublish: &now, wantErr: ErrScheduleWindow},
+		{name: "at stored publish_at", publishAt: &now, unpublish: &now, wantErr: ErrScheduleWindow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch := map[string]interface{}{"unpublish_at": tt.unpublish}
+			if err := SchedulePatch(&patch, tt.publishAt, nil); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if _, ok := patch["published_on"]; ok {
+				t.Error("patch of unpublish_at unpublished the row")
+			}
+		})
+	}
+}
+
+func TestSchedulePatchPublishAtInFuture(t *testing.T) {
+	later := time.Now().Add(time.Hour)
+	patch := map[string]interface{}{"publish_at": &later}
+
+	if err := SchedulePatch(&patch, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value, ok := patch["published_on"]; !ok || value != nil {
+		t.Errorf("row moved to the future is not unpublished: %v", patch)
+	}
+}
+
+func TestAgreementPatchOnlyEffectiveTo(t *testing.T) {
+	from := time.Now()
+	before, after := from.Add(-24*time.Hour), from.Add(24*time.Hour)
+
+	tests := []struct {
+		name    string
+		to      *time.Time
+		wantErr error
+	}{
+		{name: "after stored effective_from", to: &after},
+		{name: "cleared", to: nil},
+		{name: "before stored effective_from", to: &before, wantErr: ErrAgreementWindow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch := map[string]interface{}{"effective_to": tt.to}
+			if err := AgreementPatch(&patch, &from, nil); !errors.Is(err, tt.wantErr) {
+				t.Errorf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPublishPatch(t *testing.T) {
+	now := time.Now()
+	before, after := now.Add(-time.Hour), now.Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		patch       map[string]interface{}
+		publishedOn *time.Time
+		unpublishAt *time.Time
+		want        bool
+	}{
+		{name: "publish_at moved before now", patch: map[string]interface{}{"publish_at": &before}, want: true},
+		{name: "publish_at moved after now", patch: map[string]interface{}{"publish_at": &after}},
+		{name: "already published", patch: map[string]interface{}{"publish_at": &before}, publishedOn: &before},
+		{name: "stored unpublish_at passed", patch: map[string]interface{}{"publish_at": &before}, unpublishAt: &now},
+		{name: "patched unpublish_at passed", patch: map[string]interface{}{"publish_at": &before, "unpublish_at": &now}},
+		{name: "publish_at not patched", patch: map[string]interface{}{"remarks": "x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PublishPatch(&tt.patch, tt.publishedOn, tt.unpublishAt); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+
+			value, ok := tt.patch["published_on"]
+			if tt.want && (!ok || value != tt.patch["publish_at"]) {
+				t.Errorf("row not published on its publish_at: %v", tt.patch)
+			}
+			if !tt.want && ok {
+				t.Errorf("row published by the patch: %v", tt.patch)
+			}
+		})
+	}
+}
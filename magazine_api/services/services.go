@@ -7,14 +7,27 @@ import (
 // Module exports services present
 var Module = fx.Options(
 	fx.Provide(NewCognitoAuthService),
-	fx.Provide(NewS3BucketService),
+	fx.Provide(NewStorageService),
+	fx.Provide(NewURLResolver),
 	fx.Provide(NewUserService),
 	fx.Provide(NewUserProfileService),
 	fx.Provide(NewTransactionService),
 	fx.Provide(NewStoryService),
+	fx.Provide(NewGalleryService),
 	fx.Provide(NewAdvertService),
 	fx.Provide(NewContentService),
 	fx.Provide(NewMagazineIssueService),
 	fx.Provide(NewMagazineService),
 	fx.Provide(NewPhotoService),
+	fx.Provide(NewSubscriptionService),
+	fx.Provide(NewEntitlementService),
+	fx.Provide(NewAdBookingService),
+	fx.Provide(NewRoyaltyService),
+	fx.Provide(NewMediaService),
+	fx.Provide(NewMediaWorker),
+	fx.Provide(NewScheduler),
+	fx.Provide(NewFeedService),
+	fx.Provide(NewSlugService),
+	fx.Provide(NewUploadValidator),
+	fx.Provide(NewUploadService),
 )
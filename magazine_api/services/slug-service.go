@@ -0,0 +1,91 @@
+package services
+
+import (
+	"magazine_api/apperrors"
+	"magazine_api/component"
+	"magazine_api/lib"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// SlugStories table of stories identified by slugs
+	SlugStories = "stories"
+	// SlugIssues table of issues identified by slugs
+	SlugIssues = "magazine_issues"
+
+	// maxSlugAttempts numbered slugs tried before the start of the id is appended
+	maxSlugAttempts = 50
+)
+
+// ErrSlugTaken is returned when slug asked for is used by another row or was used before
+var ErrSlugTaken = apperrors.NewConflict("slug is taken")
+
+// SlugService gives rows unique slugs, slugs rows had before stay theirs for redirects
+type SlugService struct {
+	logger lib.Logger
+	comp   component.SlugComponent
+}
+
+// NewSlugService creates new instance of SlugService
+func NewSlugService(logger lib.Logger, comp component.SlugComponent) SlugService {
+	return SlugService{logger: logger, comp: comp}
+}
+
+// UniqueSlug slug of the text not used by other rows of the table, numbered when the slug of
+// the text is taken. Text without letters or digits gives slug from start of the id
+func (s SlugService) UniqueSlug(table string, text *string, id uuid.UUID) (string, error) {
+	base := ""
+	if text != nil {
+		base = lib.Slugify(*text)
+	}
+	if base == "" {
+		return id.String()[:8], nil
+	}
+
+	slug := base
+	for i := 2; i <= maxSlugAttempts; i++ {
+		taken, err := s.comp.SlugTaken(table, slug, id)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return slug, nil
+		}
+		slug = base + "-" + strconv.Itoa(i)
+	}
+
+	return base + "-" + id.String()[:8], nil
+}
+
+// ChangeSlug sets slug of the row to the slug asked for, or a new one from the text when none
+// is asked for. The old slug keeps redirecting to the row
+func (s SlugService) ChangeSlug(table string, id uuid.UUID, requested string, text *string) (string, error) {
+	slug := lib.Slugify(requested)
+	if requested == "" || slug == "" {
+		var err error
+		if slug, err = s.UniqueSlug(table, text, id); err != nil {
+			return "", err
+		}
+	} else {
+		taken, err := s.comp.SlugTaken(table, slug, id)
+		if err != nil {
+			return "", err
+		}
+		if taken {
+			return "", ErrSlugTaken
+		}
+	}
+
+	if err := s.comp.ChangeSlug(table, id, slug); err != nil {
+		return "", err
+	}
+
+	return slug, nil
+}
+
+// FindMovedSlug gets current slug of the row that had the slug before
+func (s SlugService) FindMovedSlug(table, slug string) (string, error) {
+	return s.comp.FindMovedSlug(table, slug)
+}
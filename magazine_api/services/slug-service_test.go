@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"magazine_api/component"
+	"magazine_api/lib"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// takenSlugs database where the slugs are used by other rows, slugs can not be changed in it
+type takenSlugs map[string]bool
+
+func (s takenSlugs) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return nil, errors.New("slugs are not changed")
+}
+
+func (s takenSlugs) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return nil, errors.New("slugs are not listed")
+}
+
+func (s takenSlugs) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return takenRow(s[args[0].(string)])
+}
+
+func (s takenSlugs) Begin(ctx context.Context) (pgx.Tx, error) {
+	return nil, errors.New("slugs are not changed")
+}
+
+type takenRow bool
+
+func (r takenRow) Scan(dest ...interface{}) error {
+	*dest[0].(*bool) = bool(r)
+	return nil
+}
+
+func newSlugService(taken ...string) SlugService {
+	slugs := takenSlugs{}
+	for _, slug := range taken {
+		slugs[slug] = true
+	}
+	return NewSlugService(lib.GetLogger(), component.SlugComponent{Beginner: slugs})
+}
+
+func TestUniqueSlug(t *testing.T) {
+	id := uuid.MustParse("3f1c2a4e-5b6d-4e7f-8a9b-0c1d2e3f4a5b")
+	text := func(s string) *string { return &s }
+
+	every := []string{"spring-issue"}
+	for i := 2; i <= maxSlugAttempts; i++ {
+		every = append(every, fmt.Sprintf("spring-issue-%d", i))
+	}
+
+	tests := []struct {
+		name  string
+		text  *string
+		taken []string
+		want  string
+	}{
+		{"free", text("Spring Issue"), nil, "spring-issue"},
+		{"devanagari", text("वसन्त अंक"), nil, "wasant-ank"},
+		{"taken", text("Spring Issue"), []string{"spring-issue"}, "spring-issue-2"},
+		{"numbered taken", text("Spring Issue"), []string{"spring-issue", "spring-issue-2", "spring-issue-3"}, "spring-issue-4"},
+		{"only numbered taken", text("Spring Issue"), []string{"spring-issue-2"}, "spring-issue"},
+		{"all numbers taken", text("Spring Issue"), every, "spring-issue-3f1c2a4e"},
+		{"without letters or digits", text("!!!"), nil, "3f1c2a4e"},
+		{"without text", nil, nil, "3f1c2a4e"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slug, err := newSlugService(tt.taken...).UniqueSlug(SlugIssues, tt.text, id)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if slug != tt.want {
+				t.Errorf("got slug %q, want %q", slug, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangeSlugRefusesTakenSlug(t *testing.T) {
+	title := "Spring Issue"
+	if _, err := newSlugService("spring-2081").ChangeSlug(SlugIssues, uuid.New(), "Spring 2081", &title); !errors.Is(err, ErrSlugTaken) {
+		t.Errorf("got %v, want ErrSlugTaken", err)
+	}
+}
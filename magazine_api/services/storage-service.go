@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"io"
+	"magazine_api/lib"
+	"mime"
+	"path/filepath"
+	"time"
+)
+
+// StorageService object storage for uploaded files
+type StorageService struct {
+	storage lib.Storage
+	logger  lib.Logger
+	env     lib.Env
+}
+
+func NewStorageService(
+	logger lib.Logger,
+	storage lib.Storage,
+	env lib.Env,
+) StorageService {
+	return StorageService{
+		logger:  logger,
+		storage: storage,
+		env:     env,
+	}
+}
+
+// UploadFile uploads the file
+func (s StorageService) UploadFile(
+	ctx context.Context,
+	file io.Reader,
+	key string,
+) error {
+	return s.storage.Put(ctx, key, file, mime.TypeByExtension(filepath.Ext(key)))
+}
+
+// DownloadFile opens the stored file, caller closes the reader
+func (s StorageService) DownloadFile(ctx context.Context, key string) (io.ReadCloser, error) {
+	return s.storage.Get(ctx, key)
+}
+
+// DeleteFile deletes the stored file
+func (s StorageService) DeleteFile(ctx context.Context, key string) error {
+	return s.storage.Delete(ctx, key)
+}
+
+// ListFiles lists keys of stored files starting with prefix
+func (s StorageService) ListFiles(ctx context.Context, prefix string) ([]string, error) {
+	return s.storage.List(ctx, prefix)
+}
+
+// GetSignedURL get the signed url for file
+func (s StorageService) GetSignedURL(
+	ctx context.Context,
+	key string,
+) string {
+	url, err := s.storage.Presign(ctx, key, time.Minute)
+	if err != nil {
+		s.logger.Error("error-generating-presigned-url", err.Error())
+		return ""
+	}
+
+	return url
+}
+
+// IsServedLocally checks if stored files are downloaded from the API
+func (s StorageService) IsServedLocally() bool {
+	return s.env.StorageBackend == lib.StorageLocal
+}
+
+// VerifySignedKey verifies signature of url served by the API
+func (s StorageService) VerifySignedKey(key string, expires int64, signature string) error {
+	return lib.VerifyObjectKey(s.env.StorageSigningKey, key, expires, signature)
+}
+
+// VerifySignedUpload verifies signature of upload url served by the API
+func (s StorageService) VerifySignedUpload(key string, maxSize, expires int64, signature string) error {
+	return lib.VerifyObjectKey(s.env.StorageSigningKey, lib.SignedPutKey(key, maxSize), expires, signature)
+}
+
+// StatFile gets size and content type of the stored file
+func (s StorageService) StatFile(ctx context.Context, key string) (*lib.ObjectInfo, error) {
+	return s.storage.Stat(ctx, key)
+}
+
+// PresignUpload creates url the client uploads the file of content type and size to with PUT
+func (s StorageService) PresignUpload(
+	ctx context.Context,
+	key, contentType string,
+	size int64,
+	expires time.Duration,
+) (string, error) {
+	return s.storage.PresignPut(ctx, key, contentType, size, expires)
+}
+
+// CreateMultipartUpload starts upload of the file in parts
+func (s StorageService) CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error) {
+	return s.storage.CreateMultipartUpload(ctx, key, contentType)
+}
+
+// PresignUploadPart creates url the client uploads the part to with PUT
+func (s StorageService) PresignUploadPart(
+	ctx context.Context,
+	key, uploadID string,
+	partNumber int32,
+	expires time.Duration,
+) (string, error) {
+	return s.storage.PresignUploadPart(ctx, key, uploadID, partNumber, expires)
+}
+
+// ListUploadParts lists parts of the multipart upload uploaded so far
+func (s StorageService) ListUploadParts(ctx context.Context, key, uploadID string) ([]lib.UploadPart, error) {
+	return s.storage.ListUploadParts(ctx, key, uploadID)
+}
+
+// CompleteMultipartUpload assembles uploaded parts into the file
+func (s StorageService) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []lib.UploadPart) error {
+	return s.storage.CompleteMultipartUpload(ctx, key, uploadID, parts)
+}
+
+// AbortMultipartUpload discards the multipart upload
+func (s StorageService) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	return s.storage.AbortMultipartUpload(ctx, key, uploadID)
+}
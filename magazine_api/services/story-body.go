@@ -0,0 +1,321 @@
+package services
+
+import (
+	"fmt"
+	"html"
+	"magazine_api/models/magazine"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxStoryBlocks blocks a story body can have
+	MaxStoryBlocks = 2000
+
+	// MaxBlockTextLength characters text of one block can have
+	MaxBlockTextLength = 20000
+
+	// ReadingWordsPerMinute reading speed reading time of stories is computed with
+	ReadingWordsPerMinute = 200
+)
+
+// StoryBodyError block of story body not matching the schema, block is -1 for the body itself
+type StoryBodyError struct {
+	Block   int
+	Message string
+}
+
+func (e *StoryBodyError) Error() string {
+	if e.Block < 0 {
+		return "story body: " + e.Message
+	}
+	return fmt.Sprintf("story body block %d: %s", e.Block, e.Message)
+}
+
+// ValidateStoryBody checks the body matches its schema, every block has the fields its type
+// requires and none of the fields of other types. Media of image blocks are not looked up
+func ValidateStoryBody(body *magazine.StoryBody) error {
+	if body.Version != 0 && body.Version != magazine.StoryBodyVersion {
+		return &StoryBodyError{Block: -1, Message: "unsupported version " + strconv.Itoa(body.Version)}
+	}
+	if len(body.Blocks) == 0 {
+		return &StoryBodyError{Block: -1, Message: "blocks are required"}
+	}
+	if len(body.Blocks) > MaxStoryBlocks {
+		return &StoryBodyError{Block: -1, Message: "at most " + strconv.Itoa(MaxStoryBlocks) + " blocks are allowed"}
+	}
+
+	for i, block := range body.Blocks {
+		if message := validateBlock(block); message != "" {
+			return &StoryBodyError{Block: i, Message: message}
+		}
+	}
+
+	return nil
+}
+
+func validateBlock(block magazine.StoryBlock) string {
+	fields := []struct {
+		name    string
+		present bool
+	}{
+		{"text", block.Text != nil},
+		{"level", block.Level != nil},
+		{"attribution", block.Attribution != nil},
+		{"pull", block.Pull},
+		{"media_id", block.MediaId != nil},
+		{"caption", block.Caption != nil},
+		{"credit", block.Credit != nil},
+		{"embed_url", block.EmbedURL != nil},
+		{"items", block.Items != nil},
+		{"ordered", block.Ordered},
+	}
+
+	var allowed []string
+	switch block.Type {
+	case magazine.BlockParagraph:
+		allowed = []string{"text"}
+	case magazine.BlockHeading:
+		allowed = []string{"text", "level"}
+		if block.Level != nil && (*block.Level < 2 || *block.Level > 4) {
+			return "heading level must be 2 to 4"
+		}
+	case magazine.BlockQuote:
+		allowed = []string{"text", "attribution", "pull"}
+	case magazine.BlockImage:
+		allowed = []string{"media_id", "caption", "credit"}
+		if block.MediaId == nil {
+			return "image needs media_id"
+		}
+	case magazine.BlockEmbed:
+		allowed = []string{"embed_url", "caption"}
+		if block.EmbedURL == nil {
+			return "embed needs embed_url"
+		}
+		u, err := url.Parse(*block.EmbedURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "embed_url must be an absolute http or https url"
+		}
+	case magazine.BlockList:
+		allowed = []string{"items", "ordered"}
+		if len(block.Items) == 0 {
+			return "list needs items"
+		}
+		for _, item := range block.Items {
+			if strings.TrimSpace(item) == "" {
+				return "list items can not be empty"
+			}
+			if len(item) > MaxBlockTextLength {
+				return "list item is longer than " + strconv.Itoa(MaxBlockTextLength) + " characters"
+			}
+		}
+	default:
+		return "unknown block type"
+	}
+
+	for _, field := range fields {
+		if field.present && !contains(allowed, field.name) {
+			return field.name + " is not allowed in " + block.Type.String() + " block"
+		}
+	}
+
+	switch block.Type {
+	case magazine.BlockParagraph, magazine.BlockHeading, magazine.BlockQuote:
+		if block.Text == nil || strings.TrimSpace(*block.Text) == "" {
+			return strings.ToLower(block.Type.String()) + " needs text"
+		}
+		if len(*block.Text) > MaxBlockTextLength {
+			return "text is longer than " + strconv.Itoa(MaxBlockTextLength) + " characters"
+		}
+	}
+
+	return ""
+}
+
+// StoryBodyHTML renders the body as html, text is escaped and urls of images are expected
+// to be resolved already
+func StoryBodyHTML(body *magazine.StoryBody) string {
+	var b strings.Builder
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case magazine.BlockParagraph:
+			b.WriteString("<p>" + htmlText(block.Text) + "</p>\n")
+		case magazine.BlockHeading:
+			level := "2"
+			if block.Level != nil {
+				level = strconv.Itoa(*block.Level)
+			}
+			b.WriteString("<h" + level + ">" + htmlText(block.Text) + "</h" + level + ">\n")
+		case magazine.BlockQuote:
+			if block.Pull {
+				b.WriteString(`<blockquote class="pull-quote">`)
+			} else {
+				b.WriteString("<blockquote>")
+			}
+			b.WriteString("<p>" + htmlText(block.Text) + "</p>")
+			if block.Attribution != nil {
+				b.WriteString("<cite>" + htmlText(block.Attribution) + "</cite>")
+			}
+			b.WriteString("</blockquote>\n")
+		case magazine.BlockImage:
+			b.WriteString("<figure>")
+			if block.URL != nil {
+				b.WriteString(`<img src="` + html.EscapeString(string(*block.URL)) + `" alt="` + htmlText(block.Caption) + `">`)
+			}
+			if block.Caption != nil || block.Credit != nil {
+				b.WriteString("<figcaption>" + htmlText(block.Caption))
+				if block.Credit != nil {
+					b.WriteString(` <span class="credit">` + htmlText(block.Credit) + "</span>")
+				}
+				b.WriteString("</figcaption>")
+			}
+			b.WriteString("</figure>\n")
+		case magazine.BlockEmbed:
+			link := html.EscapeString(blockText(block.EmbedURL))
+			b.WriteString(`<figure class="embed"><a href="` + link + `">` + link + "</a>")
+			if block.Caption != nil {
+				b.WriteString("<figcaption>" + htmlText(block.Caption) + "</figcaption>")
+			}
+			b.WriteString("</figure>\n")
+		case magazine.BlockList:
+			tag := "ul"
+			if block.Ordered {
+				tag = "ol"
+			}
+			b.WriteString("<" + tag + ">")
+			for _, item := range block.Items {
+				b.WriteString("<li>" + htmlText(&item) + "</li>")
+			}
+			b.WriteString("</" + tag + ">\n")
+		}
+	}
+
+	return b.String()
+}
+
+func htmlText(text *string) string {
+	return strings.ReplaceAll(html.EscapeString(blockText(text)), "\n", "<br>")
+}
+
+func blockText(text *string) string {
+	if text == nil {
+		return ""
+	}
+	return *text
+}
+
+// StoryBodyText renders the body as plain text with blocks separated by blank lines,
+// images are left as their captions and embeds as their urls
+func StoryBodyText(body *magazine.StoryBody) string {
+	var parts []string
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case magazine.BlockParagraph, magazine.BlockHeading:
+			parts = append(parts, blockText(block.Text))
+		case magazine.BlockQuote:
+			quote := "“" + blockText(block.Text) + "”"
+			if block.Attribution != nil {
+				quote += " — " + *block.Attribution
+			}
+			parts = append(parts, quote)
+		case magazine.BlockImage:
+			if block.Caption != nil {
+				parts = append(parts, *block.Caption)
+			}
+		case magazine.BlockEmbed:
+			parts = append(parts, blockText(block.EmbedURL))
+		case magazine.BlockList:
+			items := make([]string, len(block.Items))
+			for i, item := range block.Items {
+				bullet := "-"
+				if block.Ordered {
+					bullet = strconv.Itoa(i+1) + "."
+				}
+				items[i] = bullet + " " + item
+			}
+			parts = append(parts, strings.Join(items, "\n"))
+		}
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// StoryBodyWordCount counts words read in the body, urls of embeds are not counted
+func StoryBodyWordCount(body *magazine.StoryBody) int {
+	count := 0
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case magazine.BlockParagraph, magazine.BlockHeading:
+			count += WordCount(blockText(block.Text))
+		case magazine.BlockQuote:
+			count += WordCount(blockText(block.Text))
+			if block.Attribution != nil {
+				count += WordCount(*block.Attribution)
+			}
+		case magazine.BlockImage:
+			if block.Caption != nil {
+				count += WordCount(*block.Caption)
+			}
+		case magazine.BlockList:
+			for _, item := range block.Items {
+				count += WordCount(item)
+			}
+		}
+	}
+
+	return count
+}
+
+// ReadingTime minutes reading the words takes, rounded up
+func ReadingTime(words int) int {
+	return (words + ReadingWordsPerMinute - 1) / ReadingWordsPerMinute
+}
+
+// PreviewBody returns first blocks of the body up to the words, the block reaching the limit
+// is cut and images and embeds after the first text block are left out
+func PreviewBody(body *magazine.StoryBody, words int) *magazine.StoryBody {
+	preview := &magazine.StoryBody{Version: body.Version}
+	for _, block := range body.Blocks {
+		if words <= 0 {
+			break
+		}
+
+		switch block.Type {
+		case magazine.BlockParagraph, magazine.BlockHeading, magazine.BlockQuote:
+			count := WordCount(blockText(block.Text))
+			if count > words {
+				text := Preview(blockText(block.Text), words)
+				block.Text = &text
+			}
+			words -= count
+		case magazine.BlockList:
+			var items []string
+			for _, item := range block.Items {
+				if words <= 0 {
+					break
+				}
+				items = append(items, Preview(item, words))
+				words -= WordCount(item)
+			}
+			block.Items = items
+		default:
+			if len(preview.Blocks) > 0 {
+				continue
+			}
+		}
+
+		preview.Blocks = append(preview.Blocks, block)
+	}
+
+	return preview
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"magazine_api/lib"
+	"magazine_api/models/magazine"
+	"strings"
+	"testing"
+)
+
+func TestStoryBodyHTMLEscapesMarkup(t *testing.T) {
+	script := `<script>alert("x")</script>`
+	attribute := `" onerror="alert(1)`
+	text := func(s string) *string { return &s }
+	url := func(s string) *lib.SignedURL { u := lib.SignedURL(s); return &u }
+
+	tests := []struct {
+		name  string
+		block magazine.StoryBlock
+	}{
+		{"paragraph", magazine.StoryBlock{Type: magazine.BlockParagraph, Text: text(script)}},
+		{"heading", magazine.StoryBlock{Type: magazine.BlockHeading, Text: text(script)}},
+		{"quote", magazine.StoryBlock{Type: magazine.BlockQuote, Text: text(script), Attribution: text(script)}},
+		{"image caption in alt", magazine.StoryBlock{Type: magazine.BlockImage, URL: url("https://cdn.example.com/a.jpg"), Caption: text(attribute)}},
+		{"image caption and credit", magazine.StoryBlock{Type: magazine.BlockImage, Caption: text(script), Credit: text(script)}},
+		{"image url", magazine.StoryBlock{Type: magazine.BlockImage, URL: url("https://cdn.example.com/a.jpg" + attribute)}},
+		{"embed url", magazine.StoryBlock{Type: magazine.BlockEmbed, EmbedURL: text("https://example.com/" + attribute + script)}},
+		{"embed caption", magazine.StoryBlock{Type: magazine.BlockEmbed, EmbedURL: text("https://example.com/v"), Caption: text(script)}},
+		{"list items", magazine.StoryBlock{Type: magazine.BlockList, Items: []string{script, attribute}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered := StoryBodyHTML(&magazine.StoryBody{Blocks: []magazine.StoryBlock{tt.block}})
+
+			if strings.Contains(rendered, "<script") || strings.Contains(rendered, "</script") {
+				t.Errorf("script tag rendered: %s", rendered)
+			}
+			if strings.Contains(rendered, `" onerror=`) {
+				t.Errorf("attribute rendered: %s", rendered)
+			}
+			if !strings.Contains(rendered, "&lt;script&gt;") && !strings.Contains(rendered, "&#34; onerror=&#34;") {
+				t.Errorf("markup left out instead of escaped: %s", rendered)
+			}
+		})
+	}
+}
+
+func TestStoryBodyHTMLKeepsLineBreaks(t *testing.T) {
+	text := "first line\n<b>second</b> line"
+	rendered := StoryBodyHTML(&magazine.StoryBody{Blocks: []magazine.StoryBlock{{Type: magazine.BlockParagraph, Text: &text}}})
+
+	if want := "<p>first line<br>&lt;b&gt;second&lt;/b&gt; line</p>\n"; rendered != want {
+		t.Errorf("got %q, want %q", rendered, want)
+	}
+}
+
+func TestValidateStoryBodyRefusesScriptURLsOfEmbeds(t *testing.T) {
+	for _, link := range []string{
+		"javascript:alert(1)",
+		"JavaScript:alert(1)",
+		"data:text/html,<script>alert(1)</script>",
+		"vbscript:msgbox(1)",
+		"//example.com/relative",
+		"/videos/1",
+	} {
+		t.Run(link, func(t *testing.T) {
+			link := link
+			body := &magazine.StoryBody{Blocks: []magazine.StoryBlock{{Type: magazine.BlockEmbed, EmbedURL: &link}}}
+			if err := ValidateStoryBody(body); err == nil {
+				t.Error("embed linking to other than http or https accepted")
+			}
+		})
+	}
+}
@@ -3,7 +3,9 @@ package services
 import (
 	"magazine_api/component"
 	"magazine_api/lib"
+	"magazine_api/models"
 	"magazine_api/models/magazine"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -14,32 +16,75 @@ import (
 type StoryService struct {
 	logger lib.Logger
 	repo   component.IStoryMgmtComp
+	media  MediaService
+	slugs  SlugService
 }
 
 // NewStoryService creates new instance of StoryService
-func NewStoryService(logger lib.Logger, repo component.IStoryMgmtComp) StoryService {
-	return StoryService{logger: logger, repo: repo}
+func NewStoryService(logger lib.Logger, repo component.IStoryMgmtComp, media MediaService, slugs SlugService) StoryService {
+	return StoryService{logger: logger, repo: repo, media: media, slugs: slugs}
 }
 
-// Creates the Story in database
+// Creates the Story in database, story content of story with body is the plain text of the body
 func (u StoryService) CreateStory(story *magazine.Story) (*magazine.Story, error) {
+	if story.StoryBody != nil {
+		text, err := u.prepareBody(story.StoryBody)
+		if err != nil {
+			return nil, err
+		}
+		story.StoryContent = &text
+
+		words := StoryBodyWordCount(story.StoryBody)
+		minutes := ReadingTime(words)
+		story.WordCount, story.ReadingTime = &words, &minutes
+	} else if story.StoryContent != nil {
+		words := WordCount(*story.StoryContent)
+		minutes := ReadingTime(words)
+		story.WordCount, story.ReadingTime = &words, &minutes
+	}
+
+	publishedOn, err := PublishedOn(story.PublishAt, story.UnpublishAt)
+	if err != nil {
+		return nil, err
+	}
+	story.PublishedOn = publishedOn
+
 	story = u.BeforeCreate(story)
 
-	err := u.repo.CreateStory(*story)
+	slug, err := u.slugs.UniqueSlug(SlugStories, story.StoryTitle, story.ID)
 	if err != nil {
 		return nil, err
 	}
+	story.Slug = &slug
+
+	err = u.repo.CreateStory(*story)
+	if err != nil {
+		return nil, err
+	}
+
+	if story.StoryBody != nil {
+		if err := u.trackBody(story.ID, story.StoryBody); err != nil {
+			return nil, err
+		}
+	}
+
+	refs := map[string]*lib.SignedURL{
+		"og_image": story.OgImage,
+	}
+	if err := u.media.TrackReferences(models.MediaEntityStory, story.ID, refs); err != nil {
+		return nil, err
+	}
 
 	return story, nil
 }
 
 // Lists stories form database
-func (p StoryService) ListStories(c *gin.Context) ([]*magazine.Story, error) {
-	stories, err := p.repo.ListStories()
+func (p StoryService) ListStories(c *gin.Context) ([]*magazine.Story, models.PageInfo, error) {
+	stories, page, err := p.repo.ListStories(PaginationFrom(c), ListQueryFrom(c))
 	if err != nil {
-		return nil, err
+		return nil, page, err
 	}
-	return stories, nil
+	return stories, page, nil
 }
 
 // Gets Story by id from database
@@ -53,33 +98,64 @@ func (u StoryService) GetStoryById(id uuid.UUID) (*magazine.Story, error) {
 }
 
 // Lists Storys by profile id
-func (u StoryService) ListStoriesByProfileId(id uuid.UUID) ([]*magazine.Story, error) {
-	Story, err := u.repo.GetStoryFromCreatorId(id)
-	if err != nil {
-		return nil, err
-	}
-
-	return Story, nil
+func (u StoryService) ListStoriesByProfileId(id uuid.UUID, page models.Pagination, list models.ListQuery) ([]*magazine.Story, models.PageInfo, error) {
+	return u.repo.GetStoryFromCreatorId(id, page, list)
 }
 
 // Lists Stories by type
-func (u StoryService) ListStoriesByType(story_type string) ([]*magazine.Story, error) {
-	Story, err := u.repo.GetStoryFromType(story_type)
-	if err != nil {
-		return nil, err
-	}
-
-	return Story, nil
+func (u StoryService) ListStoriesByType(story_type string, page models.Pagination, list models.ListQuery) ([]*magazine.Story, models.PageInfo, error) {
+	return u.repo.GetStoryFromType(story_type, page, list)
 }
 
-// Update Story by id in our database
+// Update Story by id in our database, word count and reading time follow patched content.
+// Story content patched without body replaces the body
 func (u StoryService) UpdateStory(id uuid.UUID, patch *map[string]interface{}) error {
+	var body *magazine.StoryBody
+	if value, ok := (*patch)["story_body"]; ok {
+		body, _ = value.(*magazine.StoryBody)
+		if body == nil {
+			return &StoryBodyError{Block: -1, Message: "blocks are required"}
+		}
+
+		text, err := u.prepareBody(body)
+		if err != nil {
+			return err
+		}
+
+		words := StoryBodyWordCount(body)
+		(*patch)["story_content"] = text
+		(*patch)["word_count"] = words
+		(*patch)["reading_time"] = ReadingTime(words)
+	} else if value, ok := (*patch)["story_content"]; ok {
+		content, _ := value.(*string)
+		words := 0
+		if content != nil {
+			words = WordCount(*content)
+		}
+
+		(*patch)["story_body"] = nil
+		(*patch)["word_count"] = words
+		(*patch)["reading_time"] = ReadingTime(words)
+	}
+
 	err := u.repo.PatchStory(id, patch)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	if _, ok := (*patch)["story_body"]; ok {
+		if err := u.trackBody(id, body); err != nil {
+			return err
+		}
+	}
+
+	return u.media.TrackPatchedReferences(models.MediaEntityStory, id, patch, "og_image")
+}
+
+// ChangeSlug sets slug of the story to the slug asked for or a new one from its title,
+// the old slug redirects to the story
+func (u StoryService) ChangeSlug(story *magazine.Story, slug string) (string, error) {
+	return u.slugs.ChangeSlug(SlugStories, story.ID, slug, story.StoryTitle)
 }
 
 // Delete Story by in our database
@@ -110,3 +186,87 @@ func (u StoryService) BeforeCreate(Story *magazine.Story) *magazine.Story {
 
 	return Story
 }
+
+// Lists stories in contents of the issue by its content code
+func (u StoryService) ListStoriesByContentCode(contentCode string) ([]*magazine.Story, error) {
+	return u.repo.ListStoriesFromContentCode(contentCode)
+}
+
+// prepareBody validates the body and points its images to urls of their media assets,
+// the plain text of the body is returned
+func (u StoryService) prepareBody(body *magazine.StoryBody) (string, error) {
+	if err := ValidateStoryBody(body); err != nil {
+		return "", err
+	}
+	body.Version = magazine.StoryBodyVersion
+
+	var ids []uuid.UUID
+	for _, block := range body.Blocks {
+		if block.Type == magazine.BlockImage {
+			ids = append(ids, *block.MediaId)
+		}
+	}
+
+	byID := map[uuid.UUID]*models.Media{}
+	if len(ids) > 0 {
+		media, err := u.media.ListMediaByIDs(ids)
+		if err != nil {
+			return "", err
+		}
+		for _, m := range media {
+			byID[m.ID] = m
+		}
+	}
+
+	for i := range body.Blocks {
+		block := &body.Blocks[i]
+		if block.Type != magazine.BlockImage {
+			continue
+		}
+
+		m, ok := byID[*block.MediaId]
+		if !ok {
+			return "", &StoryBodyError{Block: i, Message: "media " + block.MediaId.String() + " not found"}
+		}
+		if m.MimeType == nil || !lib.IsImageContentType(*m.MimeType) {
+			return "", &StoryBodyError{Block: i, Message: "media " + block.MediaId.String() + " is not an image"}
+		}
+		block.URL = m.Key
+	}
+
+	return StoryBodyText(body), nil
+}
+
+// trackBody records media of images in the body as used by the story, body may be nil
+func (u StoryService) trackBody(id uuid.UUID, body *magazine.StoryBody) error {
+	if err := u.media.ClearReferences(models.MediaEntityStory, id, "story_body."); err != nil {
+		return err
+	}
+	if body == nil {
+		return nil
+	}
+
+	refs := map[string]*lib.SignedURL{}
+	for i, block := range body.Blocks {
+		if block.Type == magazine.BlockImage {
+			refs["story_body."+strconv.Itoa(i)] = block.URL
+		}
+	}
+
+	return u.media.TrackReferences(models.MediaEntityStory, id, refs)
+}
+
+// Lists page of published stories for readers, of the story type when it is given
+func (u StoryService) ListPublishedStories(storyType string, page models.Pagination, list models.ListQuery) ([]*magazine.Story, models.PageInfo, error) {
+	return u.repo.ListPublishedStories(storyType, page, list)
+}
+
+// Gets published story by its slug
+func (u StoryService) GetPublishedStoryBySlug(slug string) (*magazine.Story, error) {
+	return u.repo.GetPublishedStoryFromSlug(slug)
+}
+
+// Lists published stories in contents of the issue by its content code
+func (u StoryService) ListPublishedStoriesByContentCode(contentCode string) ([]*magazine.Story, error) {
+	return u.repo.ListPublishedStoriesFromContentCode(contentCode)
+}
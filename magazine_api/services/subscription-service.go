@@ -0,0 +1,148 @@
+package services
+
+import (
+	"magazine_api/component"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SubscriptionService service layer
+type SubscriptionService struct {
+	logger lib.Logger
+	comp   component.SubscriptionComponent
+}
+
+// NewSubscriptionService creates new instance of SubscriptionService
+func NewSubscriptionService(logger lib.Logger, comp component.SubscriptionComponent) SubscriptionService {
+	return SubscriptionService{logger: logger, comp: comp}
+}
+
+// CreatePlan creates the subscription plan in database
+func (s SubscriptionService) CreatePlan(plan *models.SubscriptionPlan) (*models.SubscriptionPlan, error) {
+	plan.ID = uuid.New()
+	create := time.Now()
+	plan.CreatedOn = &create
+	plan.UpdatedOn = &create
+
+	err := s.comp.CreatePlan(*plan)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// ListPlans lists page of subscription plans from database
+func (s SubscriptionService) ListPlans(page models.Pagination, list models.ListQuery) ([]*models.SubscriptionPlan, models.PageInfo, error) {
+	return s.comp.ListPlans(page, list)
+}
+
+// GetPlanByID gets subscription plan by id from database
+func (s SubscriptionService) GetPlanByID(id uuid.UUID) (*models.SubscriptionPlan, error) {
+	plan, err := s.comp.GetPlanFromID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// UpdatePlan updates subscription plan by id in database
+func (s SubscriptionService) UpdatePlan(id uuid.UUID, patch *map[string]interface{}) error {
+	return s.comp.PatchPlan(id, patch)
+}
+
+// DeletePlan soft deletes subscription plan by id in database
+func (s SubscriptionService) DeletePlan(id uuid.UUID) error {
+	return s.comp.DeletePlan(id)
+}
+
+// CreateSubscription creates subscription of user to the plan starting from start, the transaction
+// of its payment is created with it
+func (s SubscriptionService) CreateSubscription(
+	userID uuid.UUID,
+	plan *models.SubscriptionPlan,
+	start time.Time,
+	transaction *models.Transaction,
+) (*models.Subscription, error) {
+	end := s.EndDate(plan, start)
+	status := models.SubscriptionActive
+	create := time.Now()
+
+	subscription := &models.Subscription{
+		Base: models.Base{ID: uuid.New()},
+		BaseDate: models.BaseDate{
+			CreatedOn: &create,
+			UpdatedOn: &create,
+		},
+		SubscriptionBase: models.SubscriptionBase{
+			UserId:        &userID,
+			PlanId:        &plan.ID,
+			StartDate:     &start,
+			EndDate:       &end,
+			Status:        &status,
+			TransactionId: &transaction.ID,
+		},
+	}
+
+	err := s.comp.CreateSubscription(*subscription, *transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// GetSubscriptionByID gets subscription by id from database
+func (s SubscriptionService) GetSubscriptionByID(id uuid.UUID) (*models.Subscription, error) {
+	subscription, err := s.comp.GetSubscriptionFromID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// ListSubscriptionsByUserID lists subscriptions of user
+func (s SubscriptionService) ListSubscriptionsByUserID(userID uuid.UUID) ([]*models.Subscription, error) {
+	subscriptions, err := s.comp.ListSubscriptionsFromUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// PageSubscriptionsByUserID lists page of subscriptions of user newest first
+func (s SubscriptionService) PageSubscriptionsByUserID(userID uuid.UUID, page models.Pagination, list models.ListQuery) ([]*models.Subscription, models.PageInfo, error) {
+	return s.comp.PageSubscriptionsFromUserID(userID, page, list)
+}
+
+// CancelSubscription cancels subscription by id
+func (s SubscriptionService) CancelSubscription(id uuid.UUID) error {
+	return s.comp.PatchSubscription(id, &map[string]interface{}{
+		"status":     models.SubscriptionCancelled,
+		"updated_on": time.Now(),
+	})
+}
+
+// HasActiveSubscription checks if user has subscription of the format active now
+func (s SubscriptionService) HasActiveSubscription(userID uuid.UUID, format models.SubscriptionFormat) (bool, error) {
+	subscriptions, err := s.comp.ListActiveSubscriptions(userID, format, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	return len(subscriptions) > 0, nil
+}
+
+// EndDate calculates end date of subscription to the plan starting from start
+func (s SubscriptionService) EndDate(plan *models.SubscriptionPlan, start time.Time) time.Time {
+	if plan.Interval != nil && *plan.Interval == models.IntervalAnnual {
+		return start.AddDate(1, 0, 0)
+	}
+	return start.AddDate(0, 1, 0)
+}
@@ -2,7 +2,6 @@ package services
 
 import (
 	"magazine_api/component"
-	"magazine_api/constants"
 	"magazine_api/lib"
 	"magazine_api/models"
 	"time"
@@ -35,25 +34,13 @@ func (t TransactionService) CreateTransaction(assign *models.Transaction) (*mode
 }
 
 // Lists the Transaction in database
-func (t TransactionService) ListsTransaction(c *gin.Context) ([]*models.Transaction, error) {
-	assigns, err := t.comp.ListTransactions(c.MustGet(constants.Limit).(int64), c.MustGet(constants.Page).(int64))
-	if err != nil {
-		return nil, err
-	}
-
-	return assigns, nil
+func (t TransactionService) ListsTransaction(c *gin.Context) ([]*models.Transaction, models.PageInfo, error) {
+	return t.comp.ListTransactions(PaginationFrom(c), ListQueryFrom(c))
 }
 
 // Lists Deleted Transactions from database
-func (t TransactionService) ListsDeletedTransactions(c *gin.Context) ([]*models.Transaction, error) {
-
-	assigns, err := t.comp.ListDeletedTransactions(c.MustGet(constants.Limit).(int64), c.MustGet(constants.Page).(int64))
-
-	if err != nil {
-		return nil, err
-	}
-
-	return assigns, nil
+func (t TransactionService) ListsDeletedTransactions(c *gin.Context) ([]*models.Transaction, models.PageInfo, error) {
+	return t.comp.ListDeletedTransactions(PaginationFrom(c), ListQueryFrom(c))
 }
 
 // Get transaction  by id from database
@@ -69,69 +56,33 @@ func (t TransactionService) GetTransactionByID(id uuid.UUID) (*models.Transactio
 }
 
 //Get transaction by type
-func (t TransactionService) GetTransactionByType(paid_type string, c *gin.Context) ([]*models.Transaction, error) {
-	assigns, err := t.comp.GetTransactionFromType(paid_type, c.MustGet(constants.Limit).(int64), c.MustGet(constants.Page).(int64))
-
-	if err != nil {
-		return nil, err
-	}
-
-	return assigns, nil
+func (t TransactionService) GetTransactionByType(paid_type string, c *gin.Context) ([]*models.Transaction, models.PageInfo, error) {
+	return t.comp.GetTransactionFromType(paid_type, PaginationFrom(c), ListQueryFrom(c))
 }
 
 //Get transaction by medium
-func (t TransactionService) GetTransactionByMedium(paid_medium string, c *gin.Context) ([]*models.Transaction, error) {
-	assigns, err := t.comp.GetTransactionFromMedium(paid_medium, c.MustGet(constants.Limit).(int64), c.MustGet(constants.Page).(int64))
-
-	if err != nil {
-		return nil, err
-	}
-
-	return assigns, nil
+func (t TransactionService) GetTransactionByMedium(paid_medium string, c *gin.Context) ([]*models.Transaction, models.PageInfo, error) {
+	return t.comp.GetTransactionFromMedium(paid_medium, PaginationFrom(c), ListQueryFrom(c))
 }
 
 //Get transaction by date
-func (t TransactionService) GetTransactionByDate(date time.Time, c *gin.Context) ([]*models.Transaction, error) {
-	assigns, err := t.comp.GetTransactionFromDate(date, c.MustGet(constants.Limit).(int64), c.MustGet(constants.Page).(int64))
-
-	if err != nil {
-		return nil, err
-	}
-
-	return assigns, nil
+func (t TransactionService) GetTransactionByDate(date time.Time, c *gin.Context) ([]*models.Transaction, models.PageInfo, error) {
+	return t.comp.GetTransactionFromDate(date, PaginationFrom(c), ListQueryFrom(c))
 }
 
 //Get transaction by month
-func (t TransactionService) GetTransactionByMonth(month string, c *gin.Context) ([]*models.Transaction, error) {
-	assigns, err := t.comp.GetTransactionFromMonth(month, c.MustGet(constants.Limit).(int64), c.MustGet(constants.Page).(int64))
-
-	if err != nil {
-		return nil, err
-	}
-
-	return assigns, nil
+func (t TransactionService) GetTransactionByMonth(month string, c *gin.Context) ([]*models.Transaction, models.PageInfo, error) {
+	return t.comp.GetTransactionFromMonth(month, PaginationFrom(c), ListQueryFrom(c))
 }
 
 //Get transaction from account id
-func (t TransactionService) GetTransactionFromAccount(account uuid.UUID, c *gin.Context) ([]*models.Transaction, error) {
-	assigns, err := t.comp.GetTransactionFromAccount(account, c.MustGet(constants.Limit).(int64), c.MustGet(constants.Page).(int64))
-
-	if err != nil {
-		return nil, err
-	}
-
-	return assigns, nil
+func (t TransactionService) GetTransactionFromAccount(account uuid.UUID, c *gin.Context) ([]*models.Transaction, models.PageInfo, error) {
+	return t.comp.GetTransactionFromAccount(account, PaginationFrom(c), ListQueryFrom(c))
 }
 
 //Get transaction from account id
-func (t TransactionService) GetTransactionToAccount(account uuid.UUID, c *gin.Context) ([]*models.Transaction, error) {
-	assigns, err := t.comp.GetTransactionToAccount(account, c.MustGet(constants.Limit).(int64), c.MustGet(constants.Page).(int64))
-
-	if err != nil {
-		return nil, err
-	}
-
-	return assigns, nil
+func (t TransactionService) GetTransactionToAccount(account uuid.UUID, c *gin.Context) ([]*models.Transaction, models.PageInfo, error) {
+	return t.comp.GetTransactionToAccount(account, PaginationFrom(c), ListQueryFrom(c))
 }
 
 // Update user by in our database
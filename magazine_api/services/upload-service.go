@@ -0,0 +1,404 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"magazine_api/api/serializers/requests"
+	"magazine_api/api/serializers/responses"
+	"magazine_api/apperrors"
+	"magazine_api/component"
+	"magazine_api/lib"
+	"magazine_api/models"
+	"mime"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUploadContentType  = apperrors.NewValidation("content type is not supported")
+	ErrUploadEmpty        = apperrors.NewValidation("size of the file is required")
+	ErrUploadTooLarge     = apperrors.NewValidation("file is too large")
+	ErrUploadFolder       = apperrors.NewValidation("invalid folder")
+	ErrUploadExpired      = errors.New("upload has expired")
+	ErrUploadSizeMismatch = apperrors.NewValidation("uploaded file does not match the declared size")
+	ErrUploadTypeMismatch = apperrors.NewValidation("uploaded file does not match the declared content type")
+)
+
+// UploadService uploads of files straight to storage through presigned urls
+type UploadService struct {
+	logger    lib.Logger
+	env       lib.Env
+	comp      component.UploadComponent
+	storage   StorageService
+	media     MediaService
+	validator UploadValidator
+}
+
+// NewUploadService creates new instance of UploadService
+func NewUploadService(
+	logger lib.Logger,
+	env lib.Env,
+	comp component.UploadComponent,
+	storage StorageService,
+	media MediaService,
+	validator UploadValidator,
+) UploadService {
+	return UploadService{
+		logger:    logger,
+		env:       env,
+		comp:      comp,
+		storage:   storage,
+		media:     media,
+		validator: validator,
+	}
+}
+
+// Presign records upload of the declared file and creates urls the client uploads it to,
+// files above UPLOAD_MULTIPART_THRESHOLD are uploaded in parts
+func (u UploadService) Presign(
+	ctx context.Context,
+	request requests.PresignUpload,
+	uploadedBy *uuid.UUID,
+) (*responses.PresignedUpload, error) {
+	contentType := normalizeContentType(request.ContentType)
+	ext, ok := lib.ContentTypeExtensions[contentType]
+	if !ok {
+		return nil, ErrUploadContentType
+	}
+	if request.Size <= 0 {
+		return nil, ErrUploadEmpty
+	}
+	if request.Size > u.env.UploadMaxSize {
+		return nil, ErrUploadTooLarge
+	}
+
+	folder, err := cleanUploadFolder(request.Folder)
+	if err != nil {
+		return nil, err
+	}
+
+	create := time.Now()
+	expires := create.Add(u.env.UploadExpiry)
+	status := models.UploadPending
+	key := path.Join(folder, uuid.NewString()+ext)
+
+	upload := models.DirectUpload{
+		Base:     models.Base{ID: uuid.New()},
+		BaseDate: models.BaseDate{CreatedOn: &create, UpdatedOn: &create},
+		DirectUploadBase: models.DirectUploadBase{
+			Key:         &key,
+			FileName:    &request.FileName,
+			ContentType: &contentType,
+			Size:        &request.Size,
+			Status:      &status,
+			UploadedBy:  uploadedBy,
+			ExpiresOn:   &expires,
+		},
+	}
+
+	if request.Multipart || request.Size > u.env.UploadMultipartThreshold {
+		uploadID, err := u.storage.CreateMultipartUpload(ctx, key, contentType)
+		if err != nil {
+			return nil, err
+		}
+
+		partSize := u.partSize(request.Size)
+		upload.UploadId = &uploadID
+		upload.PartSize = &partSize
+	}
+
+	if err := u.comp.CreateUpload(upload); err != nil {
+		if upload.IsMultipart() {
+			if err := u.storage.AbortMultipartUpload(ctx, key, *upload.UploadId); err != nil {
+				u.logger.Error("upload-abort-error: ", err)
+			}
+		}
+		return nil, err
+	}
+
+	return u.presigned(ctx, &upload, nil)
+}
+
+// Resume creates new urls for the pending upload, parts of multipart upload already
+// uploaded are listed and left out
+func (u UploadService) Resume(ctx context.Context, id uuid.UUID, uploadedBy *uuid.UUID) (*responses.PresignedUpload, error) {
+	upload, err := u.pending(id, uploadedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if !upload.IsMultipart() {
+		return u.presigned(ctx, upload, nil)
+	}
+
+	parts, err := u.storage.ListUploadParts(ctx, *upload.Key, *upload.UploadId)
+	if err != nil {
+		return nil, err
+	}
+
+	uploaded := []lib.UploadPart{}
+	for _, part := range parts {
+		if part.Size == u.sizeOfPart(upload, part.PartNumber) {
+			uploaded = append(uploaded, part)
+		}
+	}
+
+	return u.presigned(ctx, upload, uploaded)
+}
+
+// Complete validates the uploaded file against the declared one by its size and magic bytes, scans
+// it for malware and records it as media, images are queued for generating their variants
+func (u UploadService) Complete(
+	ctx context.Context,
+	request requests.CompleteUpload,
+	uploadedBy *uuid.UUID,
+) (*models.Media, error) {
+	upload, err := u.pending(request.UploadId, uploadedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if upload.IsMultipart() {
+		parts := request.Parts
+		if len(parts) == 0 {
+			parts, err = u.storage.ListUploadParts(ctx, *upload.Key, *upload.UploadId)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if err := u.storage.CompleteMultipartUpload(ctx, *upload.Key, *upload.UploadId, parts); err != nil {
+			return nil, err
+		}
+	}
+
+	info, err := u.storage.StatFile(ctx, *upload.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Size != *upload.Size {
+		return nil, u.reject(ctx, upload, ErrUploadSizeMismatch)
+	}
+	if info.ContentType != "" && normalizeContentType(info.ContentType) != *upload.ContentType {
+		return nil, u.reject(ctx, upload, ErrUploadTypeMismatch)
+	}
+
+	_, err = u.validator.ValidateObject(ctx, func() (io.ReadCloser, error) {
+		return u.storage.DownloadFile(ctx, *upload.Key)
+	}, *upload.ContentType)
+	if UploadErrorStatus(err) != 0 {
+		return nil, u.reject(ctx, upload, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	media, err := u.media.Register(ctx, MediaObject{
+		Key:        *upload.Key,
+		MimeType:   *upload.ContentType,
+		UploadedBy: upload.UploadedBy,
+		Process:    lib.VariantContentTypes[*upload.ContentType],
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.comp.CompleteUpload(upload.ID, media.ID); err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// Abort discards the pending upload and what was uploaded of it, expired uploads included
+func (u UploadService) Abort(ctx context.Context, id uuid.UUID, uploadedBy *uuid.UUID) error {
+	upload, err := u.pending(id, uploadedBy)
+	if upload == nil {
+		return err
+	}
+
+	return u.abort(ctx, upload)
+}
+
+// AbortExpired discards uploads left pending after they expired, it returns number of uploads aborted
+func (u UploadService) AbortExpired(ctx context.Context) (int, error) {
+	uploads, err := u.comp.ListExpiredUploads(time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	for i, upload := range uploads {
+		if err := u.abort(ctx, upload); err != nil {
+			return i, err
+		}
+	}
+
+	return len(uploads), nil
+}
+
+func (u UploadService) abort(ctx context.Context, upload *models.DirectUpload) error {
+	var err error
+	if upload.IsMultipart() {
+		err = u.storage.AbortMultipartUpload(ctx, *upload.Key, *upload.UploadId)
+	} else {
+		err = u.storage.DeleteFile(ctx, *upload.Key)
+	}
+	if err != nil && !errors.Is(err, lib.ErrUploadNotFound) && !errors.Is(err, lib.ErrObjectNotFound) {
+		return err
+	}
+
+	return u.comp.AbortUpload(upload.ID)
+}
+
+// reject deletes uploaded file not matching the declared one and aborts the upload
+func (u UploadService) reject(ctx context.Context, upload *models.DirectUpload, reason error) error {
+	if err := u.storage.DeleteFile(ctx, *upload.Key); err != nil && !errors.Is(err, lib.ErrObjectNotFound) {
+		u.logger.Error("upload-reject-error: ", err)
+	}
+	if err := u.comp.AbortUpload(upload.ID); err != nil {
+		u.logger.Error("upload-reject-error: ", err)
+	}
+
+	return reason
+}
+
+// pending gets the upload while it can still be uploaded to, uploads of other users are not found.
+// Expired upload is returned along with ErrUploadExpired
+func (u UploadService) pending(id uuid.UUID, uploadedBy *uuid.UUID) (*models.DirectUpload, error) {
+	upload, err := u.comp.GetUploadFromID(id)
+	if pgxscan.NotFound(err) {
+		return nil, lib.ErrUploadNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if upload.Status == nil || *upload.Status != models.UploadPending {
+		return nil, lib.ErrUploadNotFound
+	}
+	if upload.UploadedBy != nil && (uploadedBy == nil || *upload.UploadedBy != *uploadedBy) {
+		return nil, lib.ErrUploadNotFound
+	}
+	if upload.ExpiresOn != nil && upload.ExpiresOn.Before(time.Now()) {
+		return upload, ErrUploadExpired
+	}
+
+	return upload, nil
+}
+
+// presigned creates urls of the upload, parts already uploaded are left out
+func (u UploadService) presigned(
+	ctx context.Context,
+	upload *models.DirectUpload,
+	uploaded []lib.UploadPart,
+) (*responses.PresignedUpload, error) {
+	expiry := u.env.UploadURLExpiry
+	if remaining := time.Until(*upload.ExpiresOn); remaining < expiry {
+		expiry = remaining
+	}
+
+	presigned := &responses.PresignedUpload{
+		UploadId:      upload.ID,
+		Key:           *upload.Key,
+		Multipart:     upload.IsMultipart(),
+		UploadedParts: uploaded,
+		URLExpiresOn:  time.Now().Add(expiry),
+		ExpiresOn:     *upload.ExpiresOn,
+	}
+
+	if !upload.IsMultipart() {
+		url, err := u.storage.PresignUpload(ctx, *upload.Key, *upload.ContentType, *upload.Size, expiry)
+		if err != nil {
+			return nil, err
+		}
+
+		presigned.URL = url
+		presigned.Headers = map[string]string{"Content-Type": *upload.ContentType}
+		return presigned, nil
+	}
+
+	done := map[int32]bool{}
+	for _, part := range uploaded {
+		done[part.PartNumber] = true
+	}
+
+	presigned.PartSize = *upload.PartSize
+	presigned.Parts = []responses.PresignedPart{}
+	for number := int32(1); number <= u.partCount(upload); number++ {
+		if done[number] {
+			continue
+		}
+
+		url, err := u.storage.PresignUploadPart(ctx, *upload.Key, *upload.UploadId, number, expiry)
+		if err != nil {
+			return nil, err
+		}
+
+		presigned.Parts = append(presigned.Parts, responses.PresignedPart{
+			PartNumber: number,
+			Size:       u.sizeOfPart(upload, number),
+			URL:        url,
+		})
+	}
+
+	return presigned, nil
+}
+
+// partSize size of parts of the file, grown when the file would need more parts than storage allows
+func (u UploadService) partSize(size int64) int64 {
+	partSize := u.env.UploadPartSize
+	if partSize < lib.MinUploadPartSize {
+		partSize = lib.MinUploadPartSize
+	}
+	if min := (size + lib.MaxUploadParts - 1) / lib.MaxUploadParts; partSize < min {
+		partSize = min
+	}
+	return partSize
+}
+
+func (u UploadService) partCount(upload *models.DirectUpload) int32 {
+	return int32((*upload.Size + *upload.PartSize - 1) / *upload.PartSize)
+}
+
+// sizeOfPart size of the part of multipart upload, the last part holds the remainder
+func (u UploadService) sizeOfPart(upload *models.DirectUpload, number int32) int64 {
+	if number < u.partCount(upload) {
+		return *upload.PartSize
+	}
+	return *upload.Size - int64(number-1)**upload.PartSize
+}
+
+// normalizeContentType drops parameters of the content type
+func normalizeContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return mediaType
+}
+
+// cleanUploadFolder checks folder is relative path of lowercase names
+func cleanUploadFolder(folder string) (string, error) {
+	if folder == "" {
+		return "uploads", nil
+	}
+
+	for _, name := range strings.Split(folder, "/") {
+		if name == "" || name[0] == '.' {
+			return "", ErrUploadFolder
+		}
+		for _, r := range name {
+			if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' || r == '-' || r == '.') {
+				return "", ErrUploadFolder
+			}
+		}
+	}
+
+	return folder, nil
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"magazine_api/apperrors"
+	"magazine_api/lib"
+)
+
+var (
+	ErrUploadContentMismatch = apperrors.NewValidation("file content is not of an allowed type")
+	ErrUploadCorruptImage    = apperrors.NewValidation("image cannot be decoded")
+	ErrUploadTooManyPixels   = apperrors.NewValidation("image has too many pixels")
+	ErrUploadScanFailed      = errors.New("file could not be scanned for malware")
+)
+
+// UploadValidator validates content of uploaded files instead of trusting their name
+type UploadValidator struct {
+	logger  lib.Logger
+	env     lib.Env
+	scanner lib.Scanner
+}
+
+// NewUploadValidator creates new instance of UploadValidator
+func NewUploadValidator(logger lib.Logger, env lib.Env, scanner lib.Scanner) UploadValidator {
+	return UploadValidator{
+		logger:  logger,
+		env:     env,
+		scanner: scanner,
+	}
+}
+
+// Validate detects content type of the file by its magic bytes, which must be one of allowed or
+// of any uploadable type when none given. Images with more than IMAGE_MAX_PIXELS are refused before
+// being decoded and the file is scanned for malware
+func (v UploadValidator) Validate(ctx context.Context, data []byte, allowed ...string) (string, error) {
+	contentType, err := v.sniff(data, allowed)
+	if err != nil {
+		return "", err
+	}
+
+	if lib.IsImageContentType(contentType) {
+		if err := v.checkPixels(bytes.NewReader(data)); err != nil {
+			return "", err
+		}
+	}
+
+	return contentType, v.scan(ctx, bytes.NewReader(data))
+}
+
+// ValidateObject validates stored file like Validate, the file is opened once for checking
+// its head and once more for scanning it whole
+func (v UploadValidator) ValidateObject(
+	ctx context.Context,
+	open func() (io.ReadCloser, error),
+	allowed ...string,
+) (string, error) {
+	file, err := open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	head := make([]byte, lib.SniffLength)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+
+	contentType, err := v.sniff(head[:n], allowed)
+	if err != nil {
+		return "", err
+	}
+
+	if lib.IsImageContentType(contentType) {
+		if err := v.checkPixels(io.MultiReader(bytes.NewReader(head[:n]), file)); err != nil {
+			return "", err
+		}
+	}
+
+	scanned, err := open()
+	if err != nil {
+		return "", err
+	}
+	defer scanned.Close()
+
+	return contentType, v.scan(ctx, scanned)
+}
+
+func (v UploadValidator) sniff(head []byte, allowed []string) (string, error) {
+	contentType := lib.SniffContentType(head)
+	if contentType == "" {
+		return "", ErrUploadContentMismatch
+	}
+	if len(allowed) == 0 {
+		return contentType, nil
+	}
+
+	for _, t := range allowed {
+		if t == contentType {
+			return contentType, nil
+		}
+	}
+	return "", ErrUploadContentMismatch
+}
+
+// checkPixels reads dimensions from header of the image, decompression bombs are refused
+// without allocating their pixels
+func (v UploadValidator) checkPixels(r io.Reader) error {
+	config, _, err := image.DecodeConfig(r)
+	if err != nil {
+		return ErrUploadCorruptImage
+	}
+
+	if v.env.ImageMaxPixels > 0 && int64(config.Width)*int64(config.Height) > v.env.ImageMaxPixels {
+		return fmt.Errorf("%w: %dx%d", ErrUploadTooManyPixels, config.Width, config.Height)
+	}
+
+	return nil
+}
+
+func (v UploadValidator) scan(ctx context.Context, file io.Reader) error {
+	err := v.scanner.Scan(ctx, file)
+	if err == nil || errors.Is(err, lib.ErrMalwareFound) {
+		return err
+	}
+
+	v.logger.Error("upload-scan-error: ", err)
+	return ErrUploadScanFailed
+}
+
+// UploadErrorStatus http status of upload refused for the error, 0 when error is not a refusal
+func UploadErrorStatus(err error) int {
+	kind, ok := uploadRefusal(err)
+	if !ok {
+		return 0
+	}
+	return apperrors.Status(kind)
+}
+
+// UploadError err of the kind of its refusal, errors which are not refusals are left as they are
+func UploadError(err error) error {
+	kind, ok := uploadRefusal(err)
+	if !ok {
+		return err
+	}
+	return apperrors.Wrap(kind, err.Error(), err)
+}
+
+func uploadRefusal(err error) (apperrors.Kind, bool) {
+	switch {
+	case errors.Is(err, ErrUploadContentType),
+		errors.Is(err, ErrUploadContentMismatch),
+		errors.Is(err, ErrUploadTypeMismatch),
+		errors.Is(err, ErrUploadCorruptImage):
+		return apperrors.UnsupportedMedia, true
+	case errors.Is(err, ErrUploadTooLarge),
+		errors.Is(err, ErrUploadTooManyPixels):
+		return apperrors.TooLarge, true
+	case errors.Is(err, lib.ErrMalwareFound):
+		return apperrors.Validation, true
+	case errors.Is(err, ErrUploadScanFailed):
+		return apperrors.Unavailable, true
+	case errors.Is(err, ErrUploadEmpty),
+		errors.Is(err, ErrUploadFolder),
+		errors.Is(err, ErrUploadSizeMismatch):
+		return apperrors.BadRequest, true
+	}
+	return 0, false
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"magazine_api/lib"
+	"net/http"
+	"testing"
+)
+
+func pngOf(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func newTestValidator(maxPixels int64, scanned error) UploadValidator {
+	return NewUploadValidator(lib.GetLogger(), lib.Env{ImageMaxPixels: maxPixels}, lib.StubScanner{Result: scanned})
+}
+
+func TestUploadValidatorValidate(t *testing.T) {
+	picture := pngOf(t, 10, 10)
+	scanErr := errors.New("clamd unreachable")
+
+	tests := []struct {
+		name     string
+		data     []byte
+		allowed  []string
+		pixels   int64
+		scanned  error
+		wantType string
+		wantErr  error
+	}{
+		{name: "image", data: picture, allowed: []string{"image/png"}, wantType: "image/png"},
+		{name: "any uploadable type", data: []byte("%PDF-1.7\n"), wantType: "application/pdf"},
+		{name: "type not allowed", data: []byte("%PDF-1.7\n"), allowed: []string{"image/png"}, wantErr: ErrUploadContentMismatch},
+		{name: "unknown content", data: []byte("<script>alert(1)</script>"), wantErr: ErrUploadContentMismatch},
+		{name: "corrupt image", data: picture[:16], wantErr: ErrUploadCorruptImage},
+		{name: "too many pixels", data: picture, pixels: 99, wantErr: ErrUploadTooManyPixels},
+		{name: "pixels at limit", data: picture, pixels: 100, wantType: "image/png"},
+		{name: "malware", data: picture, scanned: lib.ErrMalwareFound, wantErr: lib.ErrMalwareFound},
+		{name: "scan failed", data: picture, scanned: scanErr, wantErr: ErrUploadScanFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentType, err := newTestValidator(tt.pixels, tt.scanned).Validate(context.Background(), tt.data, tt.allowed...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && contentType != tt.wantType {
+				t.Errorf("got type %q, want %q", contentType, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestUploadValidatorValidateObject(t *testing.T) {
+	data := pngOf(t, 10, 10)
+	opened := 0
+	open := func() (io.ReadCloser, error) {
+		opened++
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+
+	contentType, err := newTestValidator(100, nil).ValidateObject(context.Background(), open, "image/png")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if contentType != "image/png" {
+		t.Errorf("got type %q, want image/png", contentType)
+	}
+	if opened != 2 {
+		t.Errorf("file opened %d times, want once for its head and once for scanning", opened)
+	}
+
+	_, err = newTestValidator(99, nil).ValidateObject(context.Background(), open, "image/png")
+	if !errors.Is(err, ErrUploadTooManyPixels) {
+		t.Errorf("got error %v, want ErrUploadTooManyPixels", err)
+	}
+}
+
+func TestUploadErrorStatusOfRefusals(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{ErrUploadContentMismatch, http.StatusUnsupportedMediaType},
+		{ErrUploadCorruptImage, http.StatusUnsupportedMediaType},
+		{ErrUploadTooManyPixels, http.StatusRequestEntityTooLarge},
+		{lib.ErrMalwareFound, http.StatusUnprocessableEntity},
+		{ErrUploadScanFailed, http.StatusServiceUnavailable},
+		{errors.New("other"), 0},
+	}
+
+	for _, tt := range tests {
+		if got := UploadErrorStatus(tt.err); got != tt.want {
+			t.Errorf("UploadErrorStatus(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
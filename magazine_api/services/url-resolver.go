@@ -0,0 +1,187 @@
+package services
+
+import (
+	"fmt"
+	"magazine_api/constants"
+	"magazine_api/lib"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var signedURLType = reflect.TypeOf(lib.SignedURL(""))
+
+// URLResolver resolves keys of stored objects in responses to downloadable urls
+type URLResolver struct {
+	logger  lib.Logger
+	storage lib.Storage
+	env     lib.Env
+}
+
+// NewURLResolver creates new instance of URLResolver
+func NewURLResolver(logger lib.Logger, storage lib.Storage, env lib.Env) URLResolver {
+	return URLResolver{logger: logger, storage: storage, env: env}
+}
+
+// Resolve replaces every lib.SignedURL reachable from data with url of its object.
+// Urls are memoised for the request, `?variant=thumb|webp|original` selects image
+// variant and `url_expiry` struct tag overrides expiry of presigned urls per field
+func (r URLResolver) Resolve(c *gin.Context, data interface{}) error {
+	w := urlWalker{
+		resolver: r,
+		c:        c,
+		variant:  c.Query("variant"),
+		cache:    r.cache(c),
+	}
+
+	return w.walk(reflect.ValueOf(data), r.env.StorageURLExpiry)
+}
+
+// URL resolves key of the stored object to url valid for expiry
+func (r URLResolver) URL(c *gin.Context, key string, expiry time.Duration) (string, error) {
+	switch r.env.StorageURLMode {
+	case lib.URLModePublic:
+		return lib.PublicBucketURL(r.env.S3BucketName, r.env.AWSRegion, key), nil
+	case lib.URLModeCDN:
+		return strings.TrimSuffix(r.env.StorageCDNURL, "/") + "/" + key, nil
+	}
+
+	if expiry <= 0 {
+		expiry = lib.SignedURLExpiry
+	}
+
+	return r.storage.Presign(c.Request.Context(), key, expiry)
+}
+
+func (r URLResolver) cache(c *gin.Context) map[string]string {
+	if cached, ok := c.Get(constants.ResolvedURLs); ok {
+		if cache, ok := cached.(map[string]string); ok {
+			return cache
+		}
+	}
+
+	cache := map[string]string{}
+	c.Set(constants.ResolvedURLs, cache)
+	return cache
+}
+
+type urlWalker struct {
+	resolver URLResolver
+	c        *gin.Context
+	variant  string
+	cache    map[string]string
+}
+
+func (w urlWalker) walk(v reflect.Value, expiry time.Duration) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return w.walk(v.Elem(), expiry)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		elem := v.Elem()
+		if !v.CanSet() || elem.Kind() == reflect.Ptr {
+			return w.walk(elem, expiry)
+		}
+		copied := reflect.New(elem.Type()).Elem()
+		copied.Set(elem)
+		if err := w.walk(copied, expiry); err != nil {
+			return err
+		}
+		v.Set(copied)
+
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+
+			fieldExpiry := expiry
+			if tag := field.Tag.Get("url_expiry"); tag != "" {
+				parsed, err := time.ParseDuration(tag)
+				if err != nil {
+					return fmt.Errorf("invalid url_expiry of %s: %w", field.Name, err)
+				}
+				fieldExpiry = parsed
+			}
+
+			if err := w.walk(v.Field(i), fieldExpiry); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		if !mayContainSignedURL(v.Type().Elem()) {
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := w.walk(v.Index(i), expiry); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		if !mayContainSignedURL(v.Type().Elem()) {
+			return nil
+		}
+		for _, key := range v.MapKeys() {
+			copied := reflect.New(v.Type().Elem()).Elem()
+			copied.Set(v.MapIndex(key))
+			if err := w.walk(copied, expiry); err != nil {
+				return err
+			}
+			v.SetMapIndex(key, copied)
+		}
+
+	case reflect.String:
+		if v.Type() == signedURLType && v.CanSet() {
+			return w.set(v, expiry)
+		}
+	}
+
+	return nil
+}
+
+func (w urlWalker) set(v reflect.Value, expiry time.Duration) error {
+	signed := lib.SignedURL(v.String())
+	if signed == "" || signed.IsResolved() {
+		return nil
+	}
+
+	key := signed.Variant(w.variant).Key()
+	cacheKey := key + "|" + expiry.String()
+	if url, ok := w.cache[cacheKey]; ok {
+		v.SetString(url)
+		return nil
+	}
+
+	url, err := w.resolver.URL(w.c, key, expiry)
+	if err != nil {
+		return fmt.Errorf("resolving url of %s: %w", key, err)
+	}
+
+	w.cache[cacheKey] = url
+	v.SetString(url)
+	return nil
+}
+
+// mayContainSignedURL skips walking collections of basic values like uuid bytes
+func mayContainSignedURL(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return false
+	case reflect.String:
+		return t == signedURLType
+	}
+	return true
+}
@@ -12,10 +12,11 @@ import (
 type UserProfileService struct {
 	logger lib.Logger
 	comp   component.UserProfileComponent
+	media  MediaService
 }
 
-func NewUserProfileService(logger lib.Logger, comp component.UserProfileComponent) UserProfileService {
-	return UserProfileService{logger: logger, comp: comp}
+func NewUserProfileService(logger lib.Logger, comp component.UserProfileComponent, media MediaService) UserProfileService {
+	return UserProfileService{logger: logger, comp: comp, media: media}
 }
 
 func (u UserProfileService) CreateUserProfile(user *models.UserProfile) (*models.UserProfile, error) {
@@ -27,6 +28,13 @@ func (u UserProfileService) CreateUserProfile(user *models.UserProfile) (*models
 		return nil, err
 	}
 
+	refs := map[string]*lib.SignedURL{
+		"picture": user.Picture,
+	}
+	if err := u.media.TrackReferences(models.MediaEntityUserProfile, user.ID, refs); err != nil {
+		return nil, err
+	}
+
 	return user, nil
 
 }
@@ -48,7 +56,7 @@ func (u UserProfileService) UpdateUserProfile(id uuid.UUID, patch *map[string]in
 		return err
 	}
 
-	return nil
+	return u.media.TrackPatchedReferences(models.MediaEntityUserProfile, id, patch, "picture")
 }
 
 // Delete cutting assign by ID in our database
@@ -68,7 +76,7 @@ func (u UserProfileService) PermanentDeleteUserProfile(id uuid.UUID) error {
 		return err
 	}
 
-	return nil
+	return u.media.ClearReferences(models.MediaEntityUserProfile, id)
 }
 
 func (u UserProfileService) BeforeCreate(profile *models.UserProfile) *models.UserProfile {
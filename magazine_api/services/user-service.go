@@ -1,9 +1,9 @@
 package services
 
 import (
+	"fmt"
 	"magazine_api/api/serializers/responses"
 	"magazine_api/component"
-	"magazine_api/constants"
 	"magazine_api/lib"
 	"magazine_api/models"
 	"time"
@@ -16,11 +16,12 @@ import (
 type UserService struct {
 	logger lib.Logger
 	repo   component.UserComponent
+	media  MediaService
 }
 
 // NewUserService creates new instance of UserService
-func NewUserService(logger lib.Logger, repo component.UserComponent) UserService {
-	return UserService{logger: logger, repo: repo}
+func NewUserService(logger lib.Logger, repo component.UserComponent, media MediaService) UserService {
+	return UserService{logger: logger, repo: repo, media: media}
 }
 
 // CreateUser Creates the user in database
@@ -35,33 +36,14 @@ func (u UserService) CreateUser(user *models.User) (*models.User, error) {
 	return user, nil
 }
 
-// ListsUsers Lists users from database
-func (u UserService) ListEmployees(c *gin.Context) (gin.H, error) {
-
-	users, err := u.repo.ListEmployees(c.MustGet(constants.Limit).(int64), c.MustGet(constants.Offset).(int64))
-	if err != nil {
-		return nil, err
-	}
-
-	limit := c.MustGet(constants.Limit).(int64)
-	size := c.MustGet(constants.Page).(int64)
-
-	return gin.H{
-		"data":       users["data"],
-		"pagination": gin.H{"has_next": (users["count"].(int64) - limit*size) > 0, "count": users["count"]},
-	}, nil
+// ListsUsers Lists page of employees from database
+func (u UserService) ListEmployees(c *gin.Context) ([]*responses.EmployeeSmall, models.PageInfo, error) {
+	return u.repo.ListEmployees(PaginationFrom(c), ListQueryFrom(c))
 }
 
-// ListEmployeesByType Lists users by type from database
-func (u UserService) ListEmployeesByType(c *gin.Context, userType string) ([]*responses.EmployeeSmall, error) {
-	users, err := u.repo.ListEmployeesByType(
-		c.MustGet(constants.Limit).(int64), c.MustGet(constants.Page).(int64), userType,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	return users, nil
+// ListEmployeesByType Lists page of employees by type from database
+func (u UserService) ListEmployeesByType(c *gin.Context, userType string) ([]*responses.EmployeeSmall, models.PageInfo, error) {
+	return u.repo.ListEmployeesByType(userType, PaginationFrom(c), ListQueryFrom(c))
 }
 
 // ListsDeletedUsers Lists Deleted users from database
@@ -114,6 +96,16 @@ func (u UserService) GetUserByID(id uuid.UUID) (*models.User, error) {
 	return user, nil
 }
 
+// ListUsersByIDs Lists users having given ids from database
+func (u UserService) ListUsersByIDs(ids []uuid.UUID) ([]*models.User, error) {
+	users, err := u.repo.GetUsersFromIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
 // UpdateUser Update user by in our database
 func (u UserService) UpdateUser(id uuid.UUID, patch *map[string]interface{}) error {
 	err := u.repo.PatchUser(id, patch)
@@ -141,7 +133,7 @@ func (u UserService) PermanentDeleteUser(id uuid.UUID) error {
 		return err
 	}
 
-	return nil
+	return u.ClearEmployeeReferences(id)
 }
 
 // DeleteAllProfilesOfUser deletes user permanently from database
@@ -151,7 +143,37 @@ func (u UserService) DeleteAllProfilesOfUser(id uuid.UUID) error {
 		return err
 	}
 
-	return nil
+	return u.ClearEmployeeReferences(id)
+}
+
+// TrackEmployeeReferences records picture and documents of employee profile of the user,
+// they are tracked under the user as the documents have no ids of their own
+func (u UserService) TrackEmployeeReferences(userID uuid.UUID, picture *lib.SignedURL, documents []models.DocumentBase) error {
+	if err := u.media.TrackReferences(
+		models.MediaEntityEmployeeProfile, userID, map[string]*lib.SignedURL{"picture": picture},
+	); err != nil {
+		return err
+	}
+
+	refs := map[string]*lib.SignedURL{}
+	for i, document := range documents {
+		refs[fmt.Sprintf("documents.%d", i)] = document.DocumentURL
+	}
+
+	if err := u.media.ClearReferences(models.MediaEntityDocument, userID); err != nil {
+		return err
+	}
+
+	return u.media.TrackReferences(models.MediaEntityDocument, userID, refs)
+}
+
+// ClearEmployeeReferences forgets picture and documents of employee profile of the user
+func (u UserService) ClearEmployeeReferences(userID uuid.UUID) error {
+	if err := u.media.ClearReferences(models.MediaEntityEmployeeProfile, userID); err != nil {
+		return err
+	}
+
+	return u.media.ClearReferences(models.MediaEntityDocument, userID)
 }
 
 func (u UserService) BeforeCreate(user *models.User) *models.User {